@@ -15,19 +15,41 @@ package main
 // @host localhost:8080
 // @BasePath /api/v1
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Type "Bearer" followed by a space and the session token
+
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 
 	_ "expenso-backend/docs"
+	"expenso-backend/domain/entities"
+	authservice "expenso-backend/infrastructure/auth"
 	"expenso-backend/infrastructure/config"
 	"expenso-backend/infrastructure/http/handlers"
+	"expenso-backend/infrastructure/http/middleware"
 	"expenso-backend/infrastructure/migration"
 	"expenso-backend/infrastructure/persistence/repositories"
+	"expenso-backend/infrastructure/scheduler"
+	"expenso-backend/usecases/interactors/analytics"
+	"expenso-backend/usecases/interactors/apitoken"
+	"expenso-backend/usecases/interactors/auth"
+	"expenso-backend/usecases/interactors/backup"
+	"expenso-backend/usecases/interactors/budget"
+	"expenso-backend/usecases/interactors/categorization"
 	"expenso-backend/usecases/interactors/category"
+	"expenso-backend/usecases/interactors/dataimport"
+	"expenso-backend/usecases/interactors/exchangerate"
 	"expenso-backend/usecases/interactors/expense"
+	"expenso-backend/usecases/interactors/household"
 	"expenso-backend/usecases/interactors/income"
+	"expenso-backend/usecases/interactors/insights"
+	"expenso-backend/usecases/interactors/member"
+	"expenso-backend/usecases/interactors/recurring"
 	"expenso-backend/usecases/interactors/tag"
 	"expenso-backend/usecases/interactors/vendors"
 
@@ -71,20 +93,73 @@ func main() {
 	incomeRepo := repositories.NewIncomeRepository(db, tagRepo)
 	vendorRepo := repositories.NewVendorRepository(db)
 	categoryRepo := repositories.NewCategoryRepository(db)
+	exchangeRateRepo := repositories.NewExchangeRateRepository(db)
+	memberRepo := repositories.NewMemberRepository(db)
+	userRepo := repositories.NewUserRepository(db)
+	householdRepo := repositories.NewHouseholdRepository(db)
+	apiTokenRepo := repositories.NewAPITokenRepository(db)
+	budgetRepo := repositories.NewBudgetRepository(db)
+	recurringRuleRepo := repositories.NewRecurringRuleRepository(db)
+	importProfileRepo := repositories.NewImportProfileRepository(db)
+	importBatchRepo := repositories.NewImportBatchRepository(db)
+	categorizationRuleRepo := repositories.NewCategorizationRuleRepository(db)
+	unitOfWork := repositories.NewUnitOfWork(db)
+
+	// Authentication services
+	passwordHasher := authservice.NewBcryptHasher(cfg.Auth.BcryptCost)
+	tokenIssuer, err := authservice.NewJWTIssuer(cfg.Auth.JWTSecret)
+	if err != nil {
+		log.Fatal("Invalid auth configuration:", err)
+	}
+	apiTokenGenerator := authservice.NewAPITokenGenerator()
 
 	// Use case layer (interactors)
-	expenseInteractor := expense.NewExpenseInteractor(expenseRepo, vendorRepo, tagRepo)
-	incomeInteractor := income.NewIncomeInteractor(incomeRepo, vendorRepo, tagRepo)
+	exchangeRateInteractor := exchangerate.NewExchangeRateInteractor(exchangeRateRepo)
+	expenseInteractor := expense.NewExpenseInteractor(expenseRepo, vendorRepo, tagRepo, memberRepo, categorizationRuleRepo, exchangeRateInteractor)
+	incomeInteractor := income.NewIncomeInteractor(incomeRepo, vendorRepo, tagRepo, memberRepo, exchangeRateInteractor)
 	vendorInteractor := vendors.NewVendorInteractor(vendorRepo)
 	categoryInteractor := category.NewCategoryInteractor(categoryRepo)
-	tagInteractor := tag.NewTagInteractor(tagRepo)
+	tagInteractor := tag.NewTagInteractor(tagRepo, expenseRepo)
+	memberInteractor := member.NewMemberInteractor(memberRepo)
+	householdInteractor := household.NewHouseholdInteractor(householdRepo, userRepo)
+	apiTokenInteractor := apitoken.NewAPITokenInteractor(apiTokenRepo, apiTokenGenerator)
+	budgetInteractor := budget.NewBudgetInteractor(budgetRepo, expenseRepo, categoryRepo, exchangeRateInteractor)
+	recurringInteractor := recurring.NewRecurringInteractor(recurringRuleRepo, vendorRepo, memberRepo, categoryRepo, tagRepo, unitOfWork, expenseInteractor, incomeInteractor)
+	importInteractor := dataimport.NewImportInteractor(vendorRepo, categoryRepo, expenseRepo, incomeRepo, memberRepo, tagRepo, importBatchRepo, categorizationRuleRepo, unitOfWork, expenseInteractor, incomeInteractor)
+	importProfileInteractor := dataimport.NewImportProfileInteractor(importProfileRepo, vendorRepo)
+	categorizationInteractor := categorization.NewCategorizationInteractor(categorizationRuleRepo, vendorRepo, categoryRepo, tagRepo)
+	analyticsInteractor := analytics.NewAnalyticsInteractor(expenseRepo, incomeRepo, exchangeRateInteractor)
+	cashFlowInteractor := analytics.NewCashFlowInteractor(expenseRepo, incomeRepo, exchangeRateInteractor)
+	forecastInteractor := analytics.NewForecastInteractor(expenseRepo, exchangeRateInteractor)
+	anomalyInteractor := insights.NewAnomalyInteractor(expenseRepo, exchangeRateInteractor)
+	backupInteractor := backup.NewBackupInteractor(householdRepo, categoryRepo, vendorRepo, tagRepo, memberRepo, expenseRepo, incomeRepo, budgetRepo, recurringRuleRepo, importProfileRepo, categorizationRuleRepo, unitOfWork)
+	authInteractor := auth.NewAuthInteractor(userRepo, householdRepo, apiTokenRepo, passwordHasher, tokenIssuer, apiTokenGenerator, cfg.GetSessionTTL(), cfg.Auth.RegistrationEnabled)
 
 	// Interface layer (HTTP handlers)
-	expenseHandler := handlers.NewExpenseHandler(expenseInteractor)
+	expenseHandler := handlers.NewExpenseHandler(expenseInteractor, cashFlowInteractor)
 	incomeHandler := handlers.NewIncomeHandler(incomeInteractor)
 	vendorHandler := handlers.NewVendorHandler(vendorInteractor)
 	categoryHandler := handlers.NewCategoryHandler(categoryInteractor)
 	tagHandler := handlers.NewTagHandler(tagInteractor)
+	exchangeRateHandler := handlers.NewExchangeRateHandler(exchangeRateInteractor)
+	memberHandler := handlers.NewMemberHandler(memberInteractor)
+	authHandler := handlers.NewAuthHandler(authInteractor)
+	householdHandler := handlers.NewHouseholdHandler(householdInteractor)
+	apiTokenHandler := handlers.NewAPITokenHandler(apiTokenInteractor)
+	budgetHandler := handlers.NewBudgetHandler(budgetInteractor)
+	recurringRuleHandler := handlers.NewRecurringRuleHandler(recurringInteractor)
+	importHandler := handlers.NewImportHandler(importInteractor, importProfileInteractor)
+	importProfileHandler := handlers.NewImportProfileHandler(importProfileInteractor)
+	categorizationRuleHandler := handlers.NewCategorizationRuleHandler(categorizationInteractor)
+	backupHandler := handlers.NewBackupHandler(backupInteractor)
+	exportHandler := handlers.NewExportHandler(expenseInteractor, incomeInteractor)
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsInteractor, cashFlowInteractor, forecastInteractor)
+	insightsHandler := handlers.NewInsightsHandler(anomalyInteractor)
+
+	// Background jobs
+	if cfg.Scheduler.Enabled {
+		scheduler.NewRecurringScheduler(recurringInteractor, cfg.GetSchedulerInterval()).Start(context.Background())
+	}
 
 	// Setup Gin router
 	router := gin.Default()
@@ -93,7 +168,8 @@ func main() {
 	router.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "http://localhost:3000")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "*")
+		c.Header("Access-Control-Allow-Headers", "Authorization, Content-Type")
+		c.Header("Access-Control-Expose-Headers", "X-Total-Count, X-Next-Cursor")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -103,60 +179,166 @@ func main() {
 		c.Next()
 	})
 
-	// API routes group
+	// Public auth routes
+	public := router.Group("/api/v1")
+	public.POST("/auth/register", authHandler.Register)
+	public.POST("/auth/login", authHandler.Login)
+
+	// API routes group, all require a valid session token and act on the token's household
 	api := router.Group("/api/v1")
+	api.Use(middleware.RequireAuth(authInteractor))
+
+	api.GET("/auth/me", authHandler.Me)
+
+	// Account management is only available to interactive sessions, never to API tokens
+	session := api.Group("", middleware.RequireSession())
+
+	// Household routes
+	session.GET("/households", householdHandler.GetHouseholds)
+	session.POST("/households", householdHandler.CreateHousehold)
+	session.POST("/households/:id/switch", authHandler.SwitchHousehold)
+	session.GET("/households/:id/users", householdHandler.GetHouseholdUsers)
+	session.POST("/households/:id/users", householdHandler.AddHouseholdUser)
+	session.DELETE("/households/:id/users/:user_id", householdHandler.RemoveHouseholdUser)
+
+	// API token routes
+	session.GET("/tokens", apiTokenHandler.GetAPITokens)
+	session.POST("/tokens", apiTokenHandler.CreateAPIToken)
+	session.DELETE("/tokens/:id", apiTokenHandler.RevokeAPIToken)
+
+	// Backup routes, owners only
+	session.GET("/admin/backup", backupHandler.Backup)
+	session.POST("/admin/restore", backupHandler.Restore)
+
+	// Exchange rates are shared by every household, so only administrators may import them
+	session.POST("/exchange-rates/import", middleware.RequireAdmin(cfg.Auth.AdminEmails), exchangeRateHandler.ImportExchangeRates)
+
+	// API tokens need the matching scope per route group; read scopes cover GET, write scopes everything else
+	expenses := api.Group("", middleware.RequireReadWriteScope(entities.ScopeExpensesRead, entities.ScopeExpensesWrite))
+	incomes := api.Group("", middleware.RequireReadWriteScope(entities.ScopeIncomesRead, entities.ScopeIncomesWrite))
+	catalog := api.Group("", middleware.RequireReadWriteScope(entities.ScopeCatalogRead, entities.ScopeCatalogWrite))
+	budgets := api.Group("", middleware.RequireReadWriteScope(entities.ScopeBudgetsRead, entities.ScopeBudgetsWrite))
+	recurringRules := api.Group("", middleware.RequireReadWriteScope(entities.ScopeRecurringRead, entities.ScopeRecurringWrite))
+	imports := api.Group("", middleware.RequireScope(entities.ScopeImport))
+	// Exports of expenses and incomes together need the scopes of both
+	transactions := expenses.Group("", middleware.RequireReadWriteScope(entities.ScopeIncomesRead, entities.ScopeIncomesWrite))
 
 	// Expense routes
-	api.GET("/expenses", expenseHandler.GetExpenses)
-	api.POST("/expenses", expenseHandler.CreateExpense)
-	api.GET("/expenses/:id", expenseHandler.GetExpense)
-	api.PUT("/expenses/:id", expenseHandler.UpdateExpense)
-	api.DELETE("/expenses/:id", expenseHandler.DeleteExpense)
-	api.GET("/expenses/export/csv", expenseHandler.ExportExpensesCSV)
-	api.POST("/expenses/import/csv/preview", expenseHandler.ImportExpensesCSVPreview)
-	api.POST("/expenses/import/csv/confirm", expenseHandler.ImportExpensesCSVConfirm)
+	expenses.GET("/expenses", expenseHandler.GetExpenses)
+	expenses.POST("/expenses", expenseHandler.CreateExpense)
+	expenses.GET("/expenses/:id", expenseHandler.GetExpense)
+	expenses.PUT("/expenses/:id", expenseHandler.UpdateExpense)
+	expenses.DELETE("/expenses/:id", expenseHandler.DeleteExpense)
+	expenses.GET("/expenses/export/csv", expenseHandler.ExportExpensesCSV)
+	expenses.GET("/expenses/export/csv/full", expenseHandler.ExportExpensesFullCSV)
+	imports.POST("/expenses/import/csv/preview", importHandler.PreviewCSVImport)
+	imports.POST("/expenses/import/csv/confirm", importHandler.ConfirmCSVImport)
 
 	// Balance and earnings routes
-	api.GET("/expenses/balance", expenseHandler.GetBalanceSummary)
-	api.GET("/expenses/actual", expenseHandler.GetActualExpenses)
-	api.GET("/expenses/earnings", expenseHandler.GetEarnings)
-	api.GET("/expenses/by-category", expenseHandler.GetExpensesByCategory)
+	transactions.GET("/expenses/balance", expenseHandler.GetBalanceSummary)
+	expenses.GET("/expenses/actual", expenseHandler.GetActualExpenses)
+	transactions.GET("/expenses/earnings", expenseHandler.GetEarnings)
+	expenses.GET("/expenses/by-category", expenseHandler.GetExpensesByCategory)
 
 	// Income routes
-	api.GET("/incomes", incomeHandler.GetIncomes)
-	api.POST("/incomes", incomeHandler.CreateIncome)
-	api.GET("/incomes/:id", incomeHandler.GetIncomeByID)
-	api.PUT("/incomes/:id", incomeHandler.UpdateIncome)
-	api.DELETE("/incomes/:id", incomeHandler.DeleteIncome)
-	api.GET("/incomes/source/:source", incomeHandler.GetIncomesBySource)
-	api.GET("/incomes/summary", incomeHandler.GetIncomesSummary)
+	incomes.GET("/incomes", incomeHandler.GetIncomes)
+	incomes.POST("/incomes", incomeHandler.CreateIncome)
+	incomes.GET("/incomes/:id", incomeHandler.GetIncomeByID)
+	incomes.PUT("/incomes/:id", incomeHandler.UpdateIncome)
+	incomes.DELETE("/incomes/:id", incomeHandler.DeleteIncome)
+	incomes.GET("/incomes/source/:source", incomeHandler.GetIncomesBySource)
+	incomes.GET("/incomes/summary", incomeHandler.GetIncomesSummary)
+	incomes.GET("/incomes/export/csv", incomeHandler.ExportIncomesCSV)
+	imports.POST("/incomes/import/csv/preview", importHandler.PreviewIncomeCSVImport)
+	imports.POST("/incomes/import/csv/confirm", importHandler.ConfirmIncomeCSVImport)
+
+	// Combined export routes
+	transactions.GET("/export/journal", exportHandler.ExportJournal)
+	transactions.GET("/export/xlsx", exportHandler.ExportXLSX)
+
+	// Analytics routes
+	transactions.GET("/analytics/aggregate", analyticsHandler.Aggregate)
+	transactions.GET("/analytics/cashflow", analyticsHandler.CashFlow)
+	expenses.GET("/analytics/forecast", analyticsHandler.Forecast)
+
+	// Insights routes
+	expenses.GET("/insights/anomalies", insightsHandler.GetAnomalies)
 
 	// Vendor routes
-	api.GET("/vendors", vendorHandler.GetVendors)
-	api.POST("/vendors", vendorHandler.CreateVendor)
-	api.GET("/vendors/:id", vendorHandler.GetVendor)
-	api.PUT("/vendors/:id", vendorHandler.UpdateVendor)
-	api.DELETE("/vendors/:id", vendorHandler.DeleteVendor)
-	api.GET("/vendors/type/:type", vendorHandler.GetVendorsByType)
+	catalog.GET("/vendors", vendorHandler.GetVendors)
+	catalog.POST("/vendors", vendorHandler.CreateVendor)
+	catalog.GET("/vendors/:id", vendorHandler.GetVendor)
+	catalog.PUT("/vendors/:id", vendorHandler.UpdateVendor)
+	catalog.DELETE("/vendors/:id", vendorHandler.DeleteVendor)
+	catalog.GET("/vendors/type/:type", vendorHandler.GetVendorsByType)
 
 	// Category routes
-	api.GET("/categories", categoryHandler.GetCategories)
-	api.POST("/categories", categoryHandler.CreateCategory)
-	api.GET("/categories/:id", categoryHandler.GetCategory)
-	api.PUT("/categories/:id", categoryHandler.UpdateCategory)
-	api.DELETE("/categories/:id", categoryHandler.DeleteCategory)
+	catalog.GET("/categories", categoryHandler.GetCategories)
+	catalog.POST("/categories", categoryHandler.CreateCategory)
+	catalog.GET("/categories/:id", categoryHandler.GetCategory)
+	catalog.PUT("/categories/:id", categoryHandler.UpdateCategory)
+	catalog.DELETE("/categories/:id", categoryHandler.DeleteCategory)
 
 	// Tag routes
-	api.GET("/tags", tagHandler.GetTags)
-	api.POST("/tags", tagHandler.CreateTag)
-	api.GET("/tags/:id", tagHandler.GetTag)
-	api.PUT("/tags/:id", tagHandler.UpdateTag)
-	api.DELETE("/tags/:id", tagHandler.DeleteTag)
+	catalog.GET("/tags", tagHandler.GetTags)
+	catalog.POST("/tags", tagHandler.CreateTag)
+	catalog.GET("/tags/:id", tagHandler.GetTag)
+	catalog.PUT("/tags/:id", tagHandler.UpdateTag)
+	catalog.DELETE("/tags/:id", tagHandler.DeleteTag)
+
+	// Member routes
+	catalog.GET("/members", memberHandler.GetMembers)
+	catalog.POST("/members", memberHandler.CreateMember)
+	catalog.GET("/members/:id", memberHandler.GetMember)
+	catalog.PUT("/members/:id", memberHandler.UpdateMember)
+	catalog.DELETE("/members/:id", memberHandler.DeleteMember)
+
+	// Categorization rule routes; the dry run changes nothing, so reading the catalog is enough
+	catalog.GET("/categorization-rules", categorizationRuleHandler.GetCategorizationRules)
+	catalog.POST("/categorization-rules", categorizationRuleHandler.CreateCategorizationRule)
+	api.POST("/categorization-rules/test", middleware.RequireScope(entities.ScopeCatalogRead), categorizationRuleHandler.TestCategorizationRules)
+	catalog.GET("/categorization-rules/:id", categorizationRuleHandler.GetCategorizationRule)
+	catalog.PUT("/categorization-rules/:id", categorizationRuleHandler.UpdateCategorizationRule)
+	catalog.DELETE("/categorization-rules/:id", categorizationRuleHandler.DeleteCategorizationRule)
 
 	// Expense-Tag relationship routes
-	api.GET("/expenses/:id/tags", tagHandler.GetTagsByExpense)
-	api.POST("/expenses/:id/tags/:tag_id", tagHandler.AddTagToExpense)
-	api.DELETE("/expenses/:id/tags/:tag_id", tagHandler.RemoveTagFromExpense)
+	expenses.GET("/expenses/:id/tags", tagHandler.GetTagsByExpense)
+	expenses.POST("/expenses/:id/tags/:tag_id", tagHandler.AddTagToExpense)
+	expenses.DELETE("/expenses/:id/tags/:tag_id", tagHandler.RemoveTagFromExpense)
+
+	// Budget routes
+	budgets.GET("/budgets", budgetHandler.GetBudgets)
+	budgets.POST("/budgets", budgetHandler.CreateBudget)
+	budgets.GET("/budgets/status", budgetHandler.GetBudgetStatus)
+	budgets.GET("/budgets/:id", budgetHandler.GetBudget)
+	budgets.PUT("/budgets/:id", budgetHandler.UpdateBudget)
+	budgets.DELETE("/budgets/:id", budgetHandler.DeleteBudget)
+
+	// Recurring rule routes
+	recurringRules.GET("/recurring-rules", recurringRuleHandler.GetRecurringRules)
+	recurringRules.POST("/recurring-rules", recurringRuleHandler.CreateRecurringRule)
+	recurringRules.GET("/recurring-rules/upcoming", recurringRuleHandler.GetUpcomingOccurrences)
+	recurringRules.GET("/recurring-rules/:id", recurringRuleHandler.GetRecurringRule)
+	recurringRules.PUT("/recurring-rules/:id", recurringRuleHandler.UpdateRecurringRule)
+	recurringRules.DELETE("/recurring-rules/:id", recurringRuleHandler.DeleteRecurringRule)
+	recurringRules.POST("/recurring-rules/:id/skip", recurringRuleHandler.SkipOccurrence)
+
+	// Bank statement import routes
+	imports.POST("/imports/statement/preview", importHandler.PreviewStatementImport)
+	imports.POST("/imports/statement/confirm", importHandler.ConfirmStatementImport)
+	imports.GET("/imports/batches", importHandler.GetImportBatches)
+	imports.GET("/imports/batches/:id", importHandler.GetImportBatch)
+	imports.POST("/imports/batches/:id/rollback", importHandler.RollBackImportBatch)
+
+	// CSV import profile routes
+	imports.GET("/import-profiles", importProfileHandler.GetImportProfiles)
+	imports.POST("/import-profiles", importProfileHandler.CreateImportProfile)
+	imports.GET("/import-profiles/:id", importProfileHandler.GetImportProfile)
+	imports.PUT("/import-profiles/:id", importProfileHandler.UpdateImportProfile)
+	imports.DELETE("/import-profiles/:id", importProfileHandler.DeleteImportProfile)
+
+	// Exchange rate routes
+	catalog.GET("/exchange-rates", exchangeRateHandler.GetExchangeRates)
 
 	// Health check
 	router.GET("/health", func(c *gin.Context) {
@@ -0,0 +1,174 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+type APITokenID int
+
+// APITokenPrefix marks bearer tokens that are API tokens rather than session tokens
+const APITokenPrefix = "exp_"
+
+type APITokenScope string
+
+const (
+	ScopeExpensesRead   APITokenScope = "expenses:read"
+	ScopeExpensesWrite  APITokenScope = "expenses:write"
+	ScopeIncomesRead    APITokenScope = "incomes:read"
+	ScopeIncomesWrite   APITokenScope = "incomes:write"
+	ScopeCatalogRead    APITokenScope = "catalog:read"  // vendors, categories, tags, members, categorization rules and exchange rates
+	ScopeCatalogWrite   APITokenScope = "catalog:write" // vendors, categories, tags, members and categorization rules
+	ScopeBudgetsRead    APITokenScope = "budgets:read"
+	ScopeBudgetsWrite   APITokenScope = "budgets:write"
+	ScopeRecurringRead  APITokenScope = "recurring:read"
+	ScopeRecurringWrite APITokenScope = "recurring:write"
+	ScopeImport         APITokenScope = "import" // CSV and bank statement imports
+)
+
+func AllAPITokenScopes() []APITokenScope {
+	return []APITokenScope{
+		ScopeExpensesRead,
+		ScopeExpensesWrite,
+		ScopeIncomesRead,
+		ScopeIncomesWrite,
+		ScopeCatalogRead,
+		ScopeCatalogWrite,
+		ScopeBudgetsRead,
+		ScopeBudgetsWrite,
+		ScopeRecurringRead,
+		ScopeRecurringWrite,
+		ScopeImport,
+	}
+}
+
+func (s APITokenScope) IsValid() bool {
+	for _, scope := range AllAPITokenScopes() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIToken is a long-lived credential for scripts and integrations.
+// It acts on behalf of its user in one household and only for its scopes.
+// Only a hash of the secret is kept; the prefix identifies the token in listings.
+type APIToken struct {
+	id          APITokenID
+	userID      UserID
+	householdID HouseholdID
+	name        string
+	prefix      string
+	tokenHash   string
+	scopes      []APITokenScope
+	lastUsedAt  *time.Time
+	createdAt   time.Time
+}
+
+func NewAPIToken(userID UserID, householdID HouseholdID, name, prefix, tokenHash string, scopes []APITokenScope) (*APIToken, error) {
+	trimmedName := strings.TrimSpace(name)
+	if trimmedName == "" {
+		return nil, errors.New("token name cannot be empty")
+	}
+	if len(trimmedName) > 100 {
+		return nil, errors.New("token name cannot be longer than 100 characters")
+	}
+
+	if tokenHash == "" {
+		return nil, errors.New("token hash cannot be empty")
+	}
+
+	if len(scopes) == 0 {
+		return nil, errors.New("token needs at least one scope")
+	}
+	var uniqueScopes []APITokenScope
+	seen := make(map[APITokenScope]bool)
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return nil, errors.New("invalid token scope: " + string(scope))
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			uniqueScopes = append(uniqueScopes, scope)
+		}
+	}
+
+	return &APIToken{
+		userID:      userID,
+		householdID: householdID,
+		name:        trimmedName,
+		prefix:      prefix,
+		tokenHash:   tokenHash,
+		scopes:      uniqueScopes,
+		createdAt:   time.Now(),
+	}, nil
+}
+
+func ReconstructAPIToken(id APITokenID, userID UserID, householdID HouseholdID, name, prefix, tokenHash string, scopes []APITokenScope, lastUsedAt *time.Time, createdAt time.Time) *APIToken {
+	return &APIToken{
+		id:          id,
+		userID:      userID,
+		householdID: householdID,
+		name:        name,
+		prefix:      prefix,
+		tokenHash:   tokenHash,
+		scopes:      scopes,
+		lastUsedAt:  lastUsedAt,
+		createdAt:   createdAt,
+	}
+}
+
+func (t *APIToken) ID() APITokenID {
+	return t.id
+}
+
+func (t *APIToken) UserID() UserID {
+	return t.userID
+}
+
+func (t *APIToken) HouseholdID() HouseholdID {
+	return t.householdID
+}
+
+func (t *APIToken) Name() string {
+	return t.name
+}
+
+func (t *APIToken) Prefix() string {
+	return t.prefix
+}
+
+func (t *APIToken) TokenHash() string {
+	return t.tokenHash
+}
+
+func (t *APIToken) Scopes() []APITokenScope {
+	return t.scopes
+}
+
+func (t *APIToken) LastUsedAt() *time.Time {
+	return t.lastUsedAt
+}
+
+func (t *APIToken) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+func (t *APIToken) HasScope(scope APITokenScope) bool {
+	for _, s := range t.scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *APIToken) MarkUsed(at time.Time) {
+	t.lastUsedAt = &at
+}
+
+func (t *APIToken) SetID(id APITokenID) {
+	t.id = id
+}
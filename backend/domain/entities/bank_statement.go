@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"time"
+
+	"expenso-backend/domain/valueobjects"
+)
+
+// StatementDirection says whether money left (debit) or reached (credit) the account
+type StatementDirection string
+
+const (
+	StatementDebit  StatementDirection = "debit"
+	StatementCredit StatementDirection = "credit"
+)
+
+// BankStatement is the format independent result of parsing a bank export
+type BankStatement struct {
+	Account string // IBAN or bank account number, empty if the file does not name one
+	Entries []*StatementEntry
+}
+
+// StatementEntry is one booked transaction of a bank statement
+type StatementEntry struct {
+	BookingDate   time.Time
+	Amount        valueobjects.Money // Always positive, Direction says which way the money went
+	Direction     StatementDirection
+	Counterparty  string     // Name of the payee for debits and of the payer for credits
+	Reference     string     // Remittance information, e.g. "Miete Oktober"
+	TransactionID string     // Stable ID of the transaction, the same every time the file is exported
+	VendorType    VendorType // Set by wide CSV layouts from the amount column
+	Issues        []string   // Problems the parser noticed, e.g. a zero amount
+}
@@ -0,0 +1,189 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"expenso-backend/domain/valueobjects"
+)
+
+type BudgetID int
+
+type BudgetPeriod string
+
+const (
+	BudgetPeriodMonthly   BudgetPeriod = "monthly"
+	BudgetPeriodQuarterly BudgetPeriod = "quarterly"
+	BudgetPeriodYearly    BudgetPeriod = "yearly"
+)
+
+func (p BudgetPeriod) IsValid() bool {
+	switch p {
+	case BudgetPeriodMonthly, BudgetPeriodQuarterly, BudgetPeriodYearly:
+		return true
+	}
+	return false
+}
+
+// Bounds returns the first and last day of the period containing date
+func (p BudgetPeriod) Bounds(date time.Time) (time.Time, time.Time) {
+	year, month, _ := date.Date()
+	var start time.Time
+	var months int
+	switch p {
+	case BudgetPeriodQuarterly:
+		start = time.Date(year, month-(month-1)%3, 1, 0, 0, 0, 0, time.UTC)
+		months = 3
+	case BudgetPeriodYearly:
+		start = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		months = 12
+	default:
+		start = time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		months = 1
+	}
+	return start, start.AddDate(0, months, -1)
+}
+
+// BudgetTargetType says what a budget's target names
+type BudgetTargetType string
+
+const (
+	BudgetTargetCategory   BudgetTargetType = "category"
+	BudgetTargetVendorType BudgetTargetType = "vendor_type"
+)
+
+func (t BudgetTargetType) IsValid() bool {
+	return t == BudgetTargetCategory || t == BudgetTargetVendorType
+}
+
+// Budget caps spending on a category or vendor type per period.
+// With rollover, whatever was left unspent in the previous period is added to the next one.
+type Budget struct {
+	id         BudgetID
+	amount     valueobjects.Money
+	period     BudgetPeriod
+	targetType BudgetTargetType
+	target     string
+	rollover   bool
+	createdAt  time.Time
+	updatedAt  time.Time
+}
+
+func NewBudget(amount valueobjects.Money, period BudgetPeriod, targetType BudgetTargetType, target string, rollover bool) (*Budget, error) {
+	budget := &Budget{rollover: rollover}
+	if err := budget.UpdateAmount(amount); err != nil {
+		return nil, err
+	}
+	if err := budget.UpdatePeriod(period); err != nil {
+		return nil, err
+	}
+	if err := budget.UpdateTarget(targetType, target); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	budget.createdAt = now
+	budget.updatedAt = now
+	return budget, nil
+}
+
+func ReconstructBudget(id BudgetID, amount valueobjects.Money, period BudgetPeriod, targetType BudgetTargetType, target string, rollover bool, createdAt, updatedAt time.Time) *Budget {
+	return &Budget{
+		id:         id,
+		amount:     amount,
+		period:     period,
+		targetType: targetType,
+		target:     target,
+		rollover:   rollover,
+		createdAt:  createdAt,
+		updatedAt:  updatedAt,
+	}
+}
+
+func (b *Budget) ID() BudgetID {
+	return b.id
+}
+
+func (b *Budget) Amount() valueobjects.Money {
+	return b.amount
+}
+
+func (b *Budget) Period() BudgetPeriod {
+	return b.period
+}
+
+func (b *Budget) TargetType() BudgetTargetType {
+	return b.targetType
+}
+
+func (b *Budget) Target() string {
+	return b.target
+}
+
+func (b *Budget) Rollover() bool {
+	return b.rollover
+}
+
+func (b *Budget) CreatedAt() time.Time {
+	return b.createdAt
+}
+
+func (b *Budget) UpdatedAt() time.Time {
+	return b.updatedAt
+}
+
+// Matches reports whether the expense counts against this budget
+func (b *Budget) Matches(expense *Expense) bool {
+	switch b.targetType {
+	case BudgetTargetCategory:
+		return expense.Category().String() == b.target
+	case BudgetTargetVendorType:
+		return expense.Vendor() != nil && string(expense.Vendor().Type()) == b.target
+	}
+	return false
+}
+
+func (b *Budget) UpdateAmount(amount valueobjects.Money) error {
+	if amount.IsZero() || amount.IsNegative() {
+		return errors.New("budget amount must be positive")
+	}
+	b.amount = amount
+	b.updatedAt = time.Now()
+	return nil
+}
+
+func (b *Budget) UpdatePeriod(period BudgetPeriod) error {
+	if !period.IsValid() {
+		return errors.New("budget period must be monthly, quarterly or yearly")
+	}
+	b.period = period
+	b.updatedAt = time.Now()
+	return nil
+}
+
+func (b *Budget) UpdateTarget(targetType BudgetTargetType, target string) error {
+	if !targetType.IsValid() {
+		return errors.New("budget target type must be category or vendor_type")
+	}
+	trimmedTarget := strings.TrimSpace(target)
+	if trimmedTarget == "" {
+		return errors.New("budget target cannot be empty")
+	}
+	if targetType == BudgetTargetVendorType && !VendorType(trimmedTarget).IsValid() {
+		return ErrInvalidVendorType
+	}
+	b.targetType = targetType
+	b.target = trimmedTarget
+	b.updatedAt = time.Now()
+	return nil
+}
+
+func (b *Budget) UpdateRollover(rollover bool) {
+	b.rollover = rollover
+	b.updatedAt = time.Now()
+}
+
+func (b *Budget) SetID(id BudgetID) {
+	b.id = id
+}
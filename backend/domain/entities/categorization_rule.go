@@ -0,0 +1,254 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"expenso-backend/domain/valueobjects"
+)
+
+// maxRulePatternLength keeps patterns to what a person writes by hand
+const maxRulePatternLength = 500
+
+type CategorizationRuleID int
+
+// RuleConditions say which expenses a categorization rule applies to; all set conditions must hold.
+// Amount matches one exact amount, MinAmount and MaxAmount a range including both ends.
+type RuleConditions struct {
+	Pattern    string // Regular expression searched in payee and comment, ignoring case
+	VendorType VendorType
+	Amount     *valueobjects.Money
+	MinAmount  *valueobjects.Money
+	MaxAmount  *valueobjects.Money
+}
+
+// RuleActions are what a categorization rule fills in; fields the expense already has are kept
+type RuleActions struct {
+	VendorID *VendorID
+	Category string
+	TagIDs   []TagID // Added to the expense's tags
+}
+
+// RuleSubject is what rules are matched against: an expense being created or a transaction being imported
+type RuleSubject struct {
+	Payee      string // Vendor name or counterparty of a bank statement
+	Comment    string
+	Amount     valueobjects.Money
+	VendorType VendorType // Empty if unknown
+}
+
+// CategorizationRule assigns vendor, category and tags to matching expenses.
+// Rules run in order of priority, lowest first, and the first matching rule wins.
+type CategorizationRule struct {
+	id         CategorizationRuleID
+	name       string
+	priority   int
+	active     bool
+	conditions RuleConditions
+	actions    RuleActions
+	pattern    *regexp.Regexp // compiled conditions.Pattern, nil without one
+	createdAt  time.Time
+	updatedAt  time.Time
+}
+
+func NewCategorizationRule(name string, priority int, conditions RuleConditions, actions RuleActions) (*CategorizationRule, error) {
+	rule := &CategorizationRule{
+		priority: priority,
+		active:   true,
+	}
+	if err := rule.UpdateName(name); err != nil {
+		return nil, err
+	}
+	if err := rule.UpdateConditions(conditions); err != nil {
+		return nil, err
+	}
+	if err := rule.UpdateActions(actions); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rule.createdAt = now
+	rule.updatedAt = now
+	return rule, nil
+}
+
+// ReconstructCategorizationRule rebuilds a stored rule. A pattern that no longer compiles makes the rule match nothing.
+func ReconstructCategorizationRule(id CategorizationRuleID, name string, priority int, active bool, conditions RuleConditions, actions RuleActions,
+	createdAt, updatedAt time.Time) *CategorizationRule {
+	rule := &CategorizationRule{
+		id:         id,
+		name:       name,
+		priority:   priority,
+		active:     active,
+		conditions: conditions,
+		actions:    actions,
+		createdAt:  createdAt,
+		updatedAt:  updatedAt,
+	}
+	if conditions.Pattern != "" {
+		rule.pattern, _ = compileRulePattern(conditions.Pattern)
+	}
+	return rule
+}
+
+func (r *CategorizationRule) ID() CategorizationRuleID {
+	return r.id
+}
+
+func (r *CategorizationRule) Name() string {
+	return r.name
+}
+
+func (r *CategorizationRule) Priority() int {
+	return r.priority
+}
+
+func (r *CategorizationRule) Active() bool {
+	return r.active
+}
+
+func (r *CategorizationRule) Conditions() RuleConditions {
+	return r.conditions
+}
+
+func (r *CategorizationRule) Actions() RuleActions {
+	return r.actions
+}
+
+func (r *CategorizationRule) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+func (r *CategorizationRule) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+// Matches reports whether all conditions of the rule hold for the subject.
+// Amounts only match in the currency of the rule.
+func (r *CategorizationRule) Matches(subject RuleSubject) bool {
+	c := r.conditions
+	if c.Pattern != "" {
+		if r.pattern == nil || !(r.pattern.MatchString(subject.Payee) || r.pattern.MatchString(subject.Comment)) {
+			return false
+		}
+	}
+	if c.VendorType != "" && c.VendorType != subject.VendorType {
+		return false
+	}
+	if c.Amount != nil && (c.Amount.Currency() != subject.Amount.Currency() || c.Amount.MinorUnits() != subject.Amount.MinorUnits()) {
+		return false
+	}
+	if c.MinAmount != nil && (c.MinAmount.Currency() != subject.Amount.Currency() || subject.Amount.MinorUnits() < c.MinAmount.MinorUnits()) {
+		return false
+	}
+	if c.MaxAmount != nil && (c.MaxAmount.Currency() != subject.Amount.Currency() || subject.Amount.MinorUnits() > c.MaxAmount.MinorUnits()) {
+		return false
+	}
+	return true
+}
+
+// FirstMatchingRule returns the first active rule matching the subject, taking rules in the order given
+func FirstMatchingRule(rules []*CategorizationRule, subject RuleSubject) *CategorizationRule {
+	for _, rule := range rules {
+		if rule.Active() && rule.Matches(subject) {
+			return rule
+		}
+	}
+	return nil
+}
+
+func (r *CategorizationRule) UpdateName(name string) error {
+	trimmedName := strings.TrimSpace(name)
+	if trimmedName == "" {
+		return errors.New("categorization rule name cannot be empty")
+	}
+	r.name = trimmedName
+	r.updatedAt = time.Now()
+	return nil
+}
+
+func (r *CategorizationRule) UpdatePriority(priority int) {
+	r.priority = priority
+	r.updatedAt = time.Now()
+}
+
+func (r *CategorizationRule) UpdateActive(active bool) {
+	r.active = active
+	r.updatedAt = time.Now()
+}
+
+func (r *CategorizationRule) UpdateConditions(conditions RuleConditions) error {
+	conditions.Pattern = strings.TrimSpace(conditions.Pattern)
+	if conditions.Pattern == "" && conditions.VendorType == "" && conditions.Amount == nil && conditions.MinAmount == nil && conditions.MaxAmount == nil {
+		return errors.New("categorization rule needs a pattern, a vendor type or an amount")
+	}
+
+	var pattern *regexp.Regexp
+	if conditions.Pattern != "" {
+		compiled, err := compileRulePattern(conditions.Pattern)
+		if err != nil {
+			return err
+		}
+		pattern = compiled
+	}
+
+	if conditions.VendorType != "" && !conditions.VendorType.IsValid() {
+		return ErrInvalidVendorType
+	}
+
+	if conditions.Amount != nil && (conditions.MinAmount != nil || conditions.MaxAmount != nil) {
+		return errors.New("categorization rule takes either an amount or a range, not both")
+	}
+	var currency string
+	for _, amount := range []*valueobjects.Money{conditions.Amount, conditions.MinAmount, conditions.MaxAmount} {
+		if amount == nil {
+			continue
+		}
+		if amount.IsNegative() {
+			return errors.New("categorization rule amounts cannot be negative")
+		}
+		if currency != "" && amount.Currency() != currency {
+			return errors.New("categorization rule amounts must share one currency")
+		}
+		currency = amount.Currency()
+	}
+	if conditions.MinAmount != nil && conditions.MaxAmount != nil && conditions.MinAmount.MinorUnits() > conditions.MaxAmount.MinorUnits() {
+		return errors.New("categorization rule minimum amount is above the maximum")
+	}
+
+	r.conditions = conditions
+	r.pattern = pattern
+	r.updatedAt = time.Now()
+	return nil
+}
+
+func (r *CategorizationRule) UpdateActions(actions RuleActions) error {
+	actions.Category = strings.TrimSpace(actions.Category)
+	if actions.VendorID == nil && actions.Category == "" && len(actions.TagIDs) == 0 {
+		return errors.New("categorization rule needs a vendor, a category or tags to assign")
+	}
+	actions.TagIDs = append([]TagID{}, actions.TagIDs...)
+
+	r.actions = actions
+	r.updatedAt = time.Now()
+	return nil
+}
+
+func (r *CategorizationRule) SetID(id CategorizationRuleID) {
+	r.id = id
+}
+
+// compileRulePattern compiles a pattern to be matched ignoring case
+func compileRulePattern(pattern string) (*regexp.Regexp, error) {
+	if len(pattern) > maxRulePatternLength {
+		return nil, fmt.Errorf("categorization rule pattern cannot be longer than %d characters", maxRulePatternLength)
+	}
+	compiled, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid categorization rule pattern: %w", err)
+	}
+	return compiled, nil
+}
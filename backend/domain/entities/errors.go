@@ -3,9 +3,36 @@ package entities
 import "errors"
 
 var (
-	ErrInvalidVendorType   = errors.New("invalid vendor type")
-	ErrVendorAlreadyExists = errors.New("vendor with this name and type already exists")
-	ErrVendorNotFound      = errors.New("vendor not found")
-	ErrExpenseNotFound     = errors.New("expense not found")
-	ErrIncomeNotFound      = errors.New("income not found")
+	ErrInvalidVendorType          = errors.New("invalid vendor type")
+	ErrVendorAlreadyExists        = errors.New("vendor with this name and type already exists")
+	ErrVendorNotFound             = errors.New("vendor not found")
+	ErrExpenseNotFound            = errors.New("expense not found")
+	ErrIncomeNotFound             = errors.New("income not found")
+	ErrExchangeRateNotFound       = errors.New("exchange rate not found")
+	ErrMemberNotFound             = errors.New("member not found")
+	ErrMemberAlreadyExists        = errors.New("member with this name already exists")
+	ErrMemberInUse                = errors.New("member still has expenses or incomes")
+	ErrUserNotFound               = errors.New("user not found")
+	ErrUserAlreadyExists          = errors.New("user with this email already exists")
+	ErrInvalidCredentials         = errors.New("invalid email or password")
+	ErrInvalidToken               = errors.New("invalid or expired token")
+	ErrRegistrationDisabled       = errors.New("registration is disabled")
+	ErrHouseholdNotFound          = errors.New("household not found")
+	ErrNotHouseholdMember         = errors.New("user is not a member of this household")
+	ErrNotHouseholdOwner          = errors.New("only household owners can do this")
+	ErrLastHouseholdOwner         = errors.New("household must keep at least one owner")
+	ErrAPITokenNotFound           = errors.New("api token not found")
+	ErrInsufficientScope          = errors.New("token lacks the required scope")
+	ErrBudgetNotFound             = errors.New("budget not found")
+	ErrBudgetAlreadyExists        = errors.New("budget for this target and period already exists")
+	ErrRecurringRuleNotFound      = errors.New("recurring rule not found")
+	ErrNotAnOccurrence            = errors.New("date is not an occurrence of the recurring rule")
+	ErrOccurrenceHandled          = errors.New("occurrence was already created or skipped")
+	ErrImportProfileNotFound      = errors.New("import profile not found")
+	ErrImportProfileExists        = errors.New("import profile with this name already exists")
+	ErrImportBatchNotFound        = errors.New("import batch not found")
+	ErrImportBatchRolledBack      = errors.New("import batch was already rolled back")
+	ErrAlreadyImported            = errors.New("this file or these transactions were already imported")
+	ErrCategorizationRuleNotFound = errors.New("categorization rule not found")
+	ErrCategorizationRuleExists   = errors.New("categorization rule with this name already exists")
 )
@@ -0,0 +1,100 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"expenso-backend/domain/valueobjects"
+)
+
+type ExchangeRateID int
+
+// ExchangeRate is the price of one unit of the base currency in the quote currency on a given day
+type ExchangeRate struct {
+	id            ExchangeRateID
+	baseCurrency  string
+	quoteCurrency string
+	date          time.Time
+	rate          float64
+	source        string
+	createdAt     time.Time
+}
+
+func NewExchangeRate(baseCurrency, quoteCurrency string, date time.Time, rate float64, source string) (*ExchangeRate, error) {
+	if !valueobjects.IsValidCurrency(baseCurrency) {
+		return nil, errors.New("invalid base currency")
+	}
+
+	if !valueobjects.IsValidCurrency(quoteCurrency) {
+		return nil, errors.New("invalid quote currency")
+	}
+
+	base := strings.ToUpper(strings.TrimSpace(baseCurrency))
+	quote := strings.ToUpper(strings.TrimSpace(quoteCurrency))
+
+	if base == quote {
+		return nil, errors.New("base and quote currency must differ")
+	}
+
+	if rate <= 0 {
+		return nil, errors.New("exchange rate must be greater than zero")
+	}
+
+	if date.IsZero() {
+		return nil, errors.New("exchange rate date is required")
+	}
+
+	return &ExchangeRate{
+		baseCurrency:  base,
+		quoteCurrency: quote,
+		date:          date,
+		rate:          rate,
+		source:        source,
+		createdAt:     time.Now(),
+	}, nil
+}
+
+func ReconstructExchangeRate(id ExchangeRateID, baseCurrency, quoteCurrency string, date time.Time, rate float64, source string, createdAt time.Time) *ExchangeRate {
+	return &ExchangeRate{
+		id:            id,
+		baseCurrency:  baseCurrency,
+		quoteCurrency: quoteCurrency,
+		date:          date,
+		rate:          rate,
+		source:        source,
+		createdAt:     createdAt,
+	}
+}
+
+func (r *ExchangeRate) ID() ExchangeRateID {
+	return r.id
+}
+
+func (r *ExchangeRate) BaseCurrency() string {
+	return r.baseCurrency
+}
+
+func (r *ExchangeRate) QuoteCurrency() string {
+	return r.quoteCurrency
+}
+
+func (r *ExchangeRate) Date() time.Time {
+	return r.date
+}
+
+func (r *ExchangeRate) Rate() float64 {
+	return r.rate
+}
+
+func (r *ExchangeRate) Source() string {
+	return r.source
+}
+
+func (r *ExchangeRate) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+func (r *ExchangeRate) SetID(id ExchangeRateID) {
+	r.id = id
+}
@@ -19,21 +19,6 @@ func (et ExpenseType) IsValid() bool {
 	return et == ExpenseTypeIncome || et == ExpenseTypeExpense
 }
 
-type AddedBy string
-
-const (
-	AddedByHe  AddedBy = "he"
-	AddedByShe AddedBy = "she"
-)
-
-func (ab AddedBy) IsValid() bool {
-	return ab == AddedByHe || ab == AddedByShe
-}
-
-func (ab AddedBy) String() string {
-	return string(ab)
-}
-
 type Category string
 
 func NewCategory(value string) (Category, error) {
@@ -59,7 +44,7 @@ type Expense struct {
 	comment     string
 	vendor      *Vendor
 	paidByCard  bool
-	addedBy     AddedBy
+	member      *Member
 	tags        []*Tag
 	createdAt   time.Time
 	updatedAt   time.Time
@@ -95,15 +80,14 @@ func NewExpense(amount valueobjects.Money, date time.Time, expenseType ExpenseTy
 		expenseType: expenseType,
 		category:    category,
 		comment:     strings.TrimSpace(comment),
-		paidByCard:  true,      // Default value is true (paid by card)
-		addedBy:     AddedByHe, // Default value is "he"
+		paidByCard:  true, // Default value is true (paid by card)
 		createdAt:   now,
 		updatedAt:   now,
 	}, nil
 }
 
 func ReconstructExpense(id ExpenseID, amount valueobjects.Money, date time.Time, expenseType ExpenseType,
-	category Category, comment string, vendor *Vendor, paidByCard bool, addedBy AddedBy, tags []*Tag, createdAt, updatedAt time.Time) *Expense {
+	category Category, comment string, vendor *Vendor, paidByCard bool, member *Member, tags []*Tag, createdAt, updatedAt time.Time) *Expense {
 	return &Expense{
 		id:          id,
 		amount:      amount,
@@ -113,7 +97,7 @@ func ReconstructExpense(id ExpenseID, amount valueobjects.Money, date time.Time,
 		comment:     comment,
 		vendor:      vendor,
 		paidByCard:  paidByCard,
-		addedBy:     addedBy,
+		member:      member,
 		tags:        tags,
 		createdAt:   createdAt,
 		updatedAt:   updatedAt,
@@ -160,8 +144,9 @@ func (e *Expense) PaidByCard() bool {
 	return e.paidByCard
 }
 
-func (e *Expense) AddedBy() AddedBy {
-	return e.addedBy
+// Member returns the household member who recorded the expense, or nil if unassigned
+func (e *Expense) Member() *Member {
+	return e.member
 }
 
 func (e *Expense) Tags() []*Tag {
@@ -208,13 +193,14 @@ func (e *Expense) UpdatePaidByCard(paidByCard bool) {
 	e.updatedAt = time.Now()
 }
 
-func (e *Expense) UpdateAddedBy(addedBy AddedBy) error {
-	if !addedBy.IsValid() {
-		return errors.New("invalid addedBy value, must be 'he' or 'she'")
-	}
-	e.addedBy = addedBy
+func (e *Expense) AssignMember(member *Member) {
+	e.member = member
+	e.updatedAt = time.Now()
+}
+
+func (e *Expense) RemoveMember() {
+	e.member = nil
 	e.updatedAt = time.Now()
-	return nil
 }
 
 func (e *Expense) AssignVendor(vendor *Vendor) {
@@ -237,11 +223,6 @@ func (e *Expense) SetTimestamps(createdAt, updatedAt time.Time) {
 	e.updatedAt = updatedAt
 }
 
-// Business logic: Check if expense is a large expense (over $100)
-func (e *Expense) IsLargeExpense() bool {
-	return e.amount.Amount() > 100.0
-}
-
 // Business logic: Check if expense is recent (within last 7 days)
 func (e *Expense) IsRecent() bool {
 	return e.date.After(time.Now().AddDate(0, 0, -7))
@@ -0,0 +1,108 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+type HouseholdID int
+
+type HouseholdRole string
+
+const (
+	HouseholdRoleOwner  HouseholdRole = "owner"
+	HouseholdRoleMember HouseholdRole = "member"
+)
+
+func (r HouseholdRole) IsValid() bool {
+	return r == HouseholdRoleOwner || r == HouseholdRoleMember
+}
+
+// Household is a tenant: every expense, income, vendor, category, tag and member belongs to exactly one
+type Household struct {
+	id        HouseholdID
+	name      string
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func NewHousehold(name string) (*Household, error) {
+	trimmedName := strings.TrimSpace(name)
+	if trimmedName == "" {
+		return nil, errors.New("household name cannot be empty")
+	}
+	if len(trimmedName) > 100 {
+		return nil, errors.New("household name cannot be longer than 100 characters")
+	}
+
+	now := time.Now()
+	return &Household{
+		name:      trimmedName,
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+func ReconstructHousehold(id HouseholdID, name string, createdAt, updatedAt time.Time) *Household {
+	return &Household{
+		id:        id,
+		name:      name,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
+func (h *Household) ID() HouseholdID {
+	return h.id
+}
+
+func (h *Household) Name() string {
+	return h.name
+}
+
+func (h *Household) CreatedAt() time.Time {
+	return h.createdAt
+}
+
+func (h *Household) UpdatedAt() time.Time {
+	return h.updatedAt
+}
+
+func (h *Household) SetID(id HouseholdID) {
+	h.id = id
+}
+
+// HouseholdMembership grants a user access to a household.
+// Depending on the query either the household or the user is loaded.
+type HouseholdMembership struct {
+	household *Household
+	user      *User
+	role      HouseholdRole
+	createdAt time.Time
+}
+
+func ReconstructHouseholdMembership(household *Household, user *User, role HouseholdRole, createdAt time.Time) *HouseholdMembership {
+	return &HouseholdMembership{
+		household: household,
+		user:      user,
+		role:      role,
+		createdAt: createdAt,
+	}
+}
+
+func (m *HouseholdMembership) Household() *Household {
+	return m.household
+}
+
+func (m *HouseholdMembership) User() *User {
+	return m.user
+}
+
+func (m *HouseholdMembership) Role() HouseholdRole {
+	return m.role
+}
+
+func (m *HouseholdMembership) CreatedAt() time.Time {
+	return m.createdAt
+}
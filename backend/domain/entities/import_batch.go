@@ -0,0 +1,144 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"expenso-backend/domain/valueobjects"
+)
+
+type ImportBatchID int
+
+// DerivedTransactionIDPrefix marks transaction IDs made up from an entry's contents because the file had none
+const DerivedTransactionIDPrefix = "derived-"
+
+// TransactionFingerprint identifies a transaction across imports when the file has no ID for it
+func TransactionFingerprint(date time.Time, amount valueobjects.Money, payee string) string {
+	payee = strings.ToLower(strings.Join(strings.Fields(payee), " "))
+	return fmt.Sprintf("tx:%s|%s|%s|%s", date.Format("2006-01-02"), amount.Decimal(), amount.Currency(), payee)
+}
+
+// Fingerprint identifies the entry across imports: by the account and transaction ID from the file if it has one,
+// since banks only keep IDs unique per account, otherwise by date, amount and payee (the reference for files without payee)
+func (e *StatementEntry) Fingerprint(account string) string {
+	if e.TransactionID != "" && !strings.HasPrefix(e.TransactionID, DerivedTransactionIDPrefix) {
+		if account = strings.TrimSpace(account); account != "" {
+			return "id:" + account + "|" + e.TransactionID
+		}
+		return "id:" + e.TransactionID
+	}
+	payee := e.Counterparty
+	if payee == "" {
+		payee = e.Reference
+	}
+	return TransactionFingerprint(e.BookingDate, e.Amount, payee)
+}
+
+// ImportBatchItem links a created transaction to the fingerprint it was imported under.
+// The IDs are nil once the transaction was deleted.
+type ImportBatchItem struct {
+	Fingerprint string
+	ExpenseID   *ExpenseID
+	IncomeID    *IncomeID
+}
+
+// ImportBatch records one confirmed import, so re-imports can be spotted and the import undone as a whole
+type ImportBatch struct {
+	id           ImportBatchID
+	source       string // csv, camt053, mt940, ofx or qfx
+	fileHash     string // SHA-256 of the imported file, empty if unknown
+	items        []ImportBatchItem
+	expenseCount int
+	incomeCount  int
+	createdAt    time.Time
+	rolledBackAt *time.Time
+}
+
+func NewImportBatch(source, fileHash string) (*ImportBatch, error) {
+	trimmedSource := strings.TrimSpace(source)
+	if trimmedSource == "" {
+		return nil, errors.New("import batch source cannot be empty")
+	}
+	return &ImportBatch{
+		source:    trimmedSource,
+		fileHash:  strings.ToLower(strings.TrimSpace(fileHash)),
+		items:     []ImportBatchItem{},
+		createdAt: time.Now(),
+	}, nil
+}
+
+// ReconstructImportBatch rebuilds a batch; items may be nil when only the summary was loaded
+func ReconstructImportBatch(id ImportBatchID, source, fileHash string, items []ImportBatchItem, expenseCount, incomeCount int, createdAt time.Time, rolledBackAt *time.Time) *ImportBatch {
+	return &ImportBatch{
+		id:           id,
+		source:       source,
+		fileHash:     fileHash,
+		items:        items,
+		expenseCount: expenseCount,
+		incomeCount:  incomeCount,
+		createdAt:    createdAt,
+		rolledBackAt: rolledBackAt,
+	}
+}
+
+func (b *ImportBatch) ID() ImportBatchID {
+	return b.id
+}
+
+func (b *ImportBatch) Source() string {
+	return b.source
+}
+
+func (b *ImportBatch) FileHash() string {
+	return b.fileHash
+}
+
+func (b *ImportBatch) Items() []ImportBatchItem {
+	return b.items
+}
+
+func (b *ImportBatch) ExpenseCount() int {
+	return b.expenseCount
+}
+
+func (b *ImportBatch) IncomeCount() int {
+	return b.incomeCount
+}
+
+func (b *ImportBatch) CreatedAt() time.Time {
+	return b.createdAt
+}
+
+func (b *ImportBatch) RolledBackAt() *time.Time {
+	return b.rolledBackAt
+}
+
+func (b *ImportBatch) IsRolledBack() bool {
+	return b.rolledBackAt != nil
+}
+
+func (b *ImportBatch) AddExpense(fingerprint string, id ExpenseID) {
+	b.items = append(b.items, ImportBatchItem{Fingerprint: fingerprint, ExpenseID: &id})
+	b.expenseCount++
+}
+
+func (b *ImportBatch) AddIncome(fingerprint string, id IncomeID) {
+	b.items = append(b.items, ImportBatchItem{Fingerprint: fingerprint, IncomeID: &id})
+	b.incomeCount++
+}
+
+// RollBack marks the batch undone; its transactions have to be deleted by the caller
+func (b *ImportBatch) RollBack() error {
+	if b.rolledBackAt != nil {
+		return ErrImportBatchRolledBack
+	}
+	now := time.Now()
+	b.rolledBackAt = &now
+	return nil
+}
+
+func (b *ImportBatch) SetID(id ImportBatchID) {
+	b.id = id
+}
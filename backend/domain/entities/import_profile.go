@@ -0,0 +1,338 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"expenso-backend/domain/valueobjects"
+)
+
+type ImportProfileID int
+
+// CSVEncoding is the character set a CSV file was saved in
+type CSVEncoding string
+
+const (
+	CSVEncodingUTF8        CSVEncoding = "utf-8"
+	CSVEncodingWindows1252 CSVEncoding = "windows-1252"
+	CSVEncodingISO88591    CSVEncoding = "iso-8859-1"
+)
+
+func (e CSVEncoding) IsValid() bool {
+	switch e {
+	case CSVEncodingUTF8, CSVEncodingWindows1252, CSVEncodingISO88591:
+		return true
+	}
+	return false
+}
+
+// CSVLayout says how a CSV file spreads transactions over its columns
+type CSVLayout string
+
+const (
+	// CSVLayoutWide has one row per day and one amount column per vendor type
+	CSVLayoutWide CSVLayout = "wide"
+	// CSVLayoutLong has one row per transaction with a single signed amount column, like bank exports
+	CSVLayoutLong CSVLayout = "long"
+)
+
+func (l CSVLayout) IsValid() bool {
+	return l == CSVLayoutWide || l == CSVLayoutLong
+}
+
+// ImportRule suggests a category and/or vendor for imported transactions.
+// A rule applies when all of its conditions hold: Match is found in the payee or memo (ignoring case)
+// and the amount came from a column of VendorType. Empty conditions always hold.
+type ImportRule struct {
+	Match      string
+	VendorType VendorType
+	Category   string
+	VendorID   *VendorID
+}
+
+// Applies reports whether the rule matches a transaction
+func (r ImportRule) Applies(payee, memo string, vendorType VendorType) bool {
+	if r.VendorType != "" && r.VendorType != vendorType {
+		return false
+	}
+	if r.Match != "" {
+		match := strings.ToLower(r.Match)
+		return strings.Contains(strings.ToLower(payee), match) || strings.Contains(strings.ToLower(memo), match)
+	}
+	return true
+}
+
+func (r ImportRule) validate() error {
+	if strings.TrimSpace(r.Match) == "" && r.VendorType == "" {
+		return errors.New("import rule needs a match text or a vendor type")
+	}
+	if r.VendorType != "" && !r.VendorType.IsValid() {
+		return ErrInvalidVendorType
+	}
+	if strings.TrimSpace(r.Category) == "" && r.VendorID == nil {
+		return errors.New("import rule needs a category or a vendor")
+	}
+	return nil
+}
+
+// ImportProfileSettings describes how to read one kind of CSV file.
+// Columns are named by their header, compared without case and surrounding spaces.
+type ImportProfileSettings struct {
+	Delimiter         string      // Single character, "," if empty
+	Encoding          CSVEncoding // utf-8 if empty
+	DecimalSeparator  string      // "." or ",", the other one is taken as thousands separator
+	DateFormats       []string    // Tried in order; YYYY, YY, MM and DD stand for year, month and day
+	SkipRows          int         // Lines before the header, e.g. account details at the top of bank exports
+	Layout            CSVLayout
+	DateColumn        string
+	AmountColumn      string                // Long layout: signed amount, negative for expenses
+	PayeeColumn       string                // Long layout, optional
+	MemoColumn        string                // Optional, becomes the comment
+	VendorTypeColumns map[string]VendorType // Wide layout: amount column header to vendor type
+	Currency          string                // Currency of all amounts, EUR if empty
+	DefaultCategory   string                // Suggested for expenses no rule matches, optional
+	Rules             []ImportRule          // First matching rule wins
+}
+
+// normalize fills in defaults and validates the settings
+func (s ImportProfileSettings) normalize() (ImportProfileSettings, error) {
+	if s.Delimiter == "" {
+		s.Delimiter = ","
+	}
+	if utf8.RuneCountInString(s.Delimiter) != 1 {
+		return s, errors.New("delimiter must be a single character")
+	}
+	if s.Encoding == "" {
+		s.Encoding = CSVEncodingUTF8
+	}
+	if !s.Encoding.IsValid() {
+		return s, errors.New("encoding must be utf-8, windows-1252 or iso-8859-1")
+	}
+	if s.DecimalSeparator == "" {
+		s.DecimalSeparator = "."
+	}
+	if s.DecimalSeparator != "." && s.DecimalSeparator != "," {
+		return s, errors.New("decimal separator must be . or ,")
+	}
+	if s.DecimalSeparator == s.Delimiter {
+		return s, errors.New("decimal separator and delimiter must differ")
+	}
+	if len(s.DateFormats) == 0 {
+		s.DateFormats = []string{"YYYY-MM-DD"}
+	}
+	if s.SkipRows < 0 {
+		return s, errors.New("skip rows cannot be negative")
+	}
+
+	currency := s.Currency
+	if currency == "" {
+		currency = valueobjects.DefaultCurrency
+	}
+	code, err := valueobjects.NormalizeCurrency(currency)
+	if err != nil {
+		return s, err
+	}
+	s.Currency = code
+
+	s.DateColumn = strings.TrimSpace(s.DateColumn)
+	s.AmountColumn = strings.TrimSpace(s.AmountColumn)
+	s.PayeeColumn = strings.TrimSpace(s.PayeeColumn)
+	s.MemoColumn = strings.TrimSpace(s.MemoColumn)
+	s.DefaultCategory = strings.TrimSpace(s.DefaultCategory)
+	if s.DateColumn == "" {
+		return s, errors.New("date column is required")
+	}
+
+	switch s.Layout {
+	case CSVLayoutWide:
+		if len(s.VendorTypeColumns) == 0 {
+			return s, errors.New("wide layout needs at least one vendor type column")
+		}
+		for column, vendorType := range s.VendorTypeColumns {
+			if !vendorType.IsValid() {
+				return s, fmt.Errorf("column %q: %w", column, ErrInvalidVendorType)
+			}
+		}
+	case CSVLayoutLong:
+		if s.AmountColumn == "" {
+			return s, errors.New("long layout needs an amount column")
+		}
+	default:
+		return s, errors.New("layout must be wide or long")
+	}
+
+	s.Rules = append([]ImportRule(nil), s.Rules...)
+	for idx, rule := range s.Rules {
+		if err := rule.validate(); err != nil {
+			return s, fmt.Errorf("rule %d: %w", idx+1, err)
+		}
+		s.Rules[idx].Match = strings.TrimSpace(rule.Match)
+		s.Rules[idx].Category = strings.TrimSpace(rule.Category)
+	}
+
+	return s, nil
+}
+
+// GoDateLayouts translates DateFormats into time.Parse layouts
+func (s ImportProfileSettings) GoDateLayouts() []string {
+	replacer := strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "DD", "02")
+	layouts := make([]string, len(s.DateFormats))
+	for i, format := range s.DateFormats {
+		layouts[i] = replacer.Replace(format)
+	}
+	return layouts
+}
+
+// ImportProfile is a saved description of a CSV layout, so spreadsheets and bank exports can be imported repeatedly
+type ImportProfile struct {
+	id        ImportProfileID
+	name      string
+	settings  ImportProfileSettings
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func NewImportProfile(name string, settings ImportProfileSettings) (*ImportProfile, error) {
+	profile := &ImportProfile{}
+	if err := profile.UpdateName(name); err != nil {
+		return nil, err
+	}
+	if err := profile.UpdateSettings(settings); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	profile.createdAt = now
+	profile.updatedAt = now
+	return profile, nil
+}
+
+func ReconstructImportProfile(id ImportProfileID, name string, settings ImportProfileSettings, createdAt, updatedAt time.Time) *ImportProfile {
+	return &ImportProfile{
+		id:        id,
+		name:      name,
+		settings:  settings,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
+// DefaultImportProfile reads the spreadsheet layout the CSV import started with:
+// a date column and one amount column per spending area.
+func DefaultImportProfile() *ImportProfile {
+	return ReconstructImportProfile(0, "Default", ImportProfileSettings{
+		Delimiter:        ",",
+		Encoding:         CSVEncodingUTF8,
+		DecimalSeparator: ".",
+		DateFormats: []string{
+			"01/02/2006", // MM/DD/YYYY (primary expected format)
+			"02/01/2006", // DD/MM/YYYY (alternative)
+			"2006-01-02",
+			"Mon Jan 02 2006 15:04:05 GMT-0700 (Central European Standard Time)",
+			"Mon Jan 02 2006 15:04:05 GMT+0100 (Central European Standard Time)",
+			"02.01.2006",
+		},
+		Layout:     CSVLayoutWide,
+		DateColumn: "date",
+		VendorTypeColumns: map[string]VendorType{
+			"food":       VendorTypeFoodStore,
+			"eating out": VendorTypeEatingOut,
+			"else":       VendorTypeElse,
+			"fees":       VendorTypeSubscriptions,
+			"household":  VendorTypeHousehold,
+			"car":        VendorTypeTransport,
+			"clothing":   VendorTypeClothing,
+			"living":     VendorTypeLiving,
+			"transport":  VendorTypeTransport,
+			"turismo":    VendorTypeTourism,
+		},
+		Currency: valueobjects.DefaultCurrency,
+		Rules: []ImportRule{
+			{VendorType: VendorTypeFoodStore, Category: "Food & Dining"},
+			{VendorType: VendorTypeEatingOut, Category: "Food & Dining"},
+			{VendorType: VendorTypeElse, Category: "Other"},
+			{VendorType: VendorTypeSubscriptions, Category: "Bills & Utilities"},
+			{VendorType: VendorTypeHousehold, Category: "Living"},
+			{VendorType: VendorTypeTransport, Category: "Transportation"},
+			{VendorType: VendorTypeClothing, Category: "Shopping"},
+			{VendorType: VendorTypeLiving, Category: "Living"},
+			{VendorType: VendorTypeTourism, Category: "Travel"},
+		},
+	}, time.Time{}, time.Time{})
+}
+
+func (p *ImportProfile) ID() ImportProfileID {
+	return p.id
+}
+
+func (p *ImportProfile) Name() string {
+	return p.name
+}
+
+func (p *ImportProfile) Settings() ImportProfileSettings {
+	return p.settings
+}
+
+func (p *ImportProfile) CreatedAt() time.Time {
+	return p.createdAt
+}
+
+func (p *ImportProfile) UpdatedAt() time.Time {
+	return p.updatedAt
+}
+
+// SuggestRule returns the first rule matching a transaction, if any
+func (p *ImportProfile) SuggestRule(payee, memo string, vendorType VendorType) *ImportRule {
+	for _, rule := range p.settings.Rules {
+		if rule.Applies(payee, memo, vendorType) {
+			return &rule
+		}
+	}
+	return nil
+}
+
+func (p *ImportProfile) UpdateName(name string) error {
+	trimmedName := strings.TrimSpace(name)
+	if trimmedName == "" {
+		return errors.New("import profile name cannot be empty")
+	}
+	p.name = trimmedName
+	p.updatedAt = time.Now()
+	return nil
+}
+
+func (p *ImportProfile) UpdateSettings(settings ImportProfileSettings) error {
+	normalized, err := settings.normalize()
+	if err != nil {
+		return err
+	}
+	p.settings = normalized
+	p.updatedAt = time.Now()
+	return nil
+}
+
+func (p *ImportProfile) SetID(id ImportProfileID) {
+	p.id = id
+}
+
+// CSVRow is one data row of a CSV file read with an import profile
+type CSVRow struct {
+	RowNumber int                           // Line in the file, counting skipped lines and the header
+	Date      string                        // Date cell as written in the file
+	Amounts   map[string]valueobjects.Money // Wide layout: amount per vendor type column, zero for empty cells
+	Entries   []*StatementEntry             // One per non-zero amount
+	Issues    []string
+}
+
+// IncomeCSVRow is one data row of an income CSV file, in the layout the income export writes
+type IncomeCSVRow struct {
+	RowNumber int
+	Entry     *StatementEntry // The income as a credit from the source, commented with the reference; nil if unreadable
+	Vendor    string          // Vendor name, if any
+	AddedBy   string          // Name of the member who recorded the income, if any
+	Tags      []string        // Tag names
+	Issues    []string
+}
@@ -17,7 +17,7 @@ type Income struct {
 	source    string
 	comment   string
 	vendor    *Vendor
-	addedBy   AddedBy
+	member    *Member
 	tags      []*Tag
 	createdAt time.Time
 	updatedAt time.Time
@@ -52,14 +52,13 @@ func NewIncome(amount valueobjects.Money, date time.Time, source string, comment
 		date:      date,
 		source:    strings.TrimSpace(source),
 		comment:   strings.TrimSpace(comment),
-		addedBy:   AddedByHe, // Default value is "he"
 		createdAt: now,
 		updatedAt: now,
 	}, nil
 }
 
 func ReconstructIncome(id IncomeID, amount valueobjects.Money, date time.Time, source string,
-	comment string, vendor *Vendor, addedBy AddedBy, tags []*Tag, createdAt, updatedAt time.Time) *Income {
+	comment string, vendor *Vendor, member *Member, tags []*Tag, createdAt, updatedAt time.Time) *Income {
 	return &Income{
 		id:        id,
 		amount:    amount,
@@ -67,7 +66,7 @@ func ReconstructIncome(id IncomeID, amount valueobjects.Money, date time.Time, s
 		source:    source,
 		comment:   comment,
 		vendor:    vendor,
-		addedBy:   addedBy,
+		member:    member,
 		tags:      tags,
 		createdAt: createdAt,
 		updatedAt: updatedAt,
@@ -106,8 +105,9 @@ func (i *Income) UpdatedAt() time.Time {
 	return i.updatedAt
 }
 
-func (i *Income) AddedBy() AddedBy {
-	return i.addedBy
+// Member returns the household member who recorded the income, or nil if unassigned
+func (i *Income) Member() *Member {
+	return i.member
 }
 
 func (i *Income) Tags() []*Tag {
@@ -153,13 +153,14 @@ func (i *Income) UpdateComment(comment string) {
 	i.updatedAt = time.Now()
 }
 
-func (i *Income) UpdateAddedBy(addedBy AddedBy) error {
-	if !addedBy.IsValid() {
-		return errors.New("invalid addedBy value, must be 'he' or 'she'")
-	}
-	i.addedBy = addedBy
+func (i *Income) AssignMember(member *Member) {
+	i.member = member
+	i.updatedAt = time.Now()
+}
+
+func (i *Income) RemoveMember() {
+	i.member = nil
 	i.updatedAt = time.Now()
-	return nil
 }
 
 func (i *Income) AssignVendor(vendor *Vendor) {
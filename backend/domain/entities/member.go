@@ -0,0 +1,76 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+type MemberID int
+
+// Member is a person in the household who records expenses and incomes
+type Member struct {
+	id        MemberID
+	name      string
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func NewMember(name string) (*Member, error) {
+	trimmedName := strings.TrimSpace(name)
+	if trimmedName == "" {
+		return nil, errors.New("member name cannot be empty")
+	}
+	if len(trimmedName) > 100 {
+		return nil, errors.New("member name cannot be longer than 100 characters")
+	}
+
+	now := time.Now()
+	return &Member{
+		name:      trimmedName,
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+func ReconstructMember(id MemberID, name string, createdAt, updatedAt time.Time) *Member {
+	return &Member{
+		id:        id,
+		name:      name,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
+func (m *Member) ID() MemberID {
+	return m.id
+}
+
+func (m *Member) Name() string {
+	return m.name
+}
+
+func (m *Member) CreatedAt() time.Time {
+	return m.createdAt
+}
+
+func (m *Member) UpdatedAt() time.Time {
+	return m.updatedAt
+}
+
+func (m *Member) UpdateName(name string) error {
+	trimmedName := strings.TrimSpace(name)
+	if trimmedName == "" {
+		return errors.New("member name cannot be empty")
+	}
+	if len(trimmedName) > 100 {
+		return errors.New("member name cannot be longer than 100 characters")
+	}
+	m.name = trimmedName
+	m.updatedAt = time.Now()
+	return nil
+}
+
+func (m *Member) SetID(id MemberID) {
+	m.id = id
+}
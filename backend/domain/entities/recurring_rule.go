@@ -0,0 +1,250 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"expenso-backend/domain/valueobjects"
+)
+
+type RecurringRuleID int
+
+// RecurringKind says whether a rule creates expenses or incomes
+type RecurringKind string
+
+const (
+	RecurringKindExpense RecurringKind = "expense"
+	RecurringKindIncome  RecurringKind = "income"
+)
+
+func (k RecurringKind) IsValid() bool {
+	return k == RecurringKindExpense || k == RecurringKindIncome
+}
+
+// RecurringRule is a template for an expense or income that repeats on a schedule, like rent or salary.
+// Category and paidByCard only apply to expenses, source only to incomes.
+type RecurringRule struct {
+	id         RecurringRuleID
+	kind       RecurringKind
+	name       string
+	amount     valueobjects.Money
+	category   string
+	source     string
+	comment    string
+	vendorID   *VendorID
+	memberID   *MemberID
+	paidByCard bool
+	tagIDs     []TagID
+	schedule   valueobjects.Recurrence
+	active     bool
+	createdAt  time.Time
+	updatedAt  time.Time
+}
+
+func NewRecurringRule(kind RecurringKind, name string, amount valueobjects.Money, schedule valueobjects.Recurrence) (*RecurringRule, error) {
+	if !kind.IsValid() {
+		return nil, errors.New("recurring rule kind must be expense or income")
+	}
+
+	rule := &RecurringRule{
+		kind:       kind,
+		schedule:   schedule,
+		paidByCard: true,
+		active:     true,
+		tagIDs:     []TagID{},
+	}
+	if err := rule.UpdateName(name); err != nil {
+		return nil, err
+	}
+	if err := rule.UpdateAmount(amount); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rule.createdAt = now
+	rule.updatedAt = now
+	return rule, nil
+}
+
+func ReconstructRecurringRule(id RecurringRuleID, kind RecurringKind, name string, amount valueobjects.Money, category, source, comment string,
+	vendorID *VendorID, memberID *MemberID, paidByCard bool, tagIDs []TagID, schedule valueobjects.Recurrence, active bool, createdAt, updatedAt time.Time) *RecurringRule {
+	return &RecurringRule{
+		id:         id,
+		kind:       kind,
+		name:       name,
+		amount:     amount,
+		category:   category,
+		source:     source,
+		comment:    comment,
+		vendorID:   vendorID,
+		memberID:   memberID,
+		paidByCard: paidByCard,
+		tagIDs:     tagIDs,
+		schedule:   schedule,
+		active:     active,
+		createdAt:  createdAt,
+		updatedAt:  updatedAt,
+	}
+}
+
+func (r *RecurringRule) ID() RecurringRuleID {
+	return r.id
+}
+
+func (r *RecurringRule) Kind() RecurringKind {
+	return r.kind
+}
+
+func (r *RecurringRule) Name() string {
+	return r.name
+}
+
+func (r *RecurringRule) Amount() valueobjects.Money {
+	return r.amount
+}
+
+func (r *RecurringRule) Category() string {
+	return r.category
+}
+
+func (r *RecurringRule) Source() string {
+	return r.source
+}
+
+func (r *RecurringRule) Comment() string {
+	return r.comment
+}
+
+func (r *RecurringRule) VendorID() *VendorID {
+	return r.vendorID
+}
+
+func (r *RecurringRule) MemberID() *MemberID {
+	return r.memberID
+}
+
+func (r *RecurringRule) PaidByCard() bool {
+	return r.paidByCard
+}
+
+func (r *RecurringRule) TagIDs() []TagID {
+	return r.tagIDs
+}
+
+func (r *RecurringRule) Schedule() valueobjects.Recurrence {
+	return r.schedule
+}
+
+func (r *RecurringRule) Active() bool {
+	return r.active
+}
+
+func (r *RecurringRule) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+func (r *RecurringRule) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+func (r *RecurringRule) UpdateName(name string) error {
+	trimmedName := strings.TrimSpace(name)
+	if trimmedName == "" {
+		return errors.New("recurring rule name cannot be empty")
+	}
+	r.name = trimmedName
+	r.updatedAt = time.Now()
+	return nil
+}
+
+func (r *RecurringRule) UpdateAmount(amount valueobjects.Money) error {
+	if amount.IsZero() || amount.IsNegative() {
+		return errors.New("recurring rule amount must be positive")
+	}
+	r.amount = amount
+	r.updatedAt = time.Now()
+	return nil
+}
+
+// UpdateCategory sets the category of the created expenses
+func (r *RecurringRule) UpdateCategory(category Category) error {
+	if r.kind != RecurringKindExpense {
+		return errors.New("only expense rules have a category")
+	}
+	r.category = category.String()
+	r.updatedAt = time.Now()
+	return nil
+}
+
+// UpdateSource sets the source of the created incomes
+func (r *RecurringRule) UpdateSource(source string) error {
+	if r.kind != RecurringKindIncome {
+		return errors.New("only income rules have a source")
+	}
+	trimmedSource := strings.TrimSpace(source)
+	if trimmedSource == "" {
+		return errors.New("income source cannot be empty")
+	}
+	r.source = trimmedSource
+	r.updatedAt = time.Now()
+	return nil
+}
+
+func (r *RecurringRule) UpdateComment(comment string) {
+	r.comment = strings.TrimSpace(comment)
+	r.updatedAt = time.Now()
+}
+
+func (r *RecurringRule) UpdateVendor(vendorID *VendorID) {
+	r.vendorID = vendorID
+	r.updatedAt = time.Now()
+}
+
+func (r *RecurringRule) UpdateMember(memberID *MemberID) {
+	r.memberID = memberID
+	r.updatedAt = time.Now()
+}
+
+func (r *RecurringRule) UpdatePaidByCard(paidByCard bool) {
+	r.paidByCard = paidByCard
+	r.updatedAt = time.Now()
+}
+
+func (r *RecurringRule) UpdateTags(tagIDs []TagID) {
+	r.tagIDs = tagIDs
+	r.updatedAt = time.Now()
+}
+
+func (r *RecurringRule) UpdateSchedule(schedule valueobjects.Recurrence) {
+	r.schedule = schedule
+	r.updatedAt = time.Now()
+}
+
+func (r *RecurringRule) UpdateActive(active bool) {
+	r.active = active
+	r.updatedAt = time.Now()
+}
+
+func (r *RecurringRule) SetID(id RecurringRuleID) {
+	r.id = id
+}
+
+// OccurrenceStatus records what happened to one scheduled date of a rule
+type OccurrenceStatus string
+
+const (
+	OccurrencePending OccurrenceStatus = "pending" // claimed, the transaction is being created
+	OccurrenceCreated OccurrenceStatus = "created"
+	OccurrenceSkipped OccurrenceStatus = "skipped"
+)
+
+// RecurringOccurrence marks a scheduled date as handled so it is never materialized twice
+type RecurringOccurrence struct {
+	RuleID    RecurringRuleID
+	Date      time.Time
+	Status    OccurrenceStatus
+	ExpenseID *ExpenseID
+	IncomeID  *IncomeID
+	CreatedAt time.Time
+}
@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+type UserID int
+
+// User is an account that can sign in to the API
+type User struct {
+	id           UserID
+	email        string
+	name         string
+	passwordHash string
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+// NewUser creates a user from an already hashed password; hashing is an infrastructure concern
+func NewUser(email, name, passwordHash string) (*User, error) {
+	normalizedEmail, err := NormalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	trimmedName := strings.TrimSpace(name)
+	if trimmedName == "" {
+		return nil, errors.New("user name cannot be empty")
+	}
+
+	if passwordHash == "" {
+		return nil, errors.New("user password hash cannot be empty")
+	}
+
+	now := time.Now()
+	return &User{
+		email:        normalizedEmail,
+		name:         trimmedName,
+		passwordHash: passwordHash,
+		createdAt:    now,
+		updatedAt:    now,
+	}, nil
+}
+
+func ReconstructUser(id UserID, email, name, passwordHash string, createdAt, updatedAt time.Time) *User {
+	return &User{
+		id:           id,
+		email:        email,
+		name:         name,
+		passwordHash: passwordHash,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+	}
+}
+
+// NormalizeEmail validates an email address and returns it trimmed and lower-cased
+func NormalizeEmail(email string) (string, error) {
+	trimmed := strings.ToLower(strings.TrimSpace(email))
+	if trimmed == "" {
+		return "", errors.New("email cannot be empty")
+	}
+	addr, err := mail.ParseAddress(trimmed)
+	if err != nil || addr.Address != trimmed {
+		return "", errors.New("invalid email address")
+	}
+	return trimmed, nil
+}
+
+func (u *User) ID() UserID {
+	return u.id
+}
+
+func (u *User) Email() string {
+	return u.email
+}
+
+func (u *User) Name() string {
+	return u.name
+}
+
+func (u *User) PasswordHash() string {
+	return u.passwordHash
+}
+
+func (u *User) CreatedAt() time.Time {
+	return u.createdAt
+}
+
+func (u *User) UpdatedAt() time.Time {
+	return u.updatedAt
+}
+
+func (u *User) SetID(id UserID) {
+	u.id = id
+}
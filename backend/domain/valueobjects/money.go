@@ -3,28 +3,105 @@ package valueobjects
 import (
 	"errors"
 	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
 )
 
+var (
+	ErrInvalidAmount    = errors.New("invalid money amount")
+	ErrCurrencyMismatch = errors.New("money currencies do not match")
+)
+
+// Money is an exact amount stored as an integer number of minor units (e.g. cents)
 type Money struct {
-	amount   float64
-	currency string
+	minorUnits int64
+	currency   string
 }
 
+// NewMoney creates money from a decimal amount, rounded half away from zero to the currency's minor unit.
+// Prefer ParseMoney when the amount is available as text.
 func NewMoney(amount float64, currency string) (Money, error) {
-	if amount < 0 {
+	return ParseMoney(strconv.FormatFloat(amount, 'f', -1, 64), currency)
+}
+
+// ParseMoney creates money from a decimal string such as "12.30" without any floating point rounding
+func ParseMoney(amount string, currency string) (Money, error) {
+	code, err := NormalizeCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	trimmed := strings.TrimSpace(amount)
+	if trimmed == "" {
+		return Money{}, ErrInvalidAmount
+	}
+
+	value, ok := new(big.Rat).SetString(trimmed)
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %s", ErrInvalidAmount, amount)
+	}
+	if value.Sign() < 0 {
 		return Money{}, errors.New("money amount cannot be negative")
 	}
-	if currency == "" {
-		currency = "EURO" // default currency
+
+	minorUnits, err := roundToMinorUnits(value, code)
+	if err != nil {
+		return Money{}, err
 	}
+
 	return Money{
-		amount:   amount,
-		currency: currency,
+		minorUnits: minorUnits,
+		currency:   code,
 	}, nil
 }
 
+// NewMoneyFromMinorUnits creates money from an integer number of minor units (e.g. 1230 cents = 12.30 EUR)
+func NewMoneyFromMinorUnits(minorUnits int64, currency string) (Money, error) {
+	code, err := NormalizeCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{
+		minorUnits: minorUnits,
+		currency:   code,
+	}, nil
+}
+
+// ZeroMoney returns zero in the given currency
+func ZeroMoney(currency string) (Money, error) {
+	return NewMoneyFromMinorUnits(0, currency)
+}
+
+func (m Money) MinorUnits() int64 {
+	return m.minorUnits
+}
+
+// Amount returns the amount as float64. It is meant for display and statistics only;
+// use MinorUnits or the arithmetic methods for calculations.
 func (m Money) Amount() float64 {
-	return m.amount
+	value, _ := m.rat().Float64()
+	return value
+}
+
+// Decimal returns the exact amount as a decimal string with the currency's number of fraction digits
+func (m Money) Decimal() string {
+	exponent := CurrencyExponent(m.currency)
+	minorUnits := m.minorUnits
+	sign := ""
+	if minorUnits < 0 {
+		sign = "-"
+		minorUnits = -minorUnits
+	}
+
+	digits := strconv.FormatInt(minorUnits, 10)
+	if exponent == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
 }
 
 func (m Money) Currency() string {
@@ -32,36 +109,171 @@ func (m Money) Currency() string {
 }
 
 func (m Money) IsZero() bool {
-	return m.amount == 0
+	return m.minorUnits == 0
 }
 
 func (m Money) IsNegative() bool {
-	return m.amount < 0
+	return m.minorUnits < 0
 }
 
 func (m Money) Add(other Money) (Money, error) {
 	if m.currency != other.currency {
-		return Money{}, errors.New("cannot add money with different currencies")
+		return Money{}, fmt.Errorf("%w: cannot add %s to %s", ErrCurrencyMismatch, other.currency, m.currency)
 	}
 	return Money{
-		amount:   m.amount + other.amount,
-		currency: m.currency,
+		minorUnits: m.minorUnits + other.minorUnits,
+		currency:   m.currency,
 	}, nil
 }
 
+// Subtract returns m - other; the result may be negative (e.g. a balance)
 func (m Money) Subtract(other Money) (Money, error) {
 	if m.currency != other.currency {
-		return Money{}, errors.New("cannot subtract money with different currencies")
+		return Money{}, fmt.Errorf("%w: cannot subtract %s from %s", ErrCurrencyMismatch, other.currency, m.currency)
 	}
-	if m.amount < other.amount {
-		return Money{}, errors.New("insufficient funds")
+	return Money{
+		minorUnits: m.minorUnits - other.minorUnits,
+		currency:   m.currency,
+	}, nil
+}
+
+// Multiply scales the amount by factor, rounding half away from zero to the minor unit.
+// The factor is taken at its shortest decimal representation, so 1.1604 is exactly 1.1604.
+func (m Money) Multiply(factor float64) (Money, error) {
+	rat, ok := new(big.Rat).SetString(strconv.FormatFloat(factor, 'f', -1, 64))
+	if !ok {
+		return Money{}, fmt.Errorf("invalid factor: %v", factor)
+	}
+
+	minorUnits, err := roundToMinorUnits(rat.Mul(rat, m.rat()), m.currency)
+	if err != nil {
+		return Money{}, err
 	}
+
 	return Money{
-		amount:   m.amount - other.amount,
-		currency: m.currency,
+		minorUnits: minorUnits,
+		currency:   m.currency,
+	}, nil
+}
+
+// Allocate splits the amount by the given ratios without losing or creating a single minor unit.
+// Remainders are handed out one minor unit at a time starting with the first share.
+func (m Money) Allocate(ratios ...int) ([]Money, error) {
+	if len(ratios) == 0 {
+		return nil, errors.New("at least one ratio is required")
+	}
+
+	var total int64
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, errors.New("ratios cannot be negative")
+		}
+		total += int64(ratio)
+	}
+	if total == 0 {
+		return nil, errors.New("ratios must not all be zero")
+	}
+
+	shares := make([]Money, len(ratios))
+	remainder := m.minorUnits
+	for i, ratio := range ratios {
+		share := m.minorUnits * int64(ratio) / total
+		shares[i] = Money{minorUnits: share, currency: m.currency}
+		remainder -= share
+	}
+
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+	for i := 0; remainder != 0; i = (i + 1) % len(shares) {
+		if ratios[i] == 0 {
+			continue
+		}
+		shares[i].minorUnits += step
+		remainder -= step
+	}
+
+	return shares, nil
+}
+
+// Convert expresses the amount in another currency, rounded half away from zero to its minor unit
+func (m Money) Convert(rate float64, currency string) (Money, error) {
+	if rate <= 0 {
+		return Money{}, errors.New("exchange rate must be greater than zero")
+	}
+	code, err := NormalizeCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	rat, ok := new(big.Rat).SetString(strconv.FormatFloat(rate, 'f', -1, 64))
+	if !ok {
+		return Money{}, fmt.Errorf("invalid exchange rate: %v", rate)
+	}
+
+	minorUnits, err := roundToMinorUnits(rat.Mul(rat, m.rat()), code)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{
+		minorUnits: minorUnits,
+		currency:   code,
+	}, nil
+}
+
+// WithCurrency returns the same amount in another currency, without conversion.
+// It fails if the currency has fewer minor units than the amount needs, e.g. EUR 12.30 as JPY.
+func (m Money) WithCurrency(currency string) (Money, error) {
+	code, err := NormalizeCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	scaled := new(big.Rat).Mul(m.rat(), new(big.Rat).SetInt(minorUnitScale(code)))
+	if !scaled.IsInt() {
+		return Money{}, fmt.Errorf("%w: %s has no minor units for %s, give the amount as well", ErrInvalidAmount, code, m.Decimal())
+	}
+	if !scaled.Num().IsInt64() {
+		return Money{}, fmt.Errorf("%w: amount out of range", ErrInvalidAmount)
+	}
+
+	return Money{
+		minorUnits: scaled.Num().Int64(),
+		currency:   code,
 	}, nil
 }
 
 func (m Money) String() string {
-	return fmt.Sprintf("%.2f %s", m.amount, m.currency)
+	return fmt.Sprintf("%s %s", m.Decimal(), m.currency)
+}
+
+// rat returns the exact amount in major units
+func (m Money) rat() *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(m.minorUnits), minorUnitScale(m.currency))
+}
+
+func minorUnitScale(currency string) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(CurrencyExponent(currency))), nil)
+}
+
+// roundToMinorUnits rounds a major-unit amount half away from zero to whole minor units
+func roundToMinorUnits(value *big.Rat, currency string) (int64, error) {
+	scaled := new(big.Rat).Mul(value, new(big.Rat).SetInt(minorUnitScale(currency)))
+
+	quotient, remainder := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	// Compare 2*|remainder| with the denominator to decide on rounding away from zero
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(scaled.Denom()) >= 0 {
+		if scaled.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	if !quotient.IsInt64() {
+		return 0, fmt.Errorf("%w: amount out of range", ErrInvalidAmount)
+	}
+	return quotient.Int64(), nil
 }
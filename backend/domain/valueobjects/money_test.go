@@ -0,0 +1,274 @@
+package valueobjects
+
+import (
+	"errors"
+	"testing"
+)
+
+func mustParseMoney(t *testing.T, amount, currency string) Money {
+	t.Helper()
+	money, err := ParseMoney(amount, currency)
+	if err != nil {
+		t.Fatalf("ParseMoney(%q, %q): %v", amount, currency, err)
+	}
+	return money
+}
+
+func TestParseMoneyRoundsHalfAwayFromZero(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency string
+		want     int64
+	}{
+		{"12.30", "EUR", 1230},
+		{"0.005", "EUR", 1},
+		{"0.0049", "EUR", 0},
+		{"2.675", "EUR", 268}, // 2.675 is below 2.675 as float64, exact parsing must still round up
+		{"1.5", "JPY", 2},
+		{"2.5", "JPY", 3},
+		{"1.4999", "JPY", 1},
+		{"1.0005", "KWD", 1001},
+		{"1.0004", "KWD", 1000},
+		{"17", "usd", 1700},
+	}
+	for _, tt := range tests {
+		money := mustParseMoney(t, tt.amount, tt.currency)
+		if money.MinorUnits() != tt.want {
+			t.Errorf("ParseMoney(%q, %q) = %d minor units, want %d", tt.amount, tt.currency, money.MinorUnits(), tt.want)
+		}
+	}
+}
+
+func TestParseMoneyRejectsInvalidInput(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency string
+	}{
+		{"", "EUR"},
+		{"abc", "EUR"},
+		{"-1.00", "EUR"},
+		{"1.00", "XXX"},
+		{"99999999999999999999", "EUR"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseMoney(tt.amount, tt.currency); err == nil {
+			t.Errorf("ParseMoney(%q, %q) succeeded, want an error", tt.amount, tt.currency)
+		}
+	}
+}
+
+func TestParseMoneyDefaultsCurrency(t *testing.T) {
+	money := mustParseMoney(t, "1", "")
+	if money.Currency() != DefaultCurrency {
+		t.Errorf("currency = %s, want %s", money.Currency(), DefaultCurrency)
+	}
+}
+
+func TestDecimalUsesCurrencyExponent(t *testing.T) {
+	tests := []struct {
+		minorUnits int64
+		currency   string
+		want       string
+	}{
+		{1230, "EUR", "12.30"},
+		{5, "EUR", "0.05"},
+		{0, "EUR", "0.00"},
+		{-1230, "EUR", "-12.30"},
+		{-5, "EUR", "-0.05"},
+		{1230, "JPY", "1230"},
+		{1230, "KWD", "1.230"},
+		{7, "KWD", "0.007"},
+	}
+	for _, tt := range tests {
+		money, err := NewMoneyFromMinorUnits(tt.minorUnits, tt.currency)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := money.Decimal(); got != tt.want {
+			t.Errorf("Decimal() of %d %s = %q, want %q", tt.minorUnits, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestNewMoneyAvoidsFloatErrors(t *testing.T) {
+	money, err := NewMoney(0.1+0.2, "EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if money.MinorUnits() != 30 {
+		t.Errorf("0.1+0.2 EUR = %d minor units, want 30", money.MinorUnits())
+	}
+}
+
+func TestAddAndSubtract(t *testing.T) {
+	a := mustParseMoney(t, "10.10", "EUR")
+	b := mustParseMoney(t, "0.20", "EUR")
+
+	sum, err := a.Add(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum.Decimal() != "10.30" {
+		t.Errorf("sum = %s, want 10.30", sum.Decimal())
+	}
+
+	difference, err := b.Subtract(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if difference.Decimal() != "-9.90" || !difference.IsNegative() {
+		t.Errorf("difference = %s, want -9.90", difference.Decimal())
+	}
+
+	if _, err := a.Add(mustParseMoney(t, "1", "USD")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("adding USD to EUR: err = %v, want ErrCurrencyMismatch", err)
+	}
+	if _, err := a.Subtract(mustParseMoney(t, "1", "USD")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("subtracting USD from EUR: err = %v, want ErrCurrencyMismatch", err)
+	}
+}
+
+func TestMultiply(t *testing.T) {
+	tests := []struct {
+		amount string
+		factor float64
+		want   string
+	}{
+		{"10.00", 1.1604, "11.60"},
+		{"0.05", 0.5, "0.03"}, // 0.025 rounds away from zero
+		{"100.00", 0, "0.00"},
+		{"3.33", 3, "9.99"},
+	}
+	for _, tt := range tests {
+		product, err := mustParseMoney(t, tt.amount, "EUR").Multiply(tt.factor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if product.Decimal() != tt.want {
+			t.Errorf("%s * %v = %s, want %s", tt.amount, tt.factor, product.Decimal(), tt.want)
+		}
+	}
+}
+
+func TestAllocateKeepsEveryMinorUnit(t *testing.T) {
+	tests := []struct {
+		amount string
+		ratios []int
+		want   []string
+	}{
+		{"100.00", []int{1, 1, 1}, []string{"33.34", "33.33", "33.33"}},
+		{"0.05", []int{1, 1, 1}, []string{"0.02", "0.02", "0.01"}},
+		{"10.00", []int{0, 1, 1}, []string{"0.00", "5.00", "5.00"}},
+		{"0.03", []int{0, 1, 0, 1}, []string{"0.00", "0.02", "0.00", "0.01"}},
+		{"1.00", []int{70, 30}, []string{"0.70", "0.30"}},
+	}
+	for _, tt := range tests {
+		money := mustParseMoney(t, tt.amount, "EUR")
+		shares, err := money.Allocate(tt.ratios...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var total int64
+		for i, share := range shares {
+			total += share.MinorUnits()
+			if share.Decimal() != tt.want[i] {
+				t.Errorf("Allocate(%s, %v)[%d] = %s, want %s", tt.amount, tt.ratios, i, share.Decimal(), tt.want[i])
+			}
+		}
+		if total != money.MinorUnits() {
+			t.Errorf("Allocate(%s, %v) shares add up to %d minor units, want %d", tt.amount, tt.ratios, total, money.MinorUnits())
+		}
+	}
+}
+
+func TestAllocateNegativeAmount(t *testing.T) {
+	money, err := NewMoneyFromMinorUnits(-100, "EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	shares, err := money.Allocate(1, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []int64{-34, -33, -33}
+	for i, share := range shares {
+		if share.MinorUnits() != want[i] {
+			t.Errorf("share %d = %d, want %d", i, share.MinorUnits(), want[i])
+		}
+	}
+}
+
+func TestAllocateRejectsInvalidRatios(t *testing.T) {
+	money := mustParseMoney(t, "1.00", "EUR")
+	for _, ratios := range [][]int{nil, {0, 0}, {1, -1}} {
+		if _, err := money.Allocate(ratios...); err == nil {
+			t.Errorf("Allocate(%v) succeeded, want an error", ratios)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		amount string
+		from   string
+		rate   float64
+		to     string
+		want   string
+	}{
+		{"10.00", "EUR", 1.1604, "USD", "11.60"},
+		{"10.00", "EUR", 162.35, "JPY", "1624"}, // 1623.5 rounds away from zero
+		{"1000", "JPY", 0.006159, "EUR", "6.16"},
+		{"1.00", "EUR", 0.33445, "KWD", "0.334"},
+		{"1.00", "EUR", 0.3345, "KWD", "0.335"},
+	}
+	for _, tt := range tests {
+		converted, err := mustParseMoney(t, tt.amount, tt.from).Convert(tt.rate, tt.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if converted.Currency() != tt.to || converted.Decimal() != tt.want {
+			t.Errorf("%s %s at %v = %s, want %s %s", tt.amount, tt.from, tt.rate, converted, tt.want, tt.to)
+		}
+	}
+
+	money := mustParseMoney(t, "1.00", "EUR")
+	if _, err := money.Convert(0, "USD"); err == nil {
+		t.Error("Convert with rate 0 succeeded, want an error")
+	}
+	if _, err := money.Convert(1, "XXX"); err == nil {
+		t.Error("Convert to an unknown currency succeeded, want an error")
+	}
+}
+
+func TestWithCurrency(t *testing.T) {
+	tests := []struct {
+		amount  string
+		from    string
+		to      string
+		want    string
+		wantErr bool
+	}{
+		{"12.00", "EUR", "JPY", "12", false},
+		{"12.30", "EUR", "JPY", "", true},
+		{"12", "JPY", "KWD", "12.000", false},
+		{"1.234", "KWD", "EUR", "", true},
+		{"1.230", "KWD", "EUR", "1.23", false},
+		{"12.30", "EUR", "USD", "12.30", false},
+	}
+	for _, tt := range tests {
+		money, err := mustParseMoney(t, tt.amount, tt.from).WithCurrency(tt.to)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidAmount) {
+				t.Errorf("%s %s as %s: err = %v, want ErrInvalidAmount", tt.amount, tt.from, tt.to, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if money.Currency() != tt.to || money.Decimal() != tt.want {
+			t.Errorf("%s %s as %s = %s, want %s", tt.amount, tt.from, tt.to, money, tt.want)
+		}
+	}
+}
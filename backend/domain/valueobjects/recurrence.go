@@ -0,0 +1,148 @@
+package valueobjects
+
+import (
+	"errors"
+	"time"
+)
+
+type RecurrenceFrequency string
+
+const (
+	RecurrenceWeekly  RecurrenceFrequency = "weekly"
+	RecurrenceMonthly RecurrenceFrequency = "monthly"
+	RecurrenceYearly  RecurrenceFrequency = "yearly"
+)
+
+func (f RecurrenceFrequency) IsValid() bool {
+	return f == RecurrenceWeekly || f == RecurrenceMonthly || f == RecurrenceYearly
+}
+
+// Recurrence is an RRULE-like schedule of calendar days: every interval weeks, months or years
+// starting at startDate, optionally until endDate (inclusive).
+// Weekly schedules repeat on the start date's weekday, yearly ones on its month and day.
+// Monthly schedules repeat on dayOfMonth; days past the end of a month fall on its last day.
+type Recurrence struct {
+	frequency  RecurrenceFrequency
+	interval   int
+	dayOfMonth int
+	startDate  time.Time
+	endDate    *time.Time
+}
+
+// NewRecurrence validates a schedule. An interval of 0 means 1; a dayOfMonth of 0 means the start date's day.
+func NewRecurrence(frequency RecurrenceFrequency, interval, dayOfMonth int, startDate time.Time, endDate *time.Time) (Recurrence, error) {
+	if !frequency.IsValid() {
+		return Recurrence{}, errors.New("frequency must be weekly, monthly or yearly")
+	}
+
+	if interval == 0 {
+		interval = 1
+	}
+	if interval < 0 || interval > 100 {
+		return Recurrence{}, errors.New("interval must be between 1 and 100")
+	}
+
+	start := calendarDay(startDate)
+	if frequency == RecurrenceMonthly {
+		if dayOfMonth == 0 {
+			dayOfMonth = start.Day()
+		}
+		if dayOfMonth < 1 || dayOfMonth > 31 {
+			return Recurrence{}, errors.New("day of month must be between 1 and 31")
+		}
+	} else if dayOfMonth != 0 {
+		return Recurrence{}, errors.New("day of month only applies to monthly schedules")
+	}
+
+	var end *time.Time
+	if endDate != nil {
+		endDay := calendarDay(*endDate)
+		if endDay.Before(start) {
+			return Recurrence{}, errors.New("end date cannot be before start date")
+		}
+		end = &endDay
+	}
+
+	return Recurrence{
+		frequency:  frequency,
+		interval:   interval,
+		dayOfMonth: dayOfMonth,
+		startDate:  start,
+		endDate:    end,
+	}, nil
+}
+
+func (r Recurrence) Frequency() RecurrenceFrequency {
+	return r.frequency
+}
+
+func (r Recurrence) Interval() int {
+	return r.interval
+}
+
+// DayOfMonth is 0 for weekly and yearly schedules
+func (r Recurrence) DayOfMonth() int {
+	return r.dayOfMonth
+}
+
+func (r Recurrence) StartDate() time.Time {
+	return r.startDate
+}
+
+func (r Recurrence) EndDate() *time.Time {
+	return r.endDate
+}
+
+// Occurrences returns the scheduled days between from and to, both inclusive, in ascending order
+func (r Recurrence) Occurrences(from, to time.Time) []time.Time {
+	from, to = calendarDay(from), calendarDay(to)
+	if r.endDate != nil && r.endDate.Before(to) {
+		to = *r.endDate
+	}
+
+	var dates []time.Time
+	for n := 0; ; n++ {
+		date := r.nth(n)
+		if date.After(to) {
+			break
+		}
+		if date.Before(from) || date.Before(r.startDate) {
+			continue
+		}
+		dates = append(dates, date)
+	}
+	return dates
+}
+
+// IsOccurrence reports whether the schedule falls on date
+func (r Recurrence) IsOccurrence(date time.Time) bool {
+	return len(r.Occurrences(date, date)) == 1
+}
+
+// nth returns the n-th candidate day counted from the start date
+func (r Recurrence) nth(n int) time.Time {
+	switch r.frequency {
+	case RecurrenceWeekly:
+		return r.startDate.AddDate(0, 0, 7*r.interval*n)
+	case RecurrenceYearly:
+		return clampedDay(r.startDate.Year()+r.interval*n, r.startDate.Month(), r.startDate.Day())
+	default:
+		return clampedDay(r.startDate.Year(), r.startDate.Month()+time.Month(r.interval*n), r.dayOfMonth)
+	}
+}
+
+// clampedDay builds a date, moving days past the end of the month to its last day.
+// month may overflow, e.g. month 14 is February of the next year.
+func clampedDay(year int, month time.Month, day int) time.Time {
+	firstOfMonth := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+func calendarDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,878 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+	"expenso-backend/usecases/interactors/backup"
+)
+
+// Format names the archive so other JSON files are rejected
+const Format = "expenso-backup"
+
+// Version is the archive version Write produces. Read accepts it and every older version.
+// Bump it when the meaning of an existing field changes; new kinds of records and new fields only need omitempty.
+const Version = 1
+
+const dateLayout = "2006-01-02"
+
+// archive is the JSON document. Records keep the IDs they had in the household the backup was taken from,
+// and refer to each other by those IDs.
+type archive struct {
+	Format              string                   `json:"format"`
+	Version             int                      `json:"version"`
+	CreatedAt           time.Time                `json:"created_at"`
+	Categories          []categoryJSON           `json:"categories"`
+	Vendors             []vendorJSON             `json:"vendors"`
+	Tags                []tagJSON                `json:"tags"`
+	Members             []memberJSON             `json:"members"`
+	Expenses            []expenseJSON            `json:"expenses"`
+	Incomes             []incomeJSON             `json:"incomes"`
+	Budgets             []budgetJSON             `json:"budgets"`
+	RecurringRules      []recurringRuleJSON      `json:"recurring_rules"`
+	ImportProfiles      []importProfileJSON      `json:"import_profiles"`
+	CategorizationRules []categorizationRuleJSON `json:"categorization_rules,omitempty"`
+}
+
+type categoryJSON struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	Icon      string    `json:"icon"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type vendorJSON struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type tagJSON struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type memberJSON struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type expenseJSON struct {
+	ID         int       `json:"id"`
+	Amount     string    `json:"amount"`
+	Currency   string    `json:"currency"`
+	Date       string    `json:"date"`
+	Type       string    `json:"type"`
+	Category   string    `json:"category"`
+	Comment    string    `json:"comment"`
+	VendorID   *int      `json:"vendor_id,omitempty"`
+	PaidByCard bool      `json:"paid_by_card"`
+	MemberID   *int      `json:"member_id,omitempty"`
+	TagIDs     []int     `json:"tag_ids"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type incomeJSON struct {
+	ID        int       `json:"id"`
+	Amount    string    `json:"amount"`
+	Currency  string    `json:"currency"`
+	Date      string    `json:"date"`
+	Source    string    `json:"source"`
+	Comment   string    `json:"comment"`
+	VendorID  *int      `json:"vendor_id,omitempty"`
+	MemberID  *int      `json:"member_id,omitempty"`
+	TagIDs    []int     `json:"tag_ids"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type budgetJSON struct {
+	ID         int       `json:"id"`
+	Amount     string    `json:"amount"`
+	Currency   string    `json:"currency"`
+	Period     string    `json:"period"`
+	TargetType string    `json:"target_type"`
+	Target     string    `json:"target"`
+	Rollover   bool      `json:"rollover"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type recurringRuleJSON struct {
+	ID          int              `json:"id"`
+	Kind        string           `json:"kind"`
+	Name        string           `json:"name"`
+	Amount      string           `json:"amount"`
+	Currency    string           `json:"currency"`
+	Category    string           `json:"category"`
+	Source      string           `json:"source"`
+	Comment     string           `json:"comment"`
+	VendorID    *int             `json:"vendor_id,omitempty"`
+	MemberID    *int             `json:"member_id,omitempty"`
+	PaidByCard  bool             `json:"paid_by_card"`
+	TagIDs      []int            `json:"tag_ids"`
+	Frequency   string           `json:"frequency"`
+	Interval    int              `json:"interval"`
+	DayOfMonth  int              `json:"day_of_month"`
+	StartDate   string           `json:"start_date"`
+	EndDate     *string          `json:"end_date,omitempty"`
+	Active      bool             `json:"active"`
+	Occurrences []occurrenceJSON `json:"occurrences"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+type occurrenceJSON struct {
+	Date      string `json:"date"`
+	Status    string `json:"status"`
+	ExpenseID *int   `json:"expense_id,omitempty"`
+	IncomeID  *int   `json:"income_id,omitempty"`
+}
+
+type importProfileJSON struct {
+	ID                int               `json:"id"`
+	Name              string            `json:"name"`
+	Delimiter         string            `json:"delimiter"`
+	Encoding          string            `json:"encoding"`
+	DecimalSeparator  string            `json:"decimal_separator"`
+	DateFormats       []string          `json:"date_formats"`
+	SkipRows          int               `json:"skip_rows"`
+	Layout            string            `json:"layout"`
+	DateColumn        string            `json:"date_column"`
+	AmountColumn      string            `json:"amount_column"`
+	PayeeColumn       string            `json:"payee_column"`
+	MemoColumn        string            `json:"memo_column"`
+	VendorTypeColumns map[string]string `json:"vendor_type_columns"`
+	Currency          string            `json:"currency"`
+	DefaultCategory   string            `json:"default_category"`
+	Rules             []importRuleJSON  `json:"rules"`
+	CreatedAt         time.Time         `json:"created_at"`
+	UpdatedAt         time.Time         `json:"updated_at"`
+}
+
+type importRuleJSON struct {
+	Match      string `json:"match,omitempty"`
+	VendorType string `json:"vendor_type,omitempty"`
+	Category   string `json:"category,omitempty"`
+	VendorID   *int   `json:"vendor_id,omitempty"`
+}
+
+type categorizationRuleJSON struct {
+	ID         int       `json:"id"`
+	Name       string    `json:"name"`
+	Priority   int       `json:"priority"`
+	Active     bool      `json:"active"`
+	Pattern    string    `json:"pattern,omitempty"`
+	VendorType string    `json:"vendor_type,omitempty"`
+	Amount     *string   `json:"amount,omitempty"`
+	MinAmount  *string   `json:"min_amount,omitempty"`
+	MaxAmount  *string   `json:"max_amount,omitempty"`
+	Currency   string    `json:"currency,omitempty"`
+	VendorID   *int      `json:"vendor_id,omitempty"`
+	Category   string    `json:"category,omitempty"`
+	TagIDs     []int     `json:"tag_ids"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Write writes the snapshot as an archive, gzip-compressed if compress is set
+func Write(w io.Writer, snapshot *backup.Snapshot, compress bool) error {
+	doc := fromSnapshot(snapshot)
+
+	if compress {
+		gz := gzip.NewWriter(w)
+		if err := encode(gz, doc); err != nil {
+			return err
+		}
+		if err := gz.Close(); err != nil {
+			return fmt.Errorf("failed to compress backup: %w", err)
+		}
+		return nil
+	}
+	return encode(w, doc)
+}
+
+func encode(w io.Writer, doc *archive) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	return nil
+}
+
+// Read parses and validates an archive, plain or gzip-compressed.
+// Every reference must point to a record in the same archive; errors name the offending record.
+func Read(data []byte) (*backup.Snapshot, error) {
+	if len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b {
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("backup is not a valid gzip file: %w", err)
+		}
+		if data, err = io.ReadAll(gz); err != nil {
+			return nil, fmt.Errorf("backup is not a valid gzip file: %w", err)
+		}
+	}
+
+	var doc archive
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("backup is not valid JSON: %w", err)
+	}
+	if doc.Format != Format {
+		return nil, fmt.Errorf("not an expenso backup: format is %q", doc.Format)
+	}
+	if doc.Version < 1 || doc.Version > Version {
+		return nil, fmt.Errorf("backup version %d is not supported, this server reads versions 1 to %d", doc.Version, Version)
+	}
+
+	return doc.toSnapshot()
+}
+
+func fromSnapshot(snapshot *backup.Snapshot) *archive {
+	doc := &archive{
+		Format:              Format,
+		Version:             Version,
+		CreatedAt:           time.Now(),
+		Categories:          make([]categoryJSON, len(snapshot.Categories)),
+		Vendors:             make([]vendorJSON, len(snapshot.Vendors)),
+		Tags:                make([]tagJSON, len(snapshot.Tags)),
+		Members:             make([]memberJSON, len(snapshot.Members)),
+		Expenses:            make([]expenseJSON, len(snapshot.Expenses)),
+		Incomes:             make([]incomeJSON, len(snapshot.Incomes)),
+		Budgets:             make([]budgetJSON, len(snapshot.Budgets)),
+		RecurringRules:      make([]recurringRuleJSON, len(snapshot.RecurringRules)),
+		ImportProfiles:      make([]importProfileJSON, len(snapshot.ImportProfiles)),
+		CategorizationRules: make([]categorizationRuleJSON, len(snapshot.CategorizationRules)),
+	}
+
+	for i, category := range snapshot.Categories {
+		doc.Categories[i] = categoryJSON{
+			ID:        int(category.ID()),
+			Name:      category.Name(),
+			Color:     category.Color(),
+			Icon:      category.Icon(),
+			CreatedAt: category.CreatedAt(),
+			UpdatedAt: category.UpdatedAt(),
+		}
+	}
+	for i, vendor := range snapshot.Vendors {
+		doc.Vendors[i] = vendorJSON{
+			ID:        int(vendor.ID()),
+			Name:      vendor.Name(),
+			Type:      string(vendor.Type()),
+			CreatedAt: vendor.CreatedAt(),
+			UpdatedAt: vendor.UpdatedAt(),
+		}
+	}
+	for i, tag := range snapshot.Tags {
+		doc.Tags[i] = tagJSON{
+			ID:        int(tag.ID()),
+			Name:      tag.Name(),
+			Color:     tag.Color(),
+			CreatedAt: tag.CreatedAt(),
+			UpdatedAt: tag.UpdatedAt(),
+		}
+	}
+	for i, member := range snapshot.Members {
+		doc.Members[i] = memberJSON{
+			ID:        int(member.ID()),
+			Name:      member.Name(),
+			CreatedAt: member.CreatedAt(),
+			UpdatedAt: member.UpdatedAt(),
+		}
+	}
+	for i, expense := range snapshot.Expenses {
+		doc.Expenses[i] = expenseJSON{
+			ID:         int(expense.ID()),
+			Amount:     expense.Amount().Decimal(),
+			Currency:   expense.Amount().Currency(),
+			Date:       expense.Date().Format(dateLayout),
+			Type:       string(expense.Type()),
+			Category:   expense.Category().String(),
+			Comment:    expense.Comment(),
+			PaidByCard: expense.PaidByCard(),
+			TagIDs:     tagIDs(expense.Tags()),
+			CreatedAt:  expense.CreatedAt(),
+			UpdatedAt:  expense.UpdatedAt(),
+		}
+		if expense.Vendor() != nil {
+			doc.Expenses[i].VendorID = intPointer(int(expense.Vendor().ID()))
+		}
+		if expense.Member() != nil {
+			doc.Expenses[i].MemberID = intPointer(int(expense.Member().ID()))
+		}
+	}
+	for i, income := range snapshot.Incomes {
+		doc.Incomes[i] = incomeJSON{
+			ID:        int(income.ID()),
+			Amount:    income.Amount().Decimal(),
+			Currency:  income.Amount().Currency(),
+			Date:      income.Date().Format(dateLayout),
+			Source:    income.Source(),
+			Comment:   income.Comment(),
+			TagIDs:    tagIDs(income.Tags()),
+			CreatedAt: income.CreatedAt(),
+			UpdatedAt: income.UpdatedAt(),
+		}
+		if income.Vendor() != nil {
+			doc.Incomes[i].VendorID = intPointer(int(income.Vendor().ID()))
+		}
+		if income.Member() != nil {
+			doc.Incomes[i].MemberID = intPointer(int(income.Member().ID()))
+		}
+	}
+	for i, budget := range snapshot.Budgets {
+		doc.Budgets[i] = budgetJSON{
+			ID:         int(budget.ID()),
+			Amount:     budget.Amount().Decimal(),
+			Currency:   budget.Amount().Currency(),
+			Period:     string(budget.Period()),
+			TargetType: string(budget.TargetType()),
+			Target:     budget.Target(),
+			Rollover:   budget.Rollover(),
+			CreatedAt:  budget.CreatedAt(),
+			UpdatedAt:  budget.UpdatedAt(),
+		}
+	}
+	for i, item := range snapshot.RecurringRules {
+		doc.RecurringRules[i] = recurringRuleToJSON(item)
+	}
+	for i, profile := range snapshot.ImportProfiles {
+		doc.ImportProfiles[i] = importProfileToJSON(profile)
+	}
+	for i, rule := range snapshot.CategorizationRules {
+		doc.CategorizationRules[i] = categorizationRuleToJSON(rule)
+	}
+
+	return doc
+}
+
+func recurringRuleToJSON(item *backup.RecurringRuleSnapshot) recurringRuleJSON {
+	rule := item.Rule
+	schedule := rule.Schedule()
+	result := recurringRuleJSON{
+		ID:          int(rule.ID()),
+		Kind:        string(rule.Kind()),
+		Name:        rule.Name(),
+		Amount:      rule.Amount().Decimal(),
+		Currency:    rule.Amount().Currency(),
+		Category:    rule.Category(),
+		Source:      rule.Source(),
+		Comment:     rule.Comment(),
+		PaidByCard:  rule.PaidByCard(),
+		TagIDs:      make([]int, len(rule.TagIDs())),
+		Frequency:   string(schedule.Frequency()),
+		Interval:    schedule.Interval(),
+		DayOfMonth:  schedule.DayOfMonth(),
+		StartDate:   schedule.StartDate().Format(dateLayout),
+		Active:      rule.Active(),
+		Occurrences: make([]occurrenceJSON, len(item.Occurrences)),
+		CreatedAt:   rule.CreatedAt(),
+		UpdatedAt:   rule.UpdatedAt(),
+	}
+	if rule.VendorID() != nil {
+		result.VendorID = intPointer(int(*rule.VendorID()))
+	}
+	if rule.MemberID() != nil {
+		result.MemberID = intPointer(int(*rule.MemberID()))
+	}
+	for i, tagID := range rule.TagIDs() {
+		result.TagIDs[i] = int(tagID)
+	}
+	if schedule.EndDate() != nil {
+		endDate := schedule.EndDate().Format(dateLayout)
+		result.EndDate = &endDate
+	}
+	for i, occurrence := range item.Occurrences {
+		result.Occurrences[i] = occurrenceJSON{
+			Date:   occurrence.Date.Format(dateLayout),
+			Status: string(occurrence.Status),
+		}
+		if occurrence.ExpenseID != nil {
+			result.Occurrences[i].ExpenseID = intPointer(int(*occurrence.ExpenseID))
+		}
+		if occurrence.IncomeID != nil {
+			result.Occurrences[i].IncomeID = intPointer(int(*occurrence.IncomeID))
+		}
+	}
+	return result
+}
+
+func importProfileToJSON(profile *entities.ImportProfile) importProfileJSON {
+	settings := profile.Settings()
+	result := importProfileJSON{
+		ID:                int(profile.ID()),
+		Name:              profile.Name(),
+		Delimiter:         settings.Delimiter,
+		Encoding:          string(settings.Encoding),
+		DecimalSeparator:  settings.DecimalSeparator,
+		DateFormats:       settings.DateFormats,
+		SkipRows:          settings.SkipRows,
+		Layout:            string(settings.Layout),
+		DateColumn:        settings.DateColumn,
+		AmountColumn:      settings.AmountColumn,
+		PayeeColumn:       settings.PayeeColumn,
+		MemoColumn:        settings.MemoColumn,
+		VendorTypeColumns: make(map[string]string, len(settings.VendorTypeColumns)),
+		Currency:          settings.Currency,
+		DefaultCategory:   settings.DefaultCategory,
+		Rules:             make([]importRuleJSON, len(settings.Rules)),
+		CreatedAt:         profile.CreatedAt(),
+		UpdatedAt:         profile.UpdatedAt(),
+	}
+	for column, vendorType := range settings.VendorTypeColumns {
+		result.VendorTypeColumns[column] = string(vendorType)
+	}
+	for i, rule := range settings.Rules {
+		result.Rules[i] = importRuleJSON{Match: rule.Match, VendorType: string(rule.VendorType), Category: rule.Category}
+		if rule.VendorID != nil {
+			result.Rules[i].VendorID = intPointer(int(*rule.VendorID))
+		}
+	}
+	return result
+}
+
+func categorizationRuleToJSON(rule *entities.CategorizationRule) categorizationRuleJSON {
+	conditions := rule.Conditions()
+	actions := rule.Actions()
+	result := categorizationRuleJSON{
+		ID:         int(rule.ID()),
+		Name:       rule.Name(),
+		Priority:   rule.Priority(),
+		Active:     rule.Active(),
+		Pattern:    conditions.Pattern,
+		VendorType: string(conditions.VendorType),
+		Category:   actions.Category,
+		TagIDs:     make([]int, len(actions.TagIDs)),
+		CreatedAt:  rule.CreatedAt(),
+		UpdatedAt:  rule.UpdatedAt(),
+	}
+	for _, field := range []struct {
+		amount *valueobjects.Money
+		target **string
+	}{
+		{conditions.Amount, &result.Amount},
+		{conditions.MinAmount, &result.MinAmount},
+		{conditions.MaxAmount, &result.MaxAmount},
+	} {
+		if field.amount == nil {
+			continue
+		}
+		decimal := field.amount.Decimal()
+		*field.target = &decimal
+		result.Currency = field.amount.Currency()
+	}
+	if actions.VendorID != nil {
+		result.VendorID = intPointer(int(*actions.VendorID))
+	}
+	for i, tagID := range actions.TagIDs {
+		result.TagIDs[i] = int(tagID)
+	}
+	return result
+}
+
+// toSnapshot validates the records the way the API would on creation and resolves the references between them
+func (doc *archive) toSnapshot() (*backup.Snapshot, error) {
+	snapshot := &backup.Snapshot{}
+	vendors := make(map[int]*entities.Vendor, len(doc.Vendors))
+	tags := make(map[int]*entities.Tag, len(doc.Tags))
+	members := make(map[int]*entities.Member, len(doc.Members))
+	expenses := make(map[int]bool, len(doc.Expenses))
+	incomes := make(map[int]bool, len(doc.Incomes))
+
+	for i, item := range doc.Categories {
+		category, err := entities.NewCategoryEntity(item.Name, item.Color, item.Icon)
+		if err != nil {
+			return nil, fmt.Errorf("categories[%d]: %w", i, err)
+		}
+		snapshot.Categories = append(snapshot.Categories, entities.ReconstructCategory(entities.CategoryID(item.ID),
+			category.Name(), category.Color(), category.Icon(), item.CreatedAt, item.UpdatedAt))
+	}
+
+	for i, item := range doc.Vendors {
+		if _, ok := vendors[item.ID]; ok {
+			return nil, fmt.Errorf("vendors[%d]: id %d is used twice", i, item.ID)
+		}
+		vendor, err := entities.NewVendor(item.Name, entities.VendorType(item.Type))
+		if err != nil {
+			return nil, fmt.Errorf("vendors[%d]: %w", i, err)
+		}
+		vendors[item.ID] = entities.ReconstructVendor(entities.VendorID(item.ID), vendor.Name(), vendor.Type(), item.CreatedAt, item.UpdatedAt)
+		snapshot.Vendors = append(snapshot.Vendors, vendors[item.ID])
+	}
+
+	for i, item := range doc.Tags {
+		if _, ok := tags[item.ID]; ok {
+			return nil, fmt.Errorf("tags[%d]: id %d is used twice", i, item.ID)
+		}
+		tag, err := entities.NewTag(item.Name, item.Color)
+		if err != nil {
+			return nil, fmt.Errorf("tags[%d]: %w", i, err)
+		}
+		tags[item.ID] = entities.ReconstructTag(entities.TagID(item.ID), tag.Name(), tag.Color(), item.CreatedAt, item.UpdatedAt)
+		snapshot.Tags = append(snapshot.Tags, tags[item.ID])
+	}
+
+	for i, item := range doc.Members {
+		if _, ok := members[item.ID]; ok {
+			return nil, fmt.Errorf("members[%d]: id %d is used twice", i, item.ID)
+		}
+		member, err := entities.NewMember(item.Name)
+		if err != nil {
+			return nil, fmt.Errorf("members[%d]: %w", i, err)
+		}
+		members[item.ID] = entities.ReconstructMember(entities.MemberID(item.ID), member.Name(), item.CreatedAt, item.UpdatedAt)
+		snapshot.Members = append(snapshot.Members, members[item.ID])
+	}
+
+	for i, item := range doc.Expenses {
+		expense, err := item.toEntity(vendors, members, tags)
+		if err != nil {
+			return nil, fmt.Errorf("expenses[%d]: %w", i, err)
+		}
+		if expenses[item.ID] {
+			return nil, fmt.Errorf("expenses[%d]: id %d is used twice", i, item.ID)
+		}
+		expenses[item.ID] = true
+		snapshot.Expenses = append(snapshot.Expenses, expense)
+	}
+
+	for i, item := range doc.Incomes {
+		income, err := item.toEntity(vendors, members, tags)
+		if err != nil {
+			return nil, fmt.Errorf("incomes[%d]: %w", i, err)
+		}
+		if incomes[item.ID] {
+			return nil, fmt.Errorf("incomes[%d]: id %d is used twice", i, item.ID)
+		}
+		incomes[item.ID] = true
+		snapshot.Incomes = append(snapshot.Incomes, income)
+	}
+
+	for i, item := range doc.Budgets {
+		amount, err := valueobjects.ParseMoney(item.Amount, item.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("budgets[%d]: %w", i, err)
+		}
+		budget, err := entities.NewBudget(amount, entities.BudgetPeriod(item.Period), entities.BudgetTargetType(item.TargetType), item.Target, item.Rollover)
+		if err != nil {
+			return nil, fmt.Errorf("budgets[%d]: %w", i, err)
+		}
+		snapshot.Budgets = append(snapshot.Budgets, entities.ReconstructBudget(entities.BudgetID(item.ID), budget.Amount(), budget.Period(),
+			budget.TargetType(), budget.Target(), budget.Rollover(), item.CreatedAt, item.UpdatedAt))
+	}
+
+	for i, item := range doc.RecurringRules {
+		rule, err := item.toSnapshot(vendors, members, tags, expenses, incomes)
+		if err != nil {
+			return nil, fmt.Errorf("recurring_rules[%d]: %w", i, err)
+		}
+		snapshot.RecurringRules = append(snapshot.RecurringRules, rule)
+	}
+
+	for i, item := range doc.ImportProfiles {
+		profile, err := item.toEntity(vendors)
+		if err != nil {
+			return nil, fmt.Errorf("import_profiles[%d]: %w", i, err)
+		}
+		snapshot.ImportProfiles = append(snapshot.ImportProfiles, profile)
+	}
+
+	for i, item := range doc.CategorizationRules {
+		rule, err := item.toEntity(vendors, tags)
+		if err != nil {
+			return nil, fmt.Errorf("categorization_rules[%d]: %w", i, err)
+		}
+		snapshot.CategorizationRules = append(snapshot.CategorizationRules, rule)
+	}
+
+	return snapshot, nil
+}
+
+func (item expenseJSON) toEntity(vendors map[int]*entities.Vendor, members map[int]*entities.Member, tags map[int]*entities.Tag) (*entities.Expense, error) {
+	amount, err := valueobjects.ParseMoney(item.Amount, item.Currency)
+	if err != nil {
+		return nil, err
+	}
+	date, err := time.Parse(dateLayout, item.Date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", item.Date)
+	}
+	expenseType := entities.ExpenseType(item.Type)
+	if !expenseType.IsValid() {
+		return nil, fmt.Errorf("invalid expense type %q", item.Type)
+	}
+	category, err := entities.NewCategory(item.Category)
+	if err != nil {
+		return nil, err
+	}
+	vendor, err := lookupVendor(vendors, item.VendorID)
+	if err != nil {
+		return nil, err
+	}
+	member, err := lookupMember(members, item.MemberID)
+	if err != nil {
+		return nil, err
+	}
+	expenseTags, err := lookupTags(tags, item.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.ReconstructExpense(entities.ExpenseID(item.ID), amount, date, expenseType, category, item.Comment,
+		vendor, item.PaidByCard, member, expenseTags, item.CreatedAt, item.UpdatedAt), nil
+}
+
+func (item incomeJSON) toEntity(vendors map[int]*entities.Vendor, members map[int]*entities.Member, tags map[int]*entities.Tag) (*entities.Income, error) {
+	amount, err := valueobjects.ParseMoney(item.Amount, item.Currency)
+	if err != nil {
+		return nil, err
+	}
+	date, err := time.Parse(dateLayout, item.Date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", item.Date)
+	}
+	if item.Source == "" {
+		return nil, errors.New("income source cannot be empty")
+	}
+	vendor, err := lookupVendor(vendors, item.VendorID)
+	if err != nil {
+		return nil, err
+	}
+	member, err := lookupMember(members, item.MemberID)
+	if err != nil {
+		return nil, err
+	}
+	incomeTags, err := lookupTags(tags, item.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.ReconstructIncome(entities.IncomeID(item.ID), amount, date, item.Source, item.Comment,
+		vendor, member, incomeTags, item.CreatedAt, item.UpdatedAt), nil
+}
+
+func (item recurringRuleJSON) toSnapshot(vendors map[int]*entities.Vendor, members map[int]*entities.Member, tags map[int]*entities.Tag,
+	expenses, incomes map[int]bool) (*backup.RecurringRuleSnapshot, error) {
+	amount, err := valueobjects.ParseMoney(item.Amount, item.Currency)
+	if err != nil {
+		return nil, err
+	}
+	startDate, err := time.Parse(dateLayout, item.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date %q, expected YYYY-MM-DD", item.StartDate)
+	}
+	var endDate *time.Time
+	if item.EndDate != nil {
+		parsed, err := time.Parse(dateLayout, *item.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end date %q, expected YYYY-MM-DD", *item.EndDate)
+		}
+		endDate = &parsed
+	}
+	schedule, err := valueobjects.NewRecurrence(valueobjects.RecurrenceFrequency(item.Frequency), item.Interval, item.DayOfMonth, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := entities.NewRecurringRule(entities.RecurringKind(item.Kind), item.Name, amount, schedule); err != nil {
+		return nil, err
+	}
+
+	var vendorID *entities.VendorID
+	if vendor, err := lookupVendor(vendors, item.VendorID); err != nil {
+		return nil, err
+	} else if vendor != nil {
+		id := vendor.ID()
+		vendorID = &id
+	}
+	var memberID *entities.MemberID
+	if member, err := lookupMember(members, item.MemberID); err != nil {
+		return nil, err
+	} else if member != nil {
+		id := member.ID()
+		memberID = &id
+	}
+	ruleTags, err := lookupTags(tags, item.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs := make([]entities.TagID, len(ruleTags))
+	for i, tag := range ruleTags {
+		tagIDs[i] = tag.ID()
+	}
+
+	rule := entities.ReconstructRecurringRule(entities.RecurringRuleID(item.ID), entities.RecurringKind(item.Kind), item.Name, amount,
+		item.Category, item.Source, item.Comment, vendorID, memberID, item.PaidByCard, tagIDs, schedule, item.Active, item.CreatedAt, item.UpdatedAt)
+
+	result := &backup.RecurringRuleSnapshot{Rule: rule}
+	for i, occurrence := range item.Occurrences {
+		date, err := time.Parse(dateLayout, occurrence.Date)
+		if err != nil {
+			return nil, fmt.Errorf("occurrences[%d]: invalid date %q, expected YYYY-MM-DD", i, occurrence.Date)
+		}
+		status := entities.OccurrenceStatus(occurrence.Status)
+		if status != entities.OccurrencePending && status != entities.OccurrenceCreated && status != entities.OccurrenceSkipped {
+			return nil, fmt.Errorf("occurrences[%d]: invalid status %q", i, occurrence.Status)
+		}
+		restored := &entities.RecurringOccurrence{RuleID: rule.ID(), Date: date, Status: status}
+		if occurrence.ExpenseID != nil {
+			if !expenses[*occurrence.ExpenseID] {
+				return nil, fmt.Errorf("occurrences[%d]: expense %d is not in the archive", i, *occurrence.ExpenseID)
+			}
+			id := entities.ExpenseID(*occurrence.ExpenseID)
+			restored.ExpenseID = &id
+		}
+		if occurrence.IncomeID != nil {
+			if !incomes[*occurrence.IncomeID] {
+				return nil, fmt.Errorf("occurrences[%d]: income %d is not in the archive", i, *occurrence.IncomeID)
+			}
+			id := entities.IncomeID(*occurrence.IncomeID)
+			restored.IncomeID = &id
+		}
+		result.Occurrences = append(result.Occurrences, restored)
+	}
+	return result, nil
+}
+
+func (item importProfileJSON) toEntity(vendors map[int]*entities.Vendor) (*entities.ImportProfile, error) {
+	settings := entities.ImportProfileSettings{
+		Delimiter:         item.Delimiter,
+		Encoding:          entities.CSVEncoding(item.Encoding),
+		DecimalSeparator:  item.DecimalSeparator,
+		DateFormats:       item.DateFormats,
+		SkipRows:          item.SkipRows,
+		Layout:            entities.CSVLayout(item.Layout),
+		DateColumn:        item.DateColumn,
+		AmountColumn:      item.AmountColumn,
+		PayeeColumn:       item.PayeeColumn,
+		MemoColumn:        item.MemoColumn,
+		VendorTypeColumns: make(map[string]entities.VendorType, len(item.VendorTypeColumns)),
+		Currency:          item.Currency,
+		DefaultCategory:   item.DefaultCategory,
+		Rules:             make([]entities.ImportRule, len(item.Rules)),
+	}
+	for column, vendorType := range item.VendorTypeColumns {
+		settings.VendorTypeColumns[column] = entities.VendorType(vendorType)
+	}
+	for i, rule := range item.Rules {
+		settings.Rules[i] = entities.ImportRule{Match: rule.Match, VendorType: entities.VendorType(rule.VendorType), Category: rule.Category}
+		if vendor, err := lookupVendor(vendors, rule.VendorID); err != nil {
+			return nil, fmt.Errorf("rules[%d]: %w", i, err)
+		} else if vendor != nil {
+			id := vendor.ID()
+			settings.Rules[i].VendorID = &id
+		}
+	}
+
+	profile, err := entities.NewImportProfile(item.Name, settings)
+	if err != nil {
+		return nil, err
+	}
+	return entities.ReconstructImportProfile(entities.ImportProfileID(item.ID), profile.Name(), profile.Settings(), item.CreatedAt, item.UpdatedAt), nil
+}
+
+func (item categorizationRuleJSON) toEntity(vendors map[int]*entities.Vendor, tags map[int]*entities.Tag) (*entities.CategorizationRule, error) {
+	conditions := entities.RuleConditions{Pattern: item.Pattern, VendorType: entities.VendorType(item.VendorType)}
+	for _, field := range []struct {
+		decimal *string
+		target  **valueobjects.Money
+	}{
+		{item.Amount, &conditions.Amount},
+		{item.MinAmount, &conditions.MinAmount},
+		{item.MaxAmount, &conditions.MaxAmount},
+	} {
+		if field.decimal == nil {
+			continue
+		}
+		amount, err := valueobjects.ParseMoney(*field.decimal, item.Currency)
+		if err != nil {
+			return nil, err
+		}
+		*field.target = &amount
+	}
+
+	actions := entities.RuleActions{Category: item.Category}
+	if vendor, err := lookupVendor(vendors, item.VendorID); err != nil {
+		return nil, err
+	} else if vendor != nil {
+		id := vendor.ID()
+		actions.VendorID = &id
+	}
+	ruleTags, err := lookupTags(tags, item.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range ruleTags {
+		actions.TagIDs = append(actions.TagIDs, tag.ID())
+	}
+
+	rule, err := entities.NewCategorizationRule(item.Name, item.Priority, conditions, actions)
+	if err != nil {
+		return nil, err
+	}
+	return entities.ReconstructCategorizationRule(entities.CategorizationRuleID(item.ID), rule.Name(), rule.Priority(), item.Active,
+		rule.Conditions(), rule.Actions(), item.CreatedAt, item.UpdatedAt), nil
+}
+
+func lookupVendor(vendors map[int]*entities.Vendor, id *int) (*entities.Vendor, error) {
+	if id == nil {
+		return nil, nil
+	}
+	vendor, ok := vendors[*id]
+	if !ok {
+		return nil, fmt.Errorf("vendor %d is not in the archive", *id)
+	}
+	return vendor, nil
+}
+
+func lookupMember(members map[int]*entities.Member, id *int) (*entities.Member, error) {
+	if id == nil {
+		return nil, nil
+	}
+	member, ok := members[*id]
+	if !ok {
+		return nil, fmt.Errorf("member %d is not in the archive", *id)
+	}
+	return member, nil
+}
+
+func lookupTags(tags map[int]*entities.Tag, ids []int) ([]*entities.Tag, error) {
+	result := make([]*entities.Tag, 0, len(ids))
+	for _, id := range ids {
+		tag, ok := tags[id]
+		if !ok {
+			return nil, fmt.Errorf("tag %d is not in the archive", id)
+		}
+		result = append(result, tag)
+	}
+	return result, nil
+}
+
+func tagIDs(tags []*entities.Tag) []int {
+	ids := make([]int, len(tags))
+	for i, tag := range tags {
+		ids[i] = int(tag.ID())
+	}
+	return ids
+}
+
+func intPointer(value int) *int {
+	return &value
+}
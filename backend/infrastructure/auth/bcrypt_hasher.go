@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"errors"
+
+	"expenso-backend/usecases/interfaces/services"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// bcrypt ignores everything past 72 bytes, so longer passwords are rejected instead of silently truncated
+const maxPasswordBytes = 72
+
+type BcryptHasher struct {
+	cost int
+}
+
+func NewBcryptHasher(cost int) services.PasswordHasher {
+	if cost < bcrypt.MinCost {
+		cost = bcrypt.DefaultCost
+	}
+	return &BcryptHasher{cost: cost}
+}
+
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	if len(password) > maxPasswordBytes {
+		return "", errors.New("password cannot be longer than 72 bytes")
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (h *BcryptHasher) Compare(hash, password string) error {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+}
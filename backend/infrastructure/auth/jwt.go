@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/usecases/interfaces/services"
+)
+
+const jwtIssuer = "expenso"
+
+// JWTIssuer signs session tokens as HS256 JSON Web Tokens
+type JWTIssuer struct {
+	secret []byte
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+type jwtPayload struct {
+	Issuer      string `json:"iss"`
+	Subject     string `json:"sub"`
+	HouseholdID int    `json:"hid,omitempty"`
+	IssuedAt    int64  `json:"iat"`
+	ExpiresAt   int64  `json:"exp"`
+}
+
+func NewJWTIssuer(secret string) (services.TokenIssuer, error) {
+	if len(secret) < 32 {
+		return nil, errors.New("jwt secret must be at least 32 characters")
+	}
+	return &JWTIssuer{secret: []byte(secret)}, nil
+}
+
+func (j *JWTIssuer) Issue(claims services.SessionClaims) (string, error) {
+	header, err := json.Marshal(jwtHeader{Alg: "HS256", Typ: "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(jwtPayload{
+		Issuer:      jwtIssuer,
+		Subject:     strconv.Itoa(int(claims.UserID)),
+		HouseholdID: int(claims.HouseholdID),
+		IssuedAt:    time.Now().Unix(),
+		ExpiresAt:   claims.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encodeSegment(header) + "." + encodeSegment(payload)
+	return signingInput + "." + encodeSegment(j.sign(signingInput)), nil
+}
+
+func (j *JWTIssuer) Verify(token string) (*services.SessionClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, entities.ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, j.sign(parts[0]+"."+parts[1])) {
+		return nil, entities.ErrInvalidToken
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return nil, entities.ErrInvalidToken
+	}
+
+	var payload jwtPayload
+	if err := decodeSegment(parts[1], &payload); err != nil || payload.Issuer != jwtIssuer {
+		return nil, entities.ErrInvalidToken
+	}
+
+	expiresAt := time.Unix(payload.ExpiresAt, 0)
+	if !time.Now().Before(expiresAt) {
+		return nil, entities.ErrInvalidToken
+	}
+
+	userID, err := strconv.Atoi(payload.Subject)
+	if err != nil {
+		return nil, entities.ErrInvalidToken
+	}
+
+	return &services.SessionClaims{
+		UserID:      entities.UserID(userID),
+		HouseholdID: entities.HouseholdID(payload.HouseholdID),
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+func (j *JWTIssuer) sign(signingInput string) []byte {
+	mac := hmac.New(sha256.New, j.secret)
+	mac.Write([]byte(signingInput))
+	return mac.Sum(nil)
+}
+
+func encodeSegment(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -25,11 +26,28 @@ type ServerConfig struct {
 	Port int    `yaml:"port"`
 }
 
+// AuthConfig holds authentication configuration
+type AuthConfig struct {
+	JWTSecret           string   `yaml:"jwt_secret"`           // HMAC key for session tokens, at least 32 characters
+	SessionTTLHours     int      `yaml:"session_ttl_hours"`    // Session token lifetime (default: 24)
+	RegistrationEnabled bool     `yaml:"registration_enabled"` // Allow sign-ups after the first account exists
+	BcryptCost          int      `yaml:"bcrypt_cost"`          // Password hashing cost (default: bcrypt default)
+	AdminEmails         []string `yaml:"admin_emails"`         // Accounts that may import the exchange rates all households share
+}
+
+// SchedulerConfig holds background job configuration
+type SchedulerConfig struct {
+	Enabled         bool `yaml:"enabled"`          // Create due recurring transactions in the background
+	IntervalMinutes int  `yaml:"interval_minutes"` // Time between runs (default: 60)
+}
+
 // Config holds all application configuration
 type Config struct {
-	Environment string         `yaml:"environment"`
-	Server      ServerConfig   `yaml:"server"`
-	Database    DatabaseConfig `yaml:"database"`
+	Environment string          `yaml:"environment"`
+	Server      ServerConfig    `yaml:"server"`
+	Database    DatabaseConfig  `yaml:"database"`
+	Auth        AuthConfig      `yaml:"auth"`
+	Scheduler   SchedulerConfig `yaml:"scheduler"`
 }
 
 // GetDatabaseURL constructs database URL from config
@@ -49,6 +67,22 @@ func (c *Config) GetServerAddress() string {
 	return fmt.Sprintf("%s:%d", c.Server.Host, c.Server.Port)
 }
 
+// GetSessionTTL returns how long issued session tokens stay valid
+func (c *Config) GetSessionTTL() time.Duration {
+	if c.Auth.SessionTTLHours <= 0 {
+		return 24 * time.Hour
+	}
+	return time.Duration(c.Auth.SessionTTLHours) * time.Hour
+}
+
+// GetSchedulerInterval returns the time between recurring scheduler runs
+func (c *Config) GetSchedulerInterval() time.Duration {
+	if c.Scheduler.IntervalMinutes <= 0 {
+		return time.Hour
+	}
+	return time.Duration(c.Scheduler.IntervalMinutes) * time.Minute
+}
+
 // LoadConfig loads configuration from YAML file
 func LoadConfig(configPath string) (*Config, error) {
 	// Check if config file exists
@@ -89,6 +123,10 @@ func LoadConfigForEnvironment() (*Config, error) {
 	}
 
 	// Override with environment variables if they exist
+	if jwtSecret := os.Getenv("JWT_SECRET"); jwtSecret != "" {
+		config.Auth.JWTSecret = jwtSecret
+	}
+
 	if dbURL := os.Getenv("DATABASE_URL"); dbURL != "" {
 		// Parse DATABASE_URL and override config
 		// For now, we'll just return an error if DATABASE_URL is set
@@ -0,0 +1,63 @@
+package exporters
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"expenso-backend/domain/entities"
+)
+
+// ExpenseCSVHeader lists the columns of the full expense export, one row per expense
+var ExpenseCSVHeader = []string{
+	"id", "date", "amount", "currency", "type", "category", "vendor", "vendor_type",
+	"paid_by_card", "added_by", "tags", "comment",
+}
+
+// WriteExpenseCSV writes every field of the expenses, in the order given, with their original amount and currency.
+// Tag names are separated by semicolons.
+func WriteExpenseCSV(w io.Writer, expenses []*entities.Expense) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(ExpenseCSVHeader); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, expense := range expenses {
+		var vendor, vendorType, addedBy string
+		if expense.Vendor() != nil {
+			vendor = expense.Vendor().Name()
+			vendorType = string(expense.Vendor().Type())
+		}
+		if expense.Member() != nil {
+			addedBy = expense.Member().Name()
+		}
+		tags := make([]string, len(expense.Tags()))
+		for i, tag := range expense.Tags() {
+			tags[i] = tag.Name()
+		}
+
+		record := []string{
+			strconv.Itoa(int(expense.ID())),
+			expense.Date().Format("2006-01-02"),
+			expense.Amount().Decimal(),
+			expense.Amount().Currency(),
+			string(expense.Type()),
+			expense.Category().String(),
+			vendor,
+			vendorType,
+			strconv.FormatBool(expense.PaidByCard()),
+			addedBy,
+			strings.Join(tags, ";"),
+			expense.Comment(),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,301 @@
+package exporters
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"expenso-backend/domain/entities"
+)
+
+// JournalFormat is a plain-text accounting dialect
+type JournalFormat string
+
+const (
+	JournalLedger    JournalFormat = "ledger"
+	JournalHledger   JournalFormat = "hledger"
+	JournalBeancount JournalFormat = "beancount"
+)
+
+func (f JournalFormat) IsValid() bool {
+	return f == JournalLedger || f == JournalHledger || f == JournalBeancount
+}
+
+// FileExtension is the extension the tools expect for the format
+func (f JournalFormat) FileExtension() string {
+	switch f {
+	case JournalHledger:
+		return "journal"
+	case JournalBeancount:
+		return "beancount"
+	default:
+		return "ledger"
+	}
+}
+
+// Payment accounts: card payments and incomes go through the bank account, everything else is cash
+const (
+	BankAccount = "Assets:Bank"
+	CashAccount = "Assets:Cash"
+)
+
+// journalEntry is one balanced transaction, the same for every format
+type journalEntry struct {
+	date      time.Time
+	kind      int // expenses before incomes on the same day
+	id        int
+	payee     string
+	narration string
+	account   string // the expense or income account
+	payment   string // the asset account
+	amount    string
+	negate    bool // money comes in: the asset account is debited
+	currency  string
+	metadata  [][2]string
+	tags      []string
+}
+
+// WriteJournal renders expenses and incomes as a journal for ledger-cli, hledger or beancount.
+// Expenses post to Expenses:<VendorType>:<Vendor>, or Expenses:<Category> without a vendor, and incomes to Income:<VendorType>:<Vendor> or Income:<Source>.
+// The output only depends on the transactions, so exports of the same data can be diffed.
+func WriteJournal(w io.Writer, format JournalFormat, expenses []*entities.Expense, incomes []*entities.Income) error {
+	if !format.IsValid() {
+		return fmt.Errorf("unknown journal format %q", format)
+	}
+
+	entries := make([]journalEntry, 0, len(expenses)+len(incomes))
+	for _, expense := range expenses {
+		entries = append(entries, expenseEntry(expense))
+	}
+	for _, income := range incomes {
+		entries = append(entries, incomeEntry(income))
+	}
+	sort.SliceStable(entries, func(a, b int) bool {
+		if !entries[a].date.Equal(entries[b].date) {
+			return entries[a].date.Before(entries[b].date)
+		}
+		if entries[a].kind != entries[b].kind {
+			return entries[a].kind < entries[b].kind
+		}
+		return entries[a].id < entries[b].id
+	})
+
+	out := bufio.NewWriter(w)
+
+	// Beancount refuses postings to accounts that were never opened
+	if format == JournalBeancount {
+		writeBeancountOpenings(out, entries)
+	}
+
+	for _, entry := range entries {
+		switch format {
+		case JournalBeancount:
+			writeBeancountEntry(out, entry)
+		default:
+			writeLedgerEntry(out, format, entry)
+		}
+	}
+
+	if err := out.Flush(); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	return nil
+}
+
+func expenseEntry(expense *entities.Expense) journalEntry {
+	entry := journalEntry{
+		date:      expense.Date(),
+		kind:      0,
+		id:        int(expense.ID()),
+		payee:     expense.Category().String(),
+		narration: expense.Category().String(),
+		amount:    expense.Amount().Decimal(),
+		currency:  expense.Amount().Currency(),
+		payment:   CashAccount,
+		metadata:  [][2]string{{"id", fmt.Sprintf("expense-%d", expense.ID())}, {"category", expense.Category().String()}},
+		tags:      tagNames(expense.Tags()),
+	}
+	if expense.PaidByCard() {
+		entry.payment = BankAccount
+	}
+
+	root := "Expenses"
+	if expense.Type() == entities.ExpenseTypeIncome {
+		root = "Income"
+		entry.negate = true
+	}
+	if vendor := expense.Vendor(); vendor != nil {
+		entry.payee = vendor.Name()
+		entry.account = accountName(root, string(vendor.Type()), vendor.Name())
+	} else {
+		entry.account = accountName(root, expense.Category().String())
+	}
+
+	if expense.Member() != nil {
+		entry.metadata = append(entry.metadata, [2]string{"member", expense.Member().Name()})
+	}
+	if expense.Comment() != "" {
+		entry.metadata = append(entry.metadata, [2]string{"comment", expense.Comment()})
+	}
+	return entry
+}
+
+func incomeEntry(income *entities.Income) journalEntry {
+	entry := journalEntry{
+		date:      income.Date(),
+		kind:      1,
+		id:        int(income.ID()),
+		payee:     income.Source(),
+		narration: income.Source(),
+		amount:    income.Amount().Decimal(),
+		currency:  income.Amount().Currency(),
+		payment:   BankAccount,
+		negate:    true,
+		metadata:  [][2]string{{"id", fmt.Sprintf("income-%d", income.ID())}, {"source", income.Source()}},
+		tags:      tagNames(income.Tags()),
+	}
+
+	if vendor := income.Vendor(); vendor != nil {
+		entry.payee = vendor.Name()
+		entry.account = accountName("Income", string(vendor.Type()), vendor.Name())
+	} else {
+		entry.account = accountName("Income", income.Source())
+	}
+
+	if income.Member() != nil {
+		entry.metadata = append(entry.metadata, [2]string{"member", income.Member().Name()})
+	}
+	if income.Comment() != "" {
+		entry.metadata = append(entry.metadata, [2]string{"comment", income.Comment()})
+	}
+	return entry
+}
+
+// postings returns the account and the asset side with their signed amounts
+func (e journalEntry) postings() [2][2]string {
+	debit, credit := e.amount, "-"+e.amount
+	if e.negate {
+		debit, credit = credit, debit
+	}
+	return [2][2]string{{e.account, debit}, {e.payment, credit}}
+}
+
+func writeLedgerEntry(out *bufio.Writer, format JournalFormat, entry journalEntry) {
+	fmt.Fprintf(out, "%s * %s\n", entry.date.Format("2006-01-02"), singleLine(entry.payee))
+	for _, pair := range entry.metadata {
+		fmt.Fprintf(out, "    ; %s: %s\n", pair[0], singleLine(pair[1]))
+	}
+	if len(entry.tags) > 0 {
+		if format == JournalHledger {
+			fmt.Fprintf(out, "    ; %s:\n", strings.Join(entry.tags, ":, "))
+		} else {
+			fmt.Fprintf(out, "    ; :%s:\n", strings.Join(entry.tags, ":"))
+		}
+	}
+	for _, posting := range entry.postings() {
+		fmt.Fprintf(out, "    %-48s  %s %s\n", posting[0], posting[1], entry.currency)
+	}
+	fmt.Fprintln(out)
+}
+
+func writeBeancountEntry(out *bufio.Writer, entry journalEntry) {
+	// Without a vendor the payee would only repeat the narration
+	if entry.payee == entry.narration {
+		fmt.Fprintf(out, "%s * %s", entry.date.Format("2006-01-02"), quote(entry.narration))
+	} else {
+		fmt.Fprintf(out, "%s * %s %s", entry.date.Format("2006-01-02"), quote(entry.payee), quote(entry.narration))
+	}
+	for _, tag := range entry.tags {
+		fmt.Fprintf(out, " #%s", tag)
+	}
+	fmt.Fprintln(out)
+	for _, pair := range entry.metadata {
+		fmt.Fprintf(out, "  %s: %s\n", pair[0], quote(pair[1]))
+	}
+	for _, posting := range entry.postings() {
+		fmt.Fprintf(out, "  %-48s  %s %s\n", posting[0], posting[1], entry.currency)
+	}
+	fmt.Fprintln(out)
+}
+
+// writeBeancountOpenings opens every account on the day it is first used
+func writeBeancountOpenings(out *bufio.Writer, entries []journalEntry) {
+	opened := make(map[string]time.Time)
+	for _, entry := range entries {
+		for _, account := range []string{entry.account, entry.payment} {
+			if first, ok := opened[account]; !ok || entry.date.Before(first) {
+				opened[account] = entry.date
+			}
+		}
+	}
+
+	accounts := make([]string, 0, len(opened))
+	for account := range opened {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+
+	for _, account := range accounts {
+		fmt.Fprintf(out, "%s open %s\n", opened[account].Format("2006-01-02"), account)
+	}
+	if len(accounts) > 0 {
+		fmt.Fprintln(out)
+	}
+}
+
+// accountName joins the parts into an account every format accepts: each part becomes CamelCase letters and digits,
+// so "food_store" turns into FoodStore and "Food & Dining" into FoodDining
+func accountName(root string, parts ...string) string {
+	components := []string{root}
+	for _, part := range parts {
+		var component strings.Builder
+		upper := true
+		for _, r := range part {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				upper = true
+				continue
+			}
+			if upper {
+				r = unicode.ToUpper(r)
+				upper = false
+			}
+			component.WriteRune(r)
+		}
+		if component.Len() > 0 {
+			components = append(components, component.String())
+		}
+	}
+	if len(components) == 1 {
+		components = append(components, "Other")
+	}
+	return strings.Join(components, ":")
+}
+
+// tagNames keeps the characters all three tools allow in tag names and replaces the rest with dashes
+func tagNames(tags []*entities.Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		name := strings.Map(func(r rune) rune {
+			if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_') {
+				return r
+			}
+			return '-'
+		}, tag.Name())
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// singleLine keeps free text from breaking the line-based ledger syntax
+func singleLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func quote(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(singleLine(text)) + `"`
+}
@@ -0,0 +1,333 @@
+package exporters
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+)
+
+// WorkbookTransaction is an expense or an income with its amount in the workbook's reporting currency.
+// Exactly one of Expense and Income is set.
+type WorkbookTransaction struct {
+	Expense  *entities.Expense
+	Income   *entities.Income
+	Reported valueobjects.Money
+}
+
+const noVendor = "no vendor"
+
+// Month sheet columns that the summary formulas refer to
+const (
+	monthColumnKind     = "B"
+	monthColumnCategory = "C"
+	monthColumnType     = "E"
+	monthColumnReported = "L"
+)
+
+type workbookRow struct {
+	transaction WorkbookTransaction
+	income      bool
+	category    string // the source for incomes
+	vendor      string
+	vendorType  string
+	reported    float64
+}
+
+// WriteWorkbook writes an .xlsx file with a summary sheet of expenses per category and vendor type and month,
+// an income vs. expenses sheet, and one sheet per month listing its transactions.
+// Summary cells are formulas over the month sheets, so edits to a month sheet carry through.
+func WriteWorkbook(w io.Writer, transactions []WorkbookTransaction, reportingCurrency string) error {
+	rows := make([]workbookRow, len(transactions))
+	for i, transaction := range transactions {
+		rows[i] = newWorkbookRow(transaction)
+	}
+	sort.SliceStable(rows, func(a, b int) bool {
+		if !rows[a].date().Equal(rows[b].date()) {
+			return rows[a].date().Before(rows[b].date())
+		}
+		if rows[a].income != rows[b].income {
+			return !rows[a].income
+		}
+		return rows[a].id() < rows[b].id()
+	})
+
+	// Month sheets in calendar order
+	var months []string
+	byMonth := make(map[string][]workbookRow)
+	for _, row := range rows {
+		month := row.date().Format("2006-01")
+		if _, ok := byMonth[month]; !ok {
+			months = append(months, month)
+		}
+		byMonth[month] = append(byMonth[month], row)
+	}
+
+	sheets := []*xlsxSheet{
+		summarySheet(months, byMonth, reportingCurrency),
+		incomeVsExpensesSheet(months, byMonth, reportingCurrency),
+	}
+	for _, month := range months {
+		sheets = append(sheets, monthSheet(month, byMonth[month], reportingCurrency))
+	}
+
+	return writeXLSX(w, sheets)
+}
+
+func newWorkbookRow(transaction WorkbookTransaction) workbookRow {
+	row := workbookRow{transaction: transaction, vendorType: noVendor, reported: transaction.Reported.Amount()}
+	var vendor *entities.Vendor
+	if transaction.Income != nil {
+		row.income = true
+		row.category = transaction.Income.Source()
+		vendor = transaction.Income.Vendor()
+	} else {
+		row.income = transaction.Expense.Type() == entities.ExpenseTypeIncome
+		row.category = transaction.Expense.Category().String()
+		vendor = transaction.Expense.Vendor()
+	}
+	if vendor != nil {
+		row.vendor = vendor.Name()
+		row.vendorType = string(vendor.Type())
+	}
+	return row
+}
+
+func (r workbookRow) date() time.Time {
+	if r.transaction.Income != nil {
+		return r.transaction.Income.Date()
+	}
+	return r.transaction.Expense.Date()
+}
+
+func (r workbookRow) id() int {
+	if r.transaction.Income != nil {
+		return int(r.transaction.Income.ID())
+	}
+	return int(r.transaction.Expense.ID())
+}
+
+func (r workbookRow) kind() string {
+	if r.income {
+		return "Income"
+	}
+	return "Expense"
+}
+
+func monthSheet(month string, rows []workbookRow, reportingCurrency string) *xlsxSheet {
+	sheet := &xlsxSheet{
+		name:         month,
+		widths:       []float64{12, 10, 22, 22, 16, 8, 14, 22, 36, 12, 9, 14},
+		freezeHeader: true,
+		autoFilter:   true,
+	}
+	sheet.addRow(
+		textCell("Date", styleHeader),
+		textCell("Kind", styleHeader),
+		textCell("Category / Source", styleHeader),
+		textCell("Vendor", styleHeader),
+		textCell("Vendor type", styleHeader),
+		textCell("Paid by", styleHeader),
+		textCell("Member", styleHeader),
+		textCell("Tags", styleHeader),
+		textCell("Comment", styleHeader),
+		textCell("Amount", styleHeader),
+		textCell("Currency", styleHeader),
+		textCell("Amount ("+reportingCurrency+")", styleHeader),
+	)
+
+	for _, row := range rows {
+		var amount valueobjects.Money
+		var member *entities.Member
+		var tags []*entities.Tag
+		var comment, paidBy string
+		if income := row.transaction.Income; income != nil {
+			amount, member, tags, comment = income.Amount(), income.Member(), income.Tags(), income.Comment()
+		} else {
+			expense := row.transaction.Expense
+			amount, member, tags, comment = expense.Amount(), expense.Member(), expense.Tags(), expense.Comment()
+			paidBy = "Cash"
+			if expense.PaidByCard() {
+				paidBy = "Card"
+			}
+		}
+
+		var memberName string
+		if member != nil {
+			memberName = member.Name()
+		}
+		tagNames := make([]string, len(tags))
+		for i, tag := range tags {
+			tagNames[i] = tag.Name()
+		}
+
+		sheet.addRow(
+			dateCell(row.date()),
+			textCell(row.kind(), styleDefault),
+			textCell(row.category, styleDefault),
+			textCell(row.vendor, styleDefault),
+			textCell(row.vendorType, styleDefault),
+			textCell(paidBy, styleDefault),
+			textCell(memberName, styleDefault),
+			textCell(strings.Join(tagNames, "; "), styleDefault),
+			textCell(comment, styleDefault),
+			numberCell(amount.Amount(), styleMoney),
+			textCell(amount.Currency(), styleDefault),
+			numberCell(row.reported, styleMoney),
+		)
+	}
+	return sheet
+}
+
+// summarySheet has two tables, expenses per category and per vendor type, with a column per month
+func summarySheet(months []string, byMonth map[string][]workbookRow, reportingCurrency string) *xlsxSheet {
+	sheet := &xlsxSheet{name: "Summary", widths: []float64{24}}
+	for range months {
+		sheet.widths = append(sheet.widths, 12)
+	}
+	sheet.widths = append(sheet.widths, 14)
+
+	categoryOf := func(row workbookRow) string { return row.category }
+	typeOf := func(row workbookRow) string { return row.vendorType }
+
+	addPivot(sheet, "Expenses by category ("+reportingCurrency+")", monthColumnCategory, categoryOf, months, byMonth)
+	sheet.addRow()
+	addPivot(sheet, "Expenses by vendor type ("+reportingCurrency+")", monthColumnType, typeOf, months, byMonth)
+	return sheet
+}
+
+// addPivot adds a header row, one row per distinct key of the month sheets' column, and a total row
+func addPivot(sheet *xlsxSheet, title, column string, keyOf func(workbookRow) string, months []string, byMonth map[string][]workbookRow) {
+	totals := make(map[string]map[string]float64)
+	for month, rows := range byMonth {
+		for _, row := range rows {
+			if row.income {
+				continue
+			}
+			key := keyOf(row)
+			if totals[key] == nil {
+				totals[key] = make(map[string]float64)
+			}
+			totals[key][month] += row.reported
+		}
+	}
+	keys := make([]string, 0, len(totals))
+	for key := range totals {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	header := []xlsxCell{textCell(title, styleHeader)}
+	for _, month := range months {
+		header = append(header, textCell(month, styleHeader))
+	}
+	header = append(header, textCell("Total", styleHeader))
+	sheet.addRow(header...)
+
+	firstRow := len(sheet.rows)
+	lastColumn := len(months) + 1
+	for _, key := range keys {
+		r := len(sheet.rows)
+		cells := []xlsxCell{textCell(key, styleDefault)}
+		var total float64
+		for _, month := range months {
+			formula := fmt.Sprintf(`SUMIFS('%[1]s'!$%[2]s:$%[2]s,'%[1]s'!$%[3]s:$%[3]s,"Expense",'%[1]s'!$%[4]s:$%[4]s,$A%[5]d)`,
+				month, monthColumnReported, monthColumnKind, column, r+1)
+			cells = append(cells, formulaCell(formula, totals[key][month], styleMoney))
+			total += totals[key][month]
+		}
+		cells = append(cells, formulaCell(fmt.Sprintf("SUM(%s:%s)", cellRef(1, r), cellRef(lastColumn-1, r)), total, styleMoneyTotal))
+		sheet.addRow(cells...)
+	}
+
+	totalRow := len(sheet.rows)
+	cells := []xlsxCell{textCell("Total", styleTotal)}
+	for c := 1; c <= lastColumn; c++ {
+		var total float64
+		for _, key := range keys {
+			if c == lastColumn {
+				for _, amount := range totals[key] {
+					total += amount
+				}
+			} else {
+				total += totals[key][months[c-1]]
+			}
+		}
+		formula := "0"
+		if len(keys) > 0 {
+			formula = fmt.Sprintf("SUM(%s:%s)", cellRef(c, firstRow), cellRef(c, totalRow-1))
+		}
+		cells = append(cells, formulaCell(formula, total, styleMoneyTotal))
+	}
+	sheet.addRow(cells...)
+}
+
+// incomeVsExpensesSheet has a row per month with income, expenses, net and the share of income kept
+func incomeVsExpensesSheet(months []string, byMonth map[string][]workbookRow, reportingCurrency string) *xlsxSheet {
+	sheet := &xlsxSheet{
+		name:         "Income vs Expenses",
+		widths:       []float64{12, 16, 16, 16, 14},
+		freezeHeader: true,
+	}
+	sheet.addRow(
+		textCell("Month", styleHeader),
+		textCell("Income ("+reportingCurrency+")", styleHeader),
+		textCell("Expenses ("+reportingCurrency+")", styleHeader),
+		textCell("Net ("+reportingCurrency+")", styleHeader),
+		textCell("Savings rate", styleHeader),
+	)
+
+	var totalIncome, totalExpenses float64
+	for _, month := range months {
+		var income, expenses float64
+		for _, row := range byMonth[month] {
+			if row.income {
+				income += row.reported
+			} else {
+				expenses += row.reported
+			}
+		}
+		totalIncome += income
+		totalExpenses += expenses
+
+		r := len(sheet.rows) + 1
+		sumOf := func(kind string) string {
+			return fmt.Sprintf(`SUMIFS('%[1]s'!$%[2]s:$%[2]s,'%[1]s'!$%[3]s:$%[3]s,"%[4]s")`, month, monthColumnReported, monthColumnKind, kind)
+		}
+		sheet.addRow(
+			textCell(month, styleDefault),
+			formulaCell(sumOf("Income"), income, styleMoney),
+			formulaCell(sumOf("Expense"), expenses, styleMoney),
+			formulaCell(fmt.Sprintf("B%d-C%d", r, r), income-expenses, styleMoney),
+			formulaCell(fmt.Sprintf("IF(B%[1]d=0,0,D%[1]d/B%[1]d)", r), savingsRate(income, expenses), stylePercent),
+		)
+	}
+
+	last := len(sheet.rows)
+	r := last + 1
+	totalOf := func(column string) string {
+		if len(months) == 0 {
+			return "0"
+		}
+		return fmt.Sprintf("SUM(%[1]s2:%[1]s%[2]d)", column, last)
+	}
+	sheet.addRow(
+		textCell("Total", styleTotal),
+		formulaCell(totalOf("B"), totalIncome, styleMoneyTotal),
+		formulaCell(totalOf("C"), totalExpenses, styleMoneyTotal),
+		formulaCell(fmt.Sprintf("B%d-C%d", r, r), totalIncome-totalExpenses, styleMoneyTotal),
+		formulaCell(fmt.Sprintf("IF(B%[1]d=0,0,D%[1]d/B%[1]d)", r), savingsRate(totalIncome, totalExpenses), stylePercentTotal),
+	)
+	return sheet
+}
+
+func savingsRate(income, expenses float64) float64 {
+	if income == 0 {
+		return 0
+	}
+	return (income - expenses) / income
+}
@@ -0,0 +1,231 @@
+package exporters
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Just enough of SpreadsheetML to write typed cells, formulas and a few formats;
+// cells are written as inline strings, so no shared string table is needed.
+
+type xlsxStyle int
+
+// Indexes into cellXfs in xlsxStyles
+const (
+	styleDefault xlsxStyle = iota
+	styleHeader
+	styleDate
+	styleMoney
+	styleMoneyTotal
+	stylePercent
+	stylePercentTotal
+	styleTotal
+)
+
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd"/></numFmts>
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="8">
+<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>
+<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>
+<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="4" fontId="1" fillId="0" borderId="0" xfId="0" applyNumberFormat="1" applyFont="1"/>
+<xf numFmtId="10" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="10" fontId="1" fillId="0" borderId="0" xfId="0" applyNumberFormat="1" applyFont="1"/>
+<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>
+</cellXfs>
+<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>
+</styleSheet>
+`
+
+type xlsxCell struct {
+	text    string
+	number  float64
+	formula string // the number is its cached result
+	numeric bool
+	style   xlsxStyle
+}
+
+func textCell(text string, style xlsxStyle) xlsxCell {
+	return xlsxCell{text: text, style: style}
+}
+
+func numberCell(number float64, style xlsxStyle) xlsxCell {
+	return xlsxCell{number: number, numeric: true, style: style}
+}
+
+func formulaCell(formula string, cached float64, style xlsxStyle) xlsxCell {
+	return xlsxCell{formula: formula, number: cached, numeric: true, style: style}
+}
+
+// excelEpoch is day zero of the 1900 date system, shifted by Excel's phantom leap day
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// dateCell stores the calendar date as a serial day number
+func dateCell(date time.Time) xlsxCell {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return numberCell(float64(day.Sub(excelEpoch)/(24*time.Hour)), styleDate)
+}
+
+type xlsxSheet struct {
+	name         string
+	widths       []float64 // per column, from A
+	rows         [][]xlsxCell
+	freezeHeader bool
+	autoFilter   bool
+}
+
+func (s *xlsxSheet) addRow(cells ...xlsxCell) {
+	s.rows = append(s.rows, cells)
+}
+
+// columnName turns a zero-based index into A, B, ..., Z, AA, ...
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+func cellRef(column, row int) string {
+	return columnName(column) + strconv.Itoa(row+1)
+}
+
+// writeXLSX writes the sheets as a workbook, the first one selected.
+// Formulas are recalculated when the file is opened, the cached values are for viewers that cannot.
+func writeXLSX(w io.Writer, sheets []*xlsxSheet) error {
+	archive := zip.NewWriter(w)
+
+	var workbook, workbookRels, contentTypes bytes.Buffer
+	workbook.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+		`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	workbookRels.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	contentTypes.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+		`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+
+	for i, sheet := range sheets {
+		id := i + 1
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escapeXML(sheet.name), id, id)
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, id, id)
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, id)
+	}
+	workbook.WriteString(`</sheets><calcPr calcId="191029" fullCalcOnLoad="1"/></workbook>`)
+	fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`, len(sheets)+1)
+	contentTypes.WriteString(`</Types>`)
+
+	parts := []struct {
+		name    string
+		content []byte
+	}{
+		{"[Content_Types].xml", contentTypes.Bytes()},
+		{"_rels/.rels", []byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`)},
+		{"xl/workbook.xml", workbook.Bytes()},
+		{"xl/_rels/workbook.xml.rels", workbookRels.Bytes()},
+		{"xl/styles.xml", []byte(xlsxStyles)},
+	}
+	for i, sheet := range sheets {
+		parts = append(parts, struct {
+			name    string
+			content []byte
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheet.xml(i == 0)})
+	}
+
+	for _, part := range parts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+		if _, err := file.Write(part.content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to write workbook: %w", err)
+	}
+	return nil
+}
+
+func (s *xlsxSheet) xml(selected bool) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+
+	buf.WriteString(`<sheetViews><sheetView workbookViewId="0"`)
+	if selected {
+		buf.WriteString(` tabSelected="1"`)
+	}
+	if s.freezeHeader {
+		buf.WriteString(`><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	} else {
+		buf.WriteString(`/></sheetViews>`)
+	}
+
+	if len(s.widths) > 0 {
+		buf.WriteString(`<cols>`)
+		for i, width := range s.widths {
+			fmt.Fprintf(&buf, `<col min="%d" max="%d" width="%g" customWidth="1"/>`, i+1, i+1, width)
+		}
+		buf.WriteString(`</cols>`)
+	}
+
+	buf.WriteString(`<sheetData>`)
+	columns := 0
+	for r, row := range s.rows {
+		fmt.Fprintf(&buf, `<row r="%d">`, r+1)
+		for c, cell := range row {
+			ref := cellRef(c, r)
+			switch {
+			case cell.formula != "":
+				fmt.Fprintf(&buf, `<c r="%s" s="%d"><f>%s</f><v>%s</v></c>`, ref, cell.style, escapeXML(cell.formula), formatNumber(cell.number))
+			case cell.numeric:
+				fmt.Fprintf(&buf, `<c r="%s" s="%d"><v>%s</v></c>`, ref, cell.style, formatNumber(cell.number))
+			case cell.text != "":
+				fmt.Fprintf(&buf, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, cell.style, escapeXML(cell.text))
+			}
+		}
+		buf.WriteString(`</row>`)
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+	buf.WriteString(`</sheetData>`)
+
+	if s.autoFilter && len(s.rows) > 0 && columns > 0 {
+		fmt.Fprintf(&buf, `<autoFilter ref="A1:%s"/>`, cellRef(columns-1, len(s.rows)-1))
+	}
+
+	buf.WriteString(`</worksheet>`)
+	return buf.Bytes()
+}
+
+func formatNumber(number float64) string {
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
+
+// escapeXML also replaces characters XML cannot hold, such as control characters pasted into comments
+func escapeXML(text string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(text))
+	return buf.String()
+}
@@ -0,0 +1,82 @@
+package dto
+
+import "encoding/json"
+
+// Response DTOs
+type AggregateStatsDTO struct {
+	Count   int         `json:"count"`
+	Sum     json.Number `json:"sum"`
+	Average json.Number `json:"average"`
+	Min     json.Number `json:"min"`
+	Max     json.Number `json:"max"`
+}
+
+// AggregateGroupDTO omits expenses or incomes when the group has none
+type AggregateGroupDTO struct {
+	Key      string             `json:"key"`   // ID for vendors, tags and members, the name otherwise; empty for transactions without one
+	Label    string             `json:"label"` // Display name
+	Expenses *AggregateStatsDTO `json:"expenses,omitempty"`
+	Incomes  *AggregateStatsDTO `json:"incomes,omitempty"`
+}
+
+type AggregateBucketDTO struct {
+	Start  string              `json:"start"` // First day, YYYY-MM-DD
+	End    string              `json:"end"`   // Last day, YYYY-MM-DD
+	Groups []AggregateGroupDTO `json:"groups"`
+}
+
+type AggregationDTO struct {
+	Period   string               `json:"period"`
+	GroupBy  string               `json:"group_by,omitempty"`
+	Currency string               `json:"currency"` // All amounts are in this currency
+	Buckets  []AggregateBucketDTO `json:"buckets"`
+}
+
+type CashFlowTotalsDTO struct {
+	Income       json.Number `json:"income"`
+	Expenses     json.Number `json:"expenses"`
+	Net          json.Number `json:"net"`
+	IncomeCount  int         `json:"income_count"`
+	ExpenseCount int         `json:"expense_count"`
+	SavingsRate  float64     `json:"savings_rate"` // Share of income not spent, e.g. 0.25; 0 without income
+}
+
+type CashFlowPeriodDTO struct {
+	Start string `json:"start"` // First day, YYYY-MM-DD
+	End   string `json:"end"`   // Last day, YYYY-MM-DD
+	CashFlowTotalsDTO
+	Balance json.Number `json:"balance"` // Running balance at the end of the period
+}
+
+type CashFlowDTO struct {
+	Period         string              `json:"period"`
+	Currency       string              `json:"currency"`        // All amounts are in this currency
+	OpeningBalance json.Number         `json:"opening_balance"` // Net of everything before start_date
+	Periods        []CashFlowPeriodDTO `json:"periods"`
+	Totals         CashFlowTotalsDTO   `json:"totals"`
+	ClosingBalance json.Number         `json:"closing_balance"`
+}
+
+// ForecastMonthDTO projects one month; spent amounts are zero for months that have not started
+type ForecastMonthDTO struct {
+	Start         string      `json:"start"` // First day, YYYY-MM-DD
+	End           string      `json:"end"`   // Last day, YYYY-MM-DD
+	SpentFixed    json.Number `json:"spent_fixed"`
+	SpentVariable json.Number `json:"spent_variable"`
+	Fixed         json.Number `json:"fixed"`    // Projected recurring costs for the whole month
+	Variable      json.Number `json:"variable"` // Projected variable spending for the whole month
+	Total         json.Number `json:"total"`
+	Low           json.Number `json:"low"`  // Lower bound of the confidence band
+	High          json.Number `json:"high"` // Upper bound of the confidence band
+}
+
+type ForecastDTO struct {
+	AsOf             string             `json:"as_of"`    // YYYY-MM-DD
+	Currency         string             `json:"currency"` // All amounts are in this currency
+	Confidence       float64            `json:"confidence"`
+	FixedVendorTypes []string           `json:"fixed_vendor_types"` // Expenses at these vendor types count as fixed costs
+	HistoryMonths    int                `json:"history_months"`
+	Seasonal         bool               `json:"seasonal"` // Whether prior years were long enough to adjust for seasonality
+	CurrentMonth     ForecastMonthDTO   `json:"current_month"`
+	Months           []ForecastMonthDTO `json:"months"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// Request DTOs
+type CreateAPITokenRequestDTO struct {
+	Name   string   `json:"name" validate:"required"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,required"`
+}
+
+// Response DTOs
+type APITokenResponseDTO struct {
+	ID          int        `json:"id"`
+	Name        string     `json:"name"`
+	Prefix      string     `json:"prefix"`
+	HouseholdID int        `json:"household_id"`
+	Scopes      []string   `json:"scopes"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// CreatedAPITokenResponseDTO is the only response that contains the secret
+type CreatedAPITokenResponseDTO struct {
+	APITokenResponseDTO
+	Token string `json:"token"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+// Request DTOs
+type RegisterRequestDTO struct {
+	Email    string `json:"email" validate:"required,email"`
+	Name     string `json:"name" validate:"required"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+type LoginRequestDTO struct {
+	Email    string `json:"email" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+// Response DTOs
+type UserResponseDTO struct {
+	ID        int       `json:"id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type AuthResponseDTO struct {
+	Token       string          `json:"token"`
+	ExpiresAt   time.Time       `json:"expires_at"`
+	HouseholdID int             `json:"household_id"`
+	User        UserResponseDTO `json:"user"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+// Response DTOs
+type RestoreCountDTO struct {
+	Created  int `json:"created"`
+	Existing int `json:"existing"`
+}
+
+// RestoreReportDTO counts per kind of record what a restore created and what it found already present
+type RestoreReportDTO struct {
+	Categories          RestoreCountDTO `json:"categories"`
+	Vendors             RestoreCountDTO `json:"vendors"`
+	Tags                RestoreCountDTO `json:"tags"`
+	Members             RestoreCountDTO `json:"members"`
+	Expenses            RestoreCountDTO `json:"expenses"`
+	Incomes             RestoreCountDTO `json:"incomes"`
+	Budgets             RestoreCountDTO `json:"budgets"`
+	RecurringRules      RestoreCountDTO `json:"recurring_rules"`
+	ImportProfiles      RestoreCountDTO `json:"import_profiles"`
+	CategorizationRules RestoreCountDTO `json:"categorization_rules"`
+}
@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Request DTOs
+type CreateBudgetRequestDTO struct {
+	Amount     json.Number `json:"amount" validate:"required"`                      // Exact decimal amount per period, e.g. 400.00
+	Currency   string      `json:"currency,omitempty" validate:"omitempty,iso4217"` // Optional, defaults to EUR if not provided
+	Period     string      `json:"period" validate:"required,oneof=monthly quarterly yearly"`
+	TargetType string      `json:"target_type" validate:"required,oneof=category vendor_type"`
+	Target     string      `json:"target" validate:"required"` // Category name or vendor type
+	Rollover   bool        `json:"rollover"`                   // Carry unspent amount into the next period
+}
+
+type UpdateBudgetRequestDTO struct {
+	Amount     *json.Number `json:"amount,omitempty"`
+	Currency   *string      `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	Period     *string      `json:"period,omitempty" validate:"omitempty,oneof=monthly quarterly yearly"`
+	TargetType *string      `json:"target_type,omitempty" validate:"omitempty,oneof=category vendor_type"`
+	Target     *string      `json:"target,omitempty"`
+	Rollover   *bool        `json:"rollover,omitempty"`
+}
+
+// Response DTOs
+type BudgetResponseDTO struct {
+	ID         int         `json:"id"`
+	Amount     json.Number `json:"amount"`
+	Currency   string      `json:"currency"`
+	Period     string      `json:"period"`
+	TargetType string      `json:"target_type"`
+	Target     string      `json:"target"`
+	Rollover   bool        `json:"rollover"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+type BudgetStatusDTO struct {
+	Budget       BudgetResponseDTO `json:"budget"`
+	PeriodStart  string            `json:"period_start"`
+	PeriodEnd    string            `json:"period_end"`
+	Carryover    json.Number       `json:"carryover"`
+	Available    json.Number       `json:"available"`
+	Spent        json.Number       `json:"spent"`
+	Remaining    json.Number       `json:"remaining"`
+	Projected    json.Number       `json:"projected"`
+	ExpenseCount int               `json:"expense_count"`
+}
@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Request DTOs
+type CreateCategorizationRuleRequestDTO struct {
+	Name       string       `json:"name" validate:"required"`
+	Priority   *int         `json:"priority,omitempty"` // Lower runs first, defaults to after the last rule
+	Active     *bool        `json:"active,omitempty"`   // Defaults to true if not provided
+	Pattern    string       `json:"pattern,omitempty"`  // Regular expression searched in payee and comment, ignoring case, e.g. REWE|Lidl
+	VendorType string       `json:"vendor_type,omitempty"`
+	Amount     *json.Number `json:"amount,omitempty"`                                // Exact amount, e.g. 17.50
+	MinAmount  *json.Number `json:"min_amount,omitempty"`                            // Lower end of a range, instead of amount
+	MaxAmount  *json.Number `json:"max_amount,omitempty"`                            // Upper end of a range, instead of amount
+	Currency   string       `json:"currency,omitempty" validate:"omitempty,iso4217"` // Currency of the amounts, defaults to EUR if not provided
+	VendorID   *int         `json:"vendor_id,omitempty"`
+	Category   string       `json:"category,omitempty"`
+	TagIDs     []int        `json:"tag_ids,omitempty"`
+}
+
+// UpdateCategorizationRuleRequestDTO changes the fields given. Conditions are replaced together:
+// if any of pattern, vendor_type, the amounts or currency is given, conditions left out are removed.
+type UpdateCategorizationRuleRequestDTO struct {
+	Name       *string      `json:"name,omitempty"`
+	Priority   *int         `json:"priority,omitempty"`
+	Active     *bool        `json:"active,omitempty"`
+	Pattern    *string      `json:"pattern,omitempty"`
+	VendorType *string      `json:"vendor_type,omitempty"`
+	Amount     *json.Number `json:"amount,omitempty"`
+	MinAmount  *json.Number `json:"min_amount,omitempty"`
+	MaxAmount  *json.Number `json:"max_amount,omitempty"`
+	Currency   *string      `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	VendorID   *int         `json:"vendor_id,omitempty"` // 0 removes the vendor
+	Category   *string      `json:"category,omitempty"`  // Empty string removes the category
+	TagIDs     *[]int       `json:"tag_ids,omitempty"`   // Empty list removes all tags
+}
+
+// TestCategorizationRulesRequestDTO describes an expense to run the rules against
+type TestCategorizationRulesRequestDTO struct {
+	Payee      string      `json:"payee,omitempty"` // Defaults to the name of the vendor
+	Comment    string      `json:"comment,omitempty"`
+	Amount     json.Number `json:"amount" validate:"required"`
+	Currency   string      `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	VendorID   *int        `json:"vendor_id,omitempty"`
+	VendorType string      `json:"vendor_type,omitempty"` // Defaults to the type of the vendor
+}
+
+// Response DTOs
+type CategorizationRuleResponseDTO struct {
+	ID         int          `json:"id"`
+	Name       string       `json:"name"`
+	Priority   int          `json:"priority"`
+	Active     bool         `json:"active"`
+	Pattern    string       `json:"pattern,omitempty"`
+	VendorType string       `json:"vendor_type,omitempty"`
+	Amount     *json.Number `json:"amount,omitempty"`
+	MinAmount  *json.Number `json:"min_amount,omitempty"`
+	MaxAmount  *json.Number `json:"max_amount,omitempty"`
+	Currency   string       `json:"currency,omitempty"` // Only with amounts
+	VendorID   *int         `json:"vendor_id,omitempty"`
+	Category   string       `json:"category,omitempty"`
+	TagIDs     []int        `json:"tag_ids"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
+
+// CategorizationRuleRefDTO names the rule a suggestion came from
+type CategorizationRuleRefDTO struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type CategorizationRuleTestDTO struct {
+	Rule    *CategorizationRuleResponseDTO  `json:"rule"`    // Rule that would fire, null if none matches
+	Matches []CategorizationRuleResponseDTO `json:"matches"` // Every active rule that matches, in the order they are applied
+}
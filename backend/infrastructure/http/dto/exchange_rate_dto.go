@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// Request DTOs with JSON annotations for syntactic validation
+type ExchangeRateImportRequestDTO struct {
+	Format string `json:"format" validate:"required,oneof=csv xml"` // ECB eurofxref file format
+	Data   string `json:"data" validate:"required"`                 // Raw file contents
+}
+
+// Response DTOs with JSON annotations
+type ExchangeRateResponseDTO struct {
+	ID            int       `json:"id"`
+	BaseCurrency  string    `json:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	Date          string    `json:"date"`
+	Rate          float64   `json:"rate"`
+	Source        string    `json:"source"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type ExchangeRateImportResponseDTO struct {
+	Imported  int    `json:"imported"`
+	FirstDate string `json:"first_date"`
+	LastDate  string `json:"last_date"`
+}
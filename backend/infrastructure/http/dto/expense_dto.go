@@ -1,76 +1,171 @@
 package dto
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+
+	"expenso-backend/domain/entities"
+)
 
 // Request DTOs with JSON annotations for syntactic validation
 type CreateExpenseRequestDTO struct {
-	Amount     float64 `json:"amount" validate:"required,gt=0"`
-	Date       string  `json:"date" validate:"required"`
-	Type       string  `json:"type" validate:"required"`
-	Category   string  `json:"category" validate:"required"`
-	Comment    string  `json:"comment"`
-	VendorID   *int    `json:"vendor_id,omitempty"`
-	PaidByCard *bool   `json:"paid_by_card,omitempty"`                               // Optional, defaults to true if not provided
-	AddedBy    *string `json:"added_by,omitempty" validate:"omitempty,oneof=he she"` // Optional, defaults to "he" if not provided
-	TagIDs     []int   `json:"tag_ids,omitempty"`                                    // Optional list of tag IDs
+	Amount     json.Number `json:"amount" validate:"required"`                      // Exact decimal amount, e.g. 12.30
+	Currency   string      `json:"currency,omitempty" validate:"omitempty,iso4217"` // Optional, defaults to EUR if not provided
+	Date       string      `json:"date" validate:"required"`
+	Type       string      `json:"type" validate:"required"`
+	Category   string      `json:"category"` // Optional if a categorization rule supplies it
+	Comment    string      `json:"comment"`
+	VendorID   *int        `json:"vendor_id,omitempty"`
+	PaidByCard *bool       `json:"paid_by_card,omitempty"` // Optional, defaults to true if not provided
+	MemberID   *int        `json:"member_id,omitempty"`    // Optional household member who recorded the expense
+	TagIDs     []int       `json:"tag_ids,omitempty"`      // Optional list of tag IDs
 }
 
 type UpdateExpenseRequestDTO struct {
-	Amount     *float64 `json:"amount,omitempty" validate:"omitempty,gt=0"`
-	Date       *string  `json:"date,omitempty"`
-	Type       *string  `json:"type,omitempty"`
-	Category   *string  `json:"category,omitempty"`
-	Comment    *string  `json:"comment,omitempty"`
-	VendorID   *int     `json:"vendor_id,omitempty"`
-	PaidByCard *bool    `json:"paid_by_card,omitempty"`
-	AddedBy    *string  `json:"added_by,omitempty" validate:"omitempty,oneof=he she"`
+	Amount     *json.Number `json:"amount,omitempty"`
+	Currency   *string      `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	Date       *string      `json:"date,omitempty"`
+	Type       *string      `json:"type,omitempty"`
+	Category   *string      `json:"category,omitempty"`
+	Comment    *string      `json:"comment,omitempty"`
+	VendorID   *int         `json:"vendor_id,omitempty"`
+	PaidByCard *bool        `json:"paid_by_card,omitempty"`
+	MemberID   *int         `json:"member_id,omitempty"` // 0 removes the member
 }
 
 // Response DTOs with JSON annotations
 type ExpenseResponseDTO struct {
 	ID         int                `json:"id"`
-	Amount     float64            `json:"amount"`
+	Amount     json.Number        `json:"amount"`
+	Currency   string             `json:"currency"`
 	Date       string             `json:"date"`
 	Type       string             `json:"type"`
 	Category   string             `json:"category"`
 	Comment    string             `json:"comment"`
 	Vendor     *VendorResponseDTO `json:"vendor,omitempty"`
 	PaidByCard bool               `json:"paid_by_card"`
-	AddedBy    string             `json:"added_by"`
+	Member     *MemberSummaryDTO  `json:"member,omitempty"`
 	Tags       []TagResponseDTO   `json:"tags,omitempty"`
 	CreatedAt  time.Time          `json:"created_at"`
 	UpdatedAt  time.Time          `json:"updated_at"`
 }
 
+// BalanceSummaryDTO reports totals in a single currency
+type BalanceSummaryDTO struct {
+	TotalEarnings json.Number `json:"total_earnings"`
+	TotalExpenses json.Number `json:"total_expenses"`
+	Balance       json.Number `json:"balance"`
+	Currency      string      `json:"currency"`
+	EarningsCount int         `json:"earnings_count"`
+	ExpensesCount int         `json:"expenses_count"`
+}
+
+// Helper function to convert domain entity to response DTO
+func ToExpenseResponseDTO(exp *entities.Expense) ExpenseResponseDTO {
+	responseDTO := ExpenseResponseDTO{
+		ID:         int(exp.ID()),
+		Amount:     json.Number(exp.Amount().Decimal()),
+		Currency:   exp.Amount().Currency(),
+		Date:       exp.Date().Format("2006-01-02"),
+		Type:       string(exp.Type()),
+		Category:   exp.Category().String(),
+		Comment:    exp.Comment(),
+		PaidByCard: exp.PaidByCard(),
+		CreatedAt:  exp.CreatedAt(),
+		UpdatedAt:  exp.UpdatedAt(),
+	}
+
+	// Add vendor if present
+	if exp.Vendor() != nil {
+		responseDTO.Vendor = &VendorResponseDTO{
+			ID:        int(exp.Vendor().ID()),
+			Name:      exp.Vendor().Name(),
+			Type:      string(exp.Vendor().Type()),
+			CreatedAt: exp.Vendor().CreatedAt(),
+			UpdatedAt: exp.Vendor().UpdatedAt(),
+		}
+	}
+
+	// Add member if present
+	if exp.Member() != nil {
+		responseDTO.Member = &MemberSummaryDTO{
+			ID:   int(exp.Member().ID()),
+			Name: exp.Member().Name(),
+		}
+	}
+
+	// Add tags if present
+	if len(exp.Tags()) > 0 {
+		for _, tag := range exp.Tags() {
+			responseDTO.Tags = append(responseDTO.Tags, TagResponseDTO{
+				ID:        int(tag.ID()),
+				Name:      tag.Name(),
+				Color:     tag.Color(),
+				CreatedAt: tag.CreatedAt(),
+				UpdatedAt: tag.UpdatedAt(),
+			})
+		}
+	}
+
+	return responseDTO
+}
+
 // CSV Import DTOs
 type CSVImportRequestDTO struct {
-	CSVData string `json:"csv_data" validate:"required"`
+	CSVData   string `json:"csv_data,omitempty" validate:"required_without=CSVBase64"`                  // File contents as text
+	CSVBase64 string `json:"csv_base64,omitempty" validate:"required_without=CSVData,omitempty,base64"` // Raw file bytes, for files that are not UTF-8
+	ProfileID *int   `json:"profile_id,omitempty"`                                                      // Import profile describing the layout, the built-in layout if omitted
 }
 
 type CSVImportPreviewDTO struct {
-	Rows []CSVRowPreviewDTO `json:"rows"`
+	Profile         string             `json:"profile"`                     // Name of the import profile used
+	FileHash        string             `json:"file_hash"`                   // For confirming the whole file with /imports/statement/confirm
+	ImportedInBatch *int               `json:"imported_in_batch,omitempty"` // Batch that already imported this file
+	Rows            []CSVRowPreviewDTO `json:"rows"`
 }
 
 type CSVRowPreviewDTO struct {
-	RowNumber int                `json:"row_number"`
-	Date      string             `json:"date"`
-	Expenses  map[string]float64 `json:"expenses"`
-	Issues    []string           `json:"issues,omitempty"`
-	Parsed    []ParsedExpenseDTO `json:"parsed_expenses"`
+	RowNumber     int                    `json:"row_number"`
+	Date          string                 `json:"date"`
+	Expenses      map[string]json.Number `json:"expenses,omitempty"` // Wide layouts: amount per vendor type column
+	Issues        []string               `json:"issues,omitempty"`
+	Parsed        []ParsedExpenseDTO     `json:"parsed_expenses"`
+	ParsedIncomes []ParsedIncomeDTO      `json:"parsed_incomes,omitempty"` // Credits and refunds
 }
 
 type ParsedExpenseDTO struct {
-	Comment    string   `json:"comment"`
-	Amount     float64  `json:"amount"`
-	Date       string   `json:"date"`
-	VendorType string   `json:"vendor_type"`
-	Category   string   `json:"category"`
-	Issues     []string `json:"issues,omitempty"`
+	Comment       string      `json:"comment"`
+	Amount        json.Number `json:"amount"`
+	Currency      string      `json:"currency"`
+	Date          string      `json:"date"`
+	VendorType    string      `json:"vendor_type,omitempty"`
+	VendorID      *int        `json:"vendor_id,omitempty"`
+	Payee         string      `json:"payee,omitempty"`
+	Category      string      `json:"category"`
+	TransactionID string      `json:"transaction_id"`
+	Fingerprint   string      `json:"fingerprint"`
+	Duplicate     bool        `json:"duplicate"` // Imported before, or an existing expense has the same date and amount
+	Issues        []string    `json:"issues,omitempty"`
+}
+
+type ParsedIncomeDTO struct {
+	Source        string      `json:"source"`
+	Comment       string      `json:"comment"`
+	Amount        json.Number `json:"amount"`
+	Currency      string      `json:"currency"`
+	Date          string      `json:"date"`
+	VendorID      *int        `json:"vendor_id,omitempty"`
+	TransactionID string      `json:"transaction_id"`
+	Fingerprint   string      `json:"fingerprint"`
+	Duplicate     bool        `json:"duplicate"` // Imported before, or an existing income has the same date and amount
+	Issues        []string    `json:"issues,omitempty"`
 }
 
 type CSVImportConfirmRequestDTO struct {
-	RowNumber int                       `json:"row_number"`
-	Expenses  []CreateExpenseRequestDTO `json:"expenses"`
+	RowNumber  int                  `json:"row_number"`
+	Expenses   []ImportedExpenseDTO `json:"expenses"`
+	Force      bool                 `json:"force"`       // Import even if every expense was imported before
+	BestEffort bool                 `json:"best_effort"` // Create every valid expense and answer with a StatementImportResultDTO reporting each one
 }
 
 // Tag DTOs
@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+// Request DTOs
+type CreateHouseholdRequestDTO struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type AddHouseholdUserRequestDTO struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role,omitempty" validate:"omitempty,oneof=owner member"`
+}
+
+// Response DTOs
+type HouseholdResponseDTO struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	Current   bool      `json:"current"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type HouseholdUserResponseDTO struct {
+	ID       int       `json:"id"`
+	Email    string    `json:"email"`
+	Name     string    `json:"name"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
@@ -0,0 +1,92 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Request DTOs with JSON annotations for syntactic validation
+type StatementImportRequestDTO struct {
+	Format string `json:"format" validate:"required,oneof=camt053 mt940 ofx qfx"` // Bank export format
+	Data   string `json:"data" validate:"required"`                               // Raw file contents
+}
+
+type StatementImportConfirmRequestDTO struct {
+	Source     string               `json:"source,omitempty" validate:"omitempty,oneof=camt053 mt940 ofx qfx csv"` // Format of the imported file
+	FileHash   string               `json:"file_hash,omitempty" validate:"omitempty,len=64,hexadecimal"`           // From the preview; a file is only imported once
+	Force      bool                 `json:"force"`                                                                 // Import even if the file or all of its rows were imported before
+	BestEffort bool                 `json:"best_effort"`                                                           // Create every valid row and report the others instead of creating nothing
+	Expenses   []ImportedExpenseDTO `json:"expenses" validate:"dive"`
+	Incomes    []ImportedIncomeDTO  `json:"incomes" validate:"dive"`
+}
+
+// ImportedExpenseDTO is an expense to create with the fingerprint the preview gave it
+type ImportedExpenseDTO struct {
+	CreateExpenseRequestDTO
+	Fingerprint string `json:"fingerprint,omitempty"` // Derived from date, amount and comment if omitted
+}
+
+type ImportedIncomeDTO struct {
+	CreateIncomeRequestDTO
+	Fingerprint string `json:"fingerprint,omitempty"` // Derived from date, amount and comment if omitted
+}
+
+// Response DTOs with JSON annotations
+type StatementImportPreviewDTO struct {
+	Format          string                   `json:"format"`
+	Account         string                   `json:"account,omitempty"`           // IBAN or account number from the file
+	FileHash        string                   `json:"file_hash"`                   // Send back on confirm
+	ImportedInBatch *int                     `json:"imported_in_batch,omitempty"` // Batch that already imported this file
+	Rows            []StatementRowPreviewDTO `json:"rows"`
+}
+
+type StatementRowPreviewDTO struct {
+	RowNumber     int                       `json:"row_number"`
+	BookingDate   string                    `json:"booking_date"`
+	Amount        json.Number               `json:"amount"`
+	Currency      string                    `json:"currency"`
+	Direction     string                    `json:"direction"` // debit or credit
+	Counterparty  string                    `json:"counterparty,omitempty"`
+	Reference     string                    `json:"reference,omitempty"`
+	TransactionID string                    `json:"transaction_id"`    // Stable ID from the file, derived from the row if the file has none
+	Fingerprint   string                    `json:"fingerprint"`       // Identifies the transaction across imports
+	Duplicate     bool                      `json:"duplicate"`         // Imported before, or an existing transaction has the same date and amount
+	Expense       *ImportedExpenseDTO       `json:"expense,omitempty"` // Suggested expense for debits, can be sent to confirm as is
+	Rule          *CategorizationRuleRefDTO `json:"rule,omitempty"`    // Categorization rule the expense suggestion came from
+	Income        *ImportedIncomeDTO        `json:"income,omitempty"`  // Suggested income for credits, can be sent to confirm as is
+	Issues        []string                  `json:"issues,omitempty"`
+}
+
+type StatementImportResultDTO struct {
+	BatchID  int                  `json:"batch_id"` // Roll the whole import back with /imports/batches/{id}/rollback; 0 if nothing was created
+	Expenses []ExpenseResponseDTO `json:"expenses"`
+	Incomes  []IncomeResponseDTO  `json:"incomes"`
+	Rows     []ImportRowResultDTO `json:"rows,omitempty"` // Outcome of every row, best-effort mode only
+}
+
+type ImportRowResultDTO struct {
+	Kind        string `json:"kind"`  // expense or income
+	Index       int    `json:"index"` // Position in the request's expenses or incomes, starting at 1
+	Fingerprint string `json:"fingerprint"`
+	Status      string `json:"status"` // created or failed
+	ExpenseID   *int   `json:"expense_id,omitempty"`
+	IncomeID    *int   `json:"income_id,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+type ImportBatchResponseDTO struct {
+	ID           int                  `json:"id"`
+	Source       string               `json:"source"`
+	FileHash     string               `json:"file_hash,omitempty"`
+	ExpenseCount int                  `json:"expense_count"`
+	IncomeCount  int                  `json:"income_count"`
+	Items        []ImportBatchItemDTO `json:"items,omitempty"` // Only when fetching a single batch
+	CreatedAt    time.Time            `json:"created_at"`
+	RolledBackAt *time.Time           `json:"rolled_back_at,omitempty"`
+}
+
+type ImportBatchItemDTO struct {
+	Fingerprint string `json:"fingerprint"`
+	ExpenseID   *int   `json:"expense_id,omitempty"` // Empty once the expense was deleted
+	IncomeID    *int   `json:"income_id,omitempty"`  // Empty once the income was deleted
+}
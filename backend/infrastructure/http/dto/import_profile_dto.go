@@ -0,0 +1,52 @@
+package dto
+
+import "time"
+
+// Request DTOs with JSON annotations for syntactic validation
+type ImportProfileRequestDTO struct {
+	Name              string            `json:"name" validate:"required"`
+	Delimiter         string            `json:"delimiter,omitempty"`                                                         // Single character, defaults to ","
+	Encoding          string            `json:"encoding,omitempty" validate:"omitempty,oneof=utf-8 windows-1252 iso-8859-1"` // Defaults to utf-8
+	DecimalSeparator  string            `json:"decimal_separator,omitempty"`                                                 // "." or ",", defaults to "."
+	DateFormats       []string          `json:"date_formats,omitempty"`                                                      // e.g. ["DD.MM.YYYY"], defaults to YYYY-MM-DD
+	SkipRows          int               `json:"skip_rows" validate:"min=0"`                                                  // Lines above the header
+	Layout            string            `json:"layout" validate:"required,oneof=wide long"`
+	DateColumn        string            `json:"date_column" validate:"required"`
+	AmountColumn      string            `json:"amount_column,omitempty"`       // Long layout: signed amount, negative for expenses
+	PayeeColumn       string            `json:"payee_column,omitempty"`        // Optional
+	MemoColumn        string            `json:"memo_column,omitempty"`         // Optional, becomes the comment
+	VendorTypeColumns map[string]string `json:"vendor_type_columns,omitempty"` // Wide layout: column header to vendor type
+	Currency          string            `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	DefaultCategory   string            `json:"default_category,omitempty"`
+	Rules             []ImportRuleDTO   `json:"rules,omitempty" validate:"dive"`
+}
+
+// ImportRuleDTO suggests a category and/or vendor when the payee or memo contains Match and/or the amount came from a VendorType column
+type ImportRuleDTO struct {
+	Match      string `json:"match,omitempty"`
+	VendorType string `json:"vendor_type,omitempty"`
+	Category   string `json:"category,omitempty"`
+	VendorID   *int   `json:"vendor_id,omitempty"`
+}
+
+// Response DTOs with JSON annotations
+type ImportProfileResponseDTO struct {
+	ID                int               `json:"id"`
+	Name              string            `json:"name"`
+	Delimiter         string            `json:"delimiter"`
+	Encoding          string            `json:"encoding"`
+	DecimalSeparator  string            `json:"decimal_separator"`
+	DateFormats       []string          `json:"date_formats"`
+	SkipRows          int               `json:"skip_rows"`
+	Layout            string            `json:"layout"`
+	DateColumn        string            `json:"date_column"`
+	AmountColumn      string            `json:"amount_column,omitempty"`
+	PayeeColumn       string            `json:"payee_column,omitempty"`
+	MemoColumn        string            `json:"memo_column,omitempty"`
+	VendorTypeColumns map[string]string `json:"vendor_type_columns,omitempty"`
+	Currency          string            `json:"currency"`
+	DefaultCategory   string            `json:"default_category,omitempty"`
+	Rules             []ImportRuleDTO   `json:"rules"`
+	CreatedAt         time.Time         `json:"created_at"`
+	UpdatedAt         time.Time         `json:"updated_at"`
+}
@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"encoding/json"
 	"time"
 
 	"expenso-backend/domain/entities"
@@ -8,34 +9,50 @@ import (
 
 // Request DTOs with JSON annotations for syntactic validation
 type CreateIncomeRequestDTO struct {
-	Amount   float64 `json:"amount" validate:"required,gt=0"`
-	Date     string  `json:"date" validate:"required"`
-	Source   string  `json:"source" validate:"required"`
-	Comment  string  `json:"comment"`
-	VendorID *int    `json:"vendor_id,omitempty"`
-	AddedBy  *string `json:"added_by,omitempty" validate:"omitempty,oneof=he she"` // Optional, defaults to "he" if not provided
-	TagIDs   *[]int  `json:"tag_ids,omitempty"`                                    // Optional list of tag IDs
+	Amount   json.Number `json:"amount" validate:"required"`                      // Exact decimal amount, e.g. 12.30
+	Currency string      `json:"currency,omitempty" validate:"omitempty,iso4217"` // Optional, defaults to EUR if not provided
+	Date     string      `json:"date" validate:"required"`
+	Source   string      `json:"source" validate:"required"`
+	Comment  string      `json:"comment"`
+	VendorID *int        `json:"vendor_id,omitempty"`
+	MemberID *int        `json:"member_id,omitempty"` // Optional household member who recorded the income
+	TagIDs   *[]int      `json:"tag_ids,omitempty"`   // Optional list of tag IDs
 }
 
 type UpdateIncomeRequestDTO struct {
-	Amount   *float64 `json:"amount,omitempty" validate:"omitempty,gt=0"`
-	Date     *string  `json:"date,omitempty"`
-	Source   *string  `json:"source,omitempty"`
-	Comment  *string  `json:"comment,omitempty"`
-	VendorID *int     `json:"vendor_id,omitempty"`
-	AddedBy  *string  `json:"added_by,omitempty" validate:"omitempty,oneof=he she"`
-	TagIDs   *[]int   `json:"tag_ids,omitempty"`
+	Amount   *json.Number `json:"amount,omitempty"`
+	Currency *string      `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	Date     *string      `json:"date,omitempty"`
+	Source   *string      `json:"source,omitempty"`
+	Comment  *string      `json:"comment,omitempty"`
+	VendorID *int         `json:"vendor_id,omitempty"`
+	MemberID *int         `json:"member_id,omitempty"` // 0 removes the member
+	TagIDs   *[]int       `json:"tag_ids,omitempty"`
+}
+
+// IncomeCSVImportRequestDTO carries a file in the layout of /incomes/export/csv
+type IncomeCSVImportRequestDTO struct {
+	CSVData   string `json:"csv_data,omitempty" validate:"required_without=CSVBase64"`                  // File contents as text
+	CSVBase64 string `json:"csv_base64,omitempty" validate:"required_without=CSVData,omitempty,base64"` // Raw file bytes, for files that are not UTF-8
+}
+
+type IncomeCSVImportConfirmRequestDTO struct {
+	FileHash   string              `json:"file_hash,omitempty" validate:"omitempty,len=64,hexadecimal"` // From the preview; a file is only imported once
+	Force      bool                `json:"force"`                                                       // Import even if the file or all of its rows were imported before
+	BestEffort bool                `json:"best_effort"`                                                 // Create every valid income and report the others instead of creating nothing
+	Incomes    []ImportedIncomeDTO `json:"incomes" validate:"required,dive"`
 }
 
 // Response DTOs with JSON annotations
 type IncomeResponseDTO struct {
 	ID        int                `json:"id"`
-	Amount    float64            `json:"amount"`
+	Amount    json.Number        `json:"amount"`
+	Currency  string             `json:"currency"`
 	Date      string             `json:"date"`
 	Source    string             `json:"source"`
 	Comment   string             `json:"comment"`
 	Vendor    *VendorResponseDTO `json:"vendor,omitempty"`
-	AddedBy   string             `json:"added_by"`
+	Member    *MemberSummaryDTO  `json:"member,omitempty"`
 	Tags      []TagResponseDTO   `json:"tags,omitempty"`
 	CreatedAt time.Time          `json:"created_at"`
 	UpdatedAt time.Time          `json:"updated_at"`
@@ -43,19 +60,37 @@ type IncomeResponseDTO struct {
 
 // Summary DTO
 type IncomeSummaryDTO struct {
-	TotalIncome float64 `json:"total_income"`
-	IncomeCount int     `json:"income_count"`
+	TotalIncome json.Number `json:"total_income"`
+	Currency    string      `json:"currency"`
+	IncomeCount int         `json:"income_count"`
+}
+
+type IncomeCSVImportPreviewDTO struct {
+	FileHash        string                   `json:"file_hash"`                   // Send back on confirm
+	ImportedInBatch *int                     `json:"imported_in_batch,omitempty"` // Batch that already imported this file
+	Rows            []IncomeCSVRowPreviewDTO `json:"rows"`
+}
+
+type IncomeCSVRowPreviewDTO struct {
+	RowNumber   int                `json:"row_number"`
+	Vendor      string             `json:"vendor,omitempty"`   // Vendor name as written in the file
+	AddedBy     string             `json:"added_by,omitempty"` // Member name as written in the file
+	Tags        []string           `json:"tags,omitempty"`     // Tag names as written in the file
+	Fingerprint string             `json:"fingerprint,omitempty"`
+	Duplicate   bool               `json:"duplicate"`        // Imported before, or an existing income has the same date and amount
+	Income      *ImportedIncomeDTO `json:"income,omitempty"` // The names resolved to IDs, can be sent to confirm as is; missing if the row is unreadable
+	Issues      []string           `json:"issues,omitempty"`
 }
 
 // Helper function to convert domain entity to response DTO
 func ToIncomeResponseDTO(income *entities.Income) IncomeResponseDTO {
 	dto := IncomeResponseDTO{
 		ID:        int(income.ID()),
-		Amount:    income.Amount().Amount(),
+		Amount:    json.Number(income.Amount().Decimal()),
+		Currency:  income.Amount().Currency(),
 		Date:      income.Date().Format("2006-01-02"),
 		Source:    income.Source(),
 		Comment:   income.Comment(),
-		AddedBy:   income.AddedBy().String(),
 		CreatedAt: income.CreatedAt(),
 		UpdatedAt: income.UpdatedAt(),
 	}
@@ -72,6 +107,14 @@ func ToIncomeResponseDTO(income *entities.Income) IncomeResponseDTO {
 		dto.Vendor = &vendorDTO
 	}
 
+	// Add member if present
+	if income.Member() != nil {
+		dto.Member = &MemberSummaryDTO{
+			ID:   int(income.Member().ID()),
+			Name: income.Member().Name(),
+		}
+	}
+
 	// Add tags if present
 	if len(income.Tags()) > 0 {
 		for _, tag := range income.Tags() {
@@ -87,4 +130,24 @@ func ToIncomeResponseDTO(income *entities.Income) IncomeResponseDTO {
 	}
 
 	return dto
-}
\ No newline at end of file
+}
+
+// ToEarningResponseDTO presents an income in the expense shape /expenses/earnings returned before incomes had a table of their own.
+// The ID is the income's.
+func ToEarningResponseDTO(income *entities.Income) ExpenseResponseDTO {
+	incomeDTO := ToIncomeResponseDTO(income)
+	return ExpenseResponseDTO{
+		ID:        incomeDTO.ID,
+		Amount:    incomeDTO.Amount,
+		Currency:  incomeDTO.Currency,
+		Date:      incomeDTO.Date,
+		Type:      string(entities.ExpenseTypeIncome),
+		Category:  incomeDTO.Source,
+		Comment:   incomeDTO.Comment,
+		Vendor:    incomeDTO.Vendor,
+		Member:    incomeDTO.Member,
+		Tags:      incomeDTO.Tags,
+		CreatedAt: incomeDTO.CreatedAt,
+		UpdatedAt: incomeDTO.UpdatedAt,
+	}
+}
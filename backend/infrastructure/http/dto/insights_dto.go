@@ -0,0 +1,12 @@
+package dto
+
+import "encoding/json"
+
+// Response DTOs
+type AnomalyDTO struct {
+	Expense  ExpenseResponseDTO `json:"expense"`
+	Amount   json.Number        `json:"amount"`   // The expense amount in the reporting currency
+	Currency string             `json:"currency"` // The reporting currency
+	Kinds    []string           `json:"kinds"`    // unusual_amount, unusual_frequency and/or new_vendor
+	Reason   string             `json:"reason"`   // Why the expense was flagged, one sentence per kind
+}
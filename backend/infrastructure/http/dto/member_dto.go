@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// Request DTOs
+type CreateMemberRequestDTO struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type UpdateMemberRequestDTO struct {
+	Name *string `json:"name,omitempty"`
+}
+
+// Response DTOs
+type MemberResponseDTO struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// MemberSummaryDTO is the member reference embedded in expense and income responses
+type MemberSummaryDTO struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Request DTOs
+type CreateRecurringRuleRequestDTO struct {
+	Kind       string      `json:"kind" validate:"required,oneof=expense income"`
+	Name       string      `json:"name" validate:"required"`
+	Amount     json.Number `json:"amount" validate:"required"`                      // Exact decimal amount per occurrence, e.g. 950.00
+	Currency   string      `json:"currency,omitempty" validate:"omitempty,iso4217"` // Optional, defaults to EUR if not provided
+	Category   string      `json:"category,omitempty"`                              // Required for expense rules
+	Source     string      `json:"source,omitempty"`                                // Required for income rules
+	Comment    string      `json:"comment,omitempty"`                               // Optional, defaults to the rule name
+	VendorID   *int        `json:"vendor_id,omitempty"`
+	MemberID   *int        `json:"member_id,omitempty"`
+	PaidByCard *bool       `json:"paid_by_card,omitempty"` // Expense rules only, defaults to true if not provided
+	TagIDs     []int       `json:"tag_ids,omitempty"`
+	Frequency  string      `json:"frequency" validate:"required,oneof=weekly monthly yearly"`
+	Interval   int         `json:"interval,omitempty" validate:"omitempty,min=1,max=100"`    // Every n weeks, months or years, defaults to 1
+	DayOfMonth int         `json:"day_of_month,omitempty" validate:"omitempty,min=1,max=31"` // Monthly rules only, defaults to the day of start_date
+	StartDate  string      `json:"start_date" validate:"required"`
+	EndDate    string      `json:"end_date,omitempty"` // Optional last day (inclusive)
+}
+
+type UpdateRecurringRuleRequestDTO struct {
+	Name       *string      `json:"name,omitempty"`
+	Amount     *json.Number `json:"amount,omitempty"`
+	Currency   *string      `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	Category   *string      `json:"category,omitempty"`
+	Source     *string      `json:"source,omitempty"`
+	Comment    *string      `json:"comment,omitempty"`
+	VendorID   *int         `json:"vendor_id,omitempty"` // 0 removes the vendor
+	MemberID   *int         `json:"member_id,omitempty"` // 0 removes the member
+	PaidByCard *bool        `json:"paid_by_card,omitempty"`
+	TagIDs     *[]int       `json:"tag_ids,omitempty"` // Empty list removes all tags
+	Frequency  *string      `json:"frequency,omitempty" validate:"omitempty,oneof=weekly monthly yearly"`
+	Interval   *int         `json:"interval,omitempty" validate:"omitempty,min=1,max=100"`
+	DayOfMonth *int         `json:"day_of_month,omitempty" validate:"omitempty,min=1,max=31"`
+	StartDate  *string      `json:"start_date,omitempty"`
+	EndDate    *string      `json:"end_date,omitempty"` // Empty string removes the end date
+	Active     *bool        `json:"active,omitempty"`
+}
+
+type SkipOccurrenceRequestDTO struct {
+	Date string `json:"date" validate:"required"` // Scheduled day to skip (YYYY-MM-DD)
+}
+
+// Response DTOs
+type RecurringRuleResponseDTO struct {
+	ID         int         `json:"id"`
+	Kind       string      `json:"kind"`
+	Name       string      `json:"name"`
+	Amount     json.Number `json:"amount"`
+	Currency   string      `json:"currency"`
+	Category   string      `json:"category,omitempty"`
+	Source     string      `json:"source,omitempty"`
+	Comment    string      `json:"comment"`
+	VendorID   *int        `json:"vendor_id,omitempty"`
+	MemberID   *int        `json:"member_id,omitempty"`
+	PaidByCard bool        `json:"paid_by_card"`
+	TagIDs     []int       `json:"tag_ids"`
+	Frequency  string      `json:"frequency"`
+	Interval   int         `json:"interval"`
+	DayOfMonth int         `json:"day_of_month,omitempty"`
+	StartDate  string      `json:"start_date"`
+	EndDate    *string     `json:"end_date,omitempty"`
+	Active     bool        `json:"active"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+type UpcomingOccurrenceDTO struct {
+	RuleID    int         `json:"rule_id"`
+	Name      string      `json:"name"`
+	Kind      string      `json:"kind"`
+	Date      string      `json:"date"`
+	Amount    json.Number `json:"amount"`
+	Currency  string      `json:"currency"`
+	Status    string      `json:"status"` // scheduled, pending, created or skipped
+	ExpenseID *int        `json:"expense_id,omitempty"`
+	IncomeID  *int        `json:"income_id,omitempty"`
+}
@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+	"expenso-backend/infrastructure/http/dto"
+	"expenso-backend/infrastructure/http/middleware"
+	"expenso-backend/usecases/interactors/analytics"
+	"expenso-backend/usecases/interfaces/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyticsHandler serves reports computed over expenses and incomes together
+type AnalyticsHandler struct {
+	analyticsInteractor *analytics.AnalyticsInteractor
+	cashFlowInteractor  *analytics.CashFlowInteractor
+	forecastInteractor  *analytics.ForecastInteractor
+}
+
+func NewAnalyticsHandler(analyticsInteractor *analytics.AnalyticsInteractor, cashFlowInteractor *analytics.CashFlowInteractor, forecastInteractor *analytics.ForecastInteractor) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsInteractor: analyticsInteractor,
+		cashFlowInteractor:  cashFlowInteractor,
+		forecastInteractor:  forecastInteractor,
+	}
+}
+
+// Aggregate godoc
+// @Summary Aggregate expenses and incomes over time
+// @Description Sum, count, average, min and max of expenses and incomes per period, optionally split by a dimension.
+// @Description Grouping happens in the database; amounts are converted into the reporting currency at the rate of their day.
+// @Description Buckets run without gaps over the date range, or from the first to the last transaction without one.
+// @Tags analytics
+// @Produce json
+// @Security BearerAuth
+// @Param period query string false "Bucket length (default month)" Enums(day, week, month, quarter, year)
+// @Param group_by query string false "Split each bucket by this dimension; tags count a transaction once per tag" Enums(category, vendor, vendor_type, tag, member, payment_method)
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param reporting_currency query string false "ISO-4217 currency to report amounts in (default EUR)"
+// @Success 200 {object} dto.AggregationDTO
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /analytics/aggregate [get]
+func (h *AnalyticsHandler) Aggregate(c *gin.Context) {
+	startDate, endDate, ok := parseDateRange(c)
+	if !ok {
+		return
+	}
+
+	query := repositories.AggregateQuery{
+		Period:            repositories.Period(c.DefaultQuery("period", string(repositories.PeriodMonth))),
+		Dimension:         repositories.Dimension(c.Query("group_by")),
+		StartDate:         startDate,
+		EndDate:           endDate,
+		ReportingCurrency: c.Query("reporting_currency"),
+	}
+
+	aggregation, err := h.analyticsInteractor.Aggregate(middleware.CurrentHouseholdID(c), query)
+	if err != nil {
+		switch {
+		case errors.Is(err, analytics.ErrInvalidPeriod), errors.Is(err, analytics.ErrInvalidDimension):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, valueobjects.ErrInvalidCurrency):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reporting_currency"})
+		case errors.Is(err, entities.ErrExchangeRateNotFound):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to aggregate transactions"})
+		}
+		return
+	}
+
+	response := dto.AggregationDTO{
+		Period:   string(aggregation.Period),
+		GroupBy:  string(aggregation.Dimension),
+		Currency: aggregation.Currency,
+		Buckets:  make([]dto.AggregateBucketDTO, len(aggregation.Buckets)),
+	}
+	for i, bucket := range aggregation.Buckets {
+		groups := make([]dto.AggregateGroupDTO, len(bucket.Groups))
+		for j, group := range bucket.Groups {
+			groups[j] = dto.AggregateGroupDTO{
+				Key:      group.Key,
+				Label:    group.Label,
+				Expenses: aggregateStatsToDTO(group.Expenses),
+				Incomes:  aggregateStatsToDTO(group.Incomes),
+			}
+		}
+		response.Buckets[i] = dto.AggregateBucketDTO{
+			Start:  bucket.Start.Format("2006-01-02"),
+			End:    bucket.End.Format("2006-01-02"),
+			Groups: groups,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CashFlow godoc
+// @Summary Get the cash flow
+// @Description Income, expenses, net, savings rate and running balance per period, in the reporting currency.
+// @Description The running balance starts from the net of everything recorded before start_date.
+// @Tags analytics
+// @Produce json
+// @Security BearerAuth
+// @Param period query string false "Period length (default month)" Enums(day, week, month, quarter, year)
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param reporting_currency query string false "ISO-4217 currency to report amounts in (default EUR)"
+// @Success 200 {object} dto.CashFlowDTO
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /analytics/cashflow [get]
+func (h *AnalyticsHandler) CashFlow(c *gin.Context) {
+	startDate, endDate, ok := parseDateRange(c)
+	if !ok {
+		return
+	}
+
+	period := repositories.Period(c.DefaultQuery("period", string(repositories.PeriodMonth)))
+	cashFlow, err := h.cashFlowInteractor.GetCashFlow(middleware.CurrentHouseholdID(c), period, startDate, endDate, c.Query("reporting_currency"))
+	if err != nil {
+		switch {
+		case errors.Is(err, analytics.ErrInvalidPeriod):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, valueobjects.ErrInvalidCurrency):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reporting_currency"})
+		case errors.Is(err, entities.ErrExchangeRateNotFound):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate cash flow"})
+		}
+		return
+	}
+
+	response := dto.CashFlowDTO{
+		Period:         string(period),
+		Currency:       cashFlow.Currency,
+		OpeningBalance: json.Number(cashFlow.OpeningBalance.Decimal()),
+		Periods:        make([]dto.CashFlowPeriodDTO, len(cashFlow.Periods)),
+		Totals:         cashFlowTotalsToDTO(cashFlow.Totals),
+		ClosingBalance: json.Number(cashFlow.ClosingBalance.Decimal()),
+	}
+	for i, p := range cashFlow.Periods {
+		response.Periods[i] = dto.CashFlowPeriodDTO{
+			Start:             p.Start.Format("2006-01-02"),
+			End:               p.End.Format("2006-01-02"),
+			CashFlowTotalsDTO: cashFlowTotalsToDTO(p.CashFlowTotals),
+			Balance:           json.Number(p.Balance.Decimal()),
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Forecast godoc
+// @Summary Forecast spending
+// @Description Projects the rest of the current month and the months after it, split into fixed costs and variable spending.
+// @Description Expenses at living and subscriptions vendors count as fixed. Variable spending follows the last year's average,
+// @Description adjusted for seasonality once there is a year of history. The band covers 80% of the outcomes the history suggests.
+// @Tags analytics
+// @Produce json
+// @Security BearerAuth
+// @Param months query int false "Months to project after the current one (default 3, at most 24)"
+// @Param as_of query string false "Date to forecast from (YYYY-MM-DD, default today)"
+// @Param reporting_currency query string false "ISO-4217 currency to report amounts in (default EUR)"
+// @Success 200 {object} dto.ForecastDTO
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /analytics/forecast [get]
+func (h *AnalyticsHandler) Forecast(c *gin.Context) {
+	months := 3
+	if value, ok := parseIntQuery(c, "months"); !ok {
+		return
+	} else if value != nil {
+		months = *value
+	}
+
+	asOf := time.Now()
+	if asOfStr := c.Query("as_of"); asOfStr != "" {
+		parsed, err := time.Parse("2006-01-02", asOfStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid as_of format (use YYYY-MM-DD)"})
+			return
+		}
+		asOf = parsed
+	}
+
+	forecast, err := h.forecastInteractor.GetForecast(middleware.CurrentHouseholdID(c), asOf, months, c.Query("reporting_currency"))
+	if err != nil {
+		switch {
+		case errors.Is(err, analytics.ErrInvalidForecastMonths):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, valueobjects.ErrInvalidCurrency):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reporting_currency"})
+		case errors.Is(err, entities.ErrExchangeRateNotFound):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to forecast spending"})
+		}
+		return
+	}
+
+	response := dto.ForecastDTO{
+		AsOf:             forecast.AsOf.Format("2006-01-02"),
+		Currency:         forecast.Currency,
+		Confidence:       analytics.ForecastConfidence,
+		FixedVendorTypes: make([]string, len(analytics.FixedVendorTypes)),
+		HistoryMonths:    forecast.HistoryMonths,
+		Seasonal:         forecast.Seasonal,
+		CurrentMonth:     forecastMonthToDTO(forecast.CurrentMonth),
+		Months:           make([]dto.ForecastMonthDTO, len(forecast.Months)),
+	}
+	for i, vendorType := range analytics.FixedVendorTypes {
+		response.FixedVendorTypes[i] = string(vendorType)
+	}
+	for i, month := range forecast.Months {
+		response.Months[i] = forecastMonthToDTO(month)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func forecastMonthToDTO(month analytics.ForecastMonth) dto.ForecastMonthDTO {
+	return dto.ForecastMonthDTO{
+		Start:         month.Start.Format("2006-01-02"),
+		End:           month.End.Format("2006-01-02"),
+		SpentFixed:    json.Number(month.SpentFixed.Decimal()),
+		SpentVariable: json.Number(month.SpentVariable.Decimal()),
+		Fixed:         json.Number(month.Fixed.Decimal()),
+		Variable:      json.Number(month.Variable.Decimal()),
+		Total:         json.Number(month.Total.Decimal()),
+		Low:           json.Number(month.Low.Decimal()),
+		High:          json.Number(month.High.Decimal()),
+	}
+}
+
+func cashFlowTotalsToDTO(totals analytics.CashFlowTotals) dto.CashFlowTotalsDTO {
+	return dto.CashFlowTotalsDTO{
+		Income:       json.Number(totals.Income.Decimal()),
+		Expenses:     json.Number(totals.Expenses.Decimal()),
+		Net:          json.Number(totals.Net.Decimal()),
+		IncomeCount:  totals.IncomeCount,
+		ExpenseCount: totals.ExpenseCount,
+		SavingsRate:  math.Round(totals.SavingsRate*10000) / 10000,
+	}
+}
+
+func aggregateStatsToDTO(stats *analytics.Stats) *dto.AggregateStatsDTO {
+	if stats == nil {
+		return nil
+	}
+	return &dto.AggregateStatsDTO{
+		Count:   stats.Count,
+		Sum:     json.Number(stats.Sum.Decimal()),
+		Average: json.Number(stats.Average.Decimal()),
+		Min:     json.Number(stats.Min.Decimal()),
+		Max:     json.Number(stats.Max.Decimal()),
+	}
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/infrastructure/http/dto"
+	"expenso-backend/infrastructure/http/middleware"
+	"expenso-backend/usecases/interactors/apitoken"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type APITokenHandler struct {
+	apiTokenInteractor *apitoken.APITokenInteractor
+	validator          *validator.Validate
+}
+
+func NewAPITokenHandler(apiTokenInteractor *apitoken.APITokenInteractor) *APITokenHandler {
+	return &APITokenHandler{
+		apiTokenInteractor: apiTokenInteractor,
+		validator:          validator.New(),
+	}
+}
+
+// GetAPITokens godoc
+// @Summary Get my API tokens
+// @Description Get the API tokens of the current user. Secrets are never returned after creation.
+// @Tags tokens
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.APITokenResponseDTO
+// @Failure 500 {object} map[string]string
+// @Router /tokens [get]
+func (h *APITokenHandler) GetAPITokens(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+
+	tokens, err := h.apiTokenInteractor.GetTokens(user.ID())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tokens"})
+		return
+	}
+
+	responseDTO := make([]dto.APITokenResponseDTO, len(tokens))
+	for i, token := range tokens {
+		responseDTO[i] = h.tokenToDTO(token)
+	}
+
+	c.JSON(http.StatusOK, responseDTO)
+}
+
+// CreateAPIToken godoc
+// @Summary Create an API token
+// @Description Create a long-lived token for scripts, acting on the current household. Scopes: expenses:read, expenses:write, incomes:read, incomes:write, catalog:read, catalog:write, budgets:read, budgets:write, recurring:read, recurring:write, import. The secret is only returned once.
+// @Tags tokens
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param token body dto.CreateAPITokenRequestDTO true "Token name and scopes"
+// @Success 201 {object} dto.CreatedAPITokenResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /tokens [post]
+func (h *APITokenHandler) CreateAPIToken(c *gin.Context) {
+	var requestDTO dto.CreateAPITokenRequestDTO
+	if err := c.ShouldBindJSON(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.validator.Struct(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, _ := middleware.CurrentUser(c)
+
+	created, err := h.apiTokenInteractor.CreateToken(user.ID(), middleware.CurrentHouseholdID(c), apitoken.CreateAPITokenCommand{
+		Name:   requestDTO.Name,
+		Scopes: requestDTO.Scopes,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.CreatedAPITokenResponseDTO{
+		APITokenResponseDTO: h.tokenToDTO(created.Token),
+		Token:               created.Secret,
+	})
+}
+
+// RevokeAPIToken godoc
+// @Summary Revoke an API token
+// @Description Delete one of the current user's API tokens; it stops working immediately
+// @Tags tokens
+// @Security BearerAuth
+// @Param id path int true "Token ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /tokens/{id} [delete]
+func (h *APITokenHandler) RevokeAPIToken(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	user, _ := middleware.CurrentUser(c)
+
+	if err := h.apiTokenInteractor.RevokeToken(user.ID(), entities.APITokenID(id)); err != nil {
+		if err == entities.ErrAPITokenNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Token not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke token"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *APITokenHandler) tokenToDTO(token *entities.APIToken) dto.APITokenResponseDTO {
+	scopes := make([]string, len(token.Scopes()))
+	for i, scope := range token.Scopes() {
+		scopes[i] = string(scope)
+	}
+
+	return dto.APITokenResponseDTO{
+		ID:          int(token.ID()),
+		Name:        token.Name(),
+		Prefix:      token.Prefix(),
+		HouseholdID: int(token.HouseholdID()),
+		Scopes:      scopes,
+		LastUsedAt:  token.LastUsedAt(),
+		CreatedAt:   token.CreatedAt(),
+	}
+}
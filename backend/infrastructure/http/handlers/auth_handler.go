@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/infrastructure/http/dto"
+	"expenso-backend/infrastructure/http/middleware"
+	"expenso-backend/usecases/interactors/auth"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type AuthHandler struct {
+	authInteractor *auth.AuthInteractor
+	validator      *validator.Validate
+}
+
+func NewAuthHandler(authInteractor *auth.AuthInteractor) *AuthHandler {
+	return &AuthHandler{
+		authInteractor: authInteractor,
+		validator:      validator.New(),
+	}
+}
+
+// Register godoc
+// @Summary Register a user account
+// @Description Create a user account and return a session token. Once the first account exists, registration must be enabled in the server config.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param user body dto.RegisterRequestDTO true "Account data"
+// @Success 201 {object} dto.AuthResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/register [post]
+func (h *AuthHandler) Register(c *gin.Context) {
+	var req dto.RegisterRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := h.authInteractor.Register(auth.RegisterCommand{
+		Email:    req.Email,
+		Name:     req.Name,
+		Password: req.Password,
+	})
+	if err != nil {
+		switch err {
+		case entities.ErrRegistrationDisabled:
+			c.JSON(http.StatusForbidden, gin.H{"error": "Registration is disabled"})
+		case entities.ErrUserAlreadyExists:
+			c.JSON(http.StatusConflict, gin.H{"error": "User with this email already exists"})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.sessionToDTO(session))
+}
+
+// Login godoc
+// @Summary Log in
+// @Description Exchange email and password for a session token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body dto.LoginRequestDTO true "Credentials"
+// @Success 200 {object} dto.AuthResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req dto.LoginRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := h.authInteractor.Login(auth.LoginCommand{
+		Email:    req.Email,
+		Password: req.Password,
+	})
+	if err != nil {
+		if err == entities.ErrInvalidCredentials {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid email or password"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log in"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.sessionToDTO(session))
+}
+
+// Me godoc
+// @Summary Get the current user
+// @Description Get the account the bearer token belongs to
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.UserResponseDTO
+// @Failure 401 {object} map[string]string
+// @Router /auth/me [get]
+func (h *AuthHandler) Me(c *gin.Context) {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.userToDTO(user))
+}
+
+// SwitchHousehold godoc
+// @Summary Switch household
+// @Description Issue a new session token acting on another household the current user belongs to
+// @Tags households
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Household ID"
+// @Success 200 {object} dto.AuthResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /households/{id}/switch [post]
+func (h *AuthHandler) SwitchHousehold(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid household ID"})
+		return
+	}
+
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
+		return
+	}
+
+	session, err := h.authInteractor.SwitchHousehold(user.ID(), entities.HouseholdID(id))
+	if err != nil {
+		if err == entities.ErrNotHouseholdMember {
+			c.JSON(http.StatusForbidden, gin.H{"error": "No access to this household"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to switch household"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.sessionToDTO(session))
+}
+
+func (h *AuthHandler) sessionToDTO(session *auth.Session) dto.AuthResponseDTO {
+	return dto.AuthResponseDTO{
+		Token:       session.Token,
+		ExpiresAt:   session.ExpiresAt,
+		HouseholdID: int(session.HouseholdID),
+		User:        h.userToDTO(session.User),
+	}
+}
+
+func (h *AuthHandler) userToDTO(user *entities.User) dto.UserResponseDTO {
+	return dto.UserResponseDTO{
+		ID:        int(user.ID()),
+		Email:     user.Email(),
+		Name:      user.Name(),
+		CreatedAt: user.CreatedAt(),
+	}
+}
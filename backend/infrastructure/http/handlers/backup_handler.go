@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/infrastructure/archive"
+	"expenso-backend/infrastructure/http/dto"
+	"expenso-backend/infrastructure/http/middleware"
+	"expenso-backend/usecases/interactors/backup"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BackupHandler struct {
+	backupInteractor *backup.BackupInteractor
+}
+
+func NewBackupHandler(backupInteractor *backup.BackupInteractor) *BackupHandler {
+	return &BackupHandler{
+		backupInteractor: backupInteractor,
+	}
+}
+
+// Backup godoc
+// @Summary Back up the household
+// @Description Download everything the current household owns as a versioned JSON archive: categories, vendors, tags, members, expenses, incomes with their tags, budgets, recurring rules and import profiles. Owners only.
+// @Tags admin
+// @Produce json
+// @Produce application/gzip
+// @Security BearerAuth
+// @Param gzip query bool false "Compress the archive with gzip"
+// @Success 200 {file} file "Backup archive"
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/backup [get]
+func (h *BackupHandler) Backup(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+
+	snapshot, err := h.backupInteractor.Backup(middleware.CurrentHouseholdID(c), user.ID())
+	if err != nil {
+		h.handleError(c, err, "Failed to back up household")
+		return
+	}
+
+	compress := c.Query("gzip") == "true"
+
+	var buf bytes.Buffer
+	if err := archive.Write(&buf, snapshot, compress); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to write backup"})
+		return
+	}
+
+	filename := fmt.Sprintf("expenso_backup_%s.json", time.Now().Format("2006-01-02"))
+	contentType := "application/json"
+	if compress {
+		filename += ".gz"
+		contentType = "application/gzip"
+	}
+
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+// Restore godoc
+// @Summary Restore a household backup
+// @Description Load an archive from /admin/backup, plain or gzip-compressed, into the current household in one transaction. Records are created with new IDs and their references remapped; categories, vendors, tags, members, import profiles and recurring rules with an existing name, budgets for an existing target and transactions identical to an existing one are kept as they are. Owners only.
+// @Tags admin
+// @Accept json
+// @Accept application/gzip
+// @Produce json
+// @Security BearerAuth
+// @Param archive body string true "Backup archive"
+// @Success 200 {object} dto.RestoreReportDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/restore [post]
+func (h *BackupHandler) Restore(c *gin.Context) {
+	data, err := c.GetRawData()
+	if err != nil || len(data) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Request body must contain a backup archive"})
+		return
+	}
+
+	snapshot, err := archive.Read(data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, _ := middleware.CurrentUser(c)
+
+	report, err := h.backupInteractor.Restore(middleware.CurrentHouseholdID(c), user.ID(), snapshot)
+	if err != nil {
+		h.handleError(c, err, "Failed to restore backup")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RestoreReportDTO{
+		Categories:          restoreCountToDTO(report.Categories),
+		Vendors:             restoreCountToDTO(report.Vendors),
+		Tags:                restoreCountToDTO(report.Tags),
+		Members:             restoreCountToDTO(report.Members),
+		Expenses:            restoreCountToDTO(report.Expenses),
+		Incomes:             restoreCountToDTO(report.Incomes),
+		Budgets:             restoreCountToDTO(report.Budgets),
+		RecurringRules:      restoreCountToDTO(report.RecurringRules),
+		ImportProfiles:      restoreCountToDTO(report.ImportProfiles),
+		CategorizationRules: restoreCountToDTO(report.CategorizationRules),
+	})
+}
+
+func (h *BackupHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch err {
+	case entities.ErrNotHouseholdMember:
+		c.JSON(http.StatusForbidden, gin.H{"error": "No access to this household"})
+	case entities.ErrNotHouseholdOwner:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only household owners can do this"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+func restoreCountToDTO(count backup.RestoreCount) dto.RestoreCountDTO {
+	return dto.RestoreCountDTO{Created: count.Created, Existing: count.Existing}
+}
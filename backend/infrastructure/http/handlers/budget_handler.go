@@ -0,0 +1,287 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/infrastructure/http/dto"
+	"expenso-backend/infrastructure/http/middleware"
+	"expenso-backend/usecases/interactors/budget"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type BudgetHandler struct {
+	budgetInteractor *budget.BudgetInteractor
+	validator        *validator.Validate
+}
+
+func NewBudgetHandler(budgetInteractor *budget.BudgetInteractor) *BudgetHandler {
+	return &BudgetHandler{
+		budgetInteractor: budgetInteractor,
+		validator:        validator.New(),
+	}
+}
+
+// GetBudgets godoc
+// @Summary Get all budgets
+// @Description Get a list of all budgets
+// @Tags budgets
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.BudgetResponseDTO
+// @Failure 500 {object} map[string]string
+// @Router /budgets [get]
+func (h *BudgetHandler) GetBudgets(c *gin.Context) {
+	budgets, err := h.budgetInteractor.GetBudgets(middleware.CurrentHouseholdID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch budgets"})
+		return
+	}
+
+	responseDTO := make([]dto.BudgetResponseDTO, len(budgets))
+	for i, b := range budgets {
+		responseDTO[i] = h.budgetToDTO(b)
+	}
+
+	c.JSON(http.StatusOK, responseDTO)
+}
+
+// GetBudget godoc
+// @Summary Get a budget by ID
+// @Description Get a single budget by its ID
+// @Tags budgets
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Budget ID"
+// @Success 200 {object} dto.BudgetResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /budgets/{id} [get]
+func (h *BudgetHandler) GetBudget(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid budget ID"})
+		return
+	}
+
+	b, err := h.budgetInteractor.GetBudget(middleware.CurrentHouseholdID(c), entities.BudgetID(id))
+	if err != nil {
+		if err == entities.ErrBudgetNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Budget not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch budget"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.budgetToDTO(b))
+}
+
+// CreateBudget godoc
+// @Summary Create a budget
+// @Description Create a spending budget for a category or vendor type per month, quarter or year
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param budget body dto.CreateBudgetRequestDTO true "Budget data"
+// @Success 201 {object} dto.BudgetResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /budgets [post]
+func (h *BudgetHandler) CreateBudget(c *gin.Context) {
+	var requestDTO dto.CreateBudgetRequestDTO
+	if err := c.ShouldBindJSON(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.validator.Struct(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	b, err := h.budgetInteractor.CreateBudget(middleware.CurrentHouseholdID(c), budget.CreateBudgetCommand{
+		Amount:     requestDTO.Amount.String(),
+		Currency:   requestDTO.Currency,
+		Period:     requestDTO.Period,
+		TargetType: requestDTO.TargetType,
+		Target:     requestDTO.Target,
+		Rollover:   requestDTO.Rollover,
+	})
+	if err != nil {
+		h.handleWriteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.budgetToDTO(b))
+}
+
+// UpdateBudget godoc
+// @Summary Update a budget
+// @Description Update an existing budget
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Budget ID"
+// @Param budget body dto.UpdateBudgetRequestDTO true "Updated budget data"
+// @Success 200 {object} dto.BudgetResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /budgets/{id} [put]
+func (h *BudgetHandler) UpdateBudget(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid budget ID"})
+		return
+	}
+
+	var requestDTO dto.UpdateBudgetRequestDTO
+	if err := c.ShouldBindJSON(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.validator.Struct(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cmd := budget.UpdateBudgetCommand{
+		ID:         entities.BudgetID(id),
+		Currency:   requestDTO.Currency,
+		Period:     requestDTO.Period,
+		TargetType: requestDTO.TargetType,
+		Target:     requestDTO.Target,
+		Rollover:   requestDTO.Rollover,
+	}
+	if requestDTO.Amount != nil {
+		amount := requestDTO.Amount.String()
+		cmd.Amount = &amount
+	}
+
+	b, err := h.budgetInteractor.UpdateBudget(middleware.CurrentHouseholdID(c), cmd)
+	if err != nil {
+		h.handleWriteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.budgetToDTO(b))
+}
+
+// DeleteBudget godoc
+// @Summary Delete a budget
+// @Description Delete a budget by its ID
+// @Tags budgets
+// @Security BearerAuth
+// @Param id path int true "Budget ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /budgets/{id} [delete]
+func (h *BudgetHandler) DeleteBudget(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid budget ID"})
+		return
+	}
+
+	if err := h.budgetInteractor.DeleteBudget(middleware.CurrentHouseholdID(c), entities.BudgetID(id)); err != nil {
+		if err == entities.ErrBudgetNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Budget not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete budget"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetBudgetStatus godoc
+// @Summary Get spend versus budget
+// @Description Get spent, remaining and projected end-of-period spend for every budget, in each budget's currency. The period is the one containing the given date.
+// @Tags budgets
+// @Produce json
+// @Security BearerAuth
+// @Param date query string false "Any day of the period (YYYY-MM-DD), defaults to today"
+// @Success 200 {array} dto.BudgetStatusDTO
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /budgets/status [get]
+func (h *BudgetHandler) GetBudgetStatus(c *gin.Context) {
+	date := time.Now()
+	if dateStr := c.Query("date"); dateStr != "" {
+		parsed, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format (use YYYY-MM-DD)"})
+			return
+		}
+		date = parsed
+	}
+
+	statuses, err := h.budgetInteractor.GetBudgetStatus(middleware.CurrentHouseholdID(c), date)
+	if err != nil {
+		if errors.Is(err, entities.ErrExchangeRateNotFound) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute budget status"})
+		}
+		return
+	}
+
+	responseDTO := make([]dto.BudgetStatusDTO, len(statuses))
+	for i, status := range statuses {
+		responseDTO[i] = dto.BudgetStatusDTO{
+			Budget:       h.budgetToDTO(status.Budget),
+			PeriodStart:  status.PeriodStart.Format("2006-01-02"),
+			PeriodEnd:    status.PeriodEnd.Format("2006-01-02"),
+			Carryover:    json.Number(status.Carryover.Decimal()),
+			Available:    json.Number(status.Available.Decimal()),
+			Spent:        json.Number(status.Spent.Decimal()),
+			Remaining:    json.Number(status.Remaining.Decimal()),
+			Projected:    json.Number(status.Projected.Decimal()),
+			ExpenseCount: status.ExpenseCount,
+		}
+	}
+
+	c.JSON(http.StatusOK, responseDTO)
+}
+
+func (h *BudgetHandler) handleWriteError(c *gin.Context, err error) {
+	switch err {
+	case entities.ErrBudgetNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Budget not found"})
+	case entities.ErrBudgetAlreadyExists:
+		c.JSON(http.StatusConflict, gin.H{"error": "Budget for this target and period already exists"})
+	case entities.ErrCategoryNotFound:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Category not found"})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+func (h *BudgetHandler) budgetToDTO(b *entities.Budget) dto.BudgetResponseDTO {
+	return dto.BudgetResponseDTO{
+		ID:         int(b.ID()),
+		Amount:     json.Number(b.Amount().Decimal()),
+		Currency:   b.Amount().Currency(),
+		Period:     string(b.Period()),
+		TargetType: string(b.TargetType()),
+		Target:     b.Target(),
+		Rollover:   b.Rollover(),
+		CreatedAt:  b.CreatedAt(),
+		UpdatedAt:  b.UpdatedAt(),
+	}
+}
@@ -0,0 +1,375 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+	"expenso-backend/infrastructure/http/dto"
+	"expenso-backend/infrastructure/http/middleware"
+	"expenso-backend/usecases/interactors/categorization"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type CategorizationRuleHandler struct {
+	categorizationInteractor *categorization.CategorizationInteractor
+	validator                *validator.Validate
+}
+
+func NewCategorizationRuleHandler(categorizationInteractor *categorization.CategorizationInteractor) *CategorizationRuleHandler {
+	return &CategorizationRuleHandler{
+		categorizationInteractor: categorizationInteractor,
+		validator:                validator.New(),
+	}
+}
+
+// GetCategorizationRules godoc
+// @Summary Get all categorization rules
+// @Description Get the categorization rules in the order they are applied: by priority, lowest first
+// @Tags categorization
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.CategorizationRuleResponseDTO
+// @Failure 500 {object} map[string]string
+// @Router /categorization-rules [get]
+func (h *CategorizationRuleHandler) GetCategorizationRules(c *gin.Context) {
+	rules, err := h.categorizationInteractor.GetRules(middleware.CurrentHouseholdID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch categorization rules"})
+		return
+	}
+
+	responseDTO := make([]dto.CategorizationRuleResponseDTO, len(rules))
+	for i, rule := range rules {
+		responseDTO[i] = h.ruleToDTO(rule)
+	}
+
+	c.JSON(http.StatusOK, responseDTO)
+}
+
+// GetCategorizationRule godoc
+// @Summary Get a categorization rule by ID
+// @Description Get a single categorization rule by its ID
+// @Tags categorization
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Categorization rule ID"
+// @Success 200 {object} dto.CategorizationRuleResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /categorization-rules/{id} [get]
+func (h *CategorizationRuleHandler) GetCategorizationRule(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid categorization rule ID"})
+		return
+	}
+
+	rule, err := h.categorizationInteractor.GetRule(middleware.CurrentHouseholdID(c), entities.CategorizationRuleID(id))
+	if err != nil {
+		if err == entities.ErrCategorizationRuleNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Categorization rule not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch categorization rule"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.ruleToDTO(rule))
+}
+
+// CreateCategorizationRule godoc
+// @Summary Create a categorization rule
+// @Description Create a rule that fills in vendor and category and adds tags to new expenses and imported debits it matches.
+// @Description All conditions given must hold: a pattern found in payee or comment, a vendor type, an exact amount or an amount range.
+// @Tags categorization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rule body dto.CreateCategorizationRuleRequestDTO true "Categorization rule data"
+// @Success 201 {object} dto.CategorizationRuleResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /categorization-rules [post]
+func (h *CategorizationRuleHandler) CreateCategorizationRule(c *gin.Context) {
+	var requestDTO dto.CreateCategorizationRuleRequestDTO
+	if err := c.ShouldBindJSON(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.validator.Struct(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cmd := categorization.CreateCategorizationRuleCommand{
+		Name:     requestDTO.Name,
+		Priority: requestDTO.Priority,
+		Active:   requestDTO.Active,
+		Conditions: categorization.RuleConditionsCommand{
+			Pattern:    requestDTO.Pattern,
+			VendorType: requestDTO.VendorType,
+			Amount:     numberString(requestDTO.Amount),
+			MinAmount:  numberString(requestDTO.MinAmount),
+			MaxAmount:  numberString(requestDTO.MaxAmount),
+			Currency:   requestDTO.Currency,
+		},
+		Category: requestDTO.Category,
+	}
+
+	if requestDTO.VendorID != nil {
+		vendorID := entities.VendorID(*requestDTO.VendorID)
+		cmd.VendorID = &vendorID
+	}
+
+	cmd.TagIDs = make([]entities.TagID, len(requestDTO.TagIDs))
+	for i, tagID := range requestDTO.TagIDs {
+		cmd.TagIDs[i] = entities.TagID(tagID)
+	}
+
+	rule, err := h.categorizationInteractor.CreateRule(middleware.CurrentHouseholdID(c), cmd)
+	if err != nil {
+		h.handleWriteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.ruleToDTO(rule))
+}
+
+// UpdateCategorizationRule godoc
+// @Summary Update a categorization rule
+// @Description Update an existing categorization rule. Conditions are replaced together: if any is given, the ones left out are removed.
+// @Description Expenses the rule already categorized are not changed.
+// @Tags categorization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Categorization rule ID"
+// @Param rule body dto.UpdateCategorizationRuleRequestDTO true "Updated categorization rule data"
+// @Success 200 {object} dto.CategorizationRuleResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /categorization-rules/{id} [put]
+func (h *CategorizationRuleHandler) UpdateCategorizationRule(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid categorization rule ID"})
+		return
+	}
+
+	var requestDTO dto.UpdateCategorizationRuleRequestDTO
+	if err := c.ShouldBindJSON(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.validator.Struct(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cmd := categorization.UpdateCategorizationRuleCommand{
+		ID:       entities.CategorizationRuleID(id),
+		Name:     requestDTO.Name,
+		Priority: requestDTO.Priority,
+		Active:   requestDTO.Active,
+		Category: requestDTO.Category,
+	}
+
+	if requestDTO.Pattern != nil || requestDTO.VendorType != nil || requestDTO.Amount != nil || requestDTO.MinAmount != nil ||
+		requestDTO.MaxAmount != nil || requestDTO.Currency != nil {
+		cmd.Conditions = &categorization.RuleConditionsCommand{
+			Amount:    numberString(requestDTO.Amount),
+			MinAmount: numberString(requestDTO.MinAmount),
+			MaxAmount: numberString(requestDTO.MaxAmount),
+		}
+		if requestDTO.Pattern != nil {
+			cmd.Conditions.Pattern = *requestDTO.Pattern
+		}
+		if requestDTO.VendorType != nil {
+			cmd.Conditions.VendorType = *requestDTO.VendorType
+		}
+		if requestDTO.Currency != nil {
+			cmd.Conditions.Currency = *requestDTO.Currency
+		}
+	}
+
+	if requestDTO.VendorID != nil {
+		vendorID := entities.VendorID(*requestDTO.VendorID)
+		cmd.VendorID = &vendorID
+	}
+
+	if requestDTO.TagIDs != nil {
+		tagIDs := make([]entities.TagID, len(*requestDTO.TagIDs))
+		for i, tagID := range *requestDTO.TagIDs {
+			tagIDs[i] = entities.TagID(tagID)
+		}
+		cmd.TagIDs = &tagIDs
+	}
+
+	rule, err := h.categorizationInteractor.UpdateRule(middleware.CurrentHouseholdID(c), cmd)
+	if err != nil {
+		h.handleWriteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.ruleToDTO(rule))
+}
+
+// DeleteCategorizationRule godoc
+// @Summary Delete a categorization rule
+// @Description Delete a categorization rule by its ID. Expenses it already categorized are kept as they are.
+// @Tags categorization
+// @Security BearerAuth
+// @Param id path int true "Categorization rule ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /categorization-rules/{id} [delete]
+func (h *CategorizationRuleHandler) DeleteCategorizationRule(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid categorization rule ID"})
+		return
+	}
+
+	if err := h.categorizationInteractor.DeleteRule(middleware.CurrentHouseholdID(c), entities.CategorizationRuleID(id)); err != nil {
+		if err == entities.ErrCategorizationRuleNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Categorization rule not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete categorization rule"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// TestCategorizationRules godoc
+// @Summary Test the categorization rules
+// @Description Dry run: shows which rule would fire for an expense, and every active rule that matches it, without saving anything
+// @Tags categorization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param expense body dto.TestCategorizationRulesRequestDTO true "Expense to test"
+// @Success 200 {object} dto.CategorizationRuleTestDTO
+// @Failure 400 {object} map[string]string
+// @Router /categorization-rules/test [post]
+func (h *CategorizationRuleHandler) TestCategorizationRules(c *gin.Context) {
+	var requestDTO dto.TestCategorizationRulesRequestDTO
+	if err := c.ShouldBindJSON(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.validator.Struct(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cmd := categorization.TestCategorizationRulesCommand{
+		Payee:      requestDTO.Payee,
+		Comment:    requestDTO.Comment,
+		Amount:     requestDTO.Amount.String(),
+		Currency:   requestDTO.Currency,
+		VendorType: requestDTO.VendorType,
+	}
+
+	if requestDTO.VendorID != nil {
+		vendorID := entities.VendorID(*requestDTO.VendorID)
+		cmd.VendorID = &vendorID
+	}
+
+	result, err := h.categorizationInteractor.TestRules(middleware.CurrentHouseholdID(c), cmd)
+	if err != nil {
+		h.handleWriteError(c, err)
+		return
+	}
+
+	responseDTO := dto.CategorizationRuleTestDTO{Matches: make([]dto.CategorizationRuleResponseDTO, len(result.Matches))}
+	if result.Rule != nil {
+		rule := h.ruleToDTO(result.Rule)
+		responseDTO.Rule = &rule
+	}
+	for i, rule := range result.Matches {
+		responseDTO.Matches[i] = h.ruleToDTO(rule)
+	}
+
+	c.JSON(http.StatusOK, responseDTO)
+}
+
+func (h *CategorizationRuleHandler) handleWriteError(c *gin.Context, err error) {
+	switch err {
+	case entities.ErrCategorizationRuleNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Categorization rule not found"})
+	case entities.ErrCategorizationRuleExists:
+		c.JSON(http.StatusConflict, gin.H{"error": "Categorization rule with this name already exists"})
+	case entities.ErrCategoryNotFound:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Category not found"})
+	case entities.ErrVendorNotFound:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Vendor not found"})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+func (h *CategorizationRuleHandler) ruleToDTO(rule *entities.CategorizationRule) dto.CategorizationRuleResponseDTO {
+	conditions := rule.Conditions()
+	actions := rule.Actions()
+	responseDTO := dto.CategorizationRuleResponseDTO{
+		ID:         int(rule.ID()),
+		Name:       rule.Name(),
+		Priority:   rule.Priority(),
+		Active:     rule.Active(),
+		Pattern:    conditions.Pattern,
+		VendorType: string(conditions.VendorType),
+		Category:   actions.Category,
+		TagIDs:     make([]int, len(actions.TagIDs)),
+		CreatedAt:  rule.CreatedAt(),
+		UpdatedAt:  rule.UpdatedAt(),
+	}
+
+	for _, field := range []struct {
+		money  *valueobjects.Money
+		target **json.Number
+	}{
+		{conditions.Amount, &responseDTO.Amount},
+		{conditions.MinAmount, &responseDTO.MinAmount},
+		{conditions.MaxAmount, &responseDTO.MaxAmount},
+	} {
+		if field.money == nil {
+			continue
+		}
+		amount := json.Number(field.money.Decimal())
+		*field.target = &amount
+		responseDTO.Currency = field.money.Currency()
+	}
+
+	if actions.VendorID != nil {
+		vendorID := int(*actions.VendorID)
+		responseDTO.VendorID = &vendorID
+	}
+
+	for i, tagID := range actions.TagIDs {
+		responseDTO.TagIDs[i] = int(tagID)
+	}
+
+	return responseDTO
+}
+
+// numberString returns the decimal of an optional JSON number, empty if it is missing
+func numberString(number *json.Number) string {
+	if number == nil {
+		return ""
+	}
+	return number.String()
+}
@@ -6,6 +6,7 @@ import (
 
 	"expenso-backend/domain/entities"
 	"expenso-backend/infrastructure/http/dto"
+	"expenso-backend/infrastructure/http/middleware"
 	"expenso-backend/usecases/interactors/category"
 
 	"github.com/gin-gonic/gin"
@@ -32,7 +33,7 @@ func NewCategoryHandler(categoryInteractor *category.CategoryInteractor) *Catego
 // @Router /categories [get]
 func (h *CategoryHandler) GetCategories(c *gin.Context) {
 	// Execute use case
-	categories, err := h.categoryInteractor.GetCategories()
+	categories, err := h.categoryInteractor.GetCategories(middleware.CurrentHouseholdID(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch categories"})
 		return
@@ -68,7 +69,7 @@ func (h *CategoryHandler) GetCategory(c *gin.Context) {
 	}
 
 	// Execute use case
-	cat, err := h.categoryInteractor.GetCategory(entities.CategoryID(id))
+	cat, err := h.categoryInteractor.GetCategory(middleware.CurrentHouseholdID(c), entities.CategoryID(id))
 	if err != nil {
 		if err == entities.ErrCategoryNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
@@ -110,7 +111,7 @@ func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 	}
 
 	// Execute use case
-	cat, err := h.categoryInteractor.CreateCategory(cmd)
+	cat, err := h.categoryInteractor.CreateCategory(middleware.CurrentHouseholdID(c), cmd)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -158,7 +159,7 @@ func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 	}
 
 	// Execute use case
-	cat, err := h.categoryInteractor.UpdateCategory(cmd)
+	cat, err := h.categoryInteractor.UpdateCategory(middleware.CurrentHouseholdID(c), cmd)
 	if err != nil {
 		if err == entities.ErrCategoryNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
@@ -195,7 +196,7 @@ func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
 	}
 
 	// Execute use case
-	err = h.categoryInteractor.DeleteCategory(entities.CategoryID(id))
+	err = h.categoryInteractor.DeleteCategory(middleware.CurrentHouseholdID(c), entities.CategoryID(id))
 	if err != nil {
 		if err == entities.ErrCategoryNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/infrastructure/http/dto"
+	"expenso-backend/infrastructure/importers"
+	"expenso-backend/usecases/interactors/exchangerate"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type ExchangeRateHandler struct {
+	exchangeRateInteractor *exchangerate.ExchangeRateInteractor
+	validator              *validator.Validate
+}
+
+func NewExchangeRateHandler(exchangeRateInteractor *exchangerate.ExchangeRateInteractor) *ExchangeRateHandler {
+	return &ExchangeRateHandler{
+		exchangeRateInteractor: exchangeRateInteractor,
+		validator:              validator.New(),
+	}
+}
+
+// ImportExchangeRates godoc
+// @Summary Import exchange rates
+// @Description Import ECB-style daily reference rates (eurofxref CSV or XML). Existing rates for the same day are replaced.
+// @Description Rates are shared by all households, so only sessions of the configured administrators may import them.
+// @Tags exchange-rates
+// @Accept json
+// @Produce json
+// @Param rates body dto.ExchangeRateImportRequestDTO true "Rate file to import"
+// @Success 201 {object} dto.ExchangeRateImportResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /exchange-rates/import [post]
+func (h *ExchangeRateHandler) ImportExchangeRates(c *gin.Context) {
+	var req dto.ExchangeRateImportRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var rates []*entities.ExchangeRate
+	var err error
+
+	switch req.Format {
+	case "csv":
+		rates, err = importers.ParseECBRatesCSV(req.Data)
+	case "xml":
+		rates, err = importers.ParseECBRatesXML(req.Data)
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(rates) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File does not contain any exchange rates"})
+		return
+	}
+
+	if err := h.exchangeRateInteractor.ImportRates(rates); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import exchange rates"})
+		return
+	}
+
+	firstDate, lastDate := rates[0].Date(), rates[0].Date()
+	for _, rate := range rates {
+		if rate.Date().Before(firstDate) {
+			firstDate = rate.Date()
+		}
+		if rate.Date().After(lastDate) {
+			lastDate = rate.Date()
+		}
+	}
+
+	c.JSON(http.StatusCreated, dto.ExchangeRateImportResponseDTO{
+		Imported:  len(rates),
+		FirstDate: firstDate.Format("2006-01-02"),
+		LastDate:  lastDate.Format("2006-01-02"),
+	})
+}
+
+// GetExchangeRates godoc
+// @Summary Get exchange rates
+// @Description Get the rates of the most recent publication day on or before the given date
+// @Tags exchange-rates
+// @Accept json
+// @Produce json
+// @Param date query string false "Date (YYYY-MM-DD), defaults to today"
+// @Success 200 {array} dto.ExchangeRateResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /exchange-rates [get]
+func (h *ExchangeRateHandler) GetExchangeRates(c *gin.Context) {
+	date := time.Now()
+	if dateStr := c.Query("date"); dateStr != "" {
+		parsed, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format (use YYYY-MM-DD)"})
+			return
+		}
+		date = parsed
+	}
+
+	rates, err := h.exchangeRateInteractor.GetRatesByDate(date)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch exchange rates"})
+		return
+	}
+
+	responseDTO := make([]dto.ExchangeRateResponseDTO, len(rates))
+	for i, rate := range rates {
+		responseDTO[i] = h.exchangeRateToDTO(rate)
+	}
+
+	c.JSON(http.StatusOK, responseDTO)
+}
+
+// Helper method to convert domain entity to DTO
+func (h *ExchangeRateHandler) exchangeRateToDTO(rate *entities.ExchangeRate) dto.ExchangeRateResponseDTO {
+	return dto.ExchangeRateResponseDTO{
+		ID:            int(rate.ID()),
+		BaseCurrency:  rate.BaseCurrency(),
+		QuoteCurrency: rate.QuoteCurrency(),
+		Date:          rate.Date().Format("2006-01-02"),
+		Rate:          rate.Rate(),
+		Source:        rate.Source(),
+		CreatedAt:     rate.CreatedAt(),
+	}
+}
@@ -1,90 +1,132 @@
 package handlers
 
 import (
+	"bytes"
 	"encoding/csv"
-	"fmt"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
-	"strings"
 	"time"
 
 	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+	"expenso-backend/infrastructure/exporters"
 	"expenso-backend/infrastructure/http/dto"
+	"expenso-backend/infrastructure/http/middleware"
+	"expenso-backend/usecases/interactors/analytics"
 	"expenso-backend/usecases/interactors/expense"
+	"expenso-backend/usecases/interfaces/repositories"
 
 	"github.com/gin-gonic/gin"
 )
 
 type ExpenseHandler struct {
-	expenseInteractor *expense.ExpenseInteractor
+	expenseInteractor  *expense.ExpenseInteractor
+	cashFlowInteractor *analytics.CashFlowInteractor
 }
 
-func NewExpenseHandler(expenseInteractor *expense.ExpenseInteractor) *ExpenseHandler {
+func NewExpenseHandler(expenseInteractor *expense.ExpenseInteractor, cashFlowInteractor *analytics.CashFlowInteractor) *ExpenseHandler {
 	return &ExpenseHandler{
-		expenseInteractor: expenseInteractor,
+		expenseInteractor:  expenseInteractor,
+		cashFlowInteractor: cashFlowInteractor,
 	}
 }
 
 // GetExpenses godoc
-// @Summary Get all expenses
-// @Description Get a list of all expenses with optional date range filtering
+// @Summary Get expenses
+// @Description List expenses, newest first unless sorted otherwise. Filtering, sorting and paging happen in the database;
+// @Description without a limit every matching expense is returned. Follow X-Next-Cursor for the next page.
 // @Tags expenses
 // @Accept json
 // @Produce json
 // @Param start_date query string false "Start date (YYYY-MM-DD)"
 // @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param category query string false "Only expenses of this category"
+// @Param vendor_id query int false "Only expenses at this vendor"
+// @Param vendor_type query string false "Only expenses at vendors of this type" Enums(food_store, shop, eating_out, subscriptions, else)
+// @Param tag_id query int false "Only expenses with this tag"
+// @Param paid_by_card query bool false "Only card (true) or cash (false) payments"
+// @Param member_id query int false "Only expenses added by this member"
+// @Param currency query string false "Only amounts recorded in this currency, required with min_amount and max_amount"
+// @Param min_amount query string false "Smallest amount in currency"
+// @Param max_amount query string false "Largest amount in currency"
+// @Param sort query string false "date, amount, created_at or vendor, prefixed with - for descending order (default -date)"
+// @Param limit query int false "Page size"
+// @Param cursor query string false "X-Next-Cursor of the previous page"
 // @Success 200 {array} dto.ExpenseResponseDTO
+// @Header 200 {integer} X-Total-Count "Number of matching expenses across all pages"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, absent on the last one"
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /expenses [get]
 func (h *ExpenseHandler) GetExpenses(c *gin.Context) {
-	// Parse optional date range parameters
-	startDateStr := c.Query("start_date")
-	endDateStr := c.Query("end_date")
+	var filter repositories.ExpenseFilter
+	var ok bool
 
-	var startDate, endDate *time.Time
+	if filter.StartDate, filter.EndDate, ok = parseDateRange(c); !ok {
+		return
+	}
+	filter.Category = c.Query("category")
+	filter.VendorType = entities.VendorType(c.Query("vendor_type"))
 
-	// Parse start date if provided
-	if startDateStr != "" {
-		parsed, err := time.Parse("2006-01-02", startDateStr)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_date format (use YYYY-MM-DD)"})
-			return
-		}
-		startDate = &parsed
+	vendorID, ok := parseIntQuery(c, "vendor_id")
+	if !ok {
+		return
+	}
+	if vendorID != nil {
+		id := entities.VendorID(*vendorID)
+		filter.VendorID = &id
 	}
 
-	// Parse end date if provided
-	if endDateStr != "" {
-		parsed, err := time.Parse("2006-01-02", endDateStr)
-		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end_date format (use YYYY-MM-DD)"})
-			return
-		}
-		endDate = &parsed
+	tagID, ok := parseIntQuery(c, "tag_id")
+	if !ok {
+		return
+	}
+	if tagID != nil {
+		id := entities.TagID(*tagID)
+		filter.TagID = &id
 	}
 
-	var expenses []*entities.Expense
-	var err error
+	memberID, ok := parseIntQuery(c, "member_id")
+	if !ok {
+		return
+	}
+	if memberID != nil {
+		id := entities.MemberID(*memberID)
+		filter.MemberID = &id
+	}
 
-	// Execute appropriate use case based on parameters
-	if startDate != nil || endDate != nil {
-		expenses, err = h.expenseInteractor.GetExpensesByDateRange(startDate, endDate)
-	} else {
-		expenses, err = h.expenseInteractor.GetExpenses()
+	if filter.PaidByCard, ok = parseBoolQuery(c, "paid_by_card"); !ok {
+		return
+	}
+	if filter.Currency, filter.MinAmount, filter.MaxAmount, ok = parseAmountRange(c); !ok {
+		return
 	}
 
+	page, ok := parsePageRequest(c, "-date",
+		repositories.SortByDate, repositories.SortByAmount, repositories.SortByCreatedAt, repositories.SortByVendor)
+	if !ok {
+		return
+	}
+
+	result, err := h.expenseInteractor.ListExpenses(middleware.CurrentHouseholdID(c), filter, page)
 	if err != nil {
+		if err == entities.ErrInvalidVendorType {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid vendor_type"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch expenses"})
 		return
 	}
 
 	// Convert domain entities to DTOs
-	responseDTO := make([]dto.ExpenseResponseDTO, len(expenses))
-	for i, exp := range expenses {
+	responseDTO := make([]dto.ExpenseResponseDTO, len(result.Expenses))
+	for i, exp := range result.Expenses {
 		responseDTO[i] = h.expenseToDTO(exp)
 	}
 
+	setPageHeaders(c, page, result.PageInfo)
 	c.JSON(http.StatusOK, responseDTO)
 }
 
@@ -109,7 +151,7 @@ func (h *ExpenseHandler) GetExpense(c *gin.Context) {
 	}
 
 	// Execute use case
-	exp, err := h.expenseInteractor.GetExpense(entities.ExpenseID(id))
+	exp, err := h.expenseInteractor.GetExpense(middleware.CurrentHouseholdID(c), entities.ExpenseID(id))
 	if err != nil {
 		if err == entities.ErrExpenseNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Expense not found"})
@@ -152,13 +194,13 @@ func (h *ExpenseHandler) CreateExpense(c *gin.Context) {
 
 	// Convert DTO to use case command
 	cmd := expense.CreateExpenseCommand{
-		Amount:     requestDTO.Amount,
+		Amount:     requestDTO.Amount.String(),
+		Currency:   requestDTO.Currency,
 		Date:       date,
 		Type:       requestDTO.Type,
 		Category:   requestDTO.Category,
 		Comment:    requestDTO.Comment,
 		PaidByCard: requestDTO.PaidByCard, // Will be nil if not provided, defaults to true
-		AddedBy:    requestDTO.AddedBy,    // Will be nil if not provided, defaults to "he"
 	}
 
 	if requestDTO.VendorID != nil {
@@ -166,6 +208,11 @@ func (h *ExpenseHandler) CreateExpense(c *gin.Context) {
 		cmd.VendorID = &vendorID
 	}
 
+	if requestDTO.MemberID != nil {
+		memberID := entities.MemberID(*requestDTO.MemberID)
+		cmd.MemberID = &memberID
+	}
+
 	// Convert tag IDs if provided
 	if len(requestDTO.TagIDs) > 0 {
 		tagIDs := make([]entities.TagID, len(requestDTO.TagIDs))
@@ -176,7 +223,7 @@ func (h *ExpenseHandler) CreateExpense(c *gin.Context) {
 	}
 
 	// Execute use case
-	exp, err := h.expenseInteractor.CreateExpense(cmd)
+	exp, err := h.expenseInteractor.CreateExpense(middleware.CurrentHouseholdID(c), cmd)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -221,7 +268,12 @@ func (h *ExpenseHandler) UpdateExpense(c *gin.Context) {
 	}
 
 	if requestDTO.Amount != nil {
-		cmd.Amount = requestDTO.Amount
+		amount := requestDTO.Amount.String()
+		cmd.Amount = &amount
+	}
+
+	if requestDTO.Currency != nil {
+		cmd.Currency = requestDTO.Currency
 	}
 
 	if requestDTO.Date != nil {
@@ -246,8 +298,9 @@ func (h *ExpenseHandler) UpdateExpense(c *gin.Context) {
 		cmd.VendorID = &vendorID
 	}
 
-	if requestDTO.AddedBy != nil {
-		cmd.AddedBy = requestDTO.AddedBy
+	if requestDTO.MemberID != nil {
+		memberID := entities.MemberID(*requestDTO.MemberID)
+		cmd.MemberID = &memberID
 	}
 
 	// Handle tag updates - note that the UpdateExpenseRequestDTO doesn't have TagIDs yet
@@ -255,7 +308,7 @@ func (h *ExpenseHandler) UpdateExpense(c *gin.Context) {
 	// For now, we skip tag updates in the update endpoint
 
 	// Execute use case
-	exp, err := h.expenseInteractor.UpdateExpense(cmd)
+	exp, err := h.expenseInteractor.UpdateExpense(middleware.CurrentHouseholdID(c), cmd)
 	if err != nil {
 		if err == entities.ErrExpenseNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Expense not found"})
@@ -292,7 +345,7 @@ func (h *ExpenseHandler) DeleteExpense(c *gin.Context) {
 	}
 
 	// Execute use case
-	err = h.expenseInteractor.DeleteExpense(entities.ExpenseID(id))
+	err = h.expenseInteractor.DeleteExpense(middleware.CurrentHouseholdID(c), entities.ExpenseID(id))
 	if err != nil {
 		if err == entities.ErrExpenseNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Expense not found"})
@@ -307,54 +360,19 @@ func (h *ExpenseHandler) DeleteExpense(c *gin.Context) {
 
 // Helper method to convert domain entity to DTO
 func (h *ExpenseHandler) expenseToDTO(exp *entities.Expense) dto.ExpenseResponseDTO {
-	responseDTO := dto.ExpenseResponseDTO{
-		ID:         int(exp.ID()),
-		Amount:     exp.Amount().Amount(),
-		Date:       exp.Date().Format("2006-01-02"),
-		Type:       string(exp.Type()),
-		Category:   exp.Category().String(),
-		Comment:    exp.Comment(),
-		PaidByCard: exp.PaidByCard(),
-		AddedBy:    exp.AddedBy().String(),
-		CreatedAt:  exp.CreatedAt(),
-		UpdatedAt:  exp.UpdatedAt(),
-	}
-
-	// Add vendor if present
-	if exp.Vendor() != nil {
-		responseDTO.Vendor = &dto.VendorResponseDTO{
-			ID:        int(exp.Vendor().ID()),
-			Name:      exp.Vendor().Name(),
-			Type:      string(exp.Vendor().Type()),
-			CreatedAt: exp.Vendor().CreatedAt(),
-			UpdatedAt: exp.Vendor().UpdatedAt(),
-		}
-	}
-
-	// Add tags if present
-	if len(exp.Tags()) > 0 {
-		for _, tag := range exp.Tags() {
-			responseDTO.Tags = append(responseDTO.Tags, dto.TagResponseDTO{
-				ID:        int(tag.ID()),
-				Name:      tag.Name(),
-				Color:     tag.Color(),
-				CreatedAt: tag.CreatedAt(),
-				UpdatedAt: tag.UpdatedAt(),
-			})
-		}
-	}
-
-	return responseDTO
+	return dto.ToExpenseResponseDTO(exp)
 }
 
 // ExportExpensesCSV godoc
 // @Summary Export expenses as CSV
-// @Description Export expenses filtered by card payment and "he" as CSV with vendor type columns
+// @Description Export card-paid expenses as CSV with vendor type columns, optionally limited to one household member
 // @Tags expenses
 // @Accept json
 // @Produce text/csv
 // @Param start_date query string false "Start date (YYYY-MM-DD)"
 // @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param reporting_currency query string false "ISO-4217 currency to report amounts in (default EUR)"
+// @Param member_id query int false "Only export expenses recorded by this member"
 // @Success 200 {string} string "CSV file"
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -386,14 +404,31 @@ func (h *ExpenseHandler) ExportExpensesCSV(c *gin.Context) {
 		endDate = &parsed
 	}
 
+	reportingCurrency, err := valueobjects.NormalizeCurrency(c.Query("reporting_currency"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reporting_currency"})
+		return
+	}
+
+	// Parse member filter if provided
+	var memberID *entities.MemberID
+	if memberIDStr := c.Query("member_id"); memberIDStr != "" {
+		parsed, err := strconv.Atoi(memberIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid member_id"})
+			return
+		}
+		id := entities.MemberID(parsed)
+		memberID = &id
+	}
+
 	var expenses []*entities.Expense
-	var err error
 
 	// Execute appropriate use case based on parameters
 	if startDate != nil || endDate != nil {
-		expenses, err = h.expenseInteractor.GetExpensesByDateRange(startDate, endDate)
+		expenses, err = h.expenseInteractor.GetExpensesByDateRange(middleware.CurrentHouseholdID(c), startDate, endDate)
 	} else {
-		expenses, err = h.expenseInteractor.GetExpenses()
+		expenses, err = h.expenseInteractor.GetExpenses(middleware.CurrentHouseholdID(c))
 	}
 
 	if err != nil {
@@ -401,16 +436,20 @@ func (h *ExpenseHandler) ExportExpensesCSV(c *gin.Context) {
 		return
 	}
 
-	// Filter expenses: only card payments by "he"
+	// Filter expenses: only card payments, by the requested member if any
 	var filteredExpenses []*entities.Expense
 	for _, expense := range expenses {
-		if expense.PaidByCard() && expense.AddedBy().String() == "he" {
-			filteredExpenses = append(filteredExpenses, expense)
+		if !expense.PaidByCard() {
+			continue
 		}
+		if memberID != nil && (expense.Member() == nil || expense.Member().ID() != *memberID) {
+			continue
+		}
+		filteredExpenses = append(filteredExpenses, expense)
 	}
 
-	// Group expenses by date and vendor type
-	dateExpenseMap := make(map[string]map[string]float64)
+	// Group expenses by date and vendor type, converted into the reporting currency
+	dateExpenseMap := make(map[string]map[string]valueobjects.Money)
 
 	for _, expense := range filteredExpenses {
 		// Format date in Central European Time
@@ -419,7 +458,7 @@ func (h *ExpenseHandler) ExportExpensesCSV(c *gin.Context) {
 		dateKey := dateInCET.Format("Mon Jan 02 2006 15:04:05") + " GMT+0100 (Central European Standard Time)"
 
 		if dateExpenseMap[dateKey] == nil {
-			dateExpenseMap[dateKey] = make(map[string]float64)
+			dateExpenseMap[dateKey] = make(map[string]valueobjects.Money)
 		}
 
 		// Map vendor types to CSV column names
@@ -451,7 +490,32 @@ func (h *ExpenseHandler) ExportExpensesCSV(c *gin.Context) {
 			columnName = "else" // Default for expenses without vendor
 		}
 
-		dateExpenseMap[dateKey][columnName] += expense.Amount().Amount()
+		amount, err := h.expenseInteractor.ConvertAmount(expense, reportingCurrency)
+		if err != nil {
+			if errors.Is(err, entities.ErrExchangeRateNotFound) {
+				c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to convert expense amount"})
+			}
+			return
+		}
+
+		if existing, ok := dateExpenseMap[dateKey][columnName]; ok {
+			amount, err = existing.Add(amount)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to sum expenses"})
+				return
+			}
+		}
+		dateExpenseMap[dateKey][columnName] = amount
+	}
+
+	zero, _ := valueobjects.ZeroMoney(reportingCurrency)
+	formatColumn := func(expensesByType map[string]valueobjects.Money, columnName string) string {
+		if amount, ok := expensesByType[columnName]; ok {
+			return amount.Decimal()
+		}
+		return zero.Decimal()
 	}
 
 	// Set response headers for CSV download
@@ -476,16 +540,16 @@ func (h *ExpenseHandler) ExportExpensesCSV(c *gin.Context) {
 	for dateKey, expensesByType := range dateExpenseMap {
 		row := []string{
 			dateKey,
-			fmt.Sprintf("%.2f", expensesByType["food"]),
-			fmt.Sprintf("%.2f", expensesByType["eating out"]),
-			fmt.Sprintf("%.2f", expensesByType["else"]),
-			fmt.Sprintf("%.2f", expensesByType["fees"]),
-			fmt.Sprintf("%.2f", expensesByType["household"]),
-			fmt.Sprintf("%.2f", expensesByType["car"]),
-			fmt.Sprintf("%.2f", expensesByType["clothing"]),
-			fmt.Sprintf("%.2f", expensesByType["living"]),
-			fmt.Sprintf("%.2f", expensesByType["transport"]),
-			fmt.Sprintf("%.2f", expensesByType["turismo"]),
+			formatColumn(expensesByType, "food"),
+			formatColumn(expensesByType, "eating out"),
+			formatColumn(expensesByType, "else"),
+			formatColumn(expensesByType, "fees"),
+			formatColumn(expensesByType, "household"),
+			formatColumn(expensesByType, "car"),
+			formatColumn(expensesByType, "clothing"),
+			formatColumn(expensesByType, "living"),
+			formatColumn(expensesByType, "transport"),
+			formatColumn(expensesByType, "turismo"),
 		}
 
 		if err := writer.Write(row); err != nil {
@@ -497,284 +561,103 @@ func (h *ExpenseHandler) ExportExpensesCSV(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
 
-// ImportExpensesCSVPreview godoc
-// @Summary Preview CSV import
-// @Description Parse and preview CSV data for import validation
+// ExportExpensesFullCSV godoc
+// @Summary Export expenses as CSV, one row per expense
+// @Description Export every field of each expense, oldest first, in its original amount and currency
 // @Tags expenses
 // @Accept json
-// @Produce json
-// @Param csv_data body dto.CSVImportRequestDTO true "CSV data to preview"
-// @Success 200 {object} dto.CSVImportPreviewDTO
+// @Produce text/csv
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param member_id query int false "Only export expenses recorded by this member"
+// @Param paid_by_card query bool false "Only export card (true) or cash (false) payments"
+// @Param category query string false "Only export expenses of this category"
+// @Param tag_id query int false "Only export expenses with this tag"
+// @Success 200 {string} string "CSV file"
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
-// @Router /expenses/import/csv/preview [post]
-func (h *ExpenseHandler) ImportExpensesCSVPreview(c *gin.Context) {
-	var requestDTO dto.CSVImportRequestDTO
-	if err := c.ShouldBindJSON(&requestDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
-		return
-	}
+// @Router /expenses/export/csv/full [get]
+func (h *ExpenseHandler) ExportExpensesFullCSV(c *gin.Context) {
+	var filter expense.ExportFilter
 
-	// Parse CSV data
-	reader := csv.NewReader(strings.NewReader(requestDTO.CSVData))
-	records, err := reader.ReadAll()
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse CSV: " + err.Error()})
-		return
-	}
-
-	if len(records) < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "CSV must have at least a header row"})
-		return
-	}
-
-	// Expected headers
-	expectedHeaders := []string{"date", "food", "eating out", "else", "fees", "household", "car", "clothing", "living", "transport", "turismo"}
-	headers := records[0]
-
-	// Validate headers
-	if len(headers) != len(expectedHeaders) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "CSV must have exactly 11 columns: date, food, eating out, else, fees, household, car, clothing, living, transport, turismo"})
-		return
-	}
-
-	// Map column names to vendor types
-	columnToVendorType := map[string]string{
-		"food":       "food_store",
-		"eating out": "eating_out",
-		"else":       "else",
-		"fees":       "subscriptions",
-		"household":  "household",
-		"car":        "transport",
-		"clothing":   "clothing",
-		"living":     "living",
-		"transport":  "transport",
-		"turismo":    "tourism",
-	}
-
-	var previewRows []dto.CSVRowPreviewDTO
-
-	// Process data rows
-	for rowIdx, record := range records[1:] {
-		rowNumber := rowIdx + 2 // +2 because we skip header and arrays are 0-indexed
-
-		if len(record) != len(headers) {
-			previewRows = append(previewRows, dto.CSVRowPreviewDTO{
-				RowNumber: rowNumber,
-				Issues:    []string{"Row has wrong number of columns"},
-			})
-			continue
-		}
-
-		var issues []string
-		var parsedExpenses []dto.ParsedExpenseDTO
-		expenses := make(map[string]float64)
-
-		// Parse date
-		dateStr := record[0]
-		parsedDate, dateErr := h.parseCSVDate(dateStr)
-		if dateErr != nil {
-			issues = append(issues, "Invalid date format: "+dateErr.Error())
-		}
-
-		// Parse amounts for each vendor type
-		for i := 1; i < len(record); i++ {
-			columnName := headers[i]
-			amountStr := strings.TrimSpace(record[i])
-
-			if amountStr == "" || amountStr == "0" || amountStr == "0.00" {
-				expenses[columnName] = 0.0
-				continue
-			}
-
-			amount, err := strconv.ParseFloat(amountStr, 64)
-			if err != nil {
-				issues = append(issues, fmt.Sprintf("Invalid amount for %s: %s", columnName, amountStr))
-				continue
-			}
-
-			if amount > 0 {
-				expenses[columnName] = amount
-
-				// Create parsed expense
-				vendorType := columnToVendorType[columnName]
-				parsedExpense := dto.ParsedExpenseDTO{
-					Comment:    fmt.Sprintf("Imported %s expense", columnName),
-					Amount:     amount,
-					Date:       parsedDate,
-					VendorType: vendorType,
-					Category:   h.getDefaultCategoryForVendorType(vendorType),
-				}
-
-				// Check for potential issues
-				if parsedExpense.Category == "" {
-					parsedExpense.Issues = append(parsedExpense.Issues, "No default category found for vendor type")
-				}
-
-				parsedExpenses = append(parsedExpenses, parsedExpense)
-			}
+	if startDateStr := c.Query("start_date"); startDateStr != "" {
+		parsed, err := time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_date format (use YYYY-MM-DD)"})
+			return
 		}
-
-		previewRows = append(previewRows, dto.CSVRowPreviewDTO{
-			RowNumber: rowNumber,
-			Date:      dateStr,
-			Expenses:  expenses,
-			Issues:    issues,
-			Parsed:    parsedExpenses,
-		})
-	}
-
-	response := dto.CSVImportPreviewDTO{
-		Rows: previewRows,
+		filter.StartDate = &parsed
 	}
 
-	c.JSON(http.StatusOK, response)
-}
-
-// ImportExpensesCSVConfirm godoc
-// @Summary Confirm and import CSV row
-// @Description Confirm and create expenses from a CSV row
-// @Tags expenses
-// @Accept json
-// @Produce json
-// @Param import_data body dto.CSVImportConfirmRequestDTO true "Expenses to import"
-// @Success 201 {array} dto.ExpenseResponseDTO
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /expenses/import/csv/confirm [post]
-func (h *ExpenseHandler) ImportExpensesCSVConfirm(c *gin.Context) {
-	var requestDTO dto.CSVImportConfirmRequestDTO
-	if err := c.ShouldBindJSON(&requestDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
-		return
-	}
-
-	var createdExpenses []dto.ExpenseResponseDTO
-
-	for _, expenseRequest := range requestDTO.Expenses {
-		// Set defaults for imported expenses
-		if expenseRequest.PaidByCard == nil {
-			paidByCard := true
-			expenseRequest.PaidByCard = &paidByCard
-		}
-		if expenseRequest.AddedBy == nil {
-			addedBy := "he"
-			expenseRequest.AddedBy = &addedBy
-		}
-		if expenseRequest.Type == "" {
-			expenseRequest.Type = "expense"
-		}
-
-		// Parse date
-		date, err := time.Parse("2006-01-02", expenseRequest.Date)
+	if endDateStr := c.Query("end_date"); endDateStr != "" {
+		parsed, err := time.Parse("2006-01-02", endDateStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid date format: %s", expenseRequest.Date)})
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end_date format (use YYYY-MM-DD)"})
 			return
 		}
+		filter.EndDate = &parsed
+	}
 
-		// For CSV imports, use the expense date as both created and updated timestamps
-		// This ensures the expense appears to have been created when it actually happened
-		expenseDateTime := time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, time.UTC)
-
-		// Convert to use case command for CSV import
-		cmd := expense.CreateExpenseFromCSVCommand{
-			Amount:     expenseRequest.Amount,
-			Date:       date,
-			Type:       expenseRequest.Type,
-			Category:   expenseRequest.Category,
-			Comment:    expenseRequest.Comment,
-			PaidByCard: expenseRequest.PaidByCard,
-			AddedBy:    expenseRequest.AddedBy,
-			CreatedAt:  expenseDateTime,
-			UpdatedAt:  expenseDateTime,
-		}
-
-		if expenseRequest.VendorID != nil {
-			vendorID := entities.VendorID(*expenseRequest.VendorID)
-			cmd.VendorID = &vendorID
-		}
-
-		// Convert tag IDs if provided
-		if len(expenseRequest.TagIDs) > 0 {
-			tagIDs := make([]entities.TagID, len(expenseRequest.TagIDs))
-			for i, tagID := range expenseRequest.TagIDs {
-				tagIDs[i] = entities.TagID(tagID)
-			}
-			cmd.TagIDs = tagIDs
-		}
-
-		// Create expense from CSV
-		exp, err := h.expenseInteractor.CreateExpenseFromCSV(cmd)
+	if memberIDStr := c.Query("member_id"); memberIDStr != "" {
+		parsed, err := strconv.Atoi(memberIDStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid member_id"})
 			return
 		}
-
-		// Convert to DTO
-		responseDTO := h.expenseToDTO(exp)
-		createdExpenses = append(createdExpenses, responseDTO)
+		memberID := entities.MemberID(parsed)
+		filter.MemberID = &memberID
 	}
 
-	c.JSON(http.StatusCreated, createdExpenses)
-}
-
-// Helper functions for CSV import
-func (h *ExpenseHandler) parseCSVDate(dateStr string) (string, error) {
-	// Expected format: "01/01/2025" (MM/DD/YYYY)
-	// Try to parse different formats
-	layouts := []string{
-		"01/02/2006", // MM/DD/YYYY (primary expected format)
-		"02/01/2006", // DD/MM/YYYY (alternative)
-		"2006-01-02", // YYYY-MM-DD
-		"Mon Jan 02 2006 15:04:05 GMT-0700 (Central European Standard Time)",
-		"Mon Jan 02 2006 15:04:05 GMT+0100 (Central European Standard Time)",
-		"02.01.2006", // DD.MM.YYYY
+	if paidByCardStr := c.Query("paid_by_card"); paidByCardStr != "" {
+		parsed, err := strconv.ParseBool(paidByCardStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid paid_by_card (use true or false)"})
+			return
+		}
+		filter.PaidByCard = &parsed
 	}
 
-	var parsedTime time.Time
-	var err error
+	filter.Category = c.Query("category")
 
-	for _, layout := range layouts {
-		parsedTime, err = time.Parse(layout, strings.TrimSpace(dateStr))
-		if err == nil {
-			break
+	if tagIDStr := c.Query("tag_id"); tagIDStr != "" {
+		parsed, err := strconv.Atoi(tagIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tag_id"})
+			return
 		}
+		tagID := entities.TagID(parsed)
+		filter.TagID = &tagID
 	}
 
+	expenses, err := h.expenseInteractor.GetExpensesForExport(middleware.CurrentHouseholdID(c), filter)
 	if err != nil {
-		return "", fmt.Errorf("unable to parse date: %s", dateStr)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch expenses"})
+		return
 	}
 
-	// Return in YYYY-MM-DD format
-	return parsedTime.Format("2006-01-02"), nil
-}
+	var buf bytes.Buffer
+	if err := exporters.WriteExpenseCSV(&buf, expenses); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to write CSV"})
+		return
+	}
 
-func (h *ExpenseHandler) getDefaultCategoryForVendorType(vendorType string) string {
-	// Map vendor types to default categories that exist in the database
-	categoryMap := map[string]string{
-		"food_store":    "Food & Dining",
-		"eating_out":    "Food & Dining",
-		"else":          "Other",
-		"subscriptions": "Bills & Utilities",
-		"household":     "Living",
-		"transport":     "Transportation",
-		"clothing":      "Shopping",
-		"living":        "Living",
-		"tourism":       "Travel",
-	}
-	return categoryMap[vendorType]
+	c.Header("Content-Disposition", "attachment; filename=expenses_full_export.csv")
+	c.Data(http.StatusOK, "text/csv", buf.Bytes())
 }
 
 // GetBalanceSummary godoc
 // @Summary Get balance summary (earnings vs expenses)
-// @Description Get balance summary with total earnings, expenses, and balance for a date range
+// @Description Get balance summary with total earnings, expenses, and balance for a date range.
+// @Description Earnings are the incomes of the range; see /analytics/cashflow for a breakdown per period.
 // @Tags expenses
 // @Accept json
 // @Produce json
 // @Param start_date query string false "Start date (YYYY-MM-DD)"
 // @Param end_date query string false "End date (YYYY-MM-DD)"
-// @Success 200 {object} map[string]interface{}
+// @Param reporting_currency query string false "ISO-4217 currency to report totals in (default EUR)"
+// @Success 200 {object} dto.BalanceSummaryDTO
 // @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /expenses/balance [get]
 func (h *ExpenseHandler) GetBalanceSummary(c *gin.Context) {
@@ -805,13 +688,27 @@ func (h *ExpenseHandler) GetBalanceSummary(c *gin.Context) {
 	}
 
 	// Execute use case
-	balanceSummary, err := h.expenseInteractor.GetBalanceSummaryByDateRange(startDate, endDate)
+	cashFlow, err := h.cashFlowInteractor.GetCashFlow(middleware.CurrentHouseholdID(c), repositories.PeriodYear, startDate, endDate, c.Query("reporting_currency"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate balance summary"})
+		switch {
+		case errors.Is(err, valueobjects.ErrInvalidCurrency):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reporting_currency"})
+		case errors.Is(err, entities.ErrExchangeRateNotFound):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate balance summary"})
+		}
 		return
 	}
 
-	c.JSON(http.StatusOK, balanceSummary)
+	c.JSON(http.StatusOK, dto.BalanceSummaryDTO{
+		TotalEarnings: json.Number(cashFlow.Totals.Income.Decimal()),
+		TotalExpenses: json.Number(cashFlow.Totals.Expenses.Decimal()),
+		Balance:       json.Number(cashFlow.Totals.Net.Decimal()),
+		Currency:      cashFlow.Currency,
+		EarningsCount: cashFlow.Totals.IncomeCount,
+		ExpensesCount: cashFlow.Totals.ExpenseCount,
+	})
 }
 
 // GetActualExpenses godoc
@@ -854,7 +751,7 @@ func (h *ExpenseHandler) GetActualExpenses(c *gin.Context) {
 	}
 
 	// Execute use case
-	expenses, err := h.expenseInteractor.GetActualExpensesByDateRange(startDate, endDate)
+	expenses, err := h.expenseInteractor.GetActualExpensesByDateRange(middleware.CurrentHouseholdID(c), startDate, endDate)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch actual expenses"})
 		return
@@ -917,7 +814,7 @@ func (h *ExpenseHandler) GetExpensesByCategory(c *gin.Context) {
 	}
 
 	// Execute use case
-	expenses, err := h.expenseInteractor.GetExpensesByCategoryAndDateRange(category, startDate, endDate)
+	expenses, err := h.expenseInteractor.GetExpensesByCategoryAndDateRange(middleware.CurrentHouseholdID(c), category, startDate, endDate)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch expenses by category"})
 		return
@@ -934,7 +831,7 @@ func (h *ExpenseHandler) GetExpensesByCategory(c *gin.Context) {
 
 // GetEarnings godoc
 // @Summary Get earnings (salary entries)
-// @Description Get salary entries (earnings) for a date range
+// @Description Get the incomes of a date range in the expense format, with type "income" and the source as category
 // @Tags expenses
 // @Accept json
 // @Produce json
@@ -972,7 +869,7 @@ func (h *ExpenseHandler) GetEarnings(c *gin.Context) {
 	}
 
 	// Execute use case
-	earnings, err := h.expenseInteractor.GetEarningsByDateRange(startDate, endDate)
+	earnings, err := h.cashFlowInteractor.GetEarnings(middleware.CurrentHouseholdID(c), startDate, endDate)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch earnings"})
 		return
@@ -980,8 +877,8 @@ func (h *ExpenseHandler) GetEarnings(c *gin.Context) {
 
 	// Convert domain entities to DTOs
 	responseDTO := make([]dto.ExpenseResponseDTO, len(earnings))
-	for i, exp := range earnings {
-		responseDTO[i] = h.expenseToDTO(exp)
+	for i, income := range earnings {
+		responseDTO[i] = dto.ToEarningResponseDTO(income)
 	}
 
 	c.JSON(http.StatusOK, responseDTO)
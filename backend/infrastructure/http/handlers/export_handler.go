@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+	"expenso-backend/infrastructure/exporters"
+	"expenso-backend/infrastructure/http/middleware"
+	"expenso-backend/usecases/interactors/expense"
+	"expenso-backend/usecases/interactors/income"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportHandler serves exports that cover expenses and incomes together
+type ExportHandler struct {
+	expenseInteractor *expense.ExpenseInteractor
+	incomeInteractor  *income.IncomeInteractor
+}
+
+func NewExportHandler(expenseInteractor *expense.ExpenseInteractor, incomeInteractor *income.IncomeInteractor) *ExportHandler {
+	return &ExportHandler{
+		expenseInteractor: expenseInteractor,
+		incomeInteractor:  incomeInteractor,
+	}
+}
+
+// ExportJournal godoc
+// @Summary Export a plain-text accounting journal
+// @Description Render expenses and incomes as a ledger-cli, hledger or beancount journal, oldest first. Expenses post to Expenses:<VendorType>:<Vendor> (Expenses:<Category> without a vendor) against Assets:Bank when paid by card and Assets:Cash otherwise; incomes post to Income:<VendorType>:<Vendor> (Income:<Source>) against Assets:Bank. Tags, comments, categories and members become metadata. The same data always gives the same file.
+// @Tags export
+// @Produce plain
+// @Security BearerAuth
+// @Param format query string false "ledger (default), hledger or beancount"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Success 200 {string} string "Journal file"
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /export/journal [get]
+func (h *ExportHandler) ExportJournal(c *gin.Context) {
+	format := exporters.JournalFormat(c.DefaultQuery("format", string(exporters.JournalLedger)))
+	if !format.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format (use ledger, hledger or beancount)"})
+		return
+	}
+
+	startDate, endDate, ok := parseDateRange(c)
+	if !ok {
+		return
+	}
+
+	householdID := middleware.CurrentHouseholdID(c)
+
+	expenses, err := h.expenseInteractor.GetExpensesForExport(householdID, expense.ExportFilter{StartDate: startDate, EndDate: endDate})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch expenses"})
+		return
+	}
+
+	incomes, err := h.incomeInteractor.GetIncomesByDateRange(householdID, startDate, endDate)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch incomes"})
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := exporters.WriteJournal(&buf, format, expenses, incomes); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to write journal"})
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=expenso."+format.FileExtension())
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", buf.Bytes())
+}
+
+// ExportXLSX godoc
+// @Summary Export an Excel workbook
+// @Description Build an .xlsx workbook with a summary sheet of expenses per category and vendor type and month, an income vs. expenses sheet, and one sheet per month listing its transactions. Amounts are also given in the reporting currency, and the summary cells are formulas over the month sheets.
+// @Tags export
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param reporting_currency query string false "ISO-4217 currency to report amounts in (default EUR)"
+// @Success 200 {file} file "Excel workbook"
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /export/xlsx [get]
+func (h *ExportHandler) ExportXLSX(c *gin.Context) {
+	startDate, endDate, ok := parseDateRange(c)
+	if !ok {
+		return
+	}
+
+	reportingCurrency, err := valueobjects.NormalizeCurrency(c.Query("reporting_currency"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reporting_currency"})
+		return
+	}
+
+	householdID := middleware.CurrentHouseholdID(c)
+
+	var expenses []*entities.Expense
+	if startDate != nil || endDate != nil {
+		expenses, err = h.expenseInteractor.GetExpensesByDateRange(householdID, startDate, endDate)
+	} else {
+		expenses, err = h.expenseInteractor.GetExpenses(householdID)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch expenses"})
+		return
+	}
+
+	incomes, err := h.incomeInteractor.GetIncomesByDateRange(householdID, startDate, endDate)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch incomes"})
+		return
+	}
+
+	transactions := make([]exporters.WorkbookTransaction, 0, len(expenses)+len(incomes))
+	for _, expense := range expenses {
+		reported, err := h.expenseInteractor.ConvertAmount(expense, reportingCurrency)
+		if err != nil {
+			h.handleConversionError(c, err)
+			return
+		}
+		transactions = append(transactions, exporters.WorkbookTransaction{Expense: expense, Reported: reported})
+	}
+	for _, income := range incomes {
+		reported, err := h.incomeInteractor.ConvertAmount(income, reportingCurrency)
+		if err != nil {
+			h.handleConversionError(c, err)
+			return
+		}
+		transactions = append(transactions, exporters.WorkbookTransaction{Income: income, Reported: reported})
+	}
+
+	var buf bytes.Buffer
+	if err := exporters.WriteWorkbook(&buf, transactions, reportingCurrency); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to write workbook"})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=expenso_%s.xlsx", time.Now().Format("2006-01-02")))
+	c.Data(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", buf.Bytes())
+}
+
+func (h *ExportHandler) handleConversionError(c *gin.Context, err error) {
+	if errors.Is(err, entities.ErrExchangeRateNotFound) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to convert amounts"})
+}
@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/infrastructure/http/dto"
+	"expenso-backend/infrastructure/http/middleware"
+	"expenso-backend/usecases/interactors/household"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type HouseholdHandler struct {
+	householdInteractor *household.HouseholdInteractor
+	validator           *validator.Validate
+}
+
+func NewHouseholdHandler(householdInteractor *household.HouseholdInteractor) *HouseholdHandler {
+	return &HouseholdHandler{
+		householdInteractor: householdInteractor,
+		validator:           validator.New(),
+	}
+}
+
+// GetHouseholds godoc
+// @Summary Get my households
+// @Description Get every household the current user has access to
+// @Tags households
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.HouseholdResponseDTO
+// @Failure 500 {object} map[string]string
+// @Router /households [get]
+func (h *HouseholdHandler) GetHouseholds(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+
+	memberships, err := h.householdInteractor.GetHouseholds(user.ID())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch households"})
+		return
+	}
+
+	current := middleware.CurrentHouseholdID(c)
+	responseDTO := make([]dto.HouseholdResponseDTO, len(memberships))
+	for i, membership := range memberships {
+		responseDTO[i] = h.householdToDTO(membership.Household(), membership.Role(), current)
+	}
+
+	c.JSON(http.StatusOK, responseDTO)
+}
+
+// CreateHousehold godoc
+// @Summary Create a household
+// @Description Create a household owned by the current user, seeded with the default categories and tags. Use /households/{id}/switch to act on it.
+// @Tags households
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param household body dto.CreateHouseholdRequestDTO true "Household data"
+// @Success 201 {object} dto.HouseholdResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /households [post]
+func (h *HouseholdHandler) CreateHousehold(c *gin.Context) {
+	var requestDTO dto.CreateHouseholdRequestDTO
+	if err := c.ShouldBindJSON(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.validator.Struct(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, _ := middleware.CurrentUser(c)
+
+	created, err := h.householdInteractor.CreateHousehold(user.ID(), household.CreateHouseholdCommand{
+		Name: requestDTO.Name,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.householdToDTO(created, entities.HouseholdRoleOwner, middleware.CurrentHouseholdID(c)))
+}
+
+// GetHouseholdUsers godoc
+// @Summary Get household users
+// @Description Get the accounts that have access to a household
+// @Tags households
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Household ID"
+// @Success 200 {array} dto.HouseholdUserResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /households/{id}/users [get]
+func (h *HouseholdHandler) GetHouseholdUsers(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid household ID"})
+		return
+	}
+
+	user, _ := middleware.CurrentUser(c)
+
+	memberships, err := h.householdInteractor.GetUsers(entities.HouseholdID(id), user.ID())
+	if err != nil {
+		h.handleError(c, err, "Failed to fetch household users")
+		return
+	}
+
+	responseDTO := make([]dto.HouseholdUserResponseDTO, len(memberships))
+	for i, membership := range memberships {
+		responseDTO[i] = h.householdUserToDTO(membership.User(), membership.Role(), membership.CreatedAt())
+	}
+
+	c.JSON(http.StatusOK, responseDTO)
+}
+
+// AddHouseholdUser godoc
+// @Summary Add a user to a household
+// @Description Give an existing account access to a household, or change its role. Owners only.
+// @Tags households
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Household ID"
+// @Param user body dto.AddHouseholdUserRequestDTO true "User email and role (owner or member, default member)"
+// @Success 200 {object} dto.HouseholdUserResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /households/{id}/users [post]
+func (h *HouseholdHandler) AddHouseholdUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid household ID"})
+		return
+	}
+
+	var requestDTO dto.AddHouseholdUserRequestDTO
+	if err := c.ShouldBindJSON(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.validator.Struct(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	requester, _ := middleware.CurrentUser(c)
+
+	membership, err := h.householdInteractor.AddUser(entities.HouseholdID(id), requester.ID(), household.AddUserCommand{
+		Email: requestDTO.Email,
+		Role:  requestDTO.Role,
+	})
+	if err != nil {
+		h.handleError(c, err, "Failed to add household user")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.householdUserToDTO(membership.User(), membership.Role(), membership.CreatedAt()))
+}
+
+// RemoveHouseholdUser godoc
+// @Summary Remove a user from a household
+// @Description Revoke an account's access to a household. Owners can remove anyone, everyone can remove themselves. The last owner cannot be removed.
+// @Tags households
+// @Security BearerAuth
+// @Param id path int true "Household ID"
+// @Param user_id path int true "User ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /households/{id}/users/{user_id} [delete]
+func (h *HouseholdHandler) RemoveHouseholdUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid household ID"})
+		return
+	}
+
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	requester, _ := middleware.CurrentUser(c)
+
+	if err := h.householdInteractor.RemoveUser(entities.HouseholdID(id), requester.ID(), entities.UserID(userID)); err != nil {
+		h.handleError(c, err, "Failed to remove household user")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *HouseholdHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch err {
+	case entities.ErrNotHouseholdMember:
+		c.JSON(http.StatusForbidden, gin.H{"error": "No access to this household"})
+	case entities.ErrNotHouseholdOwner:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only household owners can do this"})
+	case entities.ErrUserNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+	case entities.ErrLastHouseholdOwner:
+		c.JSON(http.StatusConflict, gin.H{"error": "Household must keep at least one owner"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+func (h *HouseholdHandler) householdToDTO(household *entities.Household, role entities.HouseholdRole, current entities.HouseholdID) dto.HouseholdResponseDTO {
+	return dto.HouseholdResponseDTO{
+		ID:        int(household.ID()),
+		Name:      household.Name(),
+		Role:      string(role),
+		Current:   household.ID() == current,
+		CreatedAt: household.CreatedAt(),
+	}
+}
+
+func (h *HouseholdHandler) householdUserToDTO(user *entities.User, role entities.HouseholdRole, joinedAt time.Time) dto.HouseholdUserResponseDTO {
+	return dto.HouseholdUserResponseDTO{
+		ID:       int(user.ID()),
+		Email:    user.Email(),
+		Name:     user.Name(),
+		Role:     string(role),
+		JoinedAt: joinedAt,
+	}
+}
@@ -0,0 +1,817 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/infrastructure/http/dto"
+	"expenso-backend/infrastructure/http/middleware"
+	"expenso-backend/infrastructure/importers"
+	"expenso-backend/usecases/interactors/dataimport"
+	"expenso-backend/usecases/interactors/expense"
+	"expenso-backend/usecases/interactors/income"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type ImportHandler struct {
+	importInteractor  *dataimport.ImportInteractor
+	profileInteractor *dataimport.ImportProfileInteractor
+	validator         *validator.Validate
+}
+
+func NewImportHandler(importInteractor *dataimport.ImportInteractor, profileInteractor *dataimport.ImportProfileInteractor) *ImportHandler {
+	return &ImportHandler{
+		importInteractor:  importInteractor,
+		profileInteractor: profileInteractor,
+		validator:         validator.New(),
+	}
+}
+
+// PreviewCSVImport godoc
+// @Summary Preview CSV import
+// @Description Parse a CSV file with an import profile and suggest an expense for every amount (an income for credits and refunds). Without profile_id the built-in layout is used: date plus one amount column per spending area. Nothing is saved.
+// @Tags imports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param csv_data body dto.CSVImportRequestDTO true "CSV data to preview"
+// @Success 200 {object} dto.CSVImportPreviewDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /expenses/import/csv/preview [post]
+func (h *ImportHandler) PreviewCSVImport(c *gin.Context) {
+	var req dto.CSVImportRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	data := []byte(req.CSVData)
+	if req.CSVBase64 != "" {
+		decoded, err := base64.StdEncoding.DecodeString(req.CSVBase64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "csv_base64 is not valid base64"})
+			return
+		}
+		data = decoded
+	}
+
+	householdID := middleware.CurrentHouseholdID(c)
+	var profileID entities.ImportProfileID
+	if req.ProfileID != nil {
+		profileID = entities.ImportProfileID(*req.ProfileID)
+	}
+	profile, err := h.profileInteractor.GetProfile(householdID, profileID)
+	if err != nil {
+		if err == entities.ErrImportProfileNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Import profile not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch import profile"})
+		}
+		return
+	}
+
+	rows, err := importers.ParseCSV(profile, data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hash := fileHash(data)
+	preview, err := h.importInteractor.PreviewCSV(householdID, profile, rows, hash)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to preview CSV"})
+		return
+	}
+
+	responseDTO := dto.CSVImportPreviewDTO{
+		Profile:         profile.Name(),
+		FileHash:        hash,
+		ImportedInBatch: batchIDPointer(preview.ImportedIn),
+		Rows:            make([]dto.CSVRowPreviewDTO, len(preview.Rows)),
+	}
+	for i, row := range preview.Rows {
+		responseDTO.Rows[i] = h.csvRowToDTO(row)
+	}
+
+	c.JSON(http.StatusOK, responseDTO)
+}
+
+// PreviewIncomeCSVImport godoc
+// @Summary Preview income CSV import
+// @Description Parse a CSV file in the layout of /incomes/export/csv (date, amount, currency, source, comment, vendor, added_by, tags) and resolve vendor, member and tag names. Nothing is saved.
+// @Tags imports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param csv_data body dto.IncomeCSVImportRequestDTO true "CSV data to preview"
+// @Success 200 {object} dto.IncomeCSVImportPreviewDTO
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /incomes/import/csv/preview [post]
+func (h *ImportHandler) PreviewIncomeCSVImport(c *gin.Context) {
+	var req dto.IncomeCSVImportRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	data := []byte(req.CSVData)
+	if req.CSVBase64 != "" {
+		decoded, err := base64.StdEncoding.DecodeString(req.CSVBase64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "csv_base64 is not valid base64"})
+			return
+		}
+		data = decoded
+	}
+
+	rows, err := importers.ParseIncomeCSV(data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hash := fileHash(data)
+	preview, err := h.importInteractor.PreviewIncomeCSV(middleware.CurrentHouseholdID(c), rows, hash)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to preview CSV"})
+		return
+	}
+
+	responseDTO := dto.IncomeCSVImportPreviewDTO{
+		FileHash:        hash,
+		ImportedInBatch: batchIDPointer(preview.ImportedIn),
+		Rows:            make([]dto.IncomeCSVRowPreviewDTO, len(preview.Rows)),
+	}
+	for i, row := range preview.Rows {
+		responseDTO.Rows[i] = h.incomeCSVRowToDTO(row)
+	}
+
+	c.JSON(http.StatusOK, responseDTO)
+}
+
+// ConfirmIncomeCSVImport godoc
+// @Summary Confirm an income CSV import
+// @Description Create the reviewed incomes of an income CSV preview as one import batch, all or nothing. Refused with 409 if the file (file_hash) or every income was imported before, unless force is set. With best_effort, every valid income is created and rows reports the outcome of each.
+// @Tags imports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param import_data body dto.IncomeCSVImportConfirmRequestDTO true "Incomes to create"
+// @Success 201 {object} dto.StatementImportResultDTO
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /incomes/import/csv/confirm [post]
+func (h *ImportHandler) ConfirmIncomeCSVImport(c *gin.Context) {
+	var req dto.IncomeCSVImportConfirmRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cmd := dataimport.ConfirmImportCommand{
+		Source:     "csv",
+		FileHash:   req.FileHash,
+		Force:      req.Force,
+		BestEffort: req.BestEffort,
+	}
+	for _, incomeRequest := range req.Incomes {
+		incomeCmd, err := h.incomeCommand(incomeRequest.CreateIncomeRequestDTO)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		cmd.Incomes = append(cmd.Incomes, dataimport.ImportedIncome{Fingerprint: incomeRequest.Fingerprint, Command: incomeCmd})
+	}
+
+	result, err := h.importInteractor.ConfirmImport(middleware.CurrentHouseholdID(c), cmd)
+	if err != nil {
+		h.handleConfirmError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.resultToDTO(result))
+}
+
+// PreviewStatementImport godoc
+// @Summary Preview a bank statement import
+// @Description Parse a bank statement and suggest an expense for every debit and an income for every credit. Nothing is saved; send the reviewed suggestions to /imports/statement/confirm.
+// @Tags imports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param statement body dto.StatementImportRequestDTO true "Statement format and file contents"
+// @Success 200 {object} dto.StatementImportPreviewDTO
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /imports/statement/preview [post]
+func (h *ImportHandler) PreviewStatementImport(c *gin.Context) {
+	var req dto.StatementImportRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var statement *entities.BankStatement
+	var err error
+	switch req.Format {
+	case "camt053":
+		statement, err = importers.ParseCAMT053(req.Data)
+	case "mt940":
+		statement, err = importers.ParseMT940(req.Data)
+	case "ofx", "qfx":
+		statement, err = importers.ParseOFX(req.Data)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hash := fileHash([]byte(req.Data))
+	preview, err := h.importInteractor.PreviewStatement(middleware.CurrentHouseholdID(c), statement, hash)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to preview statement"})
+		return
+	}
+
+	responseDTO := dto.StatementImportPreviewDTO{
+		Format:          req.Format,
+		Account:         preview.Account,
+		FileHash:        hash,
+		ImportedInBatch: batchIDPointer(preview.ImportedIn),
+		Rows:            make([]dto.StatementRowPreviewDTO, len(preview.Rows)),
+	}
+	for i, row := range preview.Rows {
+		responseDTO.Rows[i] = h.previewRowToDTO(row)
+	}
+
+	c.JSON(http.StatusOK, responseDTO)
+}
+
+// ConfirmCSVImport godoc
+// @Summary Confirm and import CSV row
+// @Description Create the reviewed expenses of one CSV preview row as an import batch, all or nothing. Refused with 409 if every expense was imported before, unless force is set. With best_effort, every valid expense is created and the response is a StatementImportResultDTO reporting each one.
+// @Tags imports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param import_data body dto.CSVImportConfirmRequestDTO true "Expenses to import"
+// @Success 201 {array} dto.ExpenseResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /expenses/import/csv/confirm [post]
+func (h *ImportHandler) ConfirmCSVImport(c *gin.Context) {
+	var req dto.CSVImportConfirmRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	cmd := dataimport.ConfirmImportCommand{Source: "csv", Force: req.Force, BestEffort: req.BestEffort}
+	for _, expenseRequest := range req.Expenses {
+		// CSV rows come from card statements
+		if expenseRequest.PaidByCard == nil {
+			paidByCard := true
+			expenseRequest.PaidByCard = &paidByCard
+		}
+		expenseCmd, err := h.expenseCommand(expenseRequest.CreateExpenseRequestDTO)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		cmd.Expenses = append(cmd.Expenses, dataimport.ImportedExpense{Fingerprint: expenseRequest.Fingerprint, Command: expenseCmd})
+	}
+
+	result, err := h.importInteractor.ConfirmImport(middleware.CurrentHouseholdID(c), cmd)
+	if err != nil {
+		h.handleConfirmError(c, err)
+		return
+	}
+
+	if req.BestEffort {
+		c.JSON(http.StatusCreated, h.resultToDTO(result))
+		return
+	}
+
+	createdExpenses := make([]dto.ExpenseResponseDTO, len(result.Expenses))
+	for i, exp := range result.Expenses {
+		createdExpenses[i] = dto.ToExpenseResponseDTO(exp)
+	}
+
+	c.JSON(http.StatusCreated, createdExpenses)
+}
+
+// ConfirmStatementImport godoc
+// @Summary Confirm a bank statement import
+// @Description Create the reviewed expenses and incomes of a statement or CSV preview as one import batch. Refused with 409 if the file (file_hash) or every row was imported before, unless force is set. Nothing is created if one row fails; with best_effort, every valid row is created and rows reports the outcome of each.
+// @Tags imports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param import_data body dto.StatementImportConfirmRequestDTO true "Expenses and incomes to create"
+// @Success 201 {object} dto.StatementImportResultDTO
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /imports/statement/confirm [post]
+func (h *ImportHandler) ConfirmStatementImport(c *gin.Context) {
+	var req dto.StatementImportConfirmRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cmd := dataimport.ConfirmImportCommand{
+		Source:     req.Source,
+		FileHash:   req.FileHash,
+		Force:      req.Force,
+		BestEffort: req.BestEffort,
+	}
+	if cmd.Source == "" {
+		cmd.Source = "statement"
+	}
+	for _, expenseRequest := range req.Expenses {
+		expenseCmd, err := h.expenseCommand(expenseRequest.CreateExpenseRequestDTO)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		cmd.Expenses = append(cmd.Expenses, dataimport.ImportedExpense{Fingerprint: expenseRequest.Fingerprint, Command: expenseCmd})
+	}
+	for _, incomeRequest := range req.Incomes {
+		incomeCmd, err := h.incomeCommand(incomeRequest.CreateIncomeRequestDTO)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		cmd.Incomes = append(cmd.Incomes, dataimport.ImportedIncome{Fingerprint: incomeRequest.Fingerprint, Command: incomeCmd})
+	}
+
+	result, err := h.importInteractor.ConfirmImport(middleware.CurrentHouseholdID(c), cmd)
+	if err != nil {
+		h.handleConfirmError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.resultToDTO(result))
+}
+
+// GetImportBatches godoc
+// @Summary Get import batches
+// @Description Get every confirmed import, newest first, including rolled back ones
+// @Tags imports
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.ImportBatchResponseDTO
+// @Failure 500 {object} map[string]string
+// @Router /imports/batches [get]
+func (h *ImportHandler) GetImportBatches(c *gin.Context) {
+	batches, err := h.importInteractor.GetBatches(middleware.CurrentHouseholdID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch import batches"})
+		return
+	}
+
+	responseDTO := make([]dto.ImportBatchResponseDTO, len(batches))
+	for i, batch := range batches {
+		responseDTO[i] = h.batchToDTO(batch)
+	}
+
+	c.JSON(http.StatusOK, responseDTO)
+}
+
+// GetImportBatch godoc
+// @Summary Get an import batch by ID
+// @Description Get an import batch with the fingerprint and ID of every transaction it created
+// @Tags imports
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Import batch ID"
+// @Success 200 {object} dto.ImportBatchResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /imports/batches/{id} [get]
+func (h *ImportHandler) GetImportBatch(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid import batch ID"})
+		return
+	}
+
+	batch, err := h.importInteractor.GetBatch(middleware.CurrentHouseholdID(c), entities.ImportBatchID(id))
+	if err != nil {
+		if err == entities.ErrImportBatchNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Import batch not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch import batch"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.batchToDTO(batch))
+}
+
+// RollBackImportBatch godoc
+// @Summary Roll back an import batch
+// @Description Delete every expense and income an import created. The batch is kept, marked as rolled back, so the file can be imported again.
+// @Tags imports
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Import batch ID"
+// @Success 200 {object} dto.ImportBatchResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /imports/batches/{id}/rollback [post]
+func (h *ImportHandler) RollBackImportBatch(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid import batch ID"})
+		return
+	}
+
+	batch, err := h.importInteractor.RollBackBatch(middleware.CurrentHouseholdID(c), entities.ImportBatchID(id))
+	if err != nil {
+		switch err {
+		case entities.ErrImportBatchNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Import batch not found"})
+		case entities.ErrImportBatchRolledBack:
+			c.JSON(http.StatusConflict, gin.H{"error": "Import batch was already rolled back"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to roll back import batch"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.batchToDTO(batch))
+}
+
+func (h *ImportHandler) handleConfirmError(c *gin.Context, err error) {
+	if errors.Is(err, entities.ErrAlreadyImported) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error() + "; set force to import anyway"})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
+
+func (h *ImportHandler) resultToDTO(result *dataimport.ImportResult) dto.StatementImportResultDTO {
+	responseDTO := dto.StatementImportResultDTO{
+		BatchID:  int(result.Batch.ID()),
+		Expenses: make([]dto.ExpenseResponseDTO, len(result.Expenses)),
+		Incomes:  make([]dto.IncomeResponseDTO, len(result.Incomes)),
+	}
+	for i, exp := range result.Expenses {
+		responseDTO.Expenses[i] = dto.ToExpenseResponseDTO(exp)
+	}
+	for i, inc := range result.Incomes {
+		responseDTO.Incomes[i] = dto.ToIncomeResponseDTO(inc)
+	}
+
+	for _, row := range result.Rows {
+		rowDTO := dto.ImportRowResultDTO{
+			Kind:        row.Kind,
+			Index:       row.Index,
+			Fingerprint: row.Fingerprint,
+			Status:      "created",
+		}
+		if row.Err != nil {
+			rowDTO.Status = "failed"
+			rowDTO.Error = row.Err.Error()
+		}
+		if row.Expense != nil {
+			expenseID := int(row.Expense.ID())
+			rowDTO.ExpenseID = &expenseID
+		}
+		if row.Income != nil {
+			incomeID := int(row.Income.ID())
+			rowDTO.IncomeID = &incomeID
+		}
+		responseDTO.Rows = append(responseDTO.Rows, rowDTO)
+	}
+
+	return responseDTO
+}
+
+func (h *ImportHandler) batchToDTO(batch *entities.ImportBatch) dto.ImportBatchResponseDTO {
+	responseDTO := dto.ImportBatchResponseDTO{
+		ID:           int(batch.ID()),
+		Source:       batch.Source(),
+		FileHash:     batch.FileHash(),
+		ExpenseCount: batch.ExpenseCount(),
+		IncomeCount:  batch.IncomeCount(),
+		CreatedAt:    batch.CreatedAt(),
+		RolledBackAt: batch.RolledBackAt(),
+	}
+
+	for _, item := range batch.Items() {
+		itemDTO := dto.ImportBatchItemDTO{Fingerprint: item.Fingerprint}
+		if item.ExpenseID != nil {
+			expenseID := int(*item.ExpenseID)
+			itemDTO.ExpenseID = &expenseID
+		}
+		if item.IncomeID != nil {
+			incomeID := int(*item.IncomeID)
+			itemDTO.IncomeID = &incomeID
+		}
+		responseDTO.Items = append(responseDTO.Items, itemDTO)
+	}
+
+	return responseDTO
+}
+
+func (h *ImportHandler) previewRowToDTO(row *dataimport.StatementPreviewRow) dto.StatementRowPreviewDTO {
+	entry := row.Entry
+	rowDTO := dto.StatementRowPreviewDTO{
+		RowNumber:     row.RowNumber,
+		BookingDate:   entry.BookingDate.Format("2006-01-02"),
+		Amount:        json.Number(entry.Amount.Decimal()),
+		Currency:      entry.Amount.Currency(),
+		Direction:     string(entry.Direction),
+		Counterparty:  entry.Counterparty,
+		Reference:     entry.Reference,
+		TransactionID: entry.TransactionID,
+		Fingerprint:   row.Fingerprint,
+		Duplicate:     row.Duplicate,
+		Issues:        row.Issues,
+	}
+
+	var vendorID *int
+	if row.Vendor != nil {
+		id := int(row.Vendor.ID())
+		vendorID = &id
+	}
+
+	if entry.Direction == entities.StatementDebit {
+		rowDTO.Expense = &dto.ImportedExpenseDTO{
+			CreateExpenseRequestDTO: dto.CreateExpenseRequestDTO{
+				Amount:   rowDTO.Amount,
+				Currency: rowDTO.Currency,
+				Date:     rowDTO.BookingDate,
+				Type:     string(entities.ExpenseTypeExpense),
+				Category: row.Category,
+				Comment:  row.Comment,
+				VendorID: vendorID,
+			},
+			Fingerprint: row.Fingerprint,
+		}
+		for _, tagID := range row.TagIDs {
+			rowDTO.Expense.TagIDs = append(rowDTO.Expense.TagIDs, int(tagID))
+		}
+		if row.Rule != nil {
+			rowDTO.Rule = &dto.CategorizationRuleRefDTO{ID: int(row.Rule.ID()), Name: row.Rule.Name()}
+		}
+	} else {
+		rowDTO.Income = &dto.ImportedIncomeDTO{
+			CreateIncomeRequestDTO: dto.CreateIncomeRequestDTO{
+				Amount:   rowDTO.Amount,
+				Currency: rowDTO.Currency,
+				Date:     rowDTO.BookingDate,
+				Source:   row.Source,
+				Comment:  row.Comment,
+				VendorID: vendorID,
+			},
+			Fingerprint: row.Fingerprint,
+		}
+	}
+
+	return rowDTO
+}
+
+func (h *ImportHandler) incomeCSVRowToDTO(row *dataimport.IncomeCSVPreviewRow) dto.IncomeCSVRowPreviewDTO {
+	rowDTO := dto.IncomeCSVRowPreviewDTO{
+		RowNumber: row.Row.RowNumber,
+		Vendor:    row.Row.Vendor,
+		AddedBy:   row.Row.AddedBy,
+		Tags:      row.Row.Tags,
+		Issues:    row.Row.Issues,
+	}
+
+	item := row.Item
+	if item == nil {
+		return rowDTO
+	}
+	rowDTO.Fingerprint = item.Fingerprint
+	rowDTO.Duplicate = item.Duplicate
+	rowDTO.Issues = append(rowDTO.Issues, item.Issues...)
+
+	incomeDTO := dto.CreateIncomeRequestDTO{
+		Amount:   json.Number(item.Entry.Amount.Decimal()),
+		Currency: item.Entry.Amount.Currency(),
+		Source:   item.Source,
+		Comment:  item.Comment,
+	}
+	if !item.Entry.BookingDate.IsZero() {
+		incomeDTO.Date = item.Entry.BookingDate.Format("2006-01-02")
+	}
+	if item.Vendor != nil {
+		vendorID := int(item.Vendor.ID())
+		incomeDTO.VendorID = &vendorID
+	}
+	if row.Member != nil {
+		memberID := int(row.Member.ID())
+		incomeDTO.MemberID = &memberID
+	}
+	if len(row.Tags) > 0 {
+		tagIDs := make([]int, len(row.Tags))
+		for i, tag := range row.Tags {
+			tagIDs[i] = int(tag.ID())
+		}
+		incomeDTO.TagIDs = &tagIDs
+	}
+	rowDTO.Income = &dto.ImportedIncomeDTO{CreateIncomeRequestDTO: incomeDTO, Fingerprint: item.Fingerprint}
+
+	return rowDTO
+}
+
+func (h *ImportHandler) csvRowToDTO(row *dataimport.CSVPreviewRow) dto.CSVRowPreviewDTO {
+	rowDTO := dto.CSVRowPreviewDTO{
+		RowNumber: row.Row.RowNumber,
+		Date:      row.Row.Date,
+		Issues:    row.Row.Issues,
+		Parsed:    []dto.ParsedExpenseDTO{},
+	}
+
+	if row.Row.Amounts != nil {
+		rowDTO.Expenses = make(map[string]json.Number, len(row.Row.Amounts))
+		for column, amount := range row.Row.Amounts {
+			rowDTO.Expenses[column] = json.Number(amount.Decimal())
+		}
+	}
+
+	for _, item := range row.Items {
+		entry := item.Entry
+		date := ""
+		if !entry.BookingDate.IsZero() {
+			date = entry.BookingDate.Format("2006-01-02")
+		}
+		var vendorID *int
+		if item.Vendor != nil {
+			id := int(item.Vendor.ID())
+			vendorID = &id
+		}
+
+		if entry.Direction == entities.StatementCredit {
+			rowDTO.ParsedIncomes = append(rowDTO.ParsedIncomes, dto.ParsedIncomeDTO{
+				Source:        item.Source,
+				Comment:       item.Comment,
+				Amount:        json.Number(entry.Amount.Decimal()),
+				Currency:      entry.Amount.Currency(),
+				Date:          date,
+				VendorID:      vendorID,
+				TransactionID: entry.TransactionID,
+				Fingerprint:   item.Fingerprint,
+				Duplicate:     item.Duplicate,
+				Issues:        item.Issues,
+			})
+			continue
+		}
+
+		rowDTO.Parsed = append(rowDTO.Parsed, dto.ParsedExpenseDTO{
+			Comment:       item.Comment,
+			Amount:        json.Number(entry.Amount.Decimal()),
+			Currency:      entry.Amount.Currency(),
+			Date:          date,
+			VendorType:    string(entry.VendorType),
+			VendorID:      vendorID,
+			Payee:         entry.Counterparty,
+			Category:      item.Category,
+			TransactionID: entry.TransactionID,
+			Fingerprint:   item.Fingerprint,
+			Duplicate:     item.Duplicate,
+			Issues:        item.Issues,
+		})
+	}
+
+	return rowDTO
+}
+
+// fileHash identifies an uploaded file so that importing it twice can be refused
+func fileHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func batchIDPointer(batch *entities.ImportBatch) *int {
+	if batch == nil {
+		return nil
+	}
+	id := int(batch.ID())
+	return &id
+}
+
+// importTimestamp dates imported rows to noon of their booking day, like the CSV import
+func importTimestamp(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, time.UTC)
+}
+
+func (h *ImportHandler) expenseCommand(req dto.CreateExpenseRequestDTO) (expense.CreateExpenseFromCSVCommand, error) {
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return expense.CreateExpenseFromCSVCommand{}, fmt.Errorf("invalid date %q (use YYYY-MM-DD)", req.Date)
+	}
+
+	if req.Type == "" {
+		req.Type = string(entities.ExpenseTypeExpense)
+	}
+
+	cmd := expense.CreateExpenseFromCSVCommand{
+		Amount:     req.Amount.String(),
+		Currency:   req.Currency,
+		Date:       date,
+		Type:       req.Type,
+		Category:   req.Category,
+		Comment:    req.Comment,
+		PaidByCard: req.PaidByCard,
+		CreatedAt:  importTimestamp(date),
+		UpdatedAt:  importTimestamp(date),
+	}
+
+	if req.VendorID != nil {
+		vendorID := entities.VendorID(*req.VendorID)
+		cmd.VendorID = &vendorID
+	}
+
+	if req.MemberID != nil {
+		memberID := entities.MemberID(*req.MemberID)
+		cmd.MemberID = &memberID
+	}
+
+	for _, tagID := range req.TagIDs {
+		cmd.TagIDs = append(cmd.TagIDs, entities.TagID(tagID))
+	}
+
+	return cmd, nil
+}
+
+func (h *ImportHandler) incomeCommand(req dto.CreateIncomeRequestDTO) (income.CreateIncomeFromCSVCommand, error) {
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return income.CreateIncomeFromCSVCommand{}, fmt.Errorf("invalid date %q (use YYYY-MM-DD)", req.Date)
+	}
+
+	cmd := income.CreateIncomeFromCSVCommand{
+		Amount:    req.Amount.String(),
+		Currency:  req.Currency,
+		Date:      date,
+		Source:    req.Source,
+		Comment:   req.Comment,
+		CreatedAt: importTimestamp(date),
+		UpdatedAt: importTimestamp(date),
+	}
+
+	if req.VendorID != nil {
+		vendorID := entities.VendorID(*req.VendorID)
+		cmd.VendorID = &vendorID
+	}
+
+	if req.MemberID != nil {
+		memberID := entities.MemberID(*req.MemberID)
+		cmd.MemberID = &memberID
+	}
+
+	if req.TagIDs != nil {
+		for _, tagID := range *req.TagIDs {
+			cmd.TagIDs = append(cmd.TagIDs, entities.TagID(tagID))
+		}
+	}
+
+	return cmd, nil
+}
@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/infrastructure/http/dto"
+	"expenso-backend/infrastructure/http/middleware"
+	"expenso-backend/usecases/interactors/dataimport"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type ImportProfileHandler struct {
+	profileInteractor *dataimport.ImportProfileInteractor
+	validator         *validator.Validate
+}
+
+func NewImportProfileHandler(profileInteractor *dataimport.ImportProfileInteractor) *ImportProfileHandler {
+	return &ImportProfileHandler{
+		profileInteractor: profileInteractor,
+		validator:         validator.New(),
+	}
+}
+
+// GetImportProfiles godoc
+// @Summary Get all import profiles
+// @Description Get the saved CSV import profiles of the household
+// @Tags imports
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.ImportProfileResponseDTO
+// @Failure 500 {object} map[string]string
+// @Router /import-profiles [get]
+func (h *ImportProfileHandler) GetImportProfiles(c *gin.Context) {
+	profiles, err := h.profileInteractor.GetProfiles(middleware.CurrentHouseholdID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch import profiles"})
+		return
+	}
+
+	responseDTO := make([]dto.ImportProfileResponseDTO, len(profiles))
+	for i, profile := range profiles {
+		responseDTO[i] = h.profileToDTO(profile)
+	}
+
+	c.JSON(http.StatusOK, responseDTO)
+}
+
+// GetImportProfile godoc
+// @Summary Get an import profile by ID
+// @Description Get a single import profile; ID 0 returns the built-in layout used when a CSV preview names no profile
+// @Tags imports
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Import profile ID"
+// @Success 200 {object} dto.ImportProfileResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /import-profiles/{id} [get]
+func (h *ImportProfileHandler) GetImportProfile(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid import profile ID"})
+		return
+	}
+
+	profile, err := h.profileInteractor.GetProfile(middleware.CurrentHouseholdID(c), entities.ImportProfileID(id))
+	if err != nil {
+		if err == entities.ErrImportProfileNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Import profile not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch import profile"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.profileToDTO(profile))
+}
+
+// CreateImportProfile godoc
+// @Summary Create an import profile
+// @Description Save how to read a CSV layout: delimiter, encoding, decimal separator, date formats, column mapping and category/vendor rules
+// @Tags imports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param profile body dto.ImportProfileRequestDTO true "Import profile"
+// @Success 201 {object} dto.ImportProfileResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /import-profiles [post]
+func (h *ImportProfileHandler) CreateImportProfile(c *gin.Context) {
+	var requestDTO dto.ImportProfileRequestDTO
+	if err := c.ShouldBindJSON(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.validator.Struct(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile, err := h.profileInteractor.CreateProfile(middleware.CurrentHouseholdID(c), h.saveCommand(requestDTO))
+	if err != nil {
+		h.handleWriteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.profileToDTO(profile))
+}
+
+// UpdateImportProfile godoc
+// @Summary Update an import profile
+// @Description Replace the name and all settings of an import profile
+// @Tags imports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Import profile ID"
+// @Param profile body dto.ImportProfileRequestDTO true "Import profile"
+// @Success 200 {object} dto.ImportProfileResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /import-profiles/{id} [put]
+func (h *ImportProfileHandler) UpdateImportProfile(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid import profile ID"})
+		return
+	}
+
+	var requestDTO dto.ImportProfileRequestDTO
+	if err := c.ShouldBindJSON(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.validator.Struct(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile, err := h.profileInteractor.UpdateProfile(middleware.CurrentHouseholdID(c), entities.ImportProfileID(id), h.saveCommand(requestDTO))
+	if err != nil {
+		h.handleWriteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.profileToDTO(profile))
+}
+
+// DeleteImportProfile godoc
+// @Summary Delete an import profile
+// @Description Delete an import profile by its ID
+// @Tags imports
+// @Security BearerAuth
+// @Param id path int true "Import profile ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /import-profiles/{id} [delete]
+func (h *ImportProfileHandler) DeleteImportProfile(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid import profile ID"})
+		return
+	}
+
+	if err := h.profileInteractor.DeleteProfile(middleware.CurrentHouseholdID(c), entities.ImportProfileID(id)); err != nil {
+		if err == entities.ErrImportProfileNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Import profile not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete import profile"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ImportProfileHandler) handleWriteError(c *gin.Context, err error) {
+	switch err {
+	case entities.ErrImportProfileNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Import profile not found"})
+	case entities.ErrImportProfileExists:
+		c.JSON(http.StatusConflict, gin.H{"error": "Import profile with this name already exists"})
+	case entities.ErrVendorNotFound:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Vendor of a rule not found"})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+func (h *ImportProfileHandler) saveCommand(req dto.ImportProfileRequestDTO) dataimport.SaveImportProfileCommand {
+	settings := entities.ImportProfileSettings{
+		Delimiter:         req.Delimiter,
+		Encoding:          entities.CSVEncoding(req.Encoding),
+		DecimalSeparator:  req.DecimalSeparator,
+		DateFormats:       req.DateFormats,
+		SkipRows:          req.SkipRows,
+		Layout:            entities.CSVLayout(req.Layout),
+		DateColumn:        req.DateColumn,
+		AmountColumn:      req.AmountColumn,
+		PayeeColumn:       req.PayeeColumn,
+		MemoColumn:        req.MemoColumn,
+		VendorTypeColumns: make(map[string]entities.VendorType, len(req.VendorTypeColumns)),
+		Currency:          req.Currency,
+		DefaultCategory:   req.DefaultCategory,
+		Rules:             make([]entities.ImportRule, len(req.Rules)),
+	}
+	for column, vendorType := range req.VendorTypeColumns {
+		settings.VendorTypeColumns[column] = entities.VendorType(vendorType)
+	}
+	for i, rule := range req.Rules {
+		settings.Rules[i] = entities.ImportRule{
+			Match:      rule.Match,
+			VendorType: entities.VendorType(rule.VendorType),
+			Category:   rule.Category,
+		}
+		if rule.VendorID != nil {
+			vendorID := entities.VendorID(*rule.VendorID)
+			settings.Rules[i].VendorID = &vendorID
+		}
+	}
+
+	return dataimport.SaveImportProfileCommand{Name: req.Name, Settings: settings}
+}
+
+func (h *ImportProfileHandler) profileToDTO(profile *entities.ImportProfile) dto.ImportProfileResponseDTO {
+	settings := profile.Settings()
+	responseDTO := dto.ImportProfileResponseDTO{
+		ID:               int(profile.ID()),
+		Name:             profile.Name(),
+		Delimiter:        settings.Delimiter,
+		Encoding:         string(settings.Encoding),
+		DecimalSeparator: settings.DecimalSeparator,
+		DateFormats:      settings.DateFormats,
+		SkipRows:         settings.SkipRows,
+		Layout:           string(settings.Layout),
+		DateColumn:       settings.DateColumn,
+		AmountColumn:     settings.AmountColumn,
+		PayeeColumn:      settings.PayeeColumn,
+		MemoColumn:       settings.MemoColumn,
+		Currency:         settings.Currency,
+		DefaultCategory:  settings.DefaultCategory,
+		Rules:            make([]dto.ImportRuleDTO, len(settings.Rules)),
+		CreatedAt:        profile.CreatedAt(),
+		UpdatedAt:        profile.UpdatedAt(),
+	}
+
+	if len(settings.VendorTypeColumns) > 0 {
+		responseDTO.VendorTypeColumns = make(map[string]string, len(settings.VendorTypeColumns))
+		for column, vendorType := range settings.VendorTypeColumns {
+			responseDTO.VendorTypeColumns[column] = string(vendorType)
+		}
+	}
+
+	for i, rule := range settings.Rules {
+		responseDTO.Rules[i] = dto.ImportRuleDTO{
+			Match:      rule.Match,
+			VendorType: string(rule.VendorType),
+			Category:   rule.Category,
+		}
+		if rule.VendorID != nil {
+			vendorID := int(*rule.VendorID)
+			responseDTO.Rules[i].VendorID = &vendorID
+		}
+	}
+
+	return responseDTO
+}
@@ -1,13 +1,21 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"net/http"
+	"sort"
 	"strconv"
 	"time"
 
 	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
 	"expenso-backend/infrastructure/http/dto"
+	"expenso-backend/infrastructure/http/middleware"
+	"expenso-backend/infrastructure/importers"
 	"expenso-backend/usecases/interactors/income"
+	"expenso-backend/usecases/interfaces/repositories"
 
 	"github.com/gin-gonic/gin"
 )
@@ -23,18 +31,113 @@ func NewIncomeHandler(incomeInteractor *income.IncomeInteractor) *IncomeHandler
 }
 
 // GetIncomes godoc
-// @Summary Get all incomes
-// @Description Get a list of all incomes with optional date range filtering
+// @Summary Get incomes
+// @Description List incomes, newest first unless sorted otherwise. Filtering, sorting and paging happen in the database;
+// @Description without a limit every matching income is returned. Follow X-Next-Cursor for the next page.
 // @Tags incomes
 // @Accept json
 // @Produce json
 // @Param start_date query string false "Start date (YYYY-MM-DD)"
 // @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param source query string false "Only incomes from this source"
+// @Param vendor_id query int false "Only incomes from this vendor"
+// @Param vendor_type query string false "Only incomes from vendors of this type" Enums(food_store, shop, eating_out, subscriptions, else)
+// @Param tag_id query int false "Only incomes with this tag"
+// @Param member_id query int false "Only incomes added by this member"
+// @Param currency query string false "Only amounts recorded in this currency, required with min_amount and max_amount"
+// @Param min_amount query string false "Smallest amount in currency"
+// @Param max_amount query string false "Largest amount in currency"
+// @Param sort query string false "date, amount, created_at or vendor, prefixed with - for descending order (default -date)"
+// @Param limit query int false "Page size"
+// @Param cursor query string false "X-Next-Cursor of the previous page"
 // @Success 200 {array} dto.IncomeResponseDTO
+// @Header 200 {integer} X-Total-Count "Number of matching incomes across all pages"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, absent on the last one"
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /incomes [get]
 func (h *IncomeHandler) GetIncomes(c *gin.Context) {
+	var filter repositories.IncomeFilter
+	var ok bool
+
+	if filter.StartDate, filter.EndDate, ok = parseDateRange(c); !ok {
+		return
+	}
+	filter.Source = c.Query("source")
+	filter.VendorType = entities.VendorType(c.Query("vendor_type"))
+
+	vendorID, ok := parseIntQuery(c, "vendor_id")
+	if !ok {
+		return
+	}
+	if vendorID != nil {
+		id := entities.VendorID(*vendorID)
+		filter.VendorID = &id
+	}
+
+	tagID, ok := parseIntQuery(c, "tag_id")
+	if !ok {
+		return
+	}
+	if tagID != nil {
+		id := entities.TagID(*tagID)
+		filter.TagID = &id
+	}
+
+	memberID, ok := parseIntQuery(c, "member_id")
+	if !ok {
+		return
+	}
+	if memberID != nil {
+		id := entities.MemberID(*memberID)
+		filter.MemberID = &id
+	}
+
+	if filter.Currency, filter.MinAmount, filter.MaxAmount, ok = parseAmountRange(c); !ok {
+		return
+	}
+
+	page, ok := parsePageRequest(c, "-date",
+		repositories.SortByDate, repositories.SortByAmount, repositories.SortByCreatedAt, repositories.SortByVendor)
+	if !ok {
+		return
+	}
+
+	result, err := h.incomeInteractor.ListIncomes(middleware.CurrentHouseholdID(c), filter, page)
+	if err != nil {
+		if err == entities.ErrInvalidVendorType {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid vendor_type"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch incomes"})
+		return
+	}
+
+	// Convert to DTOs
+	incomeDTOs := make([]dto.IncomeResponseDTO, 0)
+	for _, income := range result.Incomes {
+		incomeDTO := dto.ToIncomeResponseDTO(income)
+		incomeDTOs = append(incomeDTOs, incomeDTO)
+	}
+
+	setPageHeaders(c, page, result.PageInfo)
+	c.JSON(http.StatusOK, incomeDTOs)
+}
+
+// ExportIncomesCSV godoc
+// @Summary Export incomes as CSV
+// @Description Export incomes one per row with their original amount and currency, in the layout /incomes/import/csv/preview reads
+// @Tags incomes
+// @Accept json
+// @Produce text/csv
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param member_id query int false "Only export incomes recorded by this member"
+// @Success 200 {string} string "CSV file"
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /incomes/export/csv [get]
+func (h *IncomeHandler) ExportIncomesCSV(c *gin.Context) {
 	// Parse optional date range parameters
 	startDateStr := c.Query("start_date")
 	endDateStr := c.Query("end_date")
@@ -61,14 +164,25 @@ func (h *IncomeHandler) GetIncomes(c *gin.Context) {
 		endDate = &parsed
 	}
 
+	// Parse member filter if provided
+	var memberID *entities.MemberID
+	if memberIDStr := c.Query("member_id"); memberIDStr != "" {
+		parsed, err := strconv.Atoi(memberIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid member_id"})
+			return
+		}
+		id := entities.MemberID(parsed)
+		memberID = &id
+	}
+
 	var incomes []*entities.Income
 	var err error
 
-	// Execute appropriate use case based on parameters
 	if startDate != nil || endDate != nil {
-		incomes, err = h.incomeInteractor.GetIncomesByDateRange(startDate, endDate)
+		incomes, err = h.incomeInteractor.GetIncomesByDateRange(middleware.CurrentHouseholdID(c), startDate, endDate)
 	} else {
-		incomes, err = h.incomeInteractor.GetAllIncomes()
+		incomes, err = h.incomeInteractor.GetAllIncomes(middleware.CurrentHouseholdID(c))
 	}
 
 	if err != nil {
@@ -76,14 +190,27 @@ func (h *IncomeHandler) GetIncomes(c *gin.Context) {
 		return
 	}
 
-	// Convert to DTOs
-	incomeDTOs := make([]dto.IncomeResponseDTO, 0)
+	var filteredIncomes []*entities.Income
 	for _, income := range incomes {
-		incomeDTO := dto.ToIncomeResponseDTO(income)
-		incomeDTOs = append(incomeDTOs, incomeDTO)
+		if memberID != nil && (income.Member() == nil || income.Member().ID() != *memberID) {
+			continue
+		}
+		filteredIncomes = append(filteredIncomes, income)
 	}
 
-	c.JSON(http.StatusOK, incomeDTOs)
+	// Oldest first, so the file reads like a ledger
+	sort.SliceStable(filteredIncomes, func(i, j int) bool {
+		return filteredIncomes[i].Date().Before(filteredIncomes[j].Date())
+	})
+
+	var buf bytes.Buffer
+	if err := importers.WriteIncomeCSV(&buf, filteredIncomes); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to write CSV"})
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=incomes_export.csv")
+	c.Data(http.StatusOK, "text/csv", buf.Bytes())
 }
 
 // CreateIncome godoc
@@ -113,11 +240,17 @@ func (h *IncomeHandler) CreateIncome(c *gin.Context) {
 
 	// Create command
 	cmd := income.CreateIncomeCommand{
-		Amount:  req.Amount,
-		Date:    date,
-		Source:  req.Source,
-		Comment: req.Comment,
-		AddedBy: req.AddedBy,
+		Amount:   req.Amount.String(),
+		Currency: req.Currency,
+		Date:     date,
+		Source:   req.Source,
+		Comment:  req.Comment,
+	}
+
+	// Set member ID if provided
+	if req.MemberID != nil {
+		memberID := entities.MemberID(*req.MemberID)
+		cmd.MemberID = &memberID
 	}
 
 	// Set vendor ID if provided
@@ -134,7 +267,7 @@ func (h *IncomeHandler) CreateIncome(c *gin.Context) {
 	}
 
 	// Execute use case
-	createdIncome, err := h.incomeInteractor.CreateIncome(cmd)
+	createdIncome, err := h.incomeInteractor.CreateIncome(middleware.CurrentHouseholdID(c), cmd)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -165,7 +298,7 @@ func (h *IncomeHandler) GetIncomeByID(c *gin.Context) {
 		return
 	}
 
-	income, err := h.incomeInteractor.GetIncomeByID(entities.IncomeID(id))
+	income, err := h.incomeInteractor.GetIncomeByID(middleware.CurrentHouseholdID(c), entities.IncomeID(id))
 	if err != nil {
 		if err == entities.ErrIncomeNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Income not found"})
@@ -208,11 +341,20 @@ func (h *IncomeHandler) UpdateIncome(c *gin.Context) {
 
 	// Create update command
 	cmd := income.UpdateIncomeCommand{
-		ID:      entities.IncomeID(id),
-		Amount:  req.Amount,
-		Source:  req.Source,
-		Comment: req.Comment,
-		AddedBy: req.AddedBy,
+		ID:       entities.IncomeID(id),
+		Currency: req.Currency,
+		Source:   req.Source,
+		Comment:  req.Comment,
+	}
+
+	if req.MemberID != nil {
+		memberID := entities.MemberID(*req.MemberID)
+		cmd.MemberID = &memberID
+	}
+
+	if req.Amount != nil {
+		amount := req.Amount.String()
+		cmd.Amount = &amount
 	}
 
 	// Parse date if provided
@@ -241,7 +383,7 @@ func (h *IncomeHandler) UpdateIncome(c *gin.Context) {
 	}
 
 	// Execute use case
-	updatedIncome, err := h.incomeInteractor.UpdateIncome(cmd)
+	updatedIncome, err := h.incomeInteractor.UpdateIncome(middleware.CurrentHouseholdID(c), cmd)
 	if err != nil {
 		if err == entities.ErrIncomeNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Income not found"})
@@ -276,7 +418,7 @@ func (h *IncomeHandler) DeleteIncome(c *gin.Context) {
 		return
 	}
 
-	err = h.incomeInteractor.DeleteIncome(entities.IncomeID(id))
+	err = h.incomeInteractor.DeleteIncome(middleware.CurrentHouseholdID(c), entities.IncomeID(id))
 	if err != nil {
 		if err == entities.ErrIncomeNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Income not found"})
@@ -307,7 +449,7 @@ func (h *IncomeHandler) GetIncomesBySource(c *gin.Context) {
 		return
 	}
 
-	incomes, err := h.incomeInteractor.GetIncomesBySource(source)
+	incomes, err := h.incomeInteractor.GetIncomesBySource(middleware.CurrentHouseholdID(c), source)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch incomes"})
 		return
@@ -331,8 +473,10 @@ func (h *IncomeHandler) GetIncomesBySource(c *gin.Context) {
 // @Produce json
 // @Param start_date query string false "Start date (YYYY-MM-DD)"
 // @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param reporting_currency query string false "ISO-4217 currency to report totals in (default EUR)"
 // @Success 200 {object} dto.IncomeSummaryDTO
 // @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /incomes/summary [get]
 func (h *IncomeHandler) GetIncomesSummary(c *gin.Context) {
@@ -363,21 +507,29 @@ func (h *IncomeHandler) GetIncomesSummary(c *gin.Context) {
 	}
 
 	// Get total income
-	totalIncome, err := h.incomeInteractor.GetTotalIncomeByDateRange(startDate, endDate)
+	totalIncome, err := h.incomeInteractor.GetTotalIncomeByDateRange(middleware.CurrentHouseholdID(c), startDate, endDate, c.Query("reporting_currency"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate total income"})
+		switch {
+		case errors.Is(err, valueobjects.ErrInvalidCurrency):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reporting_currency"})
+		case errors.Is(err, entities.ErrExchangeRateNotFound):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate total income"})
+		}
 		return
 	}
 
 	// Get income count
-	incomeCount, err := h.incomeInteractor.GetIncomeCountByDateRange(startDate, endDate)
+	incomeCount, err := h.incomeInteractor.GetIncomeCountByDateRange(middleware.CurrentHouseholdID(c), startDate, endDate)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count incomes"})
 		return
 	}
 
 	summary := dto.IncomeSummaryDTO{
-		TotalIncome: totalIncome,
+		TotalIncome: json.Number(totalIncome.Decimal()),
+		Currency:    totalIncome.Currency(),
 		IncomeCount: incomeCount,
 	}
 
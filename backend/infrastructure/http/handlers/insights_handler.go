@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+	"expenso-backend/infrastructure/http/dto"
+	"expenso-backend/infrastructure/http/middleware"
+	"expenso-backend/usecases/interactors/insights"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InsightsHandler struct {
+	anomalyInteractor *insights.AnomalyInteractor
+}
+
+func NewInsightsHandler(anomalyInteractor *insights.AnomalyInteractor) *InsightsHandler {
+	return &InsightsHandler{
+		anomalyInteractor: anomalyInteractor,
+	}
+}
+
+// GetAnomalies godoc
+// @Summary Get unusual expenses
+// @Description Flags expenses far above the usual amounts at their vendor (or vendor type while the vendor has little history),
+// @Description unusually many expenses at one vendor within a week, and first expenses at a vendor above new_vendor_threshold.
+// @Description Each expense is compared with the year before it. Newest first.
+// @Tags insights
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD, default 30 days before end_date)"
+// @Param end_date query string false "End date (YYYY-MM-DD, default today)"
+// @Param reporting_currency query string false "ISO-4217 currency to compare amounts in (default EUR)"
+// @Param new_vendor_threshold query string false "Amount above which a first expense at a vendor is flagged (default 100)"
+// @Success 200 {array} dto.AnomalyDTO
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /insights/anomalies [get]
+func (h *InsightsHandler) GetAnomalies(c *gin.Context) {
+	startDate, endDate, ok := parseDateRange(c)
+	if !ok {
+		return
+	}
+	threshold, err := valueobjects.ParseMoney(c.DefaultQuery("new_vendor_threshold", insights.DefaultNewVendorThreshold), c.Query("reporting_currency"))
+	if err != nil {
+		if errors.Is(err, valueobjects.ErrInvalidCurrency) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reporting_currency"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid new_vendor_threshold (use a non-negative decimal number)"})
+		return
+	}
+
+	query := insights.AnomalyQuery{
+		EndDate:            time.Now(),
+		ReportingCurrency:  threshold.Currency(),
+		NewVendorThreshold: threshold,
+	}
+	if endDate != nil {
+		query.EndDate = *endDate
+	}
+	query.StartDate = query.EndDate.AddDate(0, 0, -29)
+	if startDate != nil {
+		query.StartDate = *startDate
+	}
+
+	anomalies, err := h.anomalyInteractor.DetectAnomalies(middleware.CurrentHouseholdID(c), query)
+	if err != nil {
+		switch {
+		case errors.Is(err, insights.ErrInvalidAnomalyRange):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, valueobjects.ErrInvalidCurrency):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid reporting_currency"})
+		case errors.Is(err, entities.ErrExchangeRateNotFound):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to detect anomalies"})
+		}
+		return
+	}
+
+	response := make([]dto.AnomalyDTO, len(anomalies))
+	for i, anomaly := range anomalies {
+		kinds := make([]string, len(anomaly.Kinds))
+		for j, kind := range anomaly.Kinds {
+			kinds[j] = string(kind)
+		}
+		response[i] = dto.AnomalyDTO{
+			Expense:  dto.ToExpenseResponseDTO(anomaly.Expense),
+			Amount:   json.Number(anomaly.Amount.Decimal()),
+			Currency: anomaly.Amount.Currency(),
+			Kinds:    kinds,
+			Reason:   strings.Join(anomaly.Reasons, "; "),
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"expenso-backend/domain/valueobjects"
+	"expenso-backend/usecases/interfaces/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// The helpers below read optional query parameters. Each answers 400 itself and returns false if its parameter is malformed.
+
+// parseDateRange reads the optional start_date and end_date
+func parseDateRange(c *gin.Context) (*time.Time, *time.Time, bool) {
+	var startDate, endDate *time.Time
+
+	if startDateStr := c.Query("start_date"); startDateStr != "" {
+		parsed, err := time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_date format (use YYYY-MM-DD)"})
+			return nil, nil, false
+		}
+		startDate = &parsed
+	}
+
+	if endDateStr := c.Query("end_date"); endDateStr != "" {
+		parsed, err := time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end_date format (use YYYY-MM-DD)"})
+			return nil, nil, false
+		}
+		endDate = &parsed
+	}
+
+	return startDate, endDate, true
+}
+
+func parseIntQuery(c *gin.Context, name string) (*int, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, true
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + name})
+		return nil, false
+	}
+	return &parsed, true
+}
+
+func parseBoolQuery(c *gin.Context, name string) (*bool, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, true
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + name + " (use true or false)"})
+		return nil, false
+	}
+	return &parsed, true
+}
+
+// parseAmountRange reads the optional currency and the min_amount and max_amount bounds in it.
+// Amounts in different currencies cannot be compared, so the bounds need a currency.
+func parseAmountRange(c *gin.Context) (string, *valueobjects.Money, *valueobjects.Money, bool) {
+	currency := c.Query("currency")
+	if currency != "" {
+		code, err := valueobjects.NormalizeCurrency(currency)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid currency"})
+			return "", nil, nil, false
+		}
+		currency = code
+	}
+
+	var bounds [2]*valueobjects.Money
+	for i, name := range []string{"min_amount", "max_amount"} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+		if currency == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": name + " needs a currency"})
+			return "", nil, nil, false
+		}
+		money, err := valueobjects.ParseMoney(value, currency)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + name + " (use a non-negative decimal number)"})
+			return "", nil, nil, false
+		}
+		bounds[i] = &money
+	}
+
+	return currency, bounds[0], bounds[1], true
+}
+
+// pageCursor is what the opaque cursor handed to clients encodes.
+// It remembers the order it was issued for, since its position means nothing in another one.
+type pageCursor struct {
+	Sort       repositories.SortField `json:"sort"`
+	Descending bool                   `json:"desc,omitempty"`
+	Value      string                 `json:"value"`
+	ID         int                    `json:"id"`
+}
+
+// parsePageRequest reads limit, cursor and sort. The sort is a field name, prefixed with "-" to sort descending,
+// and must be one of sorts. Without a limit every row is returned.
+func parsePageRequest(c *gin.Context, defaultSort string, sorts ...repositories.SortField) (repositories.PageRequest, bool) {
+	var page repositories.PageRequest
+
+	sort := c.DefaultQuery("sort", defaultSort)
+	page.Descending = strings.HasPrefix(sort, "-")
+	page.Sort = repositories.SortField(strings.TrimPrefix(sort, "-"))
+	valid := false
+	names := make([]string, len(sorts))
+	for i, field := range sorts {
+		valid = valid || page.Sort == field
+		names[i] = string(field)
+	}
+	if !valid {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid sort (use one of " + strings.Join(names, ", ") + ", prefixed with - for descending order)"})
+		return page, false
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit (use a positive number)"})
+			return page, false
+		}
+		page.Limit = limit
+	}
+
+	if cursorStr := c.Query("cursor"); cursorStr != "" {
+		var cursor pageCursor
+		data, err := base64.RawURLEncoding.DecodeString(cursorStr)
+		if err == nil {
+			err = json.Unmarshal(data, &cursor)
+		}
+		if err == nil {
+			err = checkCursorValue(cursor)
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+			return page, false
+		}
+		if cursor.Sort != page.Sort || cursor.Descending != page.Descending {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "The cursor belongs to a different sort order"})
+			return page, false
+		}
+		page.After = &repositories.Cursor{Value: cursor.Value, ID: cursor.ID}
+	}
+
+	return page, true
+}
+
+// checkCursorValue rejects cursors whose value the database could not compare with the sort column
+func checkCursorValue(cursor pageCursor) error {
+	var err error
+	switch cursor.Sort {
+	case repositories.SortByDate:
+		_, err = time.Parse("2006-01-02", cursor.Value)
+	case repositories.SortByAmount:
+		_, err = strconv.ParseFloat(cursor.Value, 64)
+	case repositories.SortByCreatedAt:
+		_, err = time.Parse(time.RFC3339Nano, cursor.Value)
+	}
+	return err
+}
+
+// setPageHeaders reports the number of matching rows in X-Total-Count and, unless this is the last page,
+// the cursor of the next one in X-Next-Cursor
+func setPageHeaders(c *gin.Context, page repositories.PageRequest, info repositories.PageInfo) {
+	c.Header("X-Total-Count", strconv.Itoa(info.Total))
+	if info.NextCursor == nil {
+		return
+	}
+	data, _ := json.Marshal(pageCursor{
+		Sort:       page.Sort,
+		Descending: page.Descending,
+		Value:      info.NextCursor.Value,
+		ID:         info.NextCursor.ID,
+	})
+	c.Header("X-Next-Cursor", base64.RawURLEncoding.EncodeToString(data))
+}
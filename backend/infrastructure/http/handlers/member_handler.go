@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/infrastructure/http/dto"
+	"expenso-backend/infrastructure/http/middleware"
+	"expenso-backend/usecases/interactors/member"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MemberHandler struct {
+	memberInteractor *member.MemberInteractor
+}
+
+func NewMemberHandler(memberInteractor *member.MemberInteractor) *MemberHandler {
+	return &MemberHandler{
+		memberInteractor: memberInteractor,
+	}
+}
+
+// GetMembers godoc
+// @Summary Get all household members
+// @Description Get a list of all household members
+// @Tags members
+// @Accept json
+// @Produce json
+// @Success 200 {array} dto.MemberResponseDTO
+// @Failure 500 {object} map[string]string
+// @Router /members [get]
+func (h *MemberHandler) GetMembers(c *gin.Context) {
+	// Execute use case
+	members, err := h.memberInteractor.GetMembers(middleware.CurrentHouseholdID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch members"})
+		return
+	}
+
+	// Convert domain entities to DTOs
+	responseDTO := make([]dto.MemberResponseDTO, len(members))
+	for i, m := range members {
+		responseDTO[i] = h.memberToDTO(m)
+	}
+
+	c.JSON(http.StatusOK, responseDTO)
+}
+
+// GetMember godoc
+// @Summary Get a household member by ID
+// @Description Get a single household member by its ID
+// @Tags members
+// @Accept json
+// @Produce json
+// @Param id path int true "Member ID"
+// @Success 200 {object} dto.MemberResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /members/{id} [get]
+func (h *MemberHandler) GetMember(c *gin.Context) {
+	// Parse path parameter
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid member ID"})
+		return
+	}
+
+	// Execute use case
+	m, err := h.memberInteractor.GetMember(middleware.CurrentHouseholdID(c), entities.MemberID(id))
+	if err != nil {
+		if err == entities.ErrMemberNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Member not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch member"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.memberToDTO(m))
+}
+
+// CreateMember godoc
+// @Summary Create a new household member
+// @Description Create a new household member who can record expenses and incomes
+// @Tags members
+// @Accept json
+// @Produce json
+// @Param member body dto.CreateMemberRequestDTO true "Member data"
+// @Success 201 {object} dto.MemberResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /members [post]
+func (h *MemberHandler) CreateMember(c *gin.Context) {
+	// Syntactic validation - decode JSON
+	var requestDTO dto.CreateMemberRequestDTO
+	if err := c.ShouldBindJSON(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	// Execute use case
+	m, err := h.memberInteractor.CreateMember(middleware.CurrentHouseholdID(c), member.CreateMemberCommand{
+		Name: requestDTO.Name,
+	})
+	if err != nil {
+		if err == entities.ErrMemberAlreadyExists {
+			c.JSON(http.StatusConflict, gin.H{"error": "Member with this name already exists"})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.memberToDTO(m))
+}
+
+// UpdateMember godoc
+// @Summary Update a household member
+// @Description Rename an existing household member
+// @Tags members
+// @Accept json
+// @Produce json
+// @Param id path int true "Member ID"
+// @Param member body dto.UpdateMemberRequestDTO true "Updated member data"
+// @Success 200 {object} dto.MemberResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /members/{id} [put]
+func (h *MemberHandler) UpdateMember(c *gin.Context) {
+	// Parse path parameter
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid member ID"})
+		return
+	}
+
+	// Syntactic validation - decode JSON
+	var requestDTO dto.UpdateMemberRequestDTO
+	if err := c.ShouldBindJSON(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	// Execute use case
+	m, err := h.memberInteractor.UpdateMember(middleware.CurrentHouseholdID(c), member.UpdateMemberCommand{
+		ID:   entities.MemberID(id),
+		Name: requestDTO.Name,
+	})
+	if err != nil {
+		switch err {
+		case entities.ErrMemberNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Member not found"})
+		case entities.ErrMemberAlreadyExists:
+			c.JSON(http.StatusConflict, gin.H{"error": "Member with this name already exists"})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.memberToDTO(m))
+}
+
+// DeleteMember godoc
+// @Summary Delete a household member
+// @Description Delete a household member who has not recorded any expenses or incomes
+// @Tags members
+// @Accept json
+// @Produce json
+// @Param id path int true "Member ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /members/{id} [delete]
+func (h *MemberHandler) DeleteMember(c *gin.Context) {
+	// Parse path parameter
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid member ID"})
+		return
+	}
+
+	// Execute use case
+	err = h.memberInteractor.DeleteMember(middleware.CurrentHouseholdID(c), entities.MemberID(id))
+	if err != nil {
+		switch err {
+		case entities.ErrMemberNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Member not found"})
+		case entities.ErrMemberInUse:
+			c.JSON(http.StatusConflict, gin.H{"error": "Member still has expenses or incomes"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete member"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Helper method to convert domain entity to DTO
+func (h *MemberHandler) memberToDTO(m *entities.Member) dto.MemberResponseDTO {
+	return dto.MemberResponseDTO{
+		ID:        int(m.ID()),
+		Name:      m.Name(),
+		CreatedAt: m.CreatedAt(),
+		UpdatedAt: m.UpdatedAt(),
+	}
+}
@@ -0,0 +1,469 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/infrastructure/http/dto"
+	"expenso-backend/infrastructure/http/middleware"
+	"expenso-backend/usecases/interactors/recurring"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// maxUpcomingDays caps the range of GET /recurring-rules/upcoming
+const maxUpcomingDays = 366
+
+type RecurringRuleHandler struct {
+	recurringInteractor *recurring.RecurringInteractor
+	validator           *validator.Validate
+}
+
+func NewRecurringRuleHandler(recurringInteractor *recurring.RecurringInteractor) *RecurringRuleHandler {
+	return &RecurringRuleHandler{
+		recurringInteractor: recurringInteractor,
+		validator:           validator.New(),
+	}
+}
+
+// GetRecurringRules godoc
+// @Summary Get all recurring rules
+// @Description Get a list of all recurring expense and income rules
+// @Tags recurring
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.RecurringRuleResponseDTO
+// @Failure 500 {object} map[string]string
+// @Router /recurring-rules [get]
+func (h *RecurringRuleHandler) GetRecurringRules(c *gin.Context) {
+	rules, err := h.recurringInteractor.GetRules(middleware.CurrentHouseholdID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch recurring rules"})
+		return
+	}
+
+	responseDTO := make([]dto.RecurringRuleResponseDTO, len(rules))
+	for i, rule := range rules {
+		responseDTO[i] = h.ruleToDTO(rule)
+	}
+
+	c.JSON(http.StatusOK, responseDTO)
+}
+
+// GetRecurringRule godoc
+// @Summary Get a recurring rule by ID
+// @Description Get a single recurring rule by its ID
+// @Tags recurring
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Recurring rule ID"
+// @Success 200 {object} dto.RecurringRuleResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /recurring-rules/{id} [get]
+func (h *RecurringRuleHandler) GetRecurringRule(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recurring rule ID"})
+		return
+	}
+
+	rule, err := h.recurringInteractor.GetRule(middleware.CurrentHouseholdID(c), entities.RecurringRuleID(id))
+	if err != nil {
+		if err == entities.ErrRecurringRuleNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Recurring rule not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch recurring rule"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, h.ruleToDTO(rule))
+}
+
+// CreateRecurringRule godoc
+// @Summary Create a recurring rule
+// @Description Create a template for an expense or income that the scheduler creates weekly, monthly or yearly. Dates before today are not back-filled.
+// @Tags recurring
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rule body dto.CreateRecurringRuleRequestDTO true "Recurring rule data"
+// @Success 201 {object} dto.RecurringRuleResponseDTO
+// @Failure 400 {object} map[string]string
+// @Router /recurring-rules [post]
+func (h *RecurringRuleHandler) CreateRecurringRule(c *gin.Context) {
+	var requestDTO dto.CreateRecurringRuleRequestDTO
+	if err := c.ShouldBindJSON(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.validator.Struct(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	startDate, err := time.Parse("2006-01-02", requestDTO.StartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start date format (use YYYY-MM-DD)"})
+		return
+	}
+
+	cmd := recurring.CreateRecurringRuleCommand{
+		Kind:       requestDTO.Kind,
+		Name:       requestDTO.Name,
+		Amount:     requestDTO.Amount.String(),
+		Currency:   requestDTO.Currency,
+		Category:   requestDTO.Category,
+		Source:     requestDTO.Source,
+		Comment:    requestDTO.Comment,
+		PaidByCard: requestDTO.PaidByCard,
+		Frequency:  requestDTO.Frequency,
+		Interval:   requestDTO.Interval,
+		DayOfMonth: requestDTO.DayOfMonth,
+		StartDate:  startDate,
+	}
+
+	if requestDTO.EndDate != "" {
+		endDate, err := time.Parse("2006-01-02", requestDTO.EndDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end date format (use YYYY-MM-DD)"})
+			return
+		}
+		cmd.EndDate = &endDate
+	}
+
+	if requestDTO.VendorID != nil {
+		vendorID := entities.VendorID(*requestDTO.VendorID)
+		cmd.VendorID = &vendorID
+	}
+
+	if requestDTO.MemberID != nil {
+		memberID := entities.MemberID(*requestDTO.MemberID)
+		cmd.MemberID = &memberID
+	}
+
+	cmd.TagIDs = make([]entities.TagID, len(requestDTO.TagIDs))
+	for i, tagID := range requestDTO.TagIDs {
+		cmd.TagIDs[i] = entities.TagID(tagID)
+	}
+
+	rule, err := h.recurringInteractor.CreateRule(middleware.CurrentHouseholdID(c), cmd)
+	if err != nil {
+		h.handleWriteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.ruleToDTO(rule))
+}
+
+// UpdateRecurringRule godoc
+// @Summary Update a recurring rule
+// @Description Update an existing recurring rule. Transactions it already created are not changed.
+// @Tags recurring
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Recurring rule ID"
+// @Param rule body dto.UpdateRecurringRuleRequestDTO true "Updated recurring rule data"
+// @Success 200 {object} dto.RecurringRuleResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /recurring-rules/{id} [put]
+func (h *RecurringRuleHandler) UpdateRecurringRule(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recurring rule ID"})
+		return
+	}
+
+	var requestDTO dto.UpdateRecurringRuleRequestDTO
+	if err := c.ShouldBindJSON(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.validator.Struct(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cmd := recurring.UpdateRecurringRuleCommand{
+		ID:         entities.RecurringRuleID(id),
+		Name:       requestDTO.Name,
+		Currency:   requestDTO.Currency,
+		Category:   requestDTO.Category,
+		Source:     requestDTO.Source,
+		Comment:    requestDTO.Comment,
+		PaidByCard: requestDTO.PaidByCard,
+		Frequency:  requestDTO.Frequency,
+		Interval:   requestDTO.Interval,
+		DayOfMonth: requestDTO.DayOfMonth,
+		Active:     requestDTO.Active,
+	}
+
+	if requestDTO.Amount != nil {
+		amount := requestDTO.Amount.String()
+		cmd.Amount = &amount
+	}
+
+	if requestDTO.StartDate != nil {
+		startDate, err := time.Parse("2006-01-02", *requestDTO.StartDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start date format (use YYYY-MM-DD)"})
+			return
+		}
+		cmd.StartDate = &startDate
+	}
+
+	if requestDTO.EndDate != nil {
+		var endDate time.Time // zero removes the end date
+		if *requestDTO.EndDate != "" {
+			endDate, err = time.Parse("2006-01-02", *requestDTO.EndDate)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end date format (use YYYY-MM-DD)"})
+				return
+			}
+		}
+		cmd.EndDate = &endDate
+	}
+
+	if requestDTO.VendorID != nil {
+		vendorID := entities.VendorID(*requestDTO.VendorID)
+		cmd.VendorID = &vendorID
+	}
+
+	if requestDTO.MemberID != nil {
+		memberID := entities.MemberID(*requestDTO.MemberID)
+		cmd.MemberID = &memberID
+	}
+
+	if requestDTO.TagIDs != nil {
+		tagIDs := make([]entities.TagID, len(*requestDTO.TagIDs))
+		for i, tagID := range *requestDTO.TagIDs {
+			tagIDs[i] = entities.TagID(tagID)
+		}
+		cmd.TagIDs = &tagIDs
+	}
+
+	rule, err := h.recurringInteractor.UpdateRule(middleware.CurrentHouseholdID(c), cmd)
+	if err != nil {
+		h.handleWriteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.ruleToDTO(rule))
+}
+
+// DeleteRecurringRule godoc
+// @Summary Delete a recurring rule
+// @Description Delete a recurring rule by its ID. Transactions it already created are kept.
+// @Tags recurring
+// @Security BearerAuth
+// @Param id path int true "Recurring rule ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /recurring-rules/{id} [delete]
+func (h *RecurringRuleHandler) DeleteRecurringRule(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recurring rule ID"})
+		return
+	}
+
+	if err := h.recurringInteractor.DeleteRule(middleware.CurrentHouseholdID(c), entities.RecurringRuleID(id)); err != nil {
+		if err == entities.ErrRecurringRuleNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Recurring rule not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete recurring rule"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetUpcomingOccurrences godoc
+// @Summary Get upcoming occurrences
+// @Description List the scheduled dates of all active recurring rules in a date range, with whether each was already created or skipped
+// @Tags recurring
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First day (YYYY-MM-DD), defaults to today"
+// @Param to query string false "Last day (YYYY-MM-DD), defaults to 30 days after from; at most 366 days after from"
+// @Success 200 {array} dto.UpcomingOccurrenceDTO
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /recurring-rules/upcoming [get]
+func (h *RecurringRuleHandler) GetUpcomingOccurrences(c *gin.Context) {
+	from := time.Now()
+	if fromStr := c.Query("from"); fromStr != "" {
+		parsed, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date format (use YYYY-MM-DD)"})
+			return
+		}
+		from = parsed
+	}
+
+	to := from.AddDate(0, 0, 30)
+	if toStr := c.Query("to"); toStr != "" {
+		parsed, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date format (use YYYY-MM-DD)"})
+			return
+		}
+		to = parsed
+	}
+
+	if to.Before(from) || to.After(from.AddDate(0, 0, maxUpcomingDays)) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must be between from and 366 days after it"})
+		return
+	}
+
+	upcoming, err := h.recurringInteractor.GetUpcoming(middleware.CurrentHouseholdID(c), from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch upcoming occurrences"})
+		return
+	}
+
+	responseDTO := make([]dto.UpcomingOccurrenceDTO, len(upcoming))
+	for i, u := range upcoming {
+		responseDTO[i] = dto.UpcomingOccurrenceDTO{
+			RuleID:   int(u.Rule.ID()),
+			Name:     u.Rule.Name(),
+			Kind:     string(u.Rule.Kind()),
+			Date:     u.Date.Format("2006-01-02"),
+			Amount:   json.Number(u.Rule.Amount().Decimal()),
+			Currency: u.Rule.Amount().Currency(),
+			Status:   "scheduled",
+		}
+		if u.Occurrence != nil {
+			responseDTO[i].Status = string(u.Occurrence.Status)
+			if u.Occurrence.ExpenseID != nil {
+				expenseID := int(*u.Occurrence.ExpenseID)
+				responseDTO[i].ExpenseID = &expenseID
+			}
+			if u.Occurrence.IncomeID != nil {
+				incomeID := int(*u.Occurrence.IncomeID)
+				responseDTO[i].IncomeID = &incomeID
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, responseDTO)
+}
+
+// SkipOccurrence godoc
+// @Summary Skip an occurrence
+// @Description Keep the scheduler from creating the transaction of one scheduled date
+// @Tags recurring
+// @Accept json
+// @Security BearerAuth
+// @Param id path int true "Recurring rule ID"
+// @Param occurrence body dto.SkipOccurrenceRequestDTO true "Date to skip"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /recurring-rules/{id}/skip [post]
+func (h *RecurringRuleHandler) SkipOccurrence(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recurring rule ID"})
+		return
+	}
+
+	var requestDTO dto.SkipOccurrenceRequestDTO
+	if err := c.ShouldBindJSON(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := h.validator.Struct(&requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", requestDTO.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format (use YYYY-MM-DD)"})
+		return
+	}
+
+	if err := h.recurringInteractor.SkipOccurrence(middleware.CurrentHouseholdID(c), entities.RecurringRuleID(id), date); err != nil {
+		h.handleWriteError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *RecurringRuleHandler) handleWriteError(c *gin.Context, err error) {
+	switch err {
+	case entities.ErrRecurringRuleNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": "Recurring rule not found"})
+	case entities.ErrOccurrenceHandled:
+		c.JSON(http.StatusConflict, gin.H{"error": "Occurrence was already created or skipped"})
+	case entities.ErrCategoryNotFound:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Category not found"})
+	case entities.ErrVendorNotFound:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Vendor not found"})
+	case entities.ErrMemberNotFound:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Member not found"})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+func (h *RecurringRuleHandler) ruleToDTO(rule *entities.RecurringRule) dto.RecurringRuleResponseDTO {
+	schedule := rule.Schedule()
+	responseDTO := dto.RecurringRuleResponseDTO{
+		ID:         int(rule.ID()),
+		Kind:       string(rule.Kind()),
+		Name:       rule.Name(),
+		Amount:     json.Number(rule.Amount().Decimal()),
+		Currency:   rule.Amount().Currency(),
+		Category:   rule.Category(),
+		Source:     rule.Source(),
+		Comment:    rule.Comment(),
+		PaidByCard: rule.PaidByCard(),
+		TagIDs:     make([]int, len(rule.TagIDs())),
+		Frequency:  string(schedule.Frequency()),
+		Interval:   schedule.Interval(),
+		DayOfMonth: schedule.DayOfMonth(),
+		StartDate:  schedule.StartDate().Format("2006-01-02"),
+		Active:     rule.Active(),
+		CreatedAt:  rule.CreatedAt(),
+		UpdatedAt:  rule.UpdatedAt(),
+	}
+
+	for i, tagID := range rule.TagIDs() {
+		responseDTO.TagIDs[i] = int(tagID)
+	}
+
+	if rule.VendorID() != nil {
+		vendorID := int(*rule.VendorID())
+		responseDTO.VendorID = &vendorID
+	}
+
+	if rule.MemberID() != nil {
+		memberID := int(*rule.MemberID())
+		responseDTO.MemberID = &memberID
+	}
+
+	if schedule.EndDate() != nil {
+		endDate := schedule.EndDate().Format("2006-01-02")
+		responseDTO.EndDate = &endDate
+	}
+
+	return responseDTO
+}
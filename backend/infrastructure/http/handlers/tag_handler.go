@@ -6,7 +6,9 @@ import (
 
 	"expenso-backend/domain/entities"
 	"expenso-backend/infrastructure/http/dto"
+	"expenso-backend/infrastructure/http/middleware"
 	"expenso-backend/usecases/interactors/tag"
+	"expenso-backend/usecases/interfaces/repositories"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -46,7 +48,7 @@ func (h *TagHandler) CreateTag(c *gin.Context) {
 		return
 	}
 
-	tag, err := h.tagInteractor.CreateTag(req.Name, req.Color)
+	tag, err := h.tagInteractor.CreateTag(middleware.CurrentHouseholdID(c), req.Name, req.Color)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -56,25 +58,37 @@ func (h *TagHandler) CreateTag(c *gin.Context) {
 	c.JSON(http.StatusCreated, response)
 }
 
-// @Summary Get all tags
-// @Description Retrieve all available tags
+// @Summary Get tags
+// @Description Retrieve tags by name unless sorted otherwise. Without a limit every tag is returned; follow X-Next-Cursor for the next page.
 // @Tags tags
 // @Produce json
+// @Param sort query string false "name or created_at, prefixed with - for descending order (default name)"
+// @Param limit query int false "Page size"
+// @Param cursor query string false "X-Next-Cursor of the previous page"
 // @Success 200 {array} dto.TagResponseDTO
+// @Header 200 {integer} X-Total-Count "Number of tags across all pages"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, absent on the last one"
+// @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /tags [get]
 func (h *TagHandler) GetTags(c *gin.Context) {
-	tags, err := h.tagInteractor.GetAllTags()
+	page, ok := parsePageRequest(c, "name", repositories.SortByName, repositories.SortByCreatedAt)
+	if !ok {
+		return
+	}
+
+	result, err := h.tagInteractor.ListTags(middleware.CurrentHouseholdID(c), page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	var response []dto.TagResponseDTO
-	for _, tag := range tags {
+	for _, tag := range result.Tags {
 		response = append(response, h.mapTagToResponse(tag))
 	}
 
+	setPageHeaders(c, page, result.PageInfo)
 	c.JSON(http.StatusOK, response)
 }
 
@@ -96,7 +110,7 @@ func (h *TagHandler) GetTag(c *gin.Context) {
 		return
 	}
 
-	tag, err := h.tagInteractor.GetTag(entities.TagID(id))
+	tag, err := h.tagInteractor.GetTag(middleware.CurrentHouseholdID(c), entities.TagID(id))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -150,7 +164,7 @@ func (h *TagHandler) UpdateTag(c *gin.Context) {
 		color = *req.Color
 	}
 
-	tag, err := h.tagInteractor.UpdateTag(entities.TagID(id), name, color)
+	tag, err := h.tagInteractor.UpdateTag(middleware.CurrentHouseholdID(c), entities.TagID(id), name, color)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -181,7 +195,7 @@ func (h *TagHandler) DeleteTag(c *gin.Context) {
 		return
 	}
 
-	err = h.tagInteractor.DeleteTag(entities.TagID(id))
+	err = h.tagInteractor.DeleteTag(middleware.CurrentHouseholdID(c), entities.TagID(id))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -197,6 +211,7 @@ func (h *TagHandler) DeleteTag(c *gin.Context) {
 // @Param tag_id path int true "Tag ID"
 // @Success 204
 // @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /expenses/{expense_id}/tags/{tag_id} [post]
 func (h *TagHandler) AddTagToExpense(c *gin.Context) {
@@ -214,8 +229,12 @@ func (h *TagHandler) AddTagToExpense(c *gin.Context) {
 		return
 	}
 
-	err = h.tagInteractor.AddTagToExpense(entities.ExpenseID(expenseID), entities.TagID(tagID))
+	err = h.tagInteractor.AddTagToExpense(middleware.CurrentHouseholdID(c), entities.ExpenseID(expenseID), entities.TagID(tagID))
 	if err != nil {
+		if err == entities.ErrExpenseNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Expense not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -230,6 +249,7 @@ func (h *TagHandler) AddTagToExpense(c *gin.Context) {
 // @Param tag_id path int true "Tag ID"
 // @Success 204
 // @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /expenses/{expense_id}/tags/{tag_id} [delete]
 func (h *TagHandler) RemoveTagFromExpense(c *gin.Context) {
@@ -247,8 +267,12 @@ func (h *TagHandler) RemoveTagFromExpense(c *gin.Context) {
 		return
 	}
 
-	err = h.tagInteractor.RemoveTagFromExpense(entities.ExpenseID(expenseID), entities.TagID(tagID))
+	err = h.tagInteractor.RemoveTagFromExpense(middleware.CurrentHouseholdID(c), entities.ExpenseID(expenseID), entities.TagID(tagID))
 	if err != nil {
+		if err == entities.ErrExpenseNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Expense not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -263,6 +287,7 @@ func (h *TagHandler) RemoveTagFromExpense(c *gin.Context) {
 // @Param expense_id path int true "Expense ID"
 // @Success 200 {array} dto.TagResponseDTO
 // @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /expenses/{expense_id}/tags [get]
 func (h *TagHandler) GetTagsByExpense(c *gin.Context) {
@@ -273,8 +298,12 @@ func (h *TagHandler) GetTagsByExpense(c *gin.Context) {
 		return
 	}
 
-	tags, err := h.tagInteractor.GetTagsByExpense(entities.ExpenseID(expenseID))
+	tags, err := h.tagInteractor.GetTagsByExpense(middleware.CurrentHouseholdID(c), entities.ExpenseID(expenseID))
 	if err != nil {
+		if err == entities.ErrExpenseNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Expense not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
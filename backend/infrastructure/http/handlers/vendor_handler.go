@@ -6,7 +6,9 @@ import (
 
 	"expenso-backend/domain/entities"
 	"expenso-backend/infrastructure/http/dto"
+	"expenso-backend/infrastructure/http/middleware"
 	"expenso-backend/usecases/interactors/vendors"
+	"expenso-backend/usecases/interfaces/repositories"
 
 	"github.com/gin-gonic/gin"
 )
@@ -22,28 +24,45 @@ func NewVendorHandler(vendorInteractor *vendors.VendorInteractor) *VendorHandler
 }
 
 // GetVendors godoc
-// @Summary Get all vendors
-// @Description Get a list of all vendors
+// @Summary Get vendors
+// @Description List vendors by name unless sorted otherwise. Without a limit every vendor is returned; follow X-Next-Cursor for the next page.
 // @Tags vendors
 // @Accept json
 // @Produce json
+// @Param type query string false "Only vendors of this type" Enums(food_store, shop, eating_out, subscriptions, else)
+// @Param sort query string false "name or created_at, prefixed with - for descending order (default name)"
+// @Param limit query int false "Page size"
+// @Param cursor query string false "X-Next-Cursor of the previous page"
 // @Success 200 {array} dto.VendorResponseDTO
+// @Header 200 {integer} X-Total-Count "Number of matching vendors across all pages"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, absent on the last one"
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /vendors [get]
 func (h *VendorHandler) GetVendors(c *gin.Context) {
+	page, ok := parsePageRequest(c, "name", repositories.SortByName, repositories.SortByCreatedAt)
+	if !ok {
+		return
+	}
+
 	// Execute use case
-	vendors, err := h.vendorInteractor.GetVendors()
+	result, err := h.vendorInteractor.ListVendors(middleware.CurrentHouseholdID(c), entities.VendorType(c.Query("type")), page)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch vendors"})
+		if err == entities.ErrInvalidVendorType {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid vendor type"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch vendors"})
+		}
 		return
 	}
 
 	// Convert domain entities to DTOs
-	responseDTO := make([]dto.VendorResponseDTO, len(vendors))
-	for i, v := range vendors {
+	responseDTO := make([]dto.VendorResponseDTO, len(result.Vendors))
+	for i, v := range result.Vendors {
 		responseDTO[i] = h.vendorToDTO(v)
 	}
 
+	setPageHeaders(c, page, result.PageInfo)
 	c.JSON(http.StatusOK, responseDTO)
 }
 
@@ -68,7 +87,7 @@ func (h *VendorHandler) GetVendor(c *gin.Context) {
 	}
 
 	// Execute use case
-	v, err := h.vendorInteractor.GetVendor(entities.VendorID(id))
+	v, err := h.vendorInteractor.GetVendor(middleware.CurrentHouseholdID(c), entities.VendorID(id))
 	if err != nil {
 		if err == entities.ErrVendorNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Vendor not found"})
@@ -100,7 +119,7 @@ func (h *VendorHandler) GetVendorsByType(c *gin.Context) {
 	vendorType := c.Param("type")
 
 	// Execute use case
-	vendors, err := h.vendorInteractor.GetVendorsByType(entities.VendorType(vendorType))
+	vendors, err := h.vendorInteractor.GetVendorsByType(middleware.CurrentHouseholdID(c), entities.VendorType(vendorType))
 	if err != nil {
 		if err == entities.ErrInvalidVendorType {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid vendor type"})
@@ -145,7 +164,7 @@ func (h *VendorHandler) CreateVendor(c *gin.Context) {
 	}
 
 	// Execute use case
-	v, err := h.vendorInteractor.CreateVendor(cmd)
+	v, err := h.vendorInteractor.CreateVendor(middleware.CurrentHouseholdID(c), cmd)
 	if err != nil {
 		if err == entities.ErrInvalidVendorType {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid vendor type"})
@@ -198,7 +217,7 @@ func (h *VendorHandler) UpdateVendor(c *gin.Context) {
 	}
 
 	// Execute use case
-	v, err := h.vendorInteractor.UpdateVendor(cmd)
+	v, err := h.vendorInteractor.UpdateVendor(middleware.CurrentHouseholdID(c), cmd)
 	if err != nil {
 		if err == entities.ErrVendorNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Vendor not found"})
@@ -236,7 +255,7 @@ func (h *VendorHandler) DeleteVendor(c *gin.Context) {
 	}
 
 	// Execute use case
-	err = h.vendorInteractor.DeleteVendor(entities.VendorID(id))
+	err = h.vendorInteractor.DeleteVendor(middleware.CurrentHouseholdID(c), entities.VendorID(id))
 	if err != nil {
 		if err == entities.ErrVendorNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Vendor not found"})
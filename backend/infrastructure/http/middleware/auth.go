@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/usecases/interactors/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	currentUserKey      = "currentUser"
+	currentHouseholdKey = "currentHousehold"
+	currentPrincipalKey = "currentPrincipal"
+)
+
+// RequireAuth rejects requests without a valid "Authorization: Bearer <token>" header,
+// accepting both session and API tokens, and stores the authenticated user and
+// the household the token acts on in the Gin context.
+func RequireAuth(authInteractor *auth.AuthInteractor) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := bearerToken(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing bearer token"})
+			return
+		}
+
+		principal, err := authInteractor.Authenticate(token)
+		if err != nil {
+			switch err {
+			case entities.ErrInvalidToken:
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			case entities.ErrNotHouseholdMember:
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "No access to this household"})
+			default:
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to authenticate"})
+			}
+			return
+		}
+
+		c.Set(currentUserKey, principal.User)
+		c.Set(currentHouseholdKey, principal.HouseholdID)
+		c.Set(currentPrincipalKey, principal)
+		c.Next()
+	}
+}
+
+// CurrentUser returns the user authenticated by RequireAuth
+func CurrentUser(c *gin.Context) (*entities.User, bool) {
+	value, ok := c.Get(currentUserKey)
+	if !ok {
+		return nil, false
+	}
+	user, ok := value.(*entities.User)
+	return user, ok
+}
+
+// CurrentHouseholdID returns the household every data route of the request is scoped to
+func CurrentHouseholdID(c *gin.Context) entities.HouseholdID {
+	return c.MustGet(currentHouseholdKey).(entities.HouseholdID)
+}
+
+func bearerToken(c *gin.Context) (string, bool) {
+	header := c.GetHeader("Authorization")
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
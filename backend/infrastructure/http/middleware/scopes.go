@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/usecases/interactors/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireScope rejects API tokens without scope. Session tokens always pass.
+// Must run after RequireAuth.
+func RequireScope(scope entities.APITokenScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !currentPrincipal(c).HasScope(scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Token lacks the " + string(scope) + " scope"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireReadWriteScope requires read for GET and HEAD requests and write for everything else
+func RequireReadWriteScope(read, write entities.APITokenScope) gin.HandlerFunc {
+	readCheck := RequireScope(read)
+	writeCheck := RequireScope(write)
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			readCheck(c)
+		} else {
+			writeCheck(c)
+		}
+	}
+}
+
+// RequireSession rejects API tokens, for routes that manage accounts, households and tokens
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if currentPrincipal(c).APIToken != nil {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "API tokens cannot access this route"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireAdmin only lets sessions of the listed accounts through, for routes that change data every household shares
+func RequireAdmin(emails []string) gin.HandlerFunc {
+	admins := make(map[string]bool, len(emails))
+	for _, email := range emails {
+		admins[strings.ToLower(strings.TrimSpace(email))] = true
+	}
+	return func(c *gin.Context) {
+		principal := currentPrincipal(c)
+		if principal.APIToken != nil || !admins[principal.User.Email()] {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Only administrators can access this route"})
+			return
+		}
+		c.Next()
+	}
+}
+
+func currentPrincipal(c *gin.Context) *auth.Principal {
+	return c.MustGet(currentPrincipalKey).(*auth.Principal)
+}
@@ -0,0 +1,263 @@
+package importers
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+)
+
+// camtDocument mirrors the parts of an ISO 20022 camt.053 file we use.
+// Elements are matched by local name, so every schema version (001.02 to 001.08) parses the same way.
+type camtDocument struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	IBAN      string      `xml:"Acct>Id>IBAN"`
+	AccountID string      `xml:"Acct>Id>Othr>Id"`
+	Entries   []camtEntry `xml:"Ntry"`
+}
+
+type camtEntry struct {
+	Amount         camtAmount      `xml:"Amt"`
+	CreditDebit    string          `xml:"CdtDbtInd"`
+	Status         camtStatus      `xml:"Sts"`
+	BookingDate    camtDate        `xml:"BookgDt"`
+	AcctSvcrRef    string          `xml:"AcctSvcrRef"`
+	Transactions   []camtTxDetails `xml:"NtryDtls>TxDtls"`
+	AdditionalInfo string          `xml:"AddtlNtryInf"`
+}
+
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+// camtStatus is a plain code up to 001.06 and wrapped in <Cd> from 001.08 on
+type camtStatus struct {
+	Value string `xml:",chardata"`
+	Code  string `xml:"Cd"`
+}
+
+func (s camtStatus) code() string {
+	if s.Code != "" {
+		return strings.TrimSpace(s.Code)
+	}
+	return strings.TrimSpace(s.Value)
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+type camtTxDetails struct {
+	AcctSvcrRef   string      `xml:"Refs>AcctSvcrRef"`
+	EndToEndID    string      `xml:"Refs>EndToEndId"`
+	Amount        *camtAmount `xml:"Amt"`
+	TxAmount      *camtAmount `xml:"AmtDtls>TxAmt>Amt"`
+	CreditDebit   string      `xml:"CdtDbtInd"`
+	Creditor      camtParty   `xml:"RltdPties>Cdtr"`
+	Debtor        camtParty   `xml:"RltdPties>Dbtr"`
+	Unstructured  []string    `xml:"RmtInf>Ustrd"`
+	CreditorRefs  []string    `xml:"RmtInf>Strd>CdtrRefInf>Ref"`
+	AdditionalInf string      `xml:"AddtlTxInf"`
+}
+
+// camtParty holds the name directly up to 001.06 and below <Pty> from 001.08 on
+type camtParty struct {
+	Name      string `xml:"Nm"`
+	PartyName string `xml:"Pty>Nm"`
+}
+
+func (p camtParty) name() string {
+	if p.PartyName != "" {
+		return p.PartyName
+	}
+	return p.Name
+}
+
+// ParseCAMT053 parses an ISO 20022 camt.053 bank-to-customer statement.
+// Only booked entries are returned. Batch bookings that list an amount per transaction are split
+// into one entry per transaction; otherwise the entry amount is used with the first transaction's details.
+func ParseCAMT053(data string) (*entities.BankStatement, error) {
+	var document camtDocument
+	if err := xml.Unmarshal([]byte(data), &document); err != nil {
+		return nil, fmt.Errorf("failed to parse XML: %w", err)
+	}
+
+	if len(document.Statements) == 0 {
+		return nil, errors.New("XML does not contain a camt.053 statement")
+	}
+
+	statement := &entities.BankStatement{}
+	for _, stmt := range document.Statements {
+		if statement.Account == "" {
+			statement.Account = strings.TrimSpace(firstNonEmpty(stmt.IBAN, stmt.AccountID))
+		}
+
+		for entryIdx, ntry := range stmt.Entries {
+			if status := ntry.Status.code(); status != "" && status != "BOOK" {
+				continue
+			}
+
+			entries, err := camtEntries(ntry)
+			if err != nil {
+				return nil, fmt.Errorf("entry %d: %w", entryIdx+1, err)
+			}
+			statement.Entries = append(statement.Entries, entries...)
+		}
+	}
+
+	assignMissingTransactionIDs(statement)
+	return statement, nil
+}
+
+func camtEntries(ntry camtEntry) ([]*entities.StatementEntry, error) {
+	bookingDate, err := parseCAMTDate(ntry.BookingDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ntry.Transactions) > 1 && allTransactionsHaveAmounts(ntry.Transactions) {
+		entries := make([]*entities.StatementEntry, 0, len(ntry.Transactions))
+		for _, tx := range ntry.Transactions {
+			amount := tx.Amount
+			if amount == nil {
+				amount = tx.TxAmount
+			}
+			creditDebit := firstNonEmpty(tx.CreditDebit, ntry.CreditDebit)
+			// The entry's reference is shared by all of its transactions, so it cannot tell them apart
+			entry, err := newCAMTEntry(bookingDate, *amount, creditDebit, "", ntry, &tx)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		}
+		return entries, nil
+	}
+
+	var tx *camtTxDetails
+	if len(ntry.Transactions) > 0 {
+		tx = &ntry.Transactions[0]
+	}
+	entry, err := newCAMTEntry(bookingDate, ntry.Amount, ntry.CreditDebit, ntry.AcctSvcrRef, ntry, tx)
+	if err != nil {
+		return nil, err
+	}
+	return []*entities.StatementEntry{entry}, nil
+}
+
+// newCAMTEntry builds one statement entry. Its transaction ID is the transaction's own reference, else entryRef,
+// else the end-to-end ID; entries left without one get a derived ID from assignMissingTransactionIDs.
+func newCAMTEntry(bookingDate time.Time, amount camtAmount, creditDebit, entryRef string, ntry camtEntry, tx *camtTxDetails) (*entities.StatementEntry, error) {
+	money, err := valueobjects.ParseMoney(strings.TrimSpace(amount.Value), amount.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount %q: %w", amount.Value, err)
+	}
+
+	entry := &entities.StatementEntry{
+		BookingDate:   bookingDate,
+		Amount:        money,
+		TransactionID: strings.TrimSpace(entryRef),
+		Reference:     strings.TrimSpace(ntry.AdditionalInfo),
+	}
+
+	switch strings.TrimSpace(creditDebit) {
+	case "DBIT":
+		entry.Direction = entities.StatementDebit
+	case "CRDT":
+		entry.Direction = entities.StatementCredit
+	default:
+		return nil, fmt.Errorf("invalid credit/debit indicator %q", creditDebit)
+	}
+
+	if tx == nil {
+		return entry, nil
+	}
+
+	// The counterparty is whoever is on the other side of the booking
+	if entry.Direction == entities.StatementDebit {
+		entry.Counterparty = tx.Creditor.name()
+	} else {
+		entry.Counterparty = tx.Debtor.name()
+	}
+	entry.Counterparty = strings.TrimSpace(entry.Counterparty)
+
+	if reference := joinRemittance(tx.Unstructured, tx.CreditorRefs); reference != "" {
+		entry.Reference = reference
+	} else if tx.AdditionalInf != "" {
+		entry.Reference = strings.TrimSpace(tx.AdditionalInf)
+	}
+
+	if ref := strings.TrimSpace(tx.AcctSvcrRef); ref != "" {
+		entry.TransactionID = ref
+	} else if entry.TransactionID == "" {
+		if endToEnd := strings.TrimSpace(tx.EndToEndID); endToEnd != "NOTPROVIDED" {
+			entry.TransactionID = endToEnd
+		}
+	}
+
+	return entry, nil
+}
+
+func allTransactionsHaveAmounts(transactions []camtTxDetails) bool {
+	for _, tx := range transactions {
+		if tx.Amount == nil && tx.TxAmount == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// joinRemittance joins the unstructured remittance lines, falling back to structured creditor references
+func joinRemittance(unstructured, creditorRefs []string) string {
+	lines := unstructured
+	if len(lines) == 0 {
+		lines = creditorRefs
+	}
+
+	var parts []string
+	for _, line := range lines {
+		if trimmed := strings.TrimSpace(line); trimmed != "" {
+			parts = append(parts, trimmed)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func parseCAMTDate(date camtDate) (time.Time, error) {
+	if value := strings.TrimSpace(date.Date); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unable to parse booking date: %s", value)
+		}
+		return parsed, nil
+	}
+
+	if value := strings.TrimSpace(date.DateTime); value != "" {
+		// Only the calendar day matters, keep the bank's local date
+		if len(value) >= len("2006-01-02") {
+			if parsed, err := time.Parse("2006-01-02", value[:len("2006-01-02")]); err == nil {
+				return parsed, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("unable to parse booking date: %s", value)
+	}
+
+	return time.Time{}, errors.New("entry has no booking date")
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,320 @@
+package importers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// ParseCSV reads a CSV file as described by an import profile.
+// The file as a whole is rejected if it cannot be decoded or lacks a column the profile names;
+// problems with single rows are reported on the row instead.
+func ParseCSV(profile *entities.ImportProfile, data []byte) ([]*entities.CSVRow, error) {
+	settings := profile.Settings()
+
+	text, err := decodeCSV(data, settings.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	text = strings.TrimPrefix(text, "\ufeff")
+
+	for skipped := 0; skipped < settings.SkipRows; skipped++ {
+		newline := strings.IndexByte(text, '\n')
+		if newline < 0 {
+			return nil, fmt.Errorf("file has fewer than %d lines to skip", settings.SkipRows)
+		}
+		text = text[newline+1:]
+	}
+
+	delimiter, _ := utf8.DecodeRuneInString(settings.Delimiter)
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("CSV must have at least a header row")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+
+	columns := newCSVColumns(header)
+	dateIdx, err := columns.find(settings.DateColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	var amountIdx, payeeIdx, memoIdx = -1, -1, -1
+	vendorTypes := make(map[int]entities.VendorType)
+	switch settings.Layout {
+	case entities.CSVLayoutWide:
+		for column, vendorType := range settings.VendorTypeColumns {
+			idx, err := columns.find(column)
+			if err != nil {
+				return nil, err
+			}
+			vendorTypes[idx] = vendorType
+		}
+	case entities.CSVLayoutLong:
+		if amountIdx, err = columns.find(settings.AmountColumn); err != nil {
+			return nil, err
+		}
+	}
+	if payeeIdx, err = columns.findOptional(settings.PayeeColumn); err != nil {
+		return nil, err
+	}
+	if memoIdx, err = columns.findOptional(settings.MemoColumn); err != nil {
+		return nil, err
+	}
+
+	layouts := settings.GoDateLayouts()
+	var rows []*entities.CSVRow
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CSV: %w", err)
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := &entities.CSVRow{RowNumber: line + settings.SkipRows}
+		rows = append(rows, row)
+
+		if len(record) != len(header) {
+			row.Issues = append(row.Issues, "Row has wrong number of columns")
+			continue
+		}
+
+		row.Date = record[dateIdx]
+		date, dateErr := parseCSVDate(record[dateIdx], layouts)
+		if dateErr != nil {
+			row.Issues = append(row.Issues, "Invalid date format: "+dateErr.Error())
+		}
+
+		payee := cell(record, payeeIdx)
+		memo := cell(record, memoIdx)
+
+		if settings.Layout == entities.CSVLayoutLong {
+			amount, err := normalizeCSVAmount(record[amountIdx], settings.DecimalSeparator)
+			if err != nil {
+				row.Issues = append(row.Issues, "Invalid amount: "+strings.TrimSpace(record[amountIdx]))
+				continue
+			}
+			if amount == "" {
+				row.Issues = append(row.Issues, "Amount is missing")
+				continue
+			}
+			entry, err := newStatementEntry(amount, settings.Currency)
+			if err != nil {
+				row.Issues = append(row.Issues, err.Error())
+				continue
+			}
+			entry.BookingDate = date
+			entry.Counterparty = payee
+			entry.Reference = memo
+			row.Entries = append(row.Entries, entry)
+			continue
+		}
+
+		row.Amounts = make(map[string]valueobjects.Money)
+		for idx, value := range record {
+			vendorType, ok := vendorTypes[idx]
+			if !ok {
+				continue
+			}
+			column := header[idx]
+
+			amount, err := normalizeCSVAmount(value, settings.DecimalSeparator)
+			if err != nil {
+				row.Issues = append(row.Issues, fmt.Sprintf("Invalid amount for %s: %s", column, strings.TrimSpace(value)))
+				continue
+			}
+			if amount == "" {
+				row.Amounts[column], _ = valueobjects.ZeroMoney(settings.Currency)
+				continue
+			}
+
+			// Wide sheets list spending as positive amounts, negative ones are refunds
+			entry, err := newStatementEntry(negateDecimal(amount), settings.Currency)
+			if err != nil {
+				row.Issues = append(row.Issues, fmt.Sprintf("Invalid amount for %s: %s", column, strings.TrimSpace(value)))
+				continue
+			}
+			row.Amounts[column] = entry.Amount
+			if entry.Amount.IsZero() {
+				continue
+			}
+
+			entry.BookingDate = date
+			entry.VendorType = vendorType
+			entry.Counterparty = payee
+			entry.Reference = memo
+			if entry.Reference == "" {
+				entry.Reference = fmt.Sprintf("Imported %s expense", column)
+			}
+			row.Entries = append(row.Entries, entry)
+		}
+	}
+
+	var entries []*entities.StatementEntry
+	for _, row := range rows {
+		entries = append(entries, row.Entries...)
+	}
+	assignMissingTransactionIDs(&entities.BankStatement{Entries: entries})
+
+	return rows, nil
+}
+
+// csvEncodingAuto reads files without a profile: UTF-8 if the file is valid UTF-8, windows-1252 as spreadsheets save it otherwise
+const csvEncodingAuto entities.CSVEncoding = "auto"
+
+// decodeCSV converts the file to UTF-8
+func decodeCSV(data []byte, encoding entities.CSVEncoding) (string, error) {
+	if encoding == csvEncodingAuto {
+		encoding = entities.CSVEncodingUTF8
+		if !utf8.Valid(data) {
+			encoding = entities.CSVEncodingWindows1252
+		}
+	}
+
+	switch encoding {
+	case entities.CSVEncodingWindows1252:
+		decoded, err := charmap.Windows1252.NewDecoder().Bytes(data)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode windows-1252: %w", err)
+		}
+		return string(decoded), nil
+	case entities.CSVEncodingISO88591:
+		decoded, err := charmap.ISO8859_1.NewDecoder().Bytes(data)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode iso-8859-1: %w", err)
+		}
+		return string(decoded), nil
+	}
+
+	if !utf8.Valid(data) {
+		return "", errors.New("file is not valid UTF-8, set the encoding it was saved in on the import profile")
+	}
+	return string(data), nil
+}
+
+// csvColumns finds columns by header name, ignoring case and surrounding spaces
+type csvColumns map[string]int
+
+func newCSVColumns(header []string) csvColumns {
+	columns := make(csvColumns, len(header))
+	for idx, name := range header {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if _, exists := columns[key]; !exists {
+			columns[key] = idx
+		}
+	}
+	return columns
+}
+
+func (c csvColumns) find(name string) (int, error) {
+	idx, ok := c[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return -1, fmt.Errorf("column %q not found in the CSV header", name)
+	}
+	return idx, nil
+}
+
+// findOptional returns -1 for columns the profile leaves empty
+func (c csvColumns) findOptional(name string) (int, error) {
+	if name == "" {
+		return -1, nil
+	}
+	return c.find(name)
+}
+
+// optional returns -1 for columns the file does not have
+func (c csvColumns) optional(name string) int {
+	if idx, ok := c[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return idx
+	}
+	return -1
+}
+
+func cell(record []string, idx int) string {
+	if idx < 0 {
+		return ""
+	}
+	return strings.TrimSpace(record[idx])
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func parseCSVDate(value string, layouts []string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range layouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unable to parse date: %s", value)
+}
+
+// normalizeCSVAmount turns a localized amount like "-1.234,56" or "12.50-" into a plain decimal.
+// Empty cells and a lone "0" come back empty.
+func normalizeCSVAmount(value, decimalSeparator string) (string, error) {
+	amount := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\u00a0', '\u202f', '\'':
+			return -1
+		}
+		return r
+	}, value)
+
+	thousandsSeparator := ","
+	if decimalSeparator == "," {
+		thousandsSeparator = "."
+	}
+	amount = strings.ReplaceAll(amount, thousandsSeparator, "")
+	amount = strings.Replace(amount, decimalSeparator, ".", 1)
+
+	// Some banks put the sign last
+	if strings.HasSuffix(amount, "-") && !strings.HasPrefix(amount, "-") {
+		amount = "-" + strings.TrimSuffix(amount, "-")
+	}
+
+	if amount == "" || amount == "0" {
+		return "", nil
+	}
+	if strings.ContainsFunc(amount, func(r rune) bool { return (r < '0' || r > '9') && r != '.' && r != '-' && r != '+' }) {
+		return "", fmt.Errorf("invalid amount %q", strings.TrimSpace(value))
+	}
+	return amount, nil
+}
+
+// negateDecimal flips the sign of a plain decimal string
+func negateDecimal(amount string) string {
+	if strings.HasPrefix(amount, "-") {
+		return strings.TrimPrefix(amount, "-")
+	}
+	return "-" + strings.TrimPrefix(amount, "+")
+}
@@ -0,0 +1,155 @@
+package importers
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+)
+
+// ECB reference rates are quoted as units of foreign currency per one euro
+const (
+	ecbBaseCurrency = "EUR"
+	ecbSource       = "ecb"
+)
+
+// ecbEnvelope mirrors the eurofxref XML files (daily, 90 days and historical)
+type ecbEnvelope struct {
+	Days []ecbDayCube `xml:"Cube>Cube"`
+}
+
+type ecbDayCube struct {
+	Time  string        `xml:"time,attr"`
+	Rates []ecbRateCube `xml:"Cube"`
+}
+
+type ecbRateCube struct {
+	Currency string `xml:"currency,attr"`
+	Rate     string `xml:"rate,attr"`
+}
+
+// ParseECBRatesCSV parses an ECB eurofxref CSV file.
+// The first column holds the date, every other column one currency:
+//
+//	Date, USD, JPY, GBP,
+//	14 October 2025, 1.1604, 176.41, 0.8701,
+func ParseECBRatesCSV(data string) ([]*entities.ExchangeRate, error) {
+	reader := csv.NewReader(strings.NewReader(data))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+
+	if len(records) < 2 {
+		return nil, errors.New("CSV must have a header row and at least one data row")
+	}
+
+	headers := records[0]
+	if len(headers) < 2 || !strings.EqualFold(strings.TrimSpace(headers[0]), "date") {
+		return nil, errors.New("CSV header must start with a Date column followed by currency codes")
+	}
+
+	var rates []*entities.ExchangeRate
+	for rowIdx, record := range records[1:] {
+		if len(record) == 0 || strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		date, err := parseECBDate(record[0])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", rowIdx+2, err)
+		}
+
+		for i := 1; i < len(record) && i < len(headers); i++ {
+			rate, ok, err := newECBRate(headers[i], record[i], date)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %w", rowIdx+2, err)
+			}
+			if ok {
+				rates = append(rates, rate)
+			}
+		}
+	}
+
+	return rates, nil
+}
+
+// ParseECBRatesXML parses an ECB eurofxref XML file
+func ParseECBRatesXML(data string) ([]*entities.ExchangeRate, error) {
+	var envelope ecbEnvelope
+	if err := xml.Unmarshal([]byte(data), &envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse XML: %w", err)
+	}
+
+	if len(envelope.Days) == 0 {
+		return nil, errors.New("XML does not contain any daily rates")
+	}
+
+	var rates []*entities.ExchangeRate
+	for _, day := range envelope.Days {
+		date, err := parseECBDate(day.Time)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, cube := range day.Rates {
+			rate, ok, err := newECBRate(cube.Currency, cube.Rate, date)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				rates = append(rates, rate)
+			}
+		}
+	}
+
+	return rates, nil
+}
+
+// newECBRate builds a EUR based rate; ok is false for blank values and retired currencies
+func newECBRate(currency, value string, date time.Time) (*entities.ExchangeRate, bool, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	value = strings.TrimSpace(value)
+
+	if currency == "" || value == "" || value == "N/A" || !valueobjects.IsValidCurrency(currency) {
+		return nil, false, nil
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid rate for %s: %s", currency, value)
+	}
+
+	rate, err := entities.NewExchangeRate(ecbBaseCurrency, currency, date, parsed, ecbSource)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid rate for %s: %w", currency, err)
+	}
+
+	return rate, true, nil
+}
+
+func parseECBDate(value string) (time.Time, error) {
+	layouts := []string{
+		"2006-01-02",
+		"02 January 2006",
+		"2 January 2006",
+	}
+
+	value = strings.TrimSpace(value)
+	for _, layout := range layouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unable to parse date: %s", value)
+}
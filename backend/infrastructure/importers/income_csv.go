@@ -0,0 +1,176 @@
+package importers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+)
+
+// IncomeCSVHeader lists the columns of the income CSV layout; only date, amount and source are required on import
+var IncomeCSVHeader = []string{"date", "amount", "currency", "source", "comment", "vendor", "added_by", "tags"}
+
+// incomeCSVTagSeparator separates the tag names of one income
+const incomeCSVTagSeparator = ";"
+
+// incomeCSVDateLayouts are the date formats accepted on import; the export writes the first
+var incomeCSVDateLayouts = []string{"2006-01-02", "02.01.2006"}
+
+// WriteIncomeCSV writes incomes in the layout ParseIncomeCSV reads, one row per income with its original amount and currency
+func WriteIncomeCSV(w io.Writer, incomes []*entities.Income) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(IncomeCSVHeader); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, income := range incomes {
+		var vendor, addedBy string
+		if income.Vendor() != nil {
+			vendor = income.Vendor().Name()
+		}
+		if income.Member() != nil {
+			addedBy = income.Member().Name()
+		}
+		tags := make([]string, len(income.Tags()))
+		for i, tag := range income.Tags() {
+			tags[i] = tag.Name()
+		}
+
+		record := []string{
+			income.Date().Format(incomeCSVDateLayouts[0]),
+			income.Amount().Decimal(),
+			income.Amount().Currency(),
+			income.Source(),
+			income.Comment(),
+			vendor,
+			addedBy,
+			strings.Join(tags, incomeCSVTagSeparator),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// ParseIncomeCSV reads an income CSV file as written by WriteIncomeCSV, or as saved from a spreadsheet:
+// files that are not UTF-8 are read as windows-1252, and a semicolon-separated file takes decimal commas.
+// The file as a whole is rejected if it lacks a required column; problems with single rows are reported on the row.
+func ParseIncomeCSV(data []byte) ([]*entities.IncomeCSVRow, error) {
+	text, err := decodeCSV(data, csvEncodingAuto)
+	if err != nil {
+		return nil, err
+	}
+	text = strings.TrimPrefix(text, "\ufeff")
+
+	delimiter, decimalSeparator := ',', "."
+	headerLine, _, _ := strings.Cut(text, "\n")
+	if strings.Contains(headerLine, ";") && !strings.Contains(headerLine, ",") {
+		delimiter, decimalSeparator = ';', ","
+	}
+
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("CSV must have at least a header row")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+
+	columns := newCSVColumns(header)
+	dateIdx, err := columns.find("date")
+	if err != nil {
+		return nil, err
+	}
+	amountIdx, err := columns.find("amount")
+	if err != nil {
+		return nil, err
+	}
+	sourceIdx, err := columns.find("source")
+	if err != nil {
+		return nil, err
+	}
+	currencyIdx := columns.optional("currency")
+	commentIdx := columns.optional("comment")
+	vendorIdx := columns.optional("vendor")
+	addedByIdx := columns.optional("added_by")
+	tagsIdx := columns.optional("tags")
+
+	var rows []*entities.IncomeCSVRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CSV: %w", err)
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := &entities.IncomeCSVRow{RowNumber: line}
+		rows = append(rows, row)
+
+		if len(record) != len(header) {
+			row.Issues = append(row.Issues, "Row has wrong number of columns")
+			continue
+		}
+
+		row.Vendor = cell(record, vendorIdx)
+		row.AddedBy = cell(record, addedByIdx)
+		for _, tag := range strings.Split(cell(record, tagsIdx), incomeCSVTagSeparator) {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				row.Tags = append(row.Tags, tag)
+			}
+		}
+
+		date, dateErr := parseCSVDate(record[dateIdx], incomeCSVDateLayouts)
+		if dateErr != nil {
+			row.Issues = append(row.Issues, "Invalid date format: "+dateErr.Error())
+		}
+
+		source := cell(record, sourceIdx)
+		if source == "" {
+			row.Issues = append(row.Issues, "Source is missing")
+		}
+
+		amount, err := normalizeCSVAmount(record[amountIdx], decimalSeparator)
+		if err != nil {
+			row.Issues = append(row.Issues, "Invalid amount: "+strings.TrimSpace(record[amountIdx]))
+			continue
+		}
+		if amount == "" {
+			row.Issues = append(row.Issues, "Amount is missing")
+			continue
+		}
+		money, err := valueobjects.ParseMoney(amount, cell(record, currencyIdx))
+		if err != nil {
+			row.Issues = append(row.Issues, fmt.Sprintf("Invalid amount: %v", err))
+			continue
+		}
+
+		row.Entry = &entities.StatementEntry{
+			BookingDate:  date,
+			Amount:       money,
+			Direction:    entities.StatementCredit,
+			Counterparty: source,
+			Reference:    cell(record, commentIdx),
+		}
+	}
+
+	return rows, nil
+}
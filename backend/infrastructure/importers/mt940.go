@@ -0,0 +1,294 @@
+package importers
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"expenso-backend/domain/entities"
+)
+
+var (
+	mt940TagPattern      = regexp.MustCompile(`^:(\d{2}[A-Z]?):(.*)$`)
+	mt940SubfieldPattern = regexp.MustCompile(`\?(\d{2})`)
+)
+
+// sepaPurposeTags start the parts of a German SEPA purpose text; SVWZ+ is the remittance information proper
+var sepaPurposeTags = []string{"EREF+", "KREF+", "MREF+", "CRED+", "DEBT+", "COAM+", "OAMT+", "SVWZ+", "ABWA+", "ABWE+", "IBAN+", "BIC+"}
+
+// mt940SubfieldWidth is the length at which banks wrap ?-subfield text
+const mt940SubfieldWidth = 27
+
+type mt940Field struct {
+	tag   string
+	value string
+}
+
+// ParseMT940 parses a SWIFT MT940 customer statement.
+// Each :61: line becomes an entry; the :86: line after it supplies counterparty and purpose,
+// read from the ?-subfields German banks use or taken as plain text otherwise.
+// Files with several statements, SWIFT block headers and CRLF line endings are accepted.
+func ParseMT940(data string) (*entities.BankStatement, error) {
+	fields := splitMT940Fields(data)
+	if len(fields) == 0 {
+		return nil, errors.New("file does not contain any MT940 fields")
+	}
+
+	statement := &entities.BankStatement{}
+	var currency string
+	var last *entities.StatementEntry
+
+	for _, field := range fields {
+		switch field.tag {
+		case "25":
+			if statement.Account == "" {
+				statement.Account = strings.TrimSpace(field.value)
+			}
+		case "60F", "60M":
+			// Opening balance: D/C mark, YYMMDD, currency, amount
+			value := strings.TrimSpace(field.value)
+			if len(value) < 10 {
+				return nil, fmt.Errorf("invalid opening balance %q", value)
+			}
+			currency = value[7:10]
+			last = nil
+		case "61":
+			if currency == "" {
+				return nil, errors.New("statement line before the opening balance, currency unknown")
+			}
+			entry, err := parseMT940StatementLine(field.value, currency)
+			if err != nil {
+				return nil, fmt.Errorf("entry %d: %w", len(statement.Entries)+1, err)
+			}
+			statement.Entries = append(statement.Entries, entry)
+			last = entry
+		case "86":
+			if last != nil {
+				applyMT940Details(last, field.value)
+				last = nil
+			}
+		}
+	}
+
+	if len(statement.Entries) == 0 && currency == "" {
+		return nil, errors.New("file does not contain an MT940 statement")
+	}
+
+	assignMissingTransactionIDs(statement)
+	return statement, nil
+}
+
+// splitMT940Fields collects tagged fields, joining continuation lines with newlines
+func splitMT940Fields(data string) []mt940Field {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	data = strings.ReplaceAll(data, "\r", "\n")
+
+	var fields []mt940Field
+	for _, line := range strings.Split(data, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if match := mt940TagPattern.FindStringSubmatch(trimmed); match != nil {
+			fields = append(fields, mt940Field{tag: match[1], value: match[2]})
+			continue
+		}
+
+		// Statement separators and SWIFT block headers end the current field
+		if trimmed == "" || trimmed == "-" || strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "-}") {
+			if len(fields) > 0 && fields[len(fields)-1].tag != "" {
+				fields = append(fields, mt940Field{})
+			}
+			continue
+		}
+
+		if len(fields) > 0 && fields[len(fields)-1].tag != "" {
+			fields[len(fields)-1].value += "\n" + line
+		}
+	}
+
+	return fields
+}
+
+// parseMT940StatementLine reads a :61: field:
+// value date YYMMDD, optional entry date MMDD, mark (C, D, RC, RD), optional funds code, amount,
+// transaction type, customer reference, optional //bank reference and supplementary details on the next line.
+func parseMT940StatementLine(value, currency string) (*entities.StatementEntry, error) {
+	line := value
+	if idx := strings.Index(line, "\n"); idx >= 0 {
+		line = line[:idx]
+	}
+	line = strings.TrimSpace(line)
+
+	if len(line) < 6 || !isDigits(line[:6]) {
+		return nil, fmt.Errorf("invalid statement line %q", line)
+	}
+	valueDate, err := time.Parse("060102", line[:6])
+	if err != nil {
+		return nil, fmt.Errorf("invalid value date %q", line[:6])
+	}
+	rest := line[6:]
+
+	bookingDate := valueDate
+	if len(rest) >= 4 && isDigits(rest[:4]) {
+		bookingDate, err = mt940EntryDate(valueDate, rest[:4])
+		if err != nil {
+			return nil, err
+		}
+		rest = rest[4:]
+	}
+
+	var mark string
+	for _, candidate := range []string{"RC", "RD", "C", "D"} {
+		if strings.HasPrefix(rest, candidate) {
+			mark = candidate
+			break
+		}
+	}
+	if mark == "" {
+		return nil, fmt.Errorf("invalid debit/credit mark in %q", line)
+	}
+	rest = rest[len(mark):]
+
+	// Optional funds code, the third letter of the currency
+	if rest != "" && rest[0] >= 'A' && rest[0] <= 'Z' {
+		rest = rest[1:]
+	}
+
+	amountEnd := strings.IndexFunc(rest, func(r rune) bool { return (r < '0' || r > '9') && r != ',' })
+	if amountEnd <= 0 {
+		return nil, fmt.Errorf("missing amount in %q", line)
+	}
+	money, err := parseStatementMoney(strings.Replace(rest[:amountEnd], ",", ".", 1), currency)
+	if err != nil {
+		return nil, err
+	}
+	rest = rest[amountEnd:]
+
+	// Transaction type identification code, e.g. NTRF or N024
+	if len(rest) >= 4 {
+		rest = rest[4:]
+	} else {
+		rest = ""
+	}
+
+	// Only the bank reference after "//" identifies a booking. The customer reference before it, e.g. a mandate
+	// or order number, repeats with every standing order and direct debit, so entries without one get a derived ID.
+	bankRef := ""
+	if idx := strings.Index(rest, "//"); idx >= 0 {
+		bankRef = strings.TrimSpace(rest[idx+2:])
+	}
+
+	entry := &entities.StatementEntry{
+		BookingDate:   bookingDate,
+		Amount:        money,
+		TransactionID: bankRef,
+	}
+
+	// RC reverses a credit and so takes money out, RD reverses a debit
+	switch mark {
+	case "D", "RC":
+		entry.Direction = entities.StatementDebit
+	default:
+		entry.Direction = entities.StatementCredit
+	}
+	if mark == "RC" || mark == "RD" {
+		entry.Issues = append(entry.Issues, "Reversal of an earlier booking")
+	}
+	if money.IsZero() {
+		entry.Issues = append(entry.Issues, "Amount is zero")
+	}
+
+	return entry, nil
+}
+
+// mt940EntryDate places the MMDD entry date in the year closest to the value date
+func mt940EntryDate(valueDate time.Time, monthDay string) (time.Time, error) {
+	entryDate, err := time.Parse("20060102", fmt.Sprintf("%04d%s", valueDate.Year(), monthDay))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid entry date %q", monthDay)
+	}
+
+	switch {
+	case entryDate.Sub(valueDate) > 180*24*time.Hour:
+		entryDate = entryDate.AddDate(-1, 0, 0)
+	case valueDate.Sub(entryDate) > 180*24*time.Hour:
+		entryDate = entryDate.AddDate(1, 0, 0)
+	}
+	return entryDate, nil
+}
+
+// applyMT940Details fills counterparty and reference from a :86: field
+func applyMT940Details(entry *entities.StatementEntry, value string) {
+	joined := strings.ReplaceAll(value, "\n", "")
+	if !mt940SubfieldPattern.MatchString(joined) {
+		entry.Reference = strings.Join(strings.Fields(value), " ")
+		return
+	}
+
+	subfields := make(map[string]string)
+	matches := mt940SubfieldPattern.FindAllStringSubmatchIndex(joined, -1)
+	for i, match := range matches {
+		end := len(joined)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		code := joined[match[2]:match[3]]
+		subfields[code] += joined[match[1]:end]
+	}
+
+	purpose := joinMT940Subfields(subfields, "20", "21", "22", "23", "24", "25", "26", "27", "28", "29", "60", "61", "62", "63")
+
+	entry.Counterparty = strings.TrimSpace(joinMT940Subfields(subfields, "32", "33"))
+	entry.Reference = sepaRemittance(purpose)
+	if entry.Reference == "" {
+		entry.Reference = strings.TrimSpace(subfields["00"]) // posting text, e.g. KARTENZAHLUNG
+	}
+}
+
+// joinMT940Subfields concatenates subfields in order. Banks wrap text every 27 characters,
+// so only a shorter subfield ended at a word boundary and gets a space after it.
+func joinMT940Subfields(subfields map[string]string, codes ...string) string {
+	var joined strings.Builder
+	for _, code := range codes {
+		part, ok := subfields[code]
+		if !ok {
+			continue
+		}
+		joined.WriteString(part)
+		if len([]rune(part)) < mt940SubfieldWidth {
+			joined.WriteString(" ")
+		}
+	}
+	return joined.String()
+}
+
+// sepaRemittance returns the SVWZ+ part of a SEPA purpose text, or the whole text if it has no tags
+func sepaRemittance(purpose string) string {
+	start := strings.Index(purpose, "SVWZ+")
+	if start < 0 {
+		for _, tag := range sepaPurposeTags {
+			if strings.HasPrefix(purpose, tag) {
+				return "" // only references, no free text
+			}
+		}
+		return strings.Join(strings.Fields(purpose), " ")
+	}
+
+	text := purpose[start+len("SVWZ+"):]
+	end := len(text)
+	for _, tag := range sepaPurposeTags {
+		if idx := strings.Index(text, tag); idx >= 0 && idx < end {
+			end = idx
+		}
+	}
+	return strings.Join(strings.Fields(text[:end]), " ")
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
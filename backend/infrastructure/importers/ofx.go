@@ -0,0 +1,122 @@
+package importers
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"expenso-backend/domain/entities"
+)
+
+// ofxTagPattern matches an OFX tag and the text up to the next tag.
+// OFX 1.x is SGML where leaf elements are never closed, OFX 2.x and QFX are XML; both tokenize the same way.
+var ofxTagPattern = regexp.MustCompile(`<(/?)([A-Za-z0-9.]+)>([^<]*)`)
+
+var ofxEntities = strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">", "&quot;", `"`, "&apos;", "'", "&nbsp;", " ")
+
+// ParseOFX parses the bank or credit card statement of an OFX (1.x SGML or 2.x XML) or QFX file.
+// Each STMTTRN becomes an entry: the sign of TRNAMT decides debit or credit, FITID is the transaction ID,
+// NAME (or PAYEE/NAME) the counterparty and MEMO the reference.
+func ParseOFX(data string) (*entities.BankStatement, error) {
+	start := strings.Index(strings.ToUpper(data), "<OFX>")
+	if start < 0 {
+		return nil, errors.New("file does not contain an <OFX> element")
+	}
+
+	statement := &entities.BankStatement{}
+	var currency string
+	var transaction map[string]string
+	var transactions []map[string]string
+
+	for _, match := range ofxTagPattern.FindAllStringSubmatch(data[start:], -1) {
+		closing := match[1] == "/"
+		tag := strings.ToUpper(match[2])
+		text := strings.TrimSpace(ofxEntities.Replace(match[3]))
+
+		switch {
+		case tag == "STMTTRN" && !closing:
+			transaction = make(map[string]string)
+		case tag == "STMTTRN" && closing:
+			if transaction != nil {
+				transactions = append(transactions, transaction)
+			}
+			transaction = nil
+		case closing || text == "":
+			// Aggregate boundaries and XML closing tags carry no values
+		case transaction != nil:
+			// Keep the first value, so NAME wins over a later PAYEE/NAME
+			if _, ok := transaction[tag]; !ok {
+				transaction[tag] = text
+			}
+		case tag == "CURDEF":
+			currency = text
+		case tag == "ACCTID" && statement.Account == "":
+			statement.Account = text
+		}
+	}
+
+	if len(transactions) == 0 && currency == "" {
+		return nil, errors.New("file does not contain an OFX statement")
+	}
+	if currency == "" {
+		return nil, errors.New("statement has no CURDEF currency")
+	}
+
+	for idx, transaction := range transactions {
+		entry, err := newOFXEntry(transaction, currency)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", idx+1, err)
+		}
+		statement.Entries = append(statement.Entries, entry)
+	}
+
+	assignMissingTransactionIDs(statement)
+	return statement, nil
+}
+
+func newOFXEntry(transaction map[string]string, currency string) (*entities.StatementEntry, error) {
+	amount, ok := transaction["TRNAMT"]
+	if !ok {
+		return nil, errors.New("missing TRNAMT")
+	}
+
+	entry, err := newStatementEntry(amount, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// Posting date first, the date the user initiated the transaction otherwise
+	dateValue := transaction["DTPOSTED"]
+	if dateValue == "" {
+		dateValue = transaction["DTUSER"]
+	}
+	entry.BookingDate, err = parseOFXDate(dateValue)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.TransactionID = transaction["FITID"]
+	entry.Counterparty = transaction["NAME"]
+	entry.Reference = transaction["MEMO"]
+	if entry.TransactionID == "" {
+		entry.Issues = append(entry.Issues, "Transaction has no FITID")
+	}
+
+	return entry, nil
+}
+
+// parseOFXDate reads the day of an OFX datetime such as 20251014, 20251014120000 or 20251014120000.000[-5:EST]
+func parseOFXDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 8 || !isDigits(value[:8]) {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+
+	date, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return date, nil
+}
@@ -0,0 +1,76 @@
+package importers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+)
+
+// assignMissingTransactionIDs gives entries without an ID from the file one derived from their contents.
+// Identical entries are numbered in file order, so re-importing the same export yields the same IDs.
+func assignMissingTransactionIDs(statement *entities.BankStatement) {
+	seen := make(map[string]int)
+	for _, entry := range statement.Entries {
+		if entry.TransactionID != "" {
+			continue
+		}
+
+		key := strings.Join([]string{
+			statement.Account,
+			entry.BookingDate.Format("2006-01-02"),
+			string(entry.Direction),
+			entry.Amount.Decimal(),
+			entry.Amount.Currency(),
+			entry.Counterparty,
+			entry.Reference,
+		}, "|")
+		seen[key]++
+
+		sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d", key, seen[key])))
+		entry.TransactionID = entities.DerivedTransactionIDPrefix + hex.EncodeToString(sum[:8])
+	}
+}
+
+// newStatementEntry builds an entry from a signed amount: negative amounts are debits, positive ones credits
+func newStatementEntry(amount string, currency string) (*entities.StatementEntry, error) {
+	amount = strings.TrimSpace(amount)
+	entry := &entities.StatementEntry{Direction: entities.StatementCredit}
+	if strings.HasPrefix(amount, "-") {
+		entry.Direction = entities.StatementDebit
+		amount = strings.TrimPrefix(amount, "-")
+	}
+	amount = strings.TrimPrefix(amount, "+")
+
+	// Some banks write decimal commas
+	if strings.Contains(amount, ",") && !strings.Contains(amount, ".") {
+		amount = strings.Replace(amount, ",", ".", 1)
+	}
+
+	money, err := parseStatementMoney(amount, currency)
+	if err != nil {
+		return nil, err
+	}
+	entry.Amount = money
+	if money.IsZero() {
+		entry.Issues = append(entry.Issues, "Amount is zero")
+	}
+	return entry, nil
+}
+
+// parseStatementMoney parses an unsigned amount; MT940 amounts may end in a bare decimal separator ("12,")
+func parseStatementMoney(amount, currency string) (valueobjects.Money, error) {
+	amount = strings.TrimSpace(amount)
+	if strings.HasSuffix(amount, ".") {
+		amount += "0"
+	}
+
+	money, err := valueobjects.ParseMoney(amount, currency)
+	if err != nil {
+		return valueobjects.Money{}, fmt.Errorf("invalid amount %q: %w", amount, err)
+	}
+	return money, nil
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"expenso-backend/domain/entities"
+)
+
+// Database Object with DB annotations
+type APITokenDBO struct {
+	ID          int        `db:"id"`
+	UserID      int        `db:"user_id"`
+	HouseholdID int        `db:"household_id"`
+	Name        string     `db:"name"`
+	TokenPrefix string     `db:"token_prefix"`
+	TokenHash   string     `db:"token_hash"`
+	Scopes      []string   `db:"scopes"`
+	LastUsedAt  *time.Time `db:"last_used_at"`
+	CreatedAt   time.Time  `db:"created_at"`
+}
+
+// Convert domain entity to DBO
+func (dbo *APITokenDBO) FromDomainEntity(token *entities.APIToken) {
+	dbo.ID = int(token.ID())
+	dbo.UserID = int(token.UserID())
+	dbo.HouseholdID = int(token.HouseholdID())
+	dbo.Name = token.Name()
+	dbo.TokenPrefix = token.Prefix()
+	dbo.TokenHash = token.TokenHash()
+	dbo.Scopes = make([]string, len(token.Scopes()))
+	for i, scope := range token.Scopes() {
+		dbo.Scopes[i] = string(scope)
+	}
+	dbo.LastUsedAt = token.LastUsedAt()
+	dbo.CreatedAt = token.CreatedAt()
+}
+
+// Convert DBO to domain entity
+func (dbo *APITokenDBO) ToDomainEntity() *entities.APIToken {
+	scopes := make([]entities.APITokenScope, len(dbo.Scopes))
+	for i, scope := range dbo.Scopes {
+		scopes[i] = entities.APITokenScope(scope)
+	}
+
+	return entities.ReconstructAPIToken(
+		entities.APITokenID(dbo.ID),
+		entities.UserID(dbo.UserID),
+		entities.HouseholdID(dbo.HouseholdID),
+		dbo.Name,
+		dbo.TokenPrefix,
+		dbo.TokenHash,
+		scopes,
+		dbo.LastUsedAt,
+		dbo.CreatedAt,
+	)
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+)
+
+// Database Object with DB annotations
+type BudgetDBO struct {
+	ID         int       `db:"id"`
+	Amount     string    `db:"amount"`
+	Currency   string    `db:"currency"`
+	Period     string    `db:"period"`
+	TargetType string    `db:"target_type"`
+	Target     string    `db:"target"`
+	Rollover   bool      `db:"rollover"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// Convert domain entity to DBO
+func (dbo *BudgetDBO) FromDomainEntity(budget *entities.Budget) {
+	dbo.ID = int(budget.ID())
+	dbo.Amount = budget.Amount().Decimal()
+	dbo.Currency = budget.Amount().Currency()
+	dbo.Period = string(budget.Period())
+	dbo.TargetType = string(budget.TargetType())
+	dbo.Target = budget.Target()
+	dbo.Rollover = budget.Rollover()
+	dbo.CreatedAt = budget.CreatedAt()
+	dbo.UpdatedAt = budget.UpdatedAt()
+}
+
+// Convert DBO to domain entity
+func (dbo *BudgetDBO) ToDomainEntity() (*entities.Budget, error) {
+	money, err := valueobjects.ParseMoney(dbo.Amount, dbo.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.ReconstructBudget(
+		entities.BudgetID(dbo.ID),
+		money,
+		entities.BudgetPeriod(dbo.Period),
+		entities.BudgetTargetType(dbo.TargetType),
+		dbo.Target,
+		dbo.Rollover,
+		dbo.CreatedAt,
+		dbo.UpdatedAt,
+	), nil
+}
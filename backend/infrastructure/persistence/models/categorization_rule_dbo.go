@@ -0,0 +1,122 @@
+package models
+
+import (
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+)
+
+// Database Object with DB annotations
+type CategorizationRuleDBO struct {
+	ID         int       `db:"id"`
+	Name       string    `db:"name"`
+	Priority   int       `db:"priority"`
+	Active     bool      `db:"active"`
+	Pattern    string    `db:"pattern"`
+	VendorType string    `db:"vendor_type"`
+	Amount     *string   `db:"amount"`
+	MinAmount  *string   `db:"min_amount"`
+	MaxAmount  *string   `db:"max_amount"`
+	Currency   string    `db:"currency"`
+	VendorID   *int      `db:"vendor_id"`
+	Category   string    `db:"category"`
+	TagIDs     []int64   `db:"tag_ids"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// Convert domain entity to DBO
+func (dbo *CategorizationRuleDBO) FromDomainEntity(rule *entities.CategorizationRule) {
+	conditions := rule.Conditions()
+	actions := rule.Actions()
+
+	dbo.ID = int(rule.ID())
+	dbo.Name = rule.Name()
+	dbo.Priority = rule.Priority()
+	dbo.Active = rule.Active()
+	dbo.Pattern = conditions.Pattern
+	dbo.VendorType = string(conditions.VendorType)
+
+	// The amounts share one currency; without amounts the column keeps its default
+	dbo.Currency = valueobjects.DefaultCurrency
+	dbo.Amount, dbo.MinAmount, dbo.MaxAmount = nil, nil, nil
+	for _, field := range []struct {
+		money  *valueobjects.Money
+		target **string
+	}{
+		{conditions.Amount, &dbo.Amount},
+		{conditions.MinAmount, &dbo.MinAmount},
+		{conditions.MaxAmount, &dbo.MaxAmount},
+	} {
+		if field.money == nil {
+			continue
+		}
+		decimal := field.money.Decimal()
+		*field.target = &decimal
+		dbo.Currency = field.money.Currency()
+	}
+
+	dbo.VendorID = nil
+	if actions.VendorID != nil {
+		vendorID := int(*actions.VendorID)
+		dbo.VendorID = &vendorID
+	}
+	dbo.Category = actions.Category
+
+	dbo.TagIDs = make([]int64, 0, len(actions.TagIDs))
+	for _, tagID := range actions.TagIDs {
+		dbo.TagIDs = append(dbo.TagIDs, int64(tagID))
+	}
+
+	dbo.CreatedAt = rule.CreatedAt()
+	dbo.UpdatedAt = rule.UpdatedAt()
+}
+
+// Convert DBO to domain entity
+func (dbo *CategorizationRuleDBO) ToDomainEntity() (*entities.CategorizationRule, error) {
+	conditions := entities.RuleConditions{
+		Pattern:    dbo.Pattern,
+		VendorType: entities.VendorType(dbo.VendorType),
+	}
+	for _, field := range []struct {
+		decimal *string
+		target  **valueobjects.Money
+	}{
+		{dbo.Amount, &conditions.Amount},
+		{dbo.MinAmount, &conditions.MinAmount},
+		{dbo.MaxAmount, &conditions.MaxAmount},
+	} {
+		if field.decimal == nil {
+			continue
+		}
+		money, err := valueobjects.ParseMoney(*field.decimal, dbo.Currency)
+		if err != nil {
+			return nil, err
+		}
+		*field.target = &money
+	}
+
+	actions := entities.RuleActions{
+		Category: dbo.Category,
+		TagIDs:   make([]entities.TagID, 0, len(dbo.TagIDs)),
+	}
+	if dbo.VendorID != nil {
+		vendorID := entities.VendorID(*dbo.VendorID)
+		actions.VendorID = &vendorID
+	}
+	for _, tagID := range dbo.TagIDs {
+		actions.TagIDs = append(actions.TagIDs, entities.TagID(tagID))
+	}
+
+	return entities.ReconstructCategorizationRule(
+		entities.CategorizationRuleID(dbo.ID),
+		dbo.Name,
+		dbo.Priority,
+		dbo.Active,
+		conditions,
+		actions,
+		dbo.CreatedAt,
+		dbo.UpdatedAt,
+	), nil
+}
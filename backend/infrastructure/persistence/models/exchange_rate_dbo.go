@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"expenso-backend/domain/entities"
+)
+
+// Database Object with DB annotations
+type ExchangeRateDBO struct {
+	ID            int       `db:"id"`
+	BaseCurrency  string    `db:"base_currency"`
+	QuoteCurrency string    `db:"quote_currency"`
+	Date          time.Time `db:"date"`
+	Rate          float64   `db:"rate"`
+	Source        string    `db:"source"`
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+// Convert DBO to domain entity
+func (dbo *ExchangeRateDBO) ToDomainEntity() *entities.ExchangeRate {
+	return entities.ReconstructExchangeRate(
+		entities.ExchangeRateID(dbo.ID),
+		dbo.BaseCurrency,
+		dbo.QuoteCurrency,
+		dbo.Date,
+		dbo.Rate,
+		dbo.Source,
+		dbo.CreatedAt,
+	)
+}
@@ -10,14 +10,16 @@ import (
 // Database Object with DB annotations
 type ExpenseDBO struct {
 	ID         int       `db:"id"`
-	Amount     float64   `db:"amount"`
+	Amount     string    `db:"amount"`
+	Currency   string    `db:"currency"`
 	Date       time.Time `db:"date"`
 	Type       string    `db:"type"`
 	Category   string    `db:"category"`
 	Comment    string    `db:"comment"`
 	VendorID   *int      `db:"vendor_id"`
 	PaidByCard bool      `db:"paid_by_card"`
-	AddedBy    string    `db:"added_by"`
+	MemberID   *int      `db:"member_id"`
+	MemberName *string   `db:"member_name"` // joined from members
 	CreatedAt  time.Time `db:"created_at"`
 	UpdatedAt  time.Time `db:"updated_at"`
 }
@@ -25,13 +27,20 @@ type ExpenseDBO struct {
 // Convert domain entity to DBO
 func (dbo *ExpenseDBO) FromDomainEntity(expense *entities.Expense) {
 	dbo.ID = int(expense.ID())
-	dbo.Amount = expense.Amount().Amount()
+	dbo.Amount = expense.Amount().Decimal()
+	dbo.Currency = expense.Amount().Currency()
 	dbo.Date = expense.Date()
 	dbo.Type = string(expense.Type())
 	dbo.Category = expense.Category().String()
 	dbo.Comment = expense.Comment()
 	dbo.PaidByCard = expense.PaidByCard()
-	dbo.AddedBy = expense.AddedBy().String()
+
+	if expense.Member() != nil {
+		memberID := int(expense.Member().ID())
+		dbo.MemberID = &memberID
+		memberName := expense.Member().Name()
+		dbo.MemberName = &memberName
+	}
 
 	if expense.Vendor() != nil {
 		vendorID := int(expense.Vendor().ID())
@@ -44,7 +53,7 @@ func (dbo *ExpenseDBO) FromDomainEntity(expense *entities.Expense) {
 
 // Convert DBO to domain entity
 func (dbo *ExpenseDBO) ToDomainEntity() (*entities.Expense, error) {
-	money, err := valueobjects.NewMoney(dbo.Amount, "USD")
+	money, err := valueobjects.ParseMoney(dbo.Amount, dbo.Currency)
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +72,7 @@ func (dbo *ExpenseDBO) ToDomainEntity() (*entities.Expense, error) {
 		dbo.Comment,
 		nil, // vendor will be set separately
 		dbo.PaidByCard,
-		entities.AddedBy(dbo.AddedBy),
+		dbo.member(),
 		[]*entities.Tag{}, // tags will be set separately
 		dbo.CreatedAt,
 		dbo.UpdatedAt,
@@ -71,3 +80,11 @@ func (dbo *ExpenseDBO) ToDomainEntity() (*entities.Expense, error) {
 
 	return expense, nil
 }
+
+// member rebuilds the joined member, if any
+func (dbo *ExpenseDBO) member() *entities.Member {
+	if dbo.MemberID == nil || dbo.MemberName == nil {
+		return nil
+	}
+	return entities.ReconstructMember(entities.MemberID(*dbo.MemberID), *dbo.MemberName, time.Time{}, time.Time{})
+}
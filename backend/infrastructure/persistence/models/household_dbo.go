@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"expenso-backend/domain/entities"
+)
+
+// Database Object with DB annotations
+type HouseholdDBO struct {
+	ID        int       `db:"id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Convert domain entity to DBO
+func (dbo *HouseholdDBO) FromDomainEntity(household *entities.Household) {
+	dbo.ID = int(household.ID())
+	dbo.Name = household.Name()
+	dbo.CreatedAt = household.CreatedAt()
+	dbo.UpdatedAt = household.UpdatedAt()
+}
+
+// Convert DBO to domain entity
+func (dbo *HouseholdDBO) ToDomainEntity() *entities.Household {
+	return entities.ReconstructHousehold(
+		entities.HouseholdID(dbo.ID),
+		dbo.Name,
+		dbo.CreatedAt,
+		dbo.UpdatedAt,
+	)
+}
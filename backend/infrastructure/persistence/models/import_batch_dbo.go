@@ -0,0 +1,78 @@
+package models
+
+import (
+	"time"
+
+	"expenso-backend/domain/entities"
+)
+
+// Database Object with DB annotations
+type ImportBatchDBO struct {
+	ID           int        `db:"id"`
+	Source       string     `db:"source"`
+	FileHash     string     `db:"file_hash"`
+	ExpenseCount int        `db:"expense_count"`
+	IncomeCount  int        `db:"income_count"`
+	CreatedAt    time.Time  `db:"created_at"`
+	RolledBackAt *time.Time `db:"rolled_back_at"`
+}
+
+type ImportBatchItemDBO struct {
+	Fingerprint string `db:"fingerprint"`
+	ExpenseID   *int   `db:"expense_id"`
+	IncomeID    *int   `db:"income_id"`
+}
+
+// Convert domain entity to DBO
+func (dbo *ImportBatchDBO) FromDomainEntity(batch *entities.ImportBatch) {
+	dbo.ID = int(batch.ID())
+	dbo.Source = batch.Source()
+	dbo.FileHash = batch.FileHash()
+	dbo.ExpenseCount = batch.ExpenseCount()
+	dbo.IncomeCount = batch.IncomeCount()
+	dbo.CreatedAt = batch.CreatedAt()
+	dbo.RolledBackAt = batch.RolledBackAt()
+}
+
+// Convert DBO to domain entity, items are loaded separately
+func (dbo *ImportBatchDBO) ToDomainEntity(items []entities.ImportBatchItem) *entities.ImportBatch {
+	return entities.ReconstructImportBatch(
+		entities.ImportBatchID(dbo.ID),
+		dbo.Source,
+		dbo.FileHash,
+		items,
+		dbo.ExpenseCount,
+		dbo.IncomeCount,
+		dbo.CreatedAt,
+		dbo.RolledBackAt,
+	)
+}
+
+// Convert domain item to DBO
+func (dbo *ImportBatchItemDBO) FromDomainEntity(item entities.ImportBatchItem) {
+	dbo.Fingerprint = item.Fingerprint
+	dbo.ExpenseID = nil
+	if item.ExpenseID != nil {
+		expenseID := int(*item.ExpenseID)
+		dbo.ExpenseID = &expenseID
+	}
+	dbo.IncomeID = nil
+	if item.IncomeID != nil {
+		incomeID := int(*item.IncomeID)
+		dbo.IncomeID = &incomeID
+	}
+}
+
+// Convert DBO to domain item
+func (dbo *ImportBatchItemDBO) ToDomainEntity() entities.ImportBatchItem {
+	item := entities.ImportBatchItem{Fingerprint: dbo.Fingerprint}
+	if dbo.ExpenseID != nil {
+		expenseID := entities.ExpenseID(*dbo.ExpenseID)
+		item.ExpenseID = &expenseID
+	}
+	if dbo.IncomeID != nil {
+		incomeID := entities.IncomeID(*dbo.IncomeID)
+		item.IncomeID = &incomeID
+	}
+	return item
+}
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"expenso-backend/domain/entities"
+)
+
+// Database Object with DB annotations
+type ImportProfileDBO struct {
+	ID                int       `db:"id"`
+	Name              string    `db:"name"`
+	Delimiter         string    `db:"delimiter"`
+	Encoding          string    `db:"encoding"`
+	DecimalSeparator  string    `db:"decimal_separator"`
+	DateFormats       []string  `db:"date_formats"`
+	SkipRows          int       `db:"skip_rows"`
+	Layout            string    `db:"layout"`
+	DateColumn        string    `db:"date_column"`
+	AmountColumn      string    `db:"amount_column"`
+	PayeeColumn       string    `db:"payee_column"`
+	MemoColumn        string    `db:"memo_column"`
+	VendorTypeColumns string    `db:"vendor_type_columns"` // JSON object, column header to vendor type
+	Currency          string    `db:"currency"`
+	DefaultCategory   string    `db:"default_category"`
+	Rules             string    `db:"rules"` // JSON array of importRuleJSON
+	CreatedAt         time.Time `db:"created_at"`
+	UpdatedAt         time.Time `db:"updated_at"`
+}
+
+// importRuleJSON is how an import rule is stored in the rules column
+type importRuleJSON struct {
+	Match      string `json:"match,omitempty"`
+	VendorType string `json:"vendor_type,omitempty"`
+	Category   string `json:"category,omitempty"`
+	VendorID   *int   `json:"vendor_id,omitempty"`
+}
+
+// Convert domain entity to DBO
+func (dbo *ImportProfileDBO) FromDomainEntity(profile *entities.ImportProfile) {
+	settings := profile.Settings()
+	dbo.ID = int(profile.ID())
+	dbo.Name = profile.Name()
+	dbo.Delimiter = settings.Delimiter
+	dbo.Encoding = string(settings.Encoding)
+	dbo.DecimalSeparator = settings.DecimalSeparator
+	dbo.DateFormats = settings.DateFormats
+	dbo.SkipRows = settings.SkipRows
+	dbo.Layout = string(settings.Layout)
+	dbo.DateColumn = settings.DateColumn
+	dbo.AmountColumn = settings.AmountColumn
+	dbo.PayeeColumn = settings.PayeeColumn
+	dbo.MemoColumn = settings.MemoColumn
+	dbo.Currency = settings.Currency
+	dbo.DefaultCategory = settings.DefaultCategory
+
+	columns := make(map[string]string, len(settings.VendorTypeColumns))
+	for column, vendorType := range settings.VendorTypeColumns {
+		columns[column] = string(vendorType)
+	}
+	rules := make([]importRuleJSON, len(settings.Rules))
+	for i, rule := range settings.Rules {
+		rules[i] = importRuleJSON{Match: rule.Match, VendorType: string(rule.VendorType), Category: rule.Category}
+		if rule.VendorID != nil {
+			vendorID := int(*rule.VendorID)
+			rules[i].VendorID = &vendorID
+		}
+	}
+	// Maps of strings and flat structs always marshal
+	columnsJSON, _ := json.Marshal(columns)
+	rulesJSON, _ := json.Marshal(rules)
+	dbo.VendorTypeColumns = string(columnsJSON)
+	dbo.Rules = string(rulesJSON)
+
+	dbo.CreatedAt = profile.CreatedAt()
+	dbo.UpdatedAt = profile.UpdatedAt()
+}
+
+// Convert DBO to domain entity
+func (dbo *ImportProfileDBO) ToDomainEntity() (*entities.ImportProfile, error) {
+	var columns map[string]string
+	if err := json.Unmarshal([]byte(dbo.VendorTypeColumns), &columns); err != nil {
+		return nil, err
+	}
+	var rules []importRuleJSON
+	if err := json.Unmarshal([]byte(dbo.Rules), &rules); err != nil {
+		return nil, err
+	}
+
+	settings := entities.ImportProfileSettings{
+		Delimiter:         dbo.Delimiter,
+		Encoding:          entities.CSVEncoding(dbo.Encoding),
+		DecimalSeparator:  dbo.DecimalSeparator,
+		DateFormats:       dbo.DateFormats,
+		SkipRows:          dbo.SkipRows,
+		Layout:            entities.CSVLayout(dbo.Layout),
+		DateColumn:        dbo.DateColumn,
+		AmountColumn:      dbo.AmountColumn,
+		PayeeColumn:       dbo.PayeeColumn,
+		MemoColumn:        dbo.MemoColumn,
+		VendorTypeColumns: make(map[string]entities.VendorType, len(columns)),
+		Currency:          dbo.Currency,
+		DefaultCategory:   dbo.DefaultCategory,
+		Rules:             make([]entities.ImportRule, len(rules)),
+	}
+	for column, vendorType := range columns {
+		settings.VendorTypeColumns[column] = entities.VendorType(vendorType)
+	}
+	for i, rule := range rules {
+		settings.Rules[i] = entities.ImportRule{Match: rule.Match, VendorType: entities.VendorType(rule.VendorType), Category: rule.Category}
+		if rule.VendorID != nil {
+			vendorID := entities.VendorID(*rule.VendorID)
+			settings.Rules[i].VendorID = &vendorID
+		}
+	}
+
+	return entities.ReconstructImportProfile(
+		entities.ImportProfileID(dbo.ID),
+		dbo.Name,
+		settings,
+		dbo.CreatedAt,
+		dbo.UpdatedAt,
+	), nil
+}
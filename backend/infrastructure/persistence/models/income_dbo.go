@@ -9,25 +9,34 @@ import (
 
 // Database Object with DB annotations
 type IncomeDBO struct {
-	ID        int       `db:"id"`
-	Amount    float64   `db:"amount"`
-	Date      time.Time `db:"date"`
-	Source    string    `db:"source"`
-	Comment   string    `db:"comment"`
-	VendorID  *int      `db:"vendor_id"`
-	AddedBy   string    `db:"added_by"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID         int       `db:"id"`
+	Amount     string    `db:"amount"`
+	Currency   string    `db:"currency"`
+	Date       time.Time `db:"date"`
+	Source     string    `db:"source"`
+	Comment    string    `db:"comment"`
+	VendorID   *int      `db:"vendor_id"`
+	MemberID   *int      `db:"member_id"`
+	MemberName *string   `db:"member_name"` // joined from members
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
 }
 
 // Convert domain entity to DBO
 func (dbo *IncomeDBO) FromDomainEntity(income *entities.Income) {
 	dbo.ID = int(income.ID())
-	dbo.Amount = income.Amount().Amount()
+	dbo.Amount = income.Amount().Decimal()
+	dbo.Currency = income.Amount().Currency()
 	dbo.Date = income.Date()
 	dbo.Source = income.Source()
 	dbo.Comment = income.Comment()
-	dbo.AddedBy = income.AddedBy().String()
+
+	if income.Member() != nil {
+		memberID := int(income.Member().ID())
+		dbo.MemberID = &memberID
+		memberName := income.Member().Name()
+		dbo.MemberName = &memberName
+	}
 
 	if income.Vendor() != nil {
 		vendorID := int(income.Vendor().ID())
@@ -40,7 +49,7 @@ func (dbo *IncomeDBO) FromDomainEntity(income *entities.Income) {
 
 // Convert DBO to domain entity
 func (dbo *IncomeDBO) ToDomainEntity() (*entities.Income, error) {
-	money, err := valueobjects.NewMoney(dbo.Amount, "USD")
+	money, err := valueobjects.ParseMoney(dbo.Amount, dbo.Currency)
 	if err != nil {
 		return nil, err
 	}
@@ -52,11 +61,19 @@ func (dbo *IncomeDBO) ToDomainEntity() (*entities.Income, error) {
 		dbo.Source,
 		dbo.Comment,
 		nil, // vendor will be set separately
-		entities.AddedBy(dbo.AddedBy),
+		dbo.member(),
 		[]*entities.Tag{}, // tags will be set separately
 		dbo.CreatedAt,
 		dbo.UpdatedAt,
 	)
 
 	return income, nil
-}
\ No newline at end of file
+}
+
+// member rebuilds the joined member, if any
+func (dbo *IncomeDBO) member() *entities.Member {
+	if dbo.MemberID == nil || dbo.MemberName == nil {
+		return nil
+	}
+	return entities.ReconstructMember(entities.MemberID(*dbo.MemberID), *dbo.MemberName, time.Time{}, time.Time{})
+}
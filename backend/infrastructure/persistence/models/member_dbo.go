@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"expenso-backend/domain/entities"
+)
+
+// Database Object with DB annotations
+type MemberDBO struct {
+	ID        int       `db:"id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Convert domain entity to DBO
+func (dbo *MemberDBO) FromDomainEntity(member *entities.Member) {
+	dbo.ID = int(member.ID())
+	dbo.Name = member.Name()
+	dbo.CreatedAt = member.CreatedAt()
+	dbo.UpdatedAt = member.UpdatedAt()
+}
+
+// Convert DBO to domain entity
+func (dbo *MemberDBO) ToDomainEntity() *entities.Member {
+	return entities.ReconstructMember(
+		entities.MemberID(dbo.ID),
+		dbo.Name,
+		dbo.CreatedAt,
+		dbo.UpdatedAt,
+	)
+}
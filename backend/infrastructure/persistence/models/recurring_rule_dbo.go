@@ -0,0 +1,151 @@
+package models
+
+import (
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+)
+
+// Database Object with DB annotations
+type RecurringRuleDBO struct {
+	ID          int        `db:"id"`
+	HouseholdID int        `db:"household_id"`
+	Kind        string     `db:"kind"`
+	Name        string     `db:"name"`
+	Amount      string     `db:"amount"`
+	Currency    string     `db:"currency"`
+	Category    string     `db:"category"`
+	Source      string     `db:"source"`
+	Comment     string     `db:"comment"`
+	VendorID    *int       `db:"vendor_id"`
+	MemberID    *int       `db:"member_id"`
+	PaidByCard  bool       `db:"paid_by_card"`
+	TagIDs      []int64    `db:"tag_ids"`
+	Frequency   string     `db:"frequency"`
+	Interval    int        `db:"repeat_interval"`
+	DayOfMonth  int        `db:"day_of_month"`
+	StartDate   time.Time  `db:"start_date"`
+	EndDate     *time.Time `db:"end_date"`
+	Active      bool       `db:"active"`
+	CreatedAt   time.Time  `db:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at"`
+}
+
+// Convert domain entity to DBO
+func (dbo *RecurringRuleDBO) FromDomainEntity(rule *entities.RecurringRule) {
+	dbo.ID = int(rule.ID())
+	dbo.Kind = string(rule.Kind())
+	dbo.Name = rule.Name()
+	dbo.Amount = rule.Amount().Decimal()
+	dbo.Currency = rule.Amount().Currency()
+	dbo.Category = rule.Category()
+	dbo.Source = rule.Source()
+	dbo.Comment = rule.Comment()
+	dbo.PaidByCard = rule.PaidByCard()
+
+	dbo.VendorID = nil
+	if rule.VendorID() != nil {
+		vendorID := int(*rule.VendorID())
+		dbo.VendorID = &vendorID
+	}
+
+	dbo.MemberID = nil
+	if rule.MemberID() != nil {
+		memberID := int(*rule.MemberID())
+		dbo.MemberID = &memberID
+	}
+
+	dbo.TagIDs = make([]int64, 0, len(rule.TagIDs()))
+	for _, tagID := range rule.TagIDs() {
+		dbo.TagIDs = append(dbo.TagIDs, int64(tagID))
+	}
+
+	schedule := rule.Schedule()
+	dbo.Frequency = string(schedule.Frequency())
+	dbo.Interval = schedule.Interval()
+	dbo.DayOfMonth = schedule.DayOfMonth()
+	dbo.StartDate = schedule.StartDate()
+	dbo.EndDate = schedule.EndDate()
+
+	dbo.Active = rule.Active()
+	dbo.CreatedAt = rule.CreatedAt()
+	dbo.UpdatedAt = rule.UpdatedAt()
+}
+
+// Convert DBO to domain entity
+func (dbo *RecurringRuleDBO) ToDomainEntity() (*entities.RecurringRule, error) {
+	money, err := valueobjects.ParseMoney(dbo.Amount, dbo.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := valueobjects.NewRecurrence(valueobjects.RecurrenceFrequency(dbo.Frequency), dbo.Interval, dbo.DayOfMonth, dbo.StartDate, dbo.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var vendorID *entities.VendorID
+	if dbo.VendorID != nil {
+		id := entities.VendorID(*dbo.VendorID)
+		vendorID = &id
+	}
+
+	var memberID *entities.MemberID
+	if dbo.MemberID != nil {
+		id := entities.MemberID(*dbo.MemberID)
+		memberID = &id
+	}
+
+	tagIDs := make([]entities.TagID, 0, len(dbo.TagIDs))
+	for _, tagID := range dbo.TagIDs {
+		tagIDs = append(tagIDs, entities.TagID(tagID))
+	}
+
+	return entities.ReconstructRecurringRule(
+		entities.RecurringRuleID(dbo.ID),
+		entities.RecurringKind(dbo.Kind),
+		dbo.Name,
+		money,
+		dbo.Category,
+		dbo.Source,
+		dbo.Comment,
+		vendorID,
+		memberID,
+		dbo.PaidByCard,
+		tagIDs,
+		schedule,
+		dbo.Active,
+		dbo.CreatedAt,
+		dbo.UpdatedAt,
+	), nil
+}
+
+// Database Object with DB annotations
+type RecurringOccurrenceDBO struct {
+	RuleID    int       `db:"rule_id"`
+	Date      time.Time `db:"date"`
+	Status    string    `db:"status"`
+	ExpenseID *int      `db:"expense_id"`
+	IncomeID  *int      `db:"income_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// Convert DBO to domain entity
+func (dbo *RecurringOccurrenceDBO) ToDomainEntity() *entities.RecurringOccurrence {
+	occurrence := &entities.RecurringOccurrence{
+		RuleID:    entities.RecurringRuleID(dbo.RuleID),
+		Date:      dbo.Date,
+		Status:    entities.OccurrenceStatus(dbo.Status),
+		CreatedAt: dbo.CreatedAt,
+	}
+	if dbo.ExpenseID != nil {
+		expenseID := entities.ExpenseID(*dbo.ExpenseID)
+		occurrence.ExpenseID = &expenseID
+	}
+	if dbo.IncomeID != nil {
+		incomeID := entities.IncomeID(*dbo.IncomeID)
+		occurrence.IncomeID = &incomeID
+	}
+	return occurrence
+}
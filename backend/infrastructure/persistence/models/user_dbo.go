@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"expenso-backend/domain/entities"
+)
+
+// Database Object with DB annotations
+type UserDBO struct {
+	ID           int       `db:"id"`
+	Email        string    `db:"email"`
+	Name         string    `db:"name"`
+	PasswordHash string    `db:"password_hash"`
+	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
+
+// Convert domain entity to DBO
+func (dbo *UserDBO) FromDomainEntity(user *entities.User) {
+	dbo.ID = int(user.ID())
+	dbo.Email = user.Email()
+	dbo.Name = user.Name()
+	dbo.PasswordHash = user.PasswordHash()
+	dbo.CreatedAt = user.CreatedAt()
+	dbo.UpdatedAt = user.UpdatedAt()
+}
+
+// Convert DBO to domain entity
+func (dbo *UserDBO) ToDomainEntity() *entities.User {
+	return entities.ReconstructUser(
+		entities.UserID(dbo.ID),
+		dbo.Email,
+		dbo.Name,
+		dbo.PasswordHash,
+		dbo.CreatedAt,
+		dbo.UpdatedAt,
+	)
+}
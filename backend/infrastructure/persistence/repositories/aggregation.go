@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"fmt"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/usecases/interfaces/repositories"
+)
+
+// transactionTable describes the columns expenses and incomes differ in, so both can be aggregated by one query
+type transactionTable struct {
+	from     string // the table with its alias
+	alias    string
+	category string // the column DimensionCategory groups by
+	payment  string // the expression DimensionPaymentMethod groups by
+	tagLink  string // the link table to tags and its column referencing the transaction
+	tagOwner string
+}
+
+var (
+	expenseTable = transactionTable{
+		from:     "expenses e",
+		alias:    "e",
+		category: "e.category",
+		payment:  "CASE WHEN e.paid_by_card THEN 'card' ELSE 'cash' END",
+		tagLink:  "expense_tags",
+		tagOwner: "expense_id",
+	}
+	incomeTable = transactionTable{
+		from:     "incomes i",
+		alias:    "i",
+		category: "i.source",
+		payment:  "''",
+		tagLink:  "income_tags",
+		tagOwner: "income_id",
+	}
+)
+
+// aggregate groups the household's transactions by bucket, dimension and currency in the database
+func aggregate(db DBTX, table transactionTable, householdID entities.HouseholdID, query repositories.AggregateQuery) ([]repositories.AggregateRow, error) {
+	if !query.Period.IsValid() {
+		return nil, fmt.Errorf("cannot aggregate by period %q", query.Period)
+	}
+
+	a := table.alias
+	joins := fmt.Sprintf(" LEFT JOIN vendors v ON %[1]s.vendor_id = v.id LEFT JOIN members m ON %[1]s.member_id = m.id", a)
+
+	var key, label string
+	switch query.Dimension {
+	case repositories.DimensionNone:
+		key, label = "''", "''"
+	case repositories.DimensionCategory:
+		key, label = table.category, table.category
+	case repositories.DimensionVendor:
+		key, label = "COALESCE(v.id::text, '')", "COALESCE(v.name, '')"
+	case repositories.DimensionVendorType:
+		key, label = "COALESCE(v.type, '')", "COALESCE(v.type, '')"
+	case repositories.DimensionTag:
+		joins += fmt.Sprintf(" LEFT JOIN %s lt ON lt.%s = %s.id LEFT JOIN tags t ON t.id = lt.tag_id", table.tagLink, table.tagOwner, a)
+		key, label = "COALESCE(t.id::text, '')", "COALESCE(t.name, '')"
+	case repositories.DimensionMember:
+		key, label = "COALESCE(m.id::text, '')", "COALESCE(m.name, '')"
+	case repositories.DimensionPaymentMethod:
+		key, label = table.payment, table.payment
+	default:
+		return nil, fmt.Errorf("cannot aggregate by dimension %q", query.Dimension)
+	}
+
+	var q listQuery
+	q.where(a+".household_id = ?", int(householdID))
+	if query.StartDate != nil {
+		q.where(a+".date >= ?", *query.StartDate)
+	}
+	if query.EndDate != nil {
+		q.where(a+".date <= ?", *query.EndDate)
+	}
+	q.args = append(q.args, query.ReportingCurrency)
+	reporting := fmt.Sprintf("$%d", len(q.args))
+
+	statement := fmt.Sprintf(`
+		SELECT date_trunc('%[1]s', %[2]s.date::timestamp)::date, %[3]s, %[4]s, %[2]s.currency,
+		       CASE WHEN %[2]s.currency = %[5]s THEN NULL ELSE %[2]s.date END,
+		       COUNT(*), SUM(%[2]s.amount), MIN(%[2]s.amount), MAX(%[2]s.amount)
+		FROM %[6]s%[7]s%[8]s
+		GROUP BY 1, 2, 3, 4, 5
+		ORDER BY 1, 3, 2, 4, 5`,
+		query.Period, a, key, label, reporting, table.from, joins, q.whereClause())
+
+	rows, err := db.Query(statement, q.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []repositories.AggregateRow
+	for rows.Next() {
+		var row repositories.AggregateRow
+		if err := rows.Scan(&row.Bucket, &row.Key, &row.Label, &row.Currency, &row.Date, &row.Count, &row.Sum, &row.Min, &row.Max); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
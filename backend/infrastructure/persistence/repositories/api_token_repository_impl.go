@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/infrastructure/persistence/models"
+	"expenso-backend/usecases/interfaces/repositories"
+
+	"github.com/lib/pq"
+)
+
+type APITokenRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewAPITokenRepository(db *sql.DB) repositories.APITokenRepository {
+	return &APITokenRepositoryImpl{db: db}
+}
+
+const apiTokenColumns = `id, user_id, household_id, name, token_prefix, token_hash, scopes, last_used_at, created_at`
+
+func (r *APITokenRepositoryImpl) Save(token *entities.APIToken) error {
+	query := `
+		INSERT INTO api_tokens (user_id, household_id, name, token_prefix, token_hash, scopes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+
+	var dbo models.APITokenDBO
+	dbo.FromDomainEntity(token)
+
+	var id int
+	err := r.db.QueryRow(
+		query,
+		dbo.UserID,
+		dbo.HouseholdID,
+		dbo.Name,
+		dbo.TokenPrefix,
+		dbo.TokenHash,
+		pq.Array(dbo.Scopes),
+		dbo.CreatedAt,
+	).Scan(&id)
+
+	if err != nil {
+		return fmt.Errorf("failed to save api token: %w", err)
+	}
+
+	token.SetID(entities.APITokenID(id))
+	return nil
+}
+
+func (r *APITokenRepositoryImpl) FindByHash(tokenHash string) (*entities.APIToken, error) {
+	query := `SELECT ` + apiTokenColumns + ` FROM api_tokens WHERE token_hash = $1`
+
+	dbo, err := r.scan(r.db.QueryRow(query, tokenHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrAPITokenNotFound
+		}
+		return nil, fmt.Errorf("failed to find api token: %w", err)
+	}
+
+	return dbo.ToDomainEntity(), nil
+}
+
+func (r *APITokenRepositoryImpl) FindByUser(userID entities.UserID) ([]*entities.APIToken, error) {
+	query := `SELECT ` + apiTokenColumns + ` FROM api_tokens WHERE user_id = $1 ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(query, int(userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find api tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*entities.APIToken
+	for rows.Next() {
+		dbo, err := r.scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api token: %w", err)
+		}
+		tokens = append(tokens, dbo.ToDomainEntity())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating api tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+func (r *APITokenRepositoryImpl) Delete(userID entities.UserID, id entities.APITokenID) error {
+	query := `DELETE FROM api_tokens WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.Exec(query, int(id), int(userID))
+	if err != nil {
+		return fmt.Errorf("failed to delete api token: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrAPITokenNotFound
+	}
+
+	return nil
+}
+
+func (r *APITokenRepositoryImpl) UpdateLastUsed(id entities.APITokenID, lastUsedAt time.Time) error {
+	query := `UPDATE api_tokens SET last_used_at = $2 WHERE id = $1`
+
+	if _, err := r.db.Exec(query, int(id), lastUsedAt); err != nil {
+		return fmt.Errorf("failed to update api token last use: %w", err)
+	}
+
+	return nil
+}
+
+func (r *APITokenRepositoryImpl) scan(row interface{ Scan(...interface{}) error }) (*models.APITokenDBO, error) {
+	var dbo models.APITokenDBO
+	err := row.Scan(
+		&dbo.ID,
+		&dbo.UserID,
+		&dbo.HouseholdID,
+		&dbo.Name,
+		&dbo.TokenPrefix,
+		&dbo.TokenHash,
+		pq.Array(&dbo.Scopes),
+		&dbo.LastUsedAt,
+		&dbo.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &dbo, nil
+}
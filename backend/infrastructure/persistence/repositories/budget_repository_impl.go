@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/infrastructure/persistence/models"
+	"expenso-backend/usecases/interfaces/repositories"
+)
+
+type BudgetRepositoryImpl struct {
+	db DBTX
+}
+
+func NewBudgetRepository(db DBTX) repositories.BudgetRepository {
+	return &BudgetRepositoryImpl{db: db}
+}
+
+const budgetColumns = `id, amount, currency, period, target_type, target, rollover, created_at, updated_at`
+
+func (r *BudgetRepositoryImpl) Save(householdID entities.HouseholdID, budget *entities.Budget) error {
+	query := `
+		INSERT INTO budgets (amount, currency, period, target_type, target, rollover, created_at, updated_at, household_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`
+
+	var dbo models.BudgetDBO
+	dbo.FromDomainEntity(budget)
+
+	var id int
+	err := r.db.QueryRow(
+		query,
+		dbo.Amount,
+		dbo.Currency,
+		dbo.Period,
+		dbo.TargetType,
+		dbo.Target,
+		dbo.Rollover,
+		dbo.CreatedAt,
+		dbo.UpdatedAt,
+		int(householdID),
+	).Scan(&id)
+
+	if err != nil {
+		return fmt.Errorf("failed to save budget: %w", err)
+	}
+
+	budget.SetID(entities.BudgetID(id))
+	return nil
+}
+
+func (r *BudgetRepositoryImpl) FindByID(householdID entities.HouseholdID, id entities.BudgetID) (*entities.Budget, error) {
+	query := `SELECT ` + budgetColumns + ` FROM budgets WHERE id = $1 AND household_id = $2`
+
+	budget, err := r.scan(r.db.QueryRow(query, int(id), int(householdID)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrBudgetNotFound
+		}
+		return nil, fmt.Errorf("failed to find budget: %w", err)
+	}
+
+	return budget, nil
+}
+
+func (r *BudgetRepositoryImpl) FindAll(householdID entities.HouseholdID) ([]*entities.Budget, error) {
+	query := `SELECT ` + budgetColumns + ` FROM budgets WHERE household_id = $1 ORDER BY target_type, target, period`
+
+	rows, err := r.db.Query(query, int(householdID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find budgets: %w", err)
+	}
+	defer rows.Close()
+
+	var budgets []*entities.Budget
+	for rows.Next() {
+		budget, err := r.scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan budget: %w", err)
+		}
+		budgets = append(budgets, budget)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating budgets: %w", err)
+	}
+
+	return budgets, nil
+}
+
+func (r *BudgetRepositoryImpl) FindByTarget(householdID entities.HouseholdID, targetType entities.BudgetTargetType, target string, period entities.BudgetPeriod) (*entities.Budget, error) {
+	query := `SELECT ` + budgetColumns + ` FROM budgets WHERE household_id = $1 AND target_type = $2 AND target = $3 AND period = $4`
+
+	budget, err := r.scan(r.db.QueryRow(query, int(householdID), string(targetType), target, string(period)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrBudgetNotFound
+		}
+		return nil, fmt.Errorf("failed to find budget by target: %w", err)
+	}
+
+	return budget, nil
+}
+
+func (r *BudgetRepositoryImpl) Update(householdID entities.HouseholdID, budget *entities.Budget) error {
+	query := `
+		UPDATE budgets
+		SET amount = $2, currency = $3, period = $4, target_type = $5, target = $6, rollover = $7, updated_at = $8
+		WHERE id = $1 AND household_id = $9
+	`
+
+	var dbo models.BudgetDBO
+	dbo.FromDomainEntity(budget)
+
+	result, err := r.db.Exec(
+		query,
+		dbo.ID,
+		dbo.Amount,
+		dbo.Currency,
+		dbo.Period,
+		dbo.TargetType,
+		dbo.Target,
+		dbo.Rollover,
+		dbo.UpdatedAt,
+		int(householdID),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update budget: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrBudgetNotFound
+	}
+
+	return nil
+}
+
+func (r *BudgetRepositoryImpl) Delete(householdID entities.HouseholdID, id entities.BudgetID) error {
+	query := `DELETE FROM budgets WHERE id = $1 AND household_id = $2`
+
+	result, err := r.db.Exec(query, int(id), int(householdID))
+	if err != nil {
+		return fmt.Errorf("failed to delete budget: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrBudgetNotFound
+	}
+
+	return nil
+}
+
+func (r *BudgetRepositoryImpl) scan(row interface{ Scan(...interface{}) error }) (*entities.Budget, error) {
+	var dbo models.BudgetDBO
+	err := row.Scan(
+		&dbo.ID,
+		&dbo.Amount,
+		&dbo.Currency,
+		&dbo.Period,
+		&dbo.TargetType,
+		&dbo.Target,
+		&dbo.Rollover,
+		&dbo.CreatedAt,
+		&dbo.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return dbo.ToDomainEntity()
+}
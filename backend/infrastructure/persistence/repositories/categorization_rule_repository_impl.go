@@ -0,0 +1,209 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/infrastructure/persistence/models"
+	"expenso-backend/usecases/interfaces/repositories"
+
+	"github.com/lib/pq"
+)
+
+type CategorizationRuleRepositoryImpl struct {
+	db DBTX
+}
+
+func NewCategorizationRuleRepository(db DBTX) repositories.CategorizationRuleRepository {
+	return &CategorizationRuleRepositoryImpl{db: db}
+}
+
+const categorizationRuleColumns = `id, name, priority, active, pattern, vendor_type, amount, min_amount, max_amount, currency,
+	vendor_id, category, tag_ids, created_at, updated_at`
+
+func (r *CategorizationRuleRepositoryImpl) Save(householdID entities.HouseholdID, rule *entities.CategorizationRule) error {
+	query := `
+		INSERT INTO categorization_rules (name, priority, active, pattern, vendor_type, amount, min_amount, max_amount, currency,
+			vendor_id, category, tag_ids, created_at, updated_at, household_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		RETURNING id
+	`
+
+	var dbo models.CategorizationRuleDBO
+	dbo.FromDomainEntity(rule)
+
+	var id int
+	err := r.db.QueryRow(
+		query,
+		dbo.Name,
+		dbo.Priority,
+		dbo.Active,
+		dbo.Pattern,
+		dbo.VendorType,
+		dbo.Amount,
+		dbo.MinAmount,
+		dbo.MaxAmount,
+		dbo.Currency,
+		dbo.VendorID,
+		dbo.Category,
+		pq.Array(dbo.TagIDs),
+		dbo.CreatedAt,
+		dbo.UpdatedAt,
+		int(householdID),
+	).Scan(&id)
+
+	if err != nil {
+		return fmt.Errorf("failed to save categorization rule: %w", err)
+	}
+
+	rule.SetID(entities.CategorizationRuleID(id))
+	return nil
+}
+
+func (r *CategorizationRuleRepositoryImpl) FindByID(householdID entities.HouseholdID, id entities.CategorizationRuleID) (*entities.CategorizationRule, error) {
+	query := `SELECT ` + categorizationRuleColumns + ` FROM categorization_rules WHERE id = $1 AND household_id = $2`
+
+	dbo, err := r.scan(r.db.QueryRow(query, int(id), int(householdID)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrCategorizationRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to find categorization rule: %w", err)
+	}
+
+	return dbo.ToDomainEntity()
+}
+
+func (r *CategorizationRuleRepositoryImpl) FindAll(householdID entities.HouseholdID) ([]*entities.CategorizationRule, error) {
+	query := `SELECT ` + categorizationRuleColumns + ` FROM categorization_rules WHERE household_id = $1 ORDER BY priority, id`
+
+	rows, err := r.db.Query(query, int(householdID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find categorization rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []*entities.CategorizationRule
+	for rows.Next() {
+		dbo, err := r.scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan categorization rule: %w", err)
+		}
+		rule, err := dbo.ToDomainEntity()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating categorization rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+func (r *CategorizationRuleRepositoryImpl) FindByName(householdID entities.HouseholdID, name string) (*entities.CategorizationRule, error) {
+	query := `SELECT ` + categorizationRuleColumns + ` FROM categorization_rules WHERE household_id = $1 AND name = $2`
+
+	dbo, err := r.scan(r.db.QueryRow(query, int(householdID), name))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrCategorizationRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to find categorization rule by name: %w", err)
+	}
+
+	return dbo.ToDomainEntity()
+}
+
+func (r *CategorizationRuleRepositoryImpl) Update(householdID entities.HouseholdID, rule *entities.CategorizationRule) error {
+	query := `
+		UPDATE categorization_rules
+		SET name = $2, priority = $3, active = $4, pattern = $5, vendor_type = $6, amount = $7, min_amount = $8, max_amount = $9,
+			currency = $10, vendor_id = $11, category = $12, tag_ids = $13, updated_at = $14
+		WHERE id = $1 AND household_id = $15
+	`
+
+	var dbo models.CategorizationRuleDBO
+	dbo.FromDomainEntity(rule)
+
+	result, err := r.db.Exec(
+		query,
+		dbo.ID,
+		dbo.Name,
+		dbo.Priority,
+		dbo.Active,
+		dbo.Pattern,
+		dbo.VendorType,
+		dbo.Amount,
+		dbo.MinAmount,
+		dbo.MaxAmount,
+		dbo.Currency,
+		dbo.VendorID,
+		dbo.Category,
+		pq.Array(dbo.TagIDs),
+		dbo.UpdatedAt,
+		int(householdID),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update categorization rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrCategorizationRuleNotFound
+	}
+
+	return nil
+}
+
+func (r *CategorizationRuleRepositoryImpl) Delete(householdID entities.HouseholdID, id entities.CategorizationRuleID) error {
+	query := `DELETE FROM categorization_rules WHERE id = $1 AND household_id = $2`
+
+	result, err := r.db.Exec(query, int(id), int(householdID))
+	if err != nil {
+		return fmt.Errorf("failed to delete categorization rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrCategorizationRuleNotFound
+	}
+
+	return nil
+}
+
+func (r *CategorizationRuleRepositoryImpl) scan(row interface{ Scan(...interface{}) error }) (*models.CategorizationRuleDBO, error) {
+	var dbo models.CategorizationRuleDBO
+	err := row.Scan(
+		&dbo.ID,
+		&dbo.Name,
+		&dbo.Priority,
+		&dbo.Active,
+		&dbo.Pattern,
+		&dbo.VendorType,
+		&dbo.Amount,
+		&dbo.MinAmount,
+		&dbo.MaxAmount,
+		&dbo.Currency,
+		&dbo.VendorID,
+		&dbo.Category,
+		pq.Array(&dbo.TagIDs),
+		&dbo.CreatedAt,
+		&dbo.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &dbo, nil
+}
@@ -10,17 +10,17 @@ import (
 )
 
 type CategoryRepositoryImpl struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewCategoryRepository(db *sql.DB) repositories.CategoryRepository {
+func NewCategoryRepository(db DBTX) repositories.CategoryRepository {
 	return &CategoryRepositoryImpl{db: db}
 }
 
-func (r *CategoryRepositoryImpl) Save(category *entities.CategoryEntity) error {
+func (r *CategoryRepositoryImpl) Save(householdID entities.HouseholdID, category *entities.CategoryEntity) error {
 	query := `
-		INSERT INTO categories (name, color, icon, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO categories (name, color, icon, created_at, updated_at, household_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING id
 	`
 
@@ -32,6 +32,7 @@ func (r *CategoryRepositoryImpl) Save(category *entities.CategoryEntity) error {
 		category.Icon(),
 		category.CreatedAt(),
 		category.UpdatedAt(),
+		int(householdID),
 	).Scan(&id)
 
 	if err != nil {
@@ -42,18 +43,18 @@ func (r *CategoryRepositoryImpl) Save(category *entities.CategoryEntity) error {
 	return nil
 }
 
-func (r *CategoryRepositoryImpl) FindByID(id entities.CategoryID) (*entities.CategoryEntity, error) {
+func (r *CategoryRepositoryImpl) FindByID(householdID entities.HouseholdID, id entities.CategoryID) (*entities.CategoryEntity, error) {
 	query := `
 		SELECT id, name, color, icon, created_at, updated_at
 		FROM categories
-		WHERE id = $1
+		WHERE id = $1 AND household_id = $2
 	`
 
 	var categoryID int
 	var name, color, icon string
 	var createdAt, updatedAt string
 
-	row := r.db.QueryRow(query, int(id))
+	row := r.db.QueryRow(query, int(id), int(householdID))
 	err := row.Scan(&categoryID, &name, &color, &icon, &createdAt, &updatedAt)
 
 	if err != nil {
@@ -84,18 +85,18 @@ func (r *CategoryRepositoryImpl) FindByID(id entities.CategoryID) (*entities.Cat
 	), nil
 }
 
-func (r *CategoryRepositoryImpl) FindByName(name string) (*entities.CategoryEntity, error) {
+func (r *CategoryRepositoryImpl) FindByName(householdID entities.HouseholdID, name string) (*entities.CategoryEntity, error) {
 	query := `
 		SELECT id, name, color, icon, created_at, updated_at
 		FROM categories
-		WHERE name = $1
+		WHERE household_id = $1 AND name = $2
 	`
 
 	var categoryID int
 	var categoryName, color, icon string
 	var createdAt, updatedAt string
 
-	row := r.db.QueryRow(query, name)
+	row := r.db.QueryRow(query, int(householdID), name)
 	err := row.Scan(&categoryID, &categoryName, &color, &icon, &createdAt, &updatedAt)
 
 	if err != nil {
@@ -126,14 +127,15 @@ func (r *CategoryRepositoryImpl) FindByName(name string) (*entities.CategoryEnti
 	), nil
 }
 
-func (r *CategoryRepositoryImpl) FindAll() ([]*entities.CategoryEntity, error) {
+func (r *CategoryRepositoryImpl) FindAll(householdID entities.HouseholdID) ([]*entities.CategoryEntity, error) {
 	query := `
 		SELECT id, name, color, icon, created_at, updated_at
 		FROM categories
+		WHERE household_id = $1
 		ORDER BY name ASC
 	`
 
-	rows, err := r.db.Query(query)
+	rows, err := r.db.Query(query, int(householdID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to find categories: %w", err)
 	}
@@ -176,11 +178,11 @@ func (r *CategoryRepositoryImpl) FindAll() ([]*entities.CategoryEntity, error) {
 	return categories, nil
 }
 
-func (r *CategoryRepositoryImpl) Update(category *entities.CategoryEntity) error {
+func (r *CategoryRepositoryImpl) Update(householdID entities.HouseholdID, category *entities.CategoryEntity) error {
 	query := `
 		UPDATE categories 
 		SET name = $2, color = $3, icon = $4, updated_at = $5
-		WHERE id = $1
+		WHERE id = $1 AND household_id = $6
 	`
 
 	result, err := r.db.Exec(
@@ -190,6 +192,7 @@ func (r *CategoryRepositoryImpl) Update(category *entities.CategoryEntity) error
 		category.Color(),
 		category.Icon(),
 		category.UpdatedAt(),
+		int(householdID),
 	)
 
 	if err != nil {
@@ -208,10 +211,10 @@ func (r *CategoryRepositoryImpl) Update(category *entities.CategoryEntity) error
 	return nil
 }
 
-func (r *CategoryRepositoryImpl) Delete(id entities.CategoryID) error {
-	query := `DELETE FROM categories WHERE id = $1`
+func (r *CategoryRepositoryImpl) Delete(householdID entities.HouseholdID, id entities.CategoryID) error {
+	query := `DELETE FROM categories WHERE id = $1 AND household_id = $2`
 
-	result, err := r.db.Exec(query, int(id))
+	result, err := r.db.Exec(query, int(id), int(householdID))
 	if err != nil {
 		return fmt.Errorf("failed to delete category: %w", err)
 	}
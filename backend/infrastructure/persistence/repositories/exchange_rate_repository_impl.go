@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/infrastructure/persistence/models"
+	"expenso-backend/usecases/interfaces/repositories"
+)
+
+type ExchangeRateRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewExchangeRateRepository(db *sql.DB) repositories.ExchangeRateRepository {
+	return &ExchangeRateRepositoryImpl{db: db}
+}
+
+func (r *ExchangeRateRepositoryImpl) SaveAll(rates []*entities.ExchangeRate) error {
+	query := `
+		INSERT INTO exchange_rates (base_currency, quote_currency, date, rate, source, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (base_currency, quote_currency, date)
+		DO UPDATE SET rate = EXCLUDED.rate, source = EXCLUDED.source
+		RETURNING id
+	`
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, rate := range rates {
+		var id int
+		err := tx.QueryRow(
+			query,
+			rate.BaseCurrency(),
+			rate.QuoteCurrency(),
+			rate.Date(),
+			rate.Rate(),
+			rate.Source(),
+			rate.CreatedAt(),
+		).Scan(&id)
+		if err != nil {
+			return fmt.Errorf("failed to save exchange rate: %w", err)
+		}
+		rate.SetID(entities.ExchangeRateID(id))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit exchange rates: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ExchangeRateRepositoryImpl) FindLatest(baseCurrency, quoteCurrency string, date time.Time) (*entities.ExchangeRate, error) {
+	query := `
+		SELECT id, base_currency, quote_currency, date, rate, source, created_at
+		FROM exchange_rates
+		WHERE base_currency = $1 AND quote_currency = $2 AND date <= $3
+		ORDER BY date DESC
+		LIMIT 1
+	`
+
+	var dbo models.ExchangeRateDBO
+	err := r.db.QueryRow(query, baseCurrency, quoteCurrency, date).Scan(
+		&dbo.ID, &dbo.BaseCurrency, &dbo.QuoteCurrency, &dbo.Date, &dbo.Rate, &dbo.Source, &dbo.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrExchangeRateNotFound
+		}
+		return nil, fmt.Errorf("failed to find exchange rate: %w", err)
+	}
+
+	return dbo.ToDomainEntity(), nil
+}
+
+func (r *ExchangeRateRepositoryImpl) FindByDate(date time.Time) ([]*entities.ExchangeRate, error) {
+	query := `
+		SELECT id, base_currency, quote_currency, date, rate, source, created_at
+		FROM exchange_rates
+		WHERE date = (SELECT MAX(date) FROM exchange_rates WHERE date <= $1)
+		ORDER BY base_currency, quote_currency
+	`
+
+	rows, err := r.db.Query(query, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find exchange rates: %w", err)
+	}
+	defer rows.Close()
+
+	var rates []*entities.ExchangeRate
+	for rows.Next() {
+		var dbo models.ExchangeRateDBO
+		err := rows.Scan(&dbo.ID, &dbo.BaseCurrency, &dbo.QuoteCurrency, &dbo.Date, &dbo.Rate, &dbo.Source, &dbo.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan exchange rate: %w", err)
+		}
+
+		rates = append(rates, dbo.ToDomainEntity())
+	}
+
+	return rates, rows.Err()
+}
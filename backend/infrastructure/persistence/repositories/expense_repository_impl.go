@@ -11,21 +11,21 @@ import (
 )
 
 type ExpenseRepositoryImpl struct {
-	db      *sql.DB
+	db      DBTX
 	tagRepo *TagRepository
 }
 
-func NewExpenseRepository(db *sql.DB, tagRepo *TagRepository) repositories.ExpenseRepository {
+func NewExpenseRepository(db DBTX, tagRepo *TagRepository) repositories.ExpenseRepository {
 	return &ExpenseRepositoryImpl{
 		db:      db,
 		tagRepo: tagRepo,
 	}
 }
 
-func (r *ExpenseRepositoryImpl) Save(expense *entities.Expense) error {
+func (r *ExpenseRepositoryImpl) Save(householdID entities.HouseholdID, expense *entities.Expense) error {
 	query := `
-		INSERT INTO expenses (amount, date, type, category, comment, vendor_id, paid_by_card, added_by, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO expenses (amount, currency, date, type, category, comment, vendor_id, paid_by_card, member_id, created_at, updated_at, household_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		RETURNING id
 	`
 
@@ -35,19 +35,27 @@ func (r *ExpenseRepositoryImpl) Save(expense *entities.Expense) error {
 		vendorID = &id
 	}
 
+	var memberID *int
+	if expense.Member() != nil {
+		id := int(expense.Member().ID())
+		memberID = &id
+	}
+
 	var id int
 	err := r.db.QueryRow(
 		query,
-		expense.Amount().Amount(),
+		expense.Amount().Decimal(),
+		expense.Amount().Currency(),
 		expense.Date(),
 		string(expense.Type()),
 		expense.Category().String(),
 		expense.Comment(),
 		vendorID,
 		expense.PaidByCard(),
-		expense.AddedBy().String(),
+		memberID,
 		expense.CreatedAt(),
 		expense.UpdatedAt(),
+		int(householdID),
 	).Scan(&id)
 
 	if err != nil {
@@ -58,13 +66,14 @@ func (r *ExpenseRepositoryImpl) Save(expense *entities.Expense) error {
 	return nil
 }
 
-func (r *ExpenseRepositoryImpl) FindByID(id entities.ExpenseID) (*entities.Expense, error) {
+func (r *ExpenseRepositoryImpl) FindByID(householdID entities.HouseholdID, id entities.ExpenseID) (*entities.Expense, error) {
 	query := `
-		SELECT e.id, e.amount, e.date, e.type, e.category, e.comment, e.vendor_id, e.paid_by_card, e.added_by, e.created_at, e.updated_at,
+		SELECT e.id, e.amount, e.currency, e.date, e.type, e.category, e.comment, e.vendor_id, e.paid_by_card, e.member_id, m.name, e.created_at, e.updated_at,
 		       v.id, v.name, v.type, v.created_at, v.updated_at
 		FROM expenses e
 		LEFT JOIN vendors v ON e.vendor_id = v.id
-		WHERE e.id = $1
+		LEFT JOIN members m ON e.member_id = m.id
+		WHERE e.id = $1 AND e.household_id = $2
 	`
 
 	var dbo models.ExpenseDBO
@@ -73,9 +82,9 @@ func (r *ExpenseRepositoryImpl) FindByID(id entities.ExpenseID) (*entities.Expen
 	var vName, vType *string
 	var vCreatedAt, vUpdatedAt *string
 
-	row := r.db.QueryRow(query, int(id))
+	row := r.db.QueryRow(query, int(id), int(householdID))
 	err := row.Scan(
-		&dbo.ID, &dbo.Amount, &dbo.Date, &dbo.Type, &dbo.Category, &dbo.Comment, &dbo.VendorID, &dbo.PaidByCard, &dbo.AddedBy, &dbo.CreatedAt, &dbo.UpdatedAt,
+		&dbo.ID, &dbo.Amount, &dbo.Currency, &dbo.Date, &dbo.Type, &dbo.Category, &dbo.Comment, &dbo.VendorID, &dbo.PaidByCard, &dbo.MemberID, &dbo.MemberName, &dbo.CreatedAt, &dbo.UpdatedAt,
 		&vID, &vName, &vType, &vCreatedAt, &vUpdatedAt,
 	)
 
@@ -106,16 +115,18 @@ func (r *ExpenseRepositoryImpl) FindByID(id entities.ExpenseID) (*entities.Expen
 	return expense, nil
 }
 
-func (r *ExpenseRepositoryImpl) FindAll() ([]*entities.Expense, error) {
+func (r *ExpenseRepositoryImpl) FindAll(householdID entities.HouseholdID) ([]*entities.Expense, error) {
 	query := `
-		SELECT e.id, e.amount, e.date, e.type, e.category, e.comment, e.vendor_id, e.paid_by_card, e.added_by, e.created_at, e.updated_at,
+		SELECT e.id, e.amount, e.currency, e.date, e.type, e.category, e.comment, e.vendor_id, e.paid_by_card, e.member_id, m.name, e.created_at, e.updated_at,
 		       v.id, v.name, v.type, v.created_at, v.updated_at
 		FROM expenses e
 		LEFT JOIN vendors v ON e.vendor_id = v.id
+		LEFT JOIN members m ON e.member_id = m.id
+		WHERE e.household_id = $1
 		ORDER BY e.date DESC
 	`
 
-	rows, err := r.db.Query(query)
+	rows, err := r.db.Query(query, int(householdID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to find expenses: %w", err)
 	}
@@ -129,7 +140,7 @@ func (r *ExpenseRepositoryImpl) FindAll() ([]*entities.Expense, error) {
 		var vCreatedAt, vUpdatedAt *string
 
 		err := rows.Scan(
-			&dbo.ID, &dbo.Amount, &dbo.Date, &dbo.Type, &dbo.Category, &dbo.Comment, &dbo.VendorID, &dbo.PaidByCard, &dbo.AddedBy, &dbo.CreatedAt, &dbo.UpdatedAt,
+			&dbo.ID, &dbo.Amount, &dbo.Currency, &dbo.Date, &dbo.Type, &dbo.Category, &dbo.Comment, &dbo.VendorID, &dbo.PaidByCard, &dbo.MemberID, &dbo.MemberName, &dbo.CreatedAt, &dbo.UpdatedAt,
 			&vID, &vName, &vType, &vCreatedAt, &vUpdatedAt,
 		)
 		if err != nil {
@@ -167,11 +178,11 @@ func (r *ExpenseRepositoryImpl) FindAll() ([]*entities.Expense, error) {
 	return expenses, nil
 }
 
-func (r *ExpenseRepositoryImpl) Update(expense *entities.Expense) error {
+func (r *ExpenseRepositoryImpl) Update(householdID entities.HouseholdID, expense *entities.Expense) error {
 	query := `
 		UPDATE expenses 
-		SET amount = $2, date = $3, type = $4, category = $5, comment = $6, vendor_id = $7, updated_at = $8
-		WHERE id = $1
+		SET amount = $2, currency = $3, date = $4, type = $5, category = $6, comment = $7, vendor_id = $8, member_id = $9, updated_at = $10
+		WHERE id = $1 AND household_id = $11
 	`
 
 	var vendorID *int
@@ -180,16 +191,25 @@ func (r *ExpenseRepositoryImpl) Update(expense *entities.Expense) error {
 		vendorID = &id
 	}
 
+	var memberID *int
+	if expense.Member() != nil {
+		id := int(expense.Member().ID())
+		memberID = &id
+	}
+
 	result, err := r.db.Exec(
 		query,
 		int(expense.ID()),
-		expense.Amount().Amount(),
+		expense.Amount().Decimal(),
+		expense.Amount().Currency(),
 		expense.Date(),
 		string(expense.Type()),
 		expense.Category().String(),
 		expense.Comment(),
 		vendorID,
+		memberID,
 		expense.UpdatedAt(),
+		int(householdID),
 	)
 
 	if err != nil {
@@ -208,10 +228,10 @@ func (r *ExpenseRepositoryImpl) Update(expense *entities.Expense) error {
 	return nil
 }
 
-func (r *ExpenseRepositoryImpl) Delete(id entities.ExpenseID) error {
-	query := `DELETE FROM expenses WHERE id = $1`
+func (r *ExpenseRepositoryImpl) Delete(householdID entities.HouseholdID, id entities.ExpenseID) error {
+	query := `DELETE FROM expenses WHERE id = $1 AND household_id = $2`
 
-	result, err := r.db.Exec(query, int(id))
+	result, err := r.db.Exec(query, int(id), int(householdID))
 	if err != nil {
 		return fmt.Errorf("failed to delete expense: %w", err)
 	}
@@ -228,17 +248,18 @@ func (r *ExpenseRepositoryImpl) Delete(id entities.ExpenseID) error {
 	return nil
 }
 
-func (r *ExpenseRepositoryImpl) FindByCategory(category entities.Category) ([]*entities.Expense, error) {
+func (r *ExpenseRepositoryImpl) FindByCategory(householdID entities.HouseholdID, category entities.Category) ([]*entities.Expense, error) {
 	query := `
-		SELECT e.id, e.amount, e.date, e.type, e.category, e.comment, e.vendor_id, e.paid_by_card, e.added_by, e.created_at, e.updated_at,
+		SELECT e.id, e.amount, e.currency, e.date, e.type, e.category, e.comment, e.vendor_id, e.paid_by_card, e.member_id, m.name, e.created_at, e.updated_at,
 		       v.id, v.name, v.type, v.created_at, v.updated_at
 		FROM expenses e
 		LEFT JOIN vendors v ON e.vendor_id = v.id
-		WHERE e.category = $1
+		LEFT JOIN members m ON e.member_id = m.id
+		WHERE e.household_id = $1 AND e.category = $2
 		ORDER BY e.amount DESC
 	`
 
-	rows, err := r.db.Query(query, category.String())
+	rows, err := r.db.Query(query, int(householdID), category.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to find expenses by category: %w", err)
 	}
@@ -252,7 +273,7 @@ func (r *ExpenseRepositoryImpl) FindByCategory(category entities.Category) ([]*e
 		var vCreatedAt, vUpdatedAt *string
 
 		err := rows.Scan(
-			&dbo.ID, &dbo.Amount, &dbo.Date, &dbo.Type, &dbo.Category, &dbo.Comment, &dbo.VendorID, &dbo.PaidByCard, &dbo.AddedBy, &dbo.CreatedAt, &dbo.UpdatedAt,
+			&dbo.ID, &dbo.Amount, &dbo.Currency, &dbo.Date, &dbo.Type, &dbo.Category, &dbo.Comment, &dbo.VendorID, &dbo.PaidByCard, &dbo.MemberID, &dbo.MemberName, &dbo.CreatedAt, &dbo.UpdatedAt,
 			&vID, &vName, &vType, &vCreatedAt, &vUpdatedAt,
 		)
 		if err != nil {
@@ -290,28 +311,29 @@ func (r *ExpenseRepositoryImpl) FindByCategory(category entities.Category) ([]*e
 	return expenses, nil
 }
 
-func (r *ExpenseRepositoryImpl) FindByCategoryAndDateRange(category entities.Category, startDate, endDate *time.Time) ([]*entities.Expense, error) {
+func (r *ExpenseRepositoryImpl) FindByCategoryAndDateRange(householdID entities.HouseholdID, category entities.Category, startDate, endDate *time.Time) ([]*entities.Expense, error) {
 	baseQuery := `
-		SELECT e.id, e.amount, e.date, e.type, e.category, e.comment, e.vendor_id, e.paid_by_card, e.added_by, e.created_at, e.updated_at,
+		SELECT e.id, e.amount, e.currency, e.date, e.type, e.category, e.comment, e.vendor_id, e.paid_by_card, e.member_id, m.name, e.created_at, e.updated_at,
 		       v.id, v.name, v.type, v.created_at, v.updated_at
 		FROM expenses e
 		LEFT JOIN vendors v ON e.vendor_id = v.id
-		WHERE e.category = $1
+		LEFT JOIN members m ON e.member_id = m.id
+		WHERE e.household_id = $1 AND e.category = $2
 	`
 
 	var query string
 	var args []interface{}
-	args = append(args, category.String())
+	args = append(args, int(householdID), category.String())
 
 	// Build additional WHERE clauses based on provided date range
 	if startDate != nil && endDate != nil {
-		query = baseQuery + " AND e.date >= $2 AND e.date <= $3 ORDER BY e.amount DESC"
+		query = baseQuery + " AND e.date >= $3 AND e.date <= $4 ORDER BY e.amount DESC"
 		args = append(args, *startDate, *endDate)
 	} else if startDate != nil {
-		query = baseQuery + " AND e.date >= $2 ORDER BY e.amount DESC"
+		query = baseQuery + " AND e.date >= $3 ORDER BY e.amount DESC"
 		args = append(args, *startDate)
 	} else if endDate != nil {
-		query = baseQuery + " AND e.date <= $2 ORDER BY e.amount DESC"
+		query = baseQuery + " AND e.date <= $3 ORDER BY e.amount DESC"
 		args = append(args, *endDate)
 	} else {
 		// No date filter, just category filter
@@ -332,7 +354,7 @@ func (r *ExpenseRepositoryImpl) FindByCategoryAndDateRange(category entities.Cat
 		var vCreatedAt, vUpdatedAt *string
 
 		err := rows.Scan(
-			&dbo.ID, &dbo.Amount, &dbo.Date, &dbo.Type, &dbo.Category, &dbo.Comment, &dbo.VendorID, &dbo.PaidByCard, &dbo.AddedBy, &dbo.CreatedAt, &dbo.UpdatedAt,
+			&dbo.ID, &dbo.Amount, &dbo.Currency, &dbo.Date, &dbo.Type, &dbo.Category, &dbo.Comment, &dbo.VendorID, &dbo.PaidByCard, &dbo.MemberID, &dbo.MemberName, &dbo.CreatedAt, &dbo.UpdatedAt,
 			&vID, &vName, &vType, &vCreatedAt, &vUpdatedAt,
 		)
 		if err != nil {
@@ -370,17 +392,18 @@ func (r *ExpenseRepositoryImpl) FindByCategoryAndDateRange(category entities.Cat
 	return expenses, nil
 }
 
-func (r *ExpenseRepositoryImpl) FindByVendor(vendorID entities.VendorID) ([]*entities.Expense, error) {
+func (r *ExpenseRepositoryImpl) FindByVendor(householdID entities.HouseholdID, vendorID entities.VendorID) ([]*entities.Expense, error) {
 	query := `
-		SELECT e.id, e.amount, e.date, e.type, e.category, e.comment, e.vendor_id, e.paid_by_card, e.added_by, e.created_at, e.updated_at,
+		SELECT e.id, e.amount, e.currency, e.date, e.type, e.category, e.comment, e.vendor_id, e.paid_by_card, e.member_id, m.name, e.created_at, e.updated_at,
 		       v.id, v.name, v.type, v.created_at, v.updated_at
 		FROM expenses e
 		LEFT JOIN vendors v ON e.vendor_id = v.id
-		WHERE e.vendor_id = $1
+		LEFT JOIN members m ON e.member_id = m.id
+		WHERE e.household_id = $1 AND e.vendor_id = $2
 		ORDER BY e.date DESC
 	`
 
-	rows, err := r.db.Query(query, int(vendorID))
+	rows, err := r.db.Query(query, int(householdID), int(vendorID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to find expenses by vendor: %w", err)
 	}
@@ -394,7 +417,7 @@ func (r *ExpenseRepositoryImpl) FindByVendor(vendorID entities.VendorID) ([]*ent
 		var vCreatedAt, vUpdatedAt *string
 
 		err := rows.Scan(
-			&dbo.ID, &dbo.Amount, &dbo.Date, &dbo.Type, &dbo.Category, &dbo.Comment, &dbo.VendorID, &dbo.PaidByCard, &dbo.AddedBy, &dbo.CreatedAt, &dbo.UpdatedAt,
+			&dbo.ID, &dbo.Amount, &dbo.Currency, &dbo.Date, &dbo.Type, &dbo.Category, &dbo.Comment, &dbo.VendorID, &dbo.PaidByCard, &dbo.MemberID, &dbo.MemberName, &dbo.CreatedAt, &dbo.UpdatedAt,
 			&vID, &vName, &vType, &vCreatedAt, &vUpdatedAt,
 		)
 		if err != nil {
@@ -432,27 +455,29 @@ func (r *ExpenseRepositoryImpl) FindByVendor(vendorID entities.VendorID) ([]*ent
 	return expenses, nil
 }
 
-func (r *ExpenseRepositoryImpl) FindByDateRange(startDate, endDate *time.Time) ([]*entities.Expense, error) {
+func (r *ExpenseRepositoryImpl) FindByDateRange(householdID entities.HouseholdID, startDate, endDate *time.Time) ([]*entities.Expense, error) {
 	baseQuery := `
-		SELECT e.id, e.amount, e.date, e.type, e.category, e.comment, e.vendor_id, e.paid_by_card, e.added_by, e.created_at, e.updated_at,
+		SELECT e.id, e.amount, e.currency, e.date, e.type, e.category, e.comment, e.vendor_id, e.paid_by_card, e.member_id, m.name, e.created_at, e.updated_at,
 		       v.id, v.name, v.type, v.created_at, v.updated_at
 		FROM expenses e
 		LEFT JOIN vendors v ON e.vendor_id = v.id
+		LEFT JOIN members m ON e.member_id = m.id
+		WHERE e.household_id = $1
 	`
 
 	var query string
-	var args []interface{}
+	args := []interface{}{int(householdID)}
 
-	// Build WHERE clause based on provided date range
+	// Build additional WHERE clauses based on provided date range
 	if startDate != nil && endDate != nil {
-		query = baseQuery + " WHERE e.date >= $1 AND e.date <= $2 ORDER BY e.date DESC"
-		args = []interface{}{*startDate, *endDate}
+		query = baseQuery + " AND e.date >= $2 AND e.date <= $3 ORDER BY e.date DESC"
+		args = append(args, *startDate, *endDate)
 	} else if startDate != nil {
-		query = baseQuery + " WHERE e.date >= $1 ORDER BY e.date DESC"
-		args = []interface{}{*startDate}
+		query = baseQuery + " AND e.date >= $2 ORDER BY e.date DESC"
+		args = append(args, *startDate)
 	} else if endDate != nil {
-		query = baseQuery + " WHERE e.date <= $1 ORDER BY e.date DESC"
-		args = []interface{}{*endDate}
+		query = baseQuery + " AND e.date <= $2 ORDER BY e.date DESC"
+		args = append(args, *endDate)
 	} else {
 		// No date filter, return all expenses
 		query = baseQuery + " ORDER BY e.date DESC"
@@ -472,7 +497,7 @@ func (r *ExpenseRepositoryImpl) FindByDateRange(startDate, endDate *time.Time) (
 		var vCreatedAt, vUpdatedAt *string
 
 		err := rows.Scan(
-			&dbo.ID, &dbo.Amount, &dbo.Date, &dbo.Type, &dbo.Category, &dbo.Comment, &dbo.VendorID, &dbo.PaidByCard, &dbo.AddedBy, &dbo.CreatedAt, &dbo.UpdatedAt,
+			&dbo.ID, &dbo.Amount, &dbo.Currency, &dbo.Date, &dbo.Type, &dbo.Category, &dbo.Comment, &dbo.VendorID, &dbo.PaidByCard, &dbo.MemberID, &dbo.MemberName, &dbo.CreatedAt, &dbo.UpdatedAt,
 			&vID, &vName, &vType, &vCreatedAt, &vUpdatedAt,
 		)
 		if err != nil {
@@ -509,3 +534,161 @@ func (r *ExpenseRepositoryImpl) FindByDateRange(startDate, endDate *time.Time) (
 
 	return expenses, nil
 }
+
+// expenseSortColumns are the expressions FindPage orders by
+var expenseSortColumns = map[repositories.SortField]string{
+	repositories.SortByDate:      "e.date",
+	repositories.SortByAmount:    "e.amount",
+	repositories.SortByCreatedAt: "e.created_at",
+	repositories.SortByVendor:    "COALESCE(v.name, '')",
+}
+
+func (r *ExpenseRepositoryImpl) FindPage(householdID entities.HouseholdID, filter repositories.ExpenseFilter, page repositories.PageRequest) (*repositories.ExpensePage, error) {
+	sortExpr, ok := expenseSortColumns[page.Sort]
+	if !ok {
+		return nil, fmt.Errorf("cannot sort expenses by %q", page.Sort)
+	}
+
+	var q listQuery
+	q.where("e.household_id = ?", int(householdID))
+	if filter.StartDate != nil {
+		q.where("e.date >= ?", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		q.where("e.date <= ?", *filter.EndDate)
+	}
+	if filter.Category != "" {
+		q.where("LOWER(e.category) = LOWER(?)", filter.Category)
+	}
+	if filter.VendorID != nil {
+		q.where("e.vendor_id = ?", int(*filter.VendorID))
+	}
+	if filter.VendorType != "" {
+		q.where("v.type = ?", string(filter.VendorType))
+	}
+	if filter.TagID != nil {
+		q.where("EXISTS (SELECT 1 FROM expense_tags et WHERE et.expense_id = e.id AND et.tag_id = ?)", int(*filter.TagID))
+	}
+	if filter.PaidByCard != nil {
+		q.where("e.paid_by_card = ?", *filter.PaidByCard)
+	}
+	if filter.MemberID != nil {
+		q.where("e.member_id = ?", int(*filter.MemberID))
+	}
+	if filter.Currency != "" {
+		q.where("e.currency = ?", filter.Currency)
+	}
+	if filter.MinAmount != nil {
+		q.where("e.amount >= ?", filter.MinAmount.Decimal())
+	}
+	if filter.MaxAmount != nil {
+		q.where("e.amount <= ?", filter.MaxAmount.Decimal())
+	}
+
+	from := `expenses e
+		LEFT JOIN vendors v ON e.vendor_id = v.id
+		LEFT JOIN members m ON e.member_id = m.id`
+
+	total, err := q.count(r.db, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count expenses: %w", err)
+	}
+
+	order := q.paginate(page, sortExpr, "e.id")
+	query := `
+		SELECT e.id, e.amount, e.currency, e.date, e.type, e.category, e.comment, e.vendor_id, e.paid_by_card, e.member_id, m.name, e.created_at, e.updated_at,
+		       v.id, v.name, v.type, v.created_at, v.updated_at
+		FROM ` + from + q.whereClause() + order
+
+	rows, err := r.db.Query(query, q.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expenses: %w", err)
+	}
+	defer rows.Close()
+
+	var expenses []*entities.Expense
+	var cursors []repositories.Cursor
+	for rows.Next() {
+		var dbo models.ExpenseDBO
+		var vID *int
+		var vName, vType *string
+		var vCreatedAt, vUpdatedAt *string
+
+		err := rows.Scan(
+			&dbo.ID, &dbo.Amount, &dbo.Currency, &dbo.Date, &dbo.Type, &dbo.Category, &dbo.Comment, &dbo.VendorID, &dbo.PaidByCard, &dbo.MemberID, &dbo.MemberName, &dbo.CreatedAt, &dbo.UpdatedAt,
+			&vID, &vName, &vType, &vCreatedAt, &vUpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan expense: %w", err)
+		}
+
+		expense, err := dbo.ToDomainEntity()
+		if err != nil {
+			return nil, err
+		}
+
+		if vID != nil && vName != nil && vType != nil {
+			vendorDBO := &models.VendorDBO{
+				ID:   *vID,
+				Name: *vName,
+				Type: *vType,
+			}
+			expense.AssignVendor(vendorDBO.ToDomainEntity())
+		}
+
+		// The cursor keeps the value as stored, so the next page compares against exactly what the database has
+		cursor := repositories.Cursor{ID: dbo.ID}
+		switch page.Sort {
+		case repositories.SortByDate:
+			cursor.Value = dbo.Date.Format("2006-01-02")
+		case repositories.SortByAmount:
+			cursor.Value = dbo.Amount
+		case repositories.SortByCreatedAt:
+			cursor.Value = dbo.CreatedAt.Format(time.RFC3339Nano)
+		case repositories.SortByVendor:
+			if vName != nil {
+				cursor.Value = *vName
+			}
+		}
+
+		expenses = append(expenses, expense)
+		cursors = append(cursors, cursor)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find expenses: %w", err)
+	}
+
+	next, keep := nextCursor(page, cursors)
+	expenses = expenses[:keep]
+
+	// Tags for the whole page at once, after the rows are closed so this also works inside a transaction
+	rows.Close()
+	if r.tagRepo != nil && len(expenses) > 0 {
+		ids := make([]int, len(expenses))
+		for i, expense := range expenses {
+			ids[i] = int(expense.ID())
+		}
+		tags, err := r.tagRepo.tagsByExpenseIDs(ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load expense tags: %w", err)
+		}
+		for _, expense := range expenses {
+			if len(tags[expense.ID()]) > 0 {
+				expense.SetTags(tags[expense.ID()])
+			}
+		}
+	}
+
+	return &repositories.ExpensePage{
+		Expenses: expenses,
+		PageInfo: repositories.PageInfo{Total: total, NextCursor: next},
+	}, nil
+}
+
+func (r *ExpenseRepositoryImpl) Aggregate(householdID entities.HouseholdID, query repositories.AggregateQuery) ([]repositories.AggregateRow, error) {
+	rows, err := aggregate(r.db, expenseTable, householdID, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate expenses: %w", err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,259 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/infrastructure/persistence/models"
+	"expenso-backend/usecases/interfaces/repositories"
+)
+
+// defaultCategories and defaultTags are copied into every new household
+var defaultCategories = []struct{ name, color, icon string }{
+	{"Food & Dining", "#FF6B6B", "🍽️"},
+	{"Transportation", "#4ECDC4", "🚗"},
+	{"Shopping", "#45B7D1", "🛍️"},
+	{"Entertainment", "#FFA07A", "🎬"},
+	{"Bills & Utilities", "#98D8C8", "💡"},
+	{"Health & Fitness", "#F9E79F", "🏥"},
+	{"Travel", "#DDA0DD", "✈️"},
+	{"Education", "#87CEEB", "📚"},
+	{"Gifts & Donations", "#F0E68C", "🎁"},
+	{"Car", "#FF4444", "🚗"},
+	{"Living", "#8FBC8F", "🏠"},
+	{"Other", "#D3D3D3", "📋"},
+}
+
+var defaultTags = []struct{ name, color string }{
+	{"tax_declaration", "#FF6B35"},
+	{"family_expense", "#4ECDC4"},
+	{"subscription", "#45B7D1"},
+	{"sport", "#96CEB4"},
+	{"health", "#FFEAA7"},
+	{"car", "#DDA0DD"},
+	{"insurance", "#98D8C8"},
+}
+
+type HouseholdRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewHouseholdRepository(db *sql.DB) repositories.HouseholdRepository {
+	return &HouseholdRepositoryImpl{db: db}
+}
+
+func (r *HouseholdRepositoryImpl) Create(household *entities.Household, owner entities.UserID) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id int
+	err = tx.QueryRow(
+		`INSERT INTO households (name, created_at, updated_at) VALUES ($1, $2, $3) RETURNING id`,
+		household.Name(),
+		household.CreatedAt(),
+		household.UpdatedAt(),
+	).Scan(&id)
+	if err != nil {
+		return fmt.Errorf("failed to save household: %w", err)
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO household_users (household_id, user_id, role, created_at) VALUES ($1, $2, $3, $4)`,
+		id, int(owner), string(entities.HouseholdRoleOwner), household.CreatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add household owner: %w", err)
+	}
+
+	for _, category := range defaultCategories {
+		_, err = tx.Exec(
+			`INSERT INTO categories (name, color, icon, household_id) VALUES ($1, $2, $3, $4)`,
+			category.name, category.color, category.icon, id,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to seed categories: %w", err)
+		}
+	}
+
+	for _, tag := range defaultTags {
+		_, err = tx.Exec(
+			`INSERT INTO tags (name, color, household_id) VALUES ($1, $2, $3)`,
+			tag.name, tag.color, id,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to seed tags: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit household: %w", err)
+	}
+
+	household.SetID(entities.HouseholdID(id))
+	return nil
+}
+
+func (r *HouseholdRepositoryImpl) FindByID(id entities.HouseholdID) (*entities.Household, error) {
+	query := `SELECT id, name, created_at, updated_at FROM households WHERE id = $1`
+
+	var dbo models.HouseholdDBO
+	row := r.db.QueryRow(query, int(id))
+	err := row.Scan(&dbo.ID, &dbo.Name, &dbo.CreatedAt, &dbo.UpdatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrHouseholdNotFound
+		}
+		return nil, fmt.Errorf("failed to find household: %w", err)
+	}
+
+	return dbo.ToDomainEntity(), nil
+}
+
+func (r *HouseholdRepositoryImpl) FindUnclaimed() (*entities.Household, error) {
+	query := `
+		SELECT h.id, h.name, h.created_at, h.updated_at
+		FROM households h
+		WHERE NOT EXISTS (SELECT 1 FROM household_users hu WHERE hu.household_id = h.id)
+		ORDER BY h.id ASC
+		LIMIT 1
+	`
+
+	var dbo models.HouseholdDBO
+	err := r.db.QueryRow(query).Scan(&dbo.ID, &dbo.Name, &dbo.CreatedAt, &dbo.UpdatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrHouseholdNotFound
+		}
+		return nil, fmt.Errorf("failed to find unclaimed household: %w", err)
+	}
+
+	return dbo.ToDomainEntity(), nil
+}
+
+func (r *HouseholdRepositoryImpl) FindByUser(userID entities.UserID) ([]*entities.HouseholdMembership, error) {
+	query := `
+		SELECT h.id, h.name, h.created_at, h.updated_at, hu.role, hu.created_at
+		FROM household_users hu
+		JOIN households h ON hu.household_id = h.id
+		WHERE hu.user_id = $1
+		ORDER BY h.id ASC
+	`
+
+	rows, err := r.db.Query(query, int(userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find households: %w", err)
+	}
+	defer rows.Close()
+
+	var memberships []*entities.HouseholdMembership
+	for rows.Next() {
+		var dbo models.HouseholdDBO
+		var role string
+		var joinedAt time.Time
+		if err := rows.Scan(&dbo.ID, &dbo.Name, &dbo.CreatedAt, &dbo.UpdatedAt, &role, &joinedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan household: %w", err)
+		}
+		memberships = append(memberships, entities.ReconstructHouseholdMembership(
+			dbo.ToDomainEntity(), nil, entities.HouseholdRole(role), joinedAt,
+		))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating households: %w", err)
+	}
+
+	return memberships, nil
+}
+
+func (r *HouseholdRepositoryImpl) FindUsers(id entities.HouseholdID) ([]*entities.HouseholdMembership, error) {
+	query := `
+		SELECT u.id, u.email, u.name, u.password_hash, u.created_at, u.updated_at, hu.role, hu.created_at
+		FROM household_users hu
+		JOIN users u ON hu.user_id = u.id
+		WHERE hu.household_id = $1
+		ORDER BY u.name ASC
+	`
+
+	rows, err := r.db.Query(query, int(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find household users: %w", err)
+	}
+	defer rows.Close()
+
+	var memberships []*entities.HouseholdMembership
+	for rows.Next() {
+		var dbo models.UserDBO
+		var role string
+		var joinedAt time.Time
+		if err := rows.Scan(&dbo.ID, &dbo.Email, &dbo.Name, &dbo.PasswordHash, &dbo.CreatedAt, &dbo.UpdatedAt, &role, &joinedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan household user: %w", err)
+		}
+		memberships = append(memberships, entities.ReconstructHouseholdMembership(
+			nil, dbo.ToDomainEntity(), entities.HouseholdRole(role), joinedAt,
+		))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating household users: %w", err)
+	}
+
+	return memberships, nil
+}
+
+func (r *HouseholdRepositoryImpl) FindRole(id entities.HouseholdID, userID entities.UserID) (entities.HouseholdRole, error) {
+	query := `SELECT role FROM household_users WHERE household_id = $1 AND user_id = $2`
+
+	var role string
+	err := r.db.QueryRow(query, int(id), int(userID)).Scan(&role)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", entities.ErrNotHouseholdMember
+		}
+		return "", fmt.Errorf("failed to find household role: %w", err)
+	}
+
+	return entities.HouseholdRole(role), nil
+}
+
+func (r *HouseholdRepositoryImpl) AddUser(id entities.HouseholdID, userID entities.UserID, role entities.HouseholdRole) error {
+	query := `
+		INSERT INTO household_users (household_id, user_id, role, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (household_id, user_id) DO UPDATE SET role = EXCLUDED.role
+	`
+
+	_, err := r.db.Exec(query, int(id), int(userID), string(role), time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to add household user: %w", err)
+	}
+
+	return nil
+}
+
+func (r *HouseholdRepositoryImpl) RemoveUser(id entities.HouseholdID, userID entities.UserID) error {
+	query := `DELETE FROM household_users WHERE household_id = $1 AND user_id = $2`
+
+	result, err := r.db.Exec(query, int(id), int(userID))
+	if err != nil {
+		return fmt.Errorf("failed to remove household user: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrNotHouseholdMember
+	}
+
+	return nil
+}
@@ -0,0 +1,267 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/infrastructure/persistence/models"
+	"expenso-backend/usecases/interfaces/repositories"
+
+	"github.com/lib/pq"
+)
+
+type ImportBatchRepositoryImpl struct {
+	db DBTX
+}
+
+func NewImportBatchRepository(db DBTX) repositories.ImportBatchRepository {
+	return &ImportBatchRepositoryImpl{db: db}
+}
+
+const importBatchColumns = `id, source, file_hash, expense_count, income_count, created_at, rolled_back_at`
+
+func (r *ImportBatchRepositoryImpl) Save(householdID entities.HouseholdID, batch *entities.ImportBatch) error {
+	batchQuery := `
+		INSERT INTO import_batches (source, file_hash, expense_count, income_count, created_at, rolled_back_at, household_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+	itemQuery := `INSERT INTO import_batch_items (batch_id, fingerprint, expense_id, income_id) VALUES ($1, $2, $3, $4)`
+
+	var dbo models.ImportBatchDBO
+	dbo.FromDomainEntity(batch)
+
+	var id int
+	err := inTransaction(r.db, func(tx DBTX) error {
+		err := tx.QueryRow(
+			batchQuery,
+			dbo.Source,
+			dbo.FileHash,
+			dbo.ExpenseCount,
+			dbo.IncomeCount,
+			dbo.CreatedAt,
+			dbo.RolledBackAt,
+			int(householdID),
+		).Scan(&id)
+		if err != nil {
+			return fmt.Errorf("failed to save import batch: %w", err)
+		}
+
+		for _, item := range batch.Items() {
+			var itemDBO models.ImportBatchItemDBO
+			itemDBO.FromDomainEntity(item)
+			if _, err := tx.Exec(itemQuery, id, itemDBO.Fingerprint, itemDBO.ExpenseID, itemDBO.IncomeID); err != nil {
+				return fmt.Errorf("failed to save import batch item: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	batch.SetID(entities.ImportBatchID(id))
+	return nil
+}
+
+func (r *ImportBatchRepositoryImpl) AddItem(householdID entities.HouseholdID, id entities.ImportBatchID, item entities.ImportBatchItem) error {
+	countQuery := `
+		UPDATE import_batches
+		SET expense_count = expense_count + $3, income_count = income_count + $4
+		WHERE id = $1 AND household_id = $2
+	`
+	itemQuery := `INSERT INTO import_batch_items (batch_id, fingerprint, expense_id, income_id) VALUES ($1, $2, $3, $4)`
+
+	var expenseCount, incomeCount int
+	if item.ExpenseID != nil {
+		expenseCount = 1
+	}
+	if item.IncomeID != nil {
+		incomeCount = 1
+	}
+
+	var itemDBO models.ImportBatchItemDBO
+	itemDBO.FromDomainEntity(item)
+
+	return inTransaction(r.db, func(tx DBTX) error {
+		result, err := tx.Exec(countQuery, int(id), int(householdID), expenseCount, incomeCount)
+		if err != nil {
+			return fmt.Errorf("failed to update import batch: %w", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %w", err)
+		}
+		if rowsAffected == 0 {
+			return entities.ErrImportBatchNotFound
+		}
+
+		if _, err := tx.Exec(itemQuery, int(id), itemDBO.Fingerprint, itemDBO.ExpenseID, itemDBO.IncomeID); err != nil {
+			return fmt.Errorf("failed to save import batch item: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *ImportBatchRepositoryImpl) FindByID(householdID entities.HouseholdID, id entities.ImportBatchID) (*entities.ImportBatch, error) {
+	query := `SELECT ` + importBatchColumns + ` FROM import_batches WHERE id = $1 AND household_id = $2`
+
+	dbo, err := r.scan(r.db.QueryRow(query, int(id), int(householdID)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrImportBatchNotFound
+		}
+		return nil, fmt.Errorf("failed to find import batch: %w", err)
+	}
+
+	items, err := r.findItems(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbo.ToDomainEntity(items), nil
+}
+
+func (r *ImportBatchRepositoryImpl) FindAll(householdID entities.HouseholdID) ([]*entities.ImportBatch, error) {
+	query := `SELECT ` + importBatchColumns + ` FROM import_batches WHERE household_id = $1 ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.Query(query, int(householdID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find import batches: %w", err)
+	}
+	defer rows.Close()
+
+	var batches []*entities.ImportBatch
+	for rows.Next() {
+		dbo, err := r.scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan import batch: %w", err)
+		}
+		batches = append(batches, dbo.ToDomainEntity(nil))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating import batches: %w", err)
+	}
+
+	return batches, nil
+}
+
+func (r *ImportBatchRepositoryImpl) FindByFileHash(householdID entities.HouseholdID, fileHash string) (*entities.ImportBatch, error) {
+	query := `
+		SELECT ` + importBatchColumns + ` FROM import_batches
+		WHERE household_id = $1 AND file_hash = $2 AND rolled_back_at IS NULL
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`
+
+	dbo, err := r.scan(r.db.QueryRow(query, int(householdID), fileHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrImportBatchNotFound
+		}
+		return nil, fmt.Errorf("failed to find import batch by file hash: %w", err)
+	}
+
+	return dbo.ToDomainEntity(nil), nil
+}
+
+func (r *ImportBatchRepositoryImpl) FindImported(householdID entities.HouseholdID, fingerprints []string) (map[string]entities.ImportBatchID, error) {
+	imported := make(map[string]entities.ImportBatchID)
+	if len(fingerprints) == 0 {
+		return imported, nil
+	}
+
+	query := `
+		SELECT i.fingerprint, MIN(b.id)
+		FROM import_batch_items i
+		JOIN import_batches b ON b.id = i.batch_id
+		WHERE b.household_id = $1 AND b.rolled_back_at IS NULL AND i.fingerprint = ANY($2)
+		GROUP BY i.fingerprint
+	`
+
+	rows, err := r.db.Query(query, int(householdID), pq.Array(fingerprints))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find imported fingerprints: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var fingerprint string
+		var batchID int
+		if err := rows.Scan(&fingerprint, &batchID); err != nil {
+			return nil, fmt.Errorf("failed to scan imported fingerprint: %w", err)
+		}
+		imported[fingerprint] = entities.ImportBatchID(batchID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating imported fingerprints: %w", err)
+	}
+
+	return imported, nil
+}
+
+func (r *ImportBatchRepositoryImpl) MarkRolledBack(householdID entities.HouseholdID, id entities.ImportBatchID, rolledBackAt time.Time) error {
+	query := `UPDATE import_batches SET rolled_back_at = $3 WHERE id = $1 AND household_id = $2 AND rolled_back_at IS NULL`
+
+	result, err := r.db.Exec(query, int(id), int(householdID), rolledBackAt)
+	if err != nil {
+		return fmt.Errorf("failed to roll back import batch: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrImportBatchNotFound
+	}
+
+	return nil
+}
+
+func (r *ImportBatchRepositoryImpl) findItems(batchID entities.ImportBatchID) ([]entities.ImportBatchItem, error) {
+	query := `SELECT fingerprint, expense_id, income_id FROM import_batch_items WHERE batch_id = $1 ORDER BY id`
+
+	rows, err := r.db.Query(query, int(batchID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find import batch items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []entities.ImportBatchItem{}
+	for rows.Next() {
+		var dbo models.ImportBatchItemDBO
+		if err := rows.Scan(&dbo.Fingerprint, &dbo.ExpenseID, &dbo.IncomeID); err != nil {
+			return nil, fmt.Errorf("failed to scan import batch item: %w", err)
+		}
+		items = append(items, dbo.ToDomainEntity())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating import batch items: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *ImportBatchRepositoryImpl) scan(row interface{ Scan(...interface{}) error }) (*models.ImportBatchDBO, error) {
+	var dbo models.ImportBatchDBO
+	err := row.Scan(
+		&dbo.ID,
+		&dbo.Source,
+		&dbo.FileHash,
+		&dbo.ExpenseCount,
+		&dbo.IncomeCount,
+		&dbo.CreatedAt,
+		&dbo.RolledBackAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &dbo, nil
+}
@@ -0,0 +1,215 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/infrastructure/persistence/models"
+	"expenso-backend/usecases/interfaces/repositories"
+
+	"github.com/lib/pq"
+)
+
+type ImportProfileRepositoryImpl struct {
+	db DBTX
+}
+
+func NewImportProfileRepository(db DBTX) repositories.ImportProfileRepository {
+	return &ImportProfileRepositoryImpl{db: db}
+}
+
+const importProfileColumns = `id, name, delimiter, encoding, decimal_separator, date_formats, skip_rows, layout, date_column,
+	amount_column, payee_column, memo_column, vendor_type_columns, currency, default_category, rules, created_at, updated_at`
+
+func (r *ImportProfileRepositoryImpl) Save(householdID entities.HouseholdID, profile *entities.ImportProfile) error {
+	query := `
+		INSERT INTO import_profiles (name, delimiter, encoding, decimal_separator, date_formats, skip_rows, layout, date_column,
+			amount_column, payee_column, memo_column, vendor_type_columns, currency, default_category, rules, created_at, updated_at, household_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		RETURNING id
+	`
+
+	var dbo models.ImportProfileDBO
+	dbo.FromDomainEntity(profile)
+
+	var id int
+	err := r.db.QueryRow(
+		query,
+		dbo.Name,
+		dbo.Delimiter,
+		dbo.Encoding,
+		dbo.DecimalSeparator,
+		pq.Array(dbo.DateFormats),
+		dbo.SkipRows,
+		dbo.Layout,
+		dbo.DateColumn,
+		dbo.AmountColumn,
+		dbo.PayeeColumn,
+		dbo.MemoColumn,
+		dbo.VendorTypeColumns,
+		dbo.Currency,
+		dbo.DefaultCategory,
+		dbo.Rules,
+		dbo.CreatedAt,
+		dbo.UpdatedAt,
+		int(householdID),
+	).Scan(&id)
+
+	if err != nil {
+		return fmt.Errorf("failed to save import profile: %w", err)
+	}
+
+	profile.SetID(entities.ImportProfileID(id))
+	return nil
+}
+
+func (r *ImportProfileRepositoryImpl) FindByID(householdID entities.HouseholdID, id entities.ImportProfileID) (*entities.ImportProfile, error) {
+	query := `SELECT ` + importProfileColumns + ` FROM import_profiles WHERE id = $1 AND household_id = $2`
+
+	profile, err := r.scan(r.db.QueryRow(query, int(id), int(householdID)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrImportProfileNotFound
+		}
+		return nil, fmt.Errorf("failed to find import profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+func (r *ImportProfileRepositoryImpl) FindAll(householdID entities.HouseholdID) ([]*entities.ImportProfile, error) {
+	query := `SELECT ` + importProfileColumns + ` FROM import_profiles WHERE household_id = $1 ORDER BY name`
+
+	rows, err := r.db.Query(query, int(householdID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find import profiles: %w", err)
+	}
+	defer rows.Close()
+
+	var profiles []*entities.ImportProfile
+	for rows.Next() {
+		profile, err := r.scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan import profile: %w", err)
+		}
+		profiles = append(profiles, profile)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating import profiles: %w", err)
+	}
+
+	return profiles, nil
+}
+
+func (r *ImportProfileRepositoryImpl) FindByName(householdID entities.HouseholdID, name string) (*entities.ImportProfile, error) {
+	query := `SELECT ` + importProfileColumns + ` FROM import_profiles WHERE household_id = $1 AND name = $2`
+
+	profile, err := r.scan(r.db.QueryRow(query, int(householdID), name))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrImportProfileNotFound
+		}
+		return nil, fmt.Errorf("failed to find import profile by name: %w", err)
+	}
+
+	return profile, nil
+}
+
+func (r *ImportProfileRepositoryImpl) Update(householdID entities.HouseholdID, profile *entities.ImportProfile) error {
+	query := `
+		UPDATE import_profiles
+		SET name = $2, delimiter = $3, encoding = $4, decimal_separator = $5, date_formats = $6, skip_rows = $7, layout = $8,
+			date_column = $9, amount_column = $10, payee_column = $11, memo_column = $12, vendor_type_columns = $13,
+			currency = $14, default_category = $15, rules = $16, updated_at = $17
+		WHERE id = $1 AND household_id = $18
+	`
+
+	var dbo models.ImportProfileDBO
+	dbo.FromDomainEntity(profile)
+
+	result, err := r.db.Exec(
+		query,
+		dbo.ID,
+		dbo.Name,
+		dbo.Delimiter,
+		dbo.Encoding,
+		dbo.DecimalSeparator,
+		pq.Array(dbo.DateFormats),
+		dbo.SkipRows,
+		dbo.Layout,
+		dbo.DateColumn,
+		dbo.AmountColumn,
+		dbo.PayeeColumn,
+		dbo.MemoColumn,
+		dbo.VendorTypeColumns,
+		dbo.Currency,
+		dbo.DefaultCategory,
+		dbo.Rules,
+		dbo.UpdatedAt,
+		int(householdID),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update import profile: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrImportProfileNotFound
+	}
+
+	return nil
+}
+
+func (r *ImportProfileRepositoryImpl) Delete(householdID entities.HouseholdID, id entities.ImportProfileID) error {
+	query := `DELETE FROM import_profiles WHERE id = $1 AND household_id = $2`
+
+	result, err := r.db.Exec(query, int(id), int(householdID))
+	if err != nil {
+		return fmt.Errorf("failed to delete import profile: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrImportProfileNotFound
+	}
+
+	return nil
+}
+
+func (r *ImportProfileRepositoryImpl) scan(row interface{ Scan(...interface{}) error }) (*entities.ImportProfile, error) {
+	var dbo models.ImportProfileDBO
+	err := row.Scan(
+		&dbo.ID,
+		&dbo.Name,
+		&dbo.Delimiter,
+		&dbo.Encoding,
+		&dbo.DecimalSeparator,
+		pq.Array(&dbo.DateFormats),
+		&dbo.SkipRows,
+		&dbo.Layout,
+		&dbo.DateColumn,
+		&dbo.AmountColumn,
+		&dbo.PayeeColumn,
+		&dbo.MemoColumn,
+		&dbo.VendorTypeColumns,
+		&dbo.Currency,
+		&dbo.DefaultCategory,
+		&dbo.Rules,
+		&dbo.CreatedAt,
+		&dbo.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return dbo.ToDomainEntity()
+}
@@ -11,21 +11,21 @@ import (
 )
 
 type IncomeRepositoryImpl struct {
-	db      *sql.DB
+	db      DBTX
 	tagRepo *TagRepository
 }
 
-func NewIncomeRepository(db *sql.DB, tagRepo *TagRepository) repositories.IncomeRepository {
+func NewIncomeRepository(db DBTX, tagRepo *TagRepository) repositories.IncomeRepository {
 	return &IncomeRepositoryImpl{
 		db:      db,
 		tagRepo: tagRepo,
 	}
 }
 
-func (r *IncomeRepositoryImpl) Save(income *entities.Income) error {
+func (r *IncomeRepositoryImpl) Save(householdID entities.HouseholdID, income *entities.Income) error {
 	query := `
-		INSERT INTO incomes (amount, date, source, comment, vendor_id, added_by, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO incomes (amount, currency, date, source, comment, vendor_id, member_id, created_at, updated_at, household_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING id
 	`
 
@@ -35,17 +35,25 @@ func (r *IncomeRepositoryImpl) Save(income *entities.Income) error {
 		vendorID = &id
 	}
 
+	var memberID *int
+	if income.Member() != nil {
+		id := int(income.Member().ID())
+		memberID = &id
+	}
+
 	var id int
 	err := r.db.QueryRow(
 		query,
-		income.Amount().Amount(),
+		income.Amount().Decimal(),
+		income.Amount().Currency(),
 		income.Date(),
 		income.Source(),
 		income.Comment(),
 		vendorID,
-		income.AddedBy().String(),
+		memberID,
 		income.CreatedAt(),
 		income.UpdatedAt(),
+		int(householdID),
 	).Scan(&id)
 
 	if err != nil {
@@ -56,13 +64,14 @@ func (r *IncomeRepositoryImpl) Save(income *entities.Income) error {
 	return nil
 }
 
-func (r *IncomeRepositoryImpl) FindByID(id entities.IncomeID) (*entities.Income, error) {
+func (r *IncomeRepositoryImpl) FindByID(householdID entities.HouseholdID, id entities.IncomeID) (*entities.Income, error) {
 	query := `
-		SELECT i.id, i.amount, i.date, i.source, i.comment, i.vendor_id, i.added_by, i.created_at, i.updated_at,
+		SELECT i.id, i.amount, i.currency, i.date, i.source, i.comment, i.vendor_id, i.member_id, m.name, i.created_at, i.updated_at,
 		       v.id, v.name, v.type, v.created_at, v.updated_at
 		FROM incomes i
 		LEFT JOIN vendors v ON i.vendor_id = v.id
-		WHERE i.id = $1
+		LEFT JOIN members m ON i.member_id = m.id
+		WHERE i.id = $1 AND i.household_id = $2
 	`
 
 	var dbo models.IncomeDBO
@@ -70,9 +79,9 @@ func (r *IncomeRepositoryImpl) FindByID(id entities.IncomeID) (*entities.Income,
 	var vName, vType *string
 	var vCreatedAt, vUpdatedAt *string
 
-	row := r.db.QueryRow(query, int(id))
+	row := r.db.QueryRow(query, int(id), int(householdID))
 	err := row.Scan(
-		&dbo.ID, &dbo.Amount, &dbo.Date, &dbo.Source, &dbo.Comment, &dbo.VendorID, &dbo.AddedBy, &dbo.CreatedAt, &dbo.UpdatedAt,
+		&dbo.ID, &dbo.Amount, &dbo.Currency, &dbo.Date, &dbo.Source, &dbo.Comment, &dbo.VendorID, &dbo.MemberID, &dbo.MemberName, &dbo.CreatedAt, &dbo.UpdatedAt,
 		&vID, &vName, &vType, &vCreatedAt, &vUpdatedAt,
 	)
 
@@ -103,16 +112,18 @@ func (r *IncomeRepositoryImpl) FindByID(id entities.IncomeID) (*entities.Income,
 	return income, nil
 }
 
-func (r *IncomeRepositoryImpl) FindAll() ([]*entities.Income, error) {
+func (r *IncomeRepositoryImpl) FindAll(householdID entities.HouseholdID) ([]*entities.Income, error) {
 	query := `
-		SELECT i.id, i.amount, i.date, i.source, i.comment, i.vendor_id, i.added_by, i.created_at, i.updated_at,
+		SELECT i.id, i.amount, i.currency, i.date, i.source, i.comment, i.vendor_id, i.member_id, m.name, i.created_at, i.updated_at,
 		       v.id, v.name, v.type, v.created_at, v.updated_at
 		FROM incomes i
 		LEFT JOIN vendors v ON i.vendor_id = v.id
+		LEFT JOIN members m ON i.member_id = m.id
+		WHERE i.household_id = $1
 		ORDER BY i.date DESC
 	`
 
-	rows, err := r.db.Query(query)
+	rows, err := r.db.Query(query, int(householdID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to find incomes: %w", err)
 	}
@@ -126,7 +137,7 @@ func (r *IncomeRepositoryImpl) FindAll() ([]*entities.Income, error) {
 		var vCreatedAt, vUpdatedAt *string
 
 		err := rows.Scan(
-			&dbo.ID, &dbo.Amount, &dbo.Date, &dbo.Source, &dbo.Comment, &dbo.VendorID, &dbo.AddedBy, &dbo.CreatedAt, &dbo.UpdatedAt,
+			&dbo.ID, &dbo.Amount, &dbo.Currency, &dbo.Date, &dbo.Source, &dbo.Comment, &dbo.VendorID, &dbo.MemberID, &dbo.MemberName, &dbo.CreatedAt, &dbo.UpdatedAt,
 			&vID, &vName, &vType, &vCreatedAt, &vUpdatedAt,
 		)
 		if err != nil {
@@ -164,11 +175,11 @@ func (r *IncomeRepositoryImpl) FindAll() ([]*entities.Income, error) {
 	return incomes, nil
 }
 
-func (r *IncomeRepositoryImpl) Update(income *entities.Income) error {
+func (r *IncomeRepositoryImpl) Update(householdID entities.HouseholdID, income *entities.Income) error {
 	query := `
 		UPDATE incomes 
-		SET amount = $2, date = $3, source = $4, comment = $5, vendor_id = $6, updated_at = $7
-		WHERE id = $1
+		SET amount = $2, currency = $3, date = $4, source = $5, comment = $6, vendor_id = $7, member_id = $8, updated_at = $9
+		WHERE id = $1 AND household_id = $10
 	`
 
 	var vendorID *int
@@ -177,15 +188,24 @@ func (r *IncomeRepositoryImpl) Update(income *entities.Income) error {
 		vendorID = &id
 	}
 
+	var memberID *int
+	if income.Member() != nil {
+		id := int(income.Member().ID())
+		memberID = &id
+	}
+
 	result, err := r.db.Exec(
 		query,
 		int(income.ID()),
-		income.Amount().Amount(),
+		income.Amount().Decimal(),
+		income.Amount().Currency(),
 		income.Date(),
 		income.Source(),
 		income.Comment(),
 		vendorID,
+		memberID,
 		income.UpdatedAt(),
+		int(householdID),
 	)
 
 	if err != nil {
@@ -204,10 +224,10 @@ func (r *IncomeRepositoryImpl) Update(income *entities.Income) error {
 	return nil
 }
 
-func (r *IncomeRepositoryImpl) Delete(id entities.IncomeID) error {
-	query := `DELETE FROM incomes WHERE id = $1`
+func (r *IncomeRepositoryImpl) Delete(householdID entities.HouseholdID, id entities.IncomeID) error {
+	query := `DELETE FROM incomes WHERE id = $1 AND household_id = $2`
 
-	result, err := r.db.Exec(query, int(id))
+	result, err := r.db.Exec(query, int(id), int(householdID))
 	if err != nil {
 		return fmt.Errorf("failed to delete income: %w", err)
 	}
@@ -224,17 +244,18 @@ func (r *IncomeRepositoryImpl) Delete(id entities.IncomeID) error {
 	return nil
 }
 
-func (r *IncomeRepositoryImpl) FindBySource(source string) ([]*entities.Income, error) {
+func (r *IncomeRepositoryImpl) FindBySource(householdID entities.HouseholdID, source string) ([]*entities.Income, error) {
 	query := `
-		SELECT i.id, i.amount, i.date, i.source, i.comment, i.vendor_id, i.added_by, i.created_at, i.updated_at,
+		SELECT i.id, i.amount, i.currency, i.date, i.source, i.comment, i.vendor_id, i.member_id, m.name, i.created_at, i.updated_at,
 		       v.id, v.name, v.type, v.created_at, v.updated_at
 		FROM incomes i
 		LEFT JOIN vendors v ON i.vendor_id = v.id
-		WHERE i.source = $1
+		LEFT JOIN members m ON i.member_id = m.id
+		WHERE i.household_id = $1 AND i.source = $2
 		ORDER BY i.date DESC
 	`
 
-	rows, err := r.db.Query(query, source)
+	rows, err := r.db.Query(query, int(householdID), source)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find incomes by source: %w", err)
 	}
@@ -248,7 +269,7 @@ func (r *IncomeRepositoryImpl) FindBySource(source string) ([]*entities.Income,
 		var vCreatedAt, vUpdatedAt *string
 
 		err := rows.Scan(
-			&dbo.ID, &dbo.Amount, &dbo.Date, &dbo.Source, &dbo.Comment, &dbo.VendorID, &dbo.AddedBy, &dbo.CreatedAt, &dbo.UpdatedAt,
+			&dbo.ID, &dbo.Amount, &dbo.Currency, &dbo.Date, &dbo.Source, &dbo.Comment, &dbo.VendorID, &dbo.MemberID, &dbo.MemberName, &dbo.CreatedAt, &dbo.UpdatedAt,
 			&vID, &vName, &vType, &vCreatedAt, &vUpdatedAt,
 		)
 		if err != nil {
@@ -278,17 +299,18 @@ func (r *IncomeRepositoryImpl) FindBySource(source string) ([]*entities.Income,
 	return incomes, nil
 }
 
-func (r *IncomeRepositoryImpl) FindByVendor(vendorID entities.VendorID) ([]*entities.Income, error) {
+func (r *IncomeRepositoryImpl) FindByVendor(householdID entities.HouseholdID, vendorID entities.VendorID) ([]*entities.Income, error) {
 	query := `
-		SELECT i.id, i.amount, i.date, i.source, i.comment, i.vendor_id, i.added_by, i.created_at, i.updated_at,
+		SELECT i.id, i.amount, i.currency, i.date, i.source, i.comment, i.vendor_id, i.member_id, m.name, i.created_at, i.updated_at,
 		       v.id, v.name, v.type, v.created_at, v.updated_at
 		FROM incomes i
 		LEFT JOIN vendors v ON i.vendor_id = v.id
-		WHERE i.vendor_id = $1
+		LEFT JOIN members m ON i.member_id = m.id
+		WHERE i.household_id = $1 AND i.vendor_id = $2
 		ORDER BY i.date DESC
 	`
 
-	rows, err := r.db.Query(query, int(vendorID))
+	rows, err := r.db.Query(query, int(householdID), int(vendorID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to find incomes by vendor: %w", err)
 	}
@@ -302,7 +324,7 @@ func (r *IncomeRepositoryImpl) FindByVendor(vendorID entities.VendorID) ([]*enti
 		var vCreatedAt, vUpdatedAt *string
 
 		err := rows.Scan(
-			&dbo.ID, &dbo.Amount, &dbo.Date, &dbo.Source, &dbo.Comment, &dbo.VendorID, &dbo.AddedBy, &dbo.CreatedAt, &dbo.UpdatedAt,
+			&dbo.ID, &dbo.Amount, &dbo.Currency, &dbo.Date, &dbo.Source, &dbo.Comment, &dbo.VendorID, &dbo.MemberID, &dbo.MemberName, &dbo.CreatedAt, &dbo.UpdatedAt,
 			&vID, &vName, &vType, &vCreatedAt, &vUpdatedAt,
 		)
 		if err != nil {
@@ -332,27 +354,29 @@ func (r *IncomeRepositoryImpl) FindByVendor(vendorID entities.VendorID) ([]*enti
 	return incomes, nil
 }
 
-func (r *IncomeRepositoryImpl) FindByDateRange(startDate, endDate *time.Time) ([]*entities.Income, error) {
+func (r *IncomeRepositoryImpl) FindByDateRange(householdID entities.HouseholdID, startDate, endDate *time.Time) ([]*entities.Income, error) {
 	baseQuery := `
-		SELECT i.id, i.amount, i.date, i.source, i.comment, i.vendor_id, i.added_by, i.created_at, i.updated_at,
+		SELECT i.id, i.amount, i.currency, i.date, i.source, i.comment, i.vendor_id, i.member_id, m.name, i.created_at, i.updated_at,
 		       v.id, v.name, v.type, v.created_at, v.updated_at
 		FROM incomes i
 		LEFT JOIN vendors v ON i.vendor_id = v.id
+		LEFT JOIN members m ON i.member_id = m.id
+		WHERE i.household_id = $1
 	`
 
 	var query string
-	var args []interface{}
+	args := []interface{}{int(householdID)}
 
-	// Build WHERE clause based on provided date range
+	// Build additional WHERE clauses based on provided date range
 	if startDate != nil && endDate != nil {
-		query = baseQuery + " WHERE i.date >= $1 AND i.date <= $2 ORDER BY i.date DESC"
-		args = []interface{}{*startDate, *endDate}
+		query = baseQuery + " AND i.date >= $2 AND i.date <= $3 ORDER BY i.date DESC"
+		args = append(args, *startDate, *endDate)
 	} else if startDate != nil {
-		query = baseQuery + " WHERE i.date >= $1 ORDER BY i.date DESC"
-		args = []interface{}{*startDate}
+		query = baseQuery + " AND i.date >= $2 ORDER BY i.date DESC"
+		args = append(args, *startDate)
 	} else if endDate != nil {
-		query = baseQuery + " WHERE i.date <= $1 ORDER BY i.date DESC"
-		args = []interface{}{*endDate}
+		query = baseQuery + " AND i.date <= $2 ORDER BY i.date DESC"
+		args = append(args, *endDate)
 	} else {
 		// No date filter, return all incomes
 		query = baseQuery + " ORDER BY i.date DESC"
@@ -372,7 +396,7 @@ func (r *IncomeRepositoryImpl) FindByDateRange(startDate, endDate *time.Time) ([
 		var vCreatedAt, vUpdatedAt *string
 
 		err := rows.Scan(
-			&dbo.ID, &dbo.Amount, &dbo.Date, &dbo.Source, &dbo.Comment, &dbo.VendorID, &dbo.AddedBy, &dbo.CreatedAt, &dbo.UpdatedAt,
+			&dbo.ID, &dbo.Amount, &dbo.Currency, &dbo.Date, &dbo.Source, &dbo.Comment, &dbo.VendorID, &dbo.MemberID, &dbo.MemberName, &dbo.CreatedAt, &dbo.UpdatedAt,
 			&vID, &vName, &vType, &vCreatedAt, &vUpdatedAt,
 		)
 		if err != nil {
@@ -408,4 +432,159 @@ func (r *IncomeRepositoryImpl) FindByDateRange(startDate, endDate *time.Time) ([
 	}
 
 	return incomes, nil
-}
\ No newline at end of file
+}
+
+// incomeSortColumns are the expressions FindPage orders by
+var incomeSortColumns = map[repositories.SortField]string{
+	repositories.SortByDate:      "i.date",
+	repositories.SortByAmount:    "i.amount",
+	repositories.SortByCreatedAt: "i.created_at",
+	repositories.SortByVendor:    "COALESCE(v.name, '')",
+}
+
+func (r *IncomeRepositoryImpl) FindPage(householdID entities.HouseholdID, filter repositories.IncomeFilter, page repositories.PageRequest) (*repositories.IncomePage, error) {
+	sortExpr, ok := incomeSortColumns[page.Sort]
+	if !ok {
+		return nil, fmt.Errorf("cannot sort incomes by %q", page.Sort)
+	}
+
+	var q listQuery
+	q.where("i.household_id = ?", int(householdID))
+	if filter.StartDate != nil {
+		q.where("i.date >= ?", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		q.where("i.date <= ?", *filter.EndDate)
+	}
+	if filter.Source != "" {
+		q.where("LOWER(i.source) = LOWER(?)", filter.Source)
+	}
+	if filter.VendorID != nil {
+		q.where("i.vendor_id = ?", int(*filter.VendorID))
+	}
+	if filter.VendorType != "" {
+		q.where("v.type = ?", string(filter.VendorType))
+	}
+	if filter.TagID != nil {
+		q.where("EXISTS (SELECT 1 FROM income_tags it WHERE it.income_id = i.id AND it.tag_id = ?)", int(*filter.TagID))
+	}
+	if filter.MemberID != nil {
+		q.where("i.member_id = ?", int(*filter.MemberID))
+	}
+	if filter.Currency != "" {
+		q.where("i.currency = ?", filter.Currency)
+	}
+	if filter.MinAmount != nil {
+		q.where("i.amount >= ?", filter.MinAmount.Decimal())
+	}
+	if filter.MaxAmount != nil {
+		q.where("i.amount <= ?", filter.MaxAmount.Decimal())
+	}
+
+	from := `incomes i
+		LEFT JOIN vendors v ON i.vendor_id = v.id
+		LEFT JOIN members m ON i.member_id = m.id`
+
+	total, err := q.count(r.db, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count incomes: %w", err)
+	}
+
+	order := q.paginate(page, sortExpr, "i.id")
+	query := `
+		SELECT i.id, i.amount, i.currency, i.date, i.source, i.comment, i.vendor_id, i.member_id, m.name, i.created_at, i.updated_at,
+		       v.id, v.name, v.type, v.created_at, v.updated_at
+		FROM ` + from + q.whereClause() + order
+
+	rows, err := r.db.Query(query, q.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find incomes: %w", err)
+	}
+	defer rows.Close()
+
+	var incomes []*entities.Income
+	var cursors []repositories.Cursor
+	for rows.Next() {
+		var dbo models.IncomeDBO
+		var vID *int
+		var vName, vType *string
+		var vCreatedAt, vUpdatedAt *string
+
+		err := rows.Scan(
+			&dbo.ID, &dbo.Amount, &dbo.Currency, &dbo.Date, &dbo.Source, &dbo.Comment, &dbo.VendorID, &dbo.MemberID, &dbo.MemberName, &dbo.CreatedAt, &dbo.UpdatedAt,
+			&vID, &vName, &vType, &vCreatedAt, &vUpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan income: %w", err)
+		}
+
+		income, err := dbo.ToDomainEntity()
+		if err != nil {
+			return nil, err
+		}
+
+		if vID != nil && vName != nil && vType != nil {
+			vendorDBO := &models.VendorDBO{
+				ID:   *vID,
+				Name: *vName,
+				Type: *vType,
+			}
+			income.AssignVendor(vendorDBO.ToDomainEntity())
+		}
+
+		// The cursor keeps the value as stored, so the next page compares against exactly what the database has
+		cursor := repositories.Cursor{ID: dbo.ID}
+		switch page.Sort {
+		case repositories.SortByDate:
+			cursor.Value = dbo.Date.Format("2006-01-02")
+		case repositories.SortByAmount:
+			cursor.Value = dbo.Amount
+		case repositories.SortByCreatedAt:
+			cursor.Value = dbo.CreatedAt.Format(time.RFC3339Nano)
+		case repositories.SortByVendor:
+			if vName != nil {
+				cursor.Value = *vName
+			}
+		}
+
+		incomes = append(incomes, income)
+		cursors = append(cursors, cursor)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find incomes: %w", err)
+	}
+
+	next, keep := nextCursor(page, cursors)
+	incomes = incomes[:keep]
+
+	// Tags for the whole page at once, after the rows are closed so this also works inside a transaction
+	rows.Close()
+	if r.tagRepo != nil && len(incomes) > 0 {
+		ids := make([]int, len(incomes))
+		for i, income := range incomes {
+			ids[i] = int(income.ID())
+		}
+		tags, err := r.tagRepo.tagsByIncomeIDs(ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load income tags: %w", err)
+		}
+		for _, income := range incomes {
+			if len(tags[income.ID()]) > 0 {
+				income.SetTags(tags[income.ID()])
+			}
+		}
+	}
+
+	return &repositories.IncomePage{
+		Incomes:  incomes,
+		PageInfo: repositories.PageInfo{Total: total, NextCursor: next},
+	}, nil
+}
+
+func (r *IncomeRepositoryImpl) Aggregate(householdID entities.HouseholdID, query repositories.AggregateQuery) ([]repositories.AggregateRow, error) {
+	rows, err := aggregate(r.db, incomeTable, householdID, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate incomes: %w", err)
+	}
+	return rows, nil
+}
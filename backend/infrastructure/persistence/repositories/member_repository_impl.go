@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/infrastructure/persistence/models"
+	"expenso-backend/usecases/interfaces/repositories"
+)
+
+type MemberRepositoryImpl struct {
+	db DBTX
+}
+
+func NewMemberRepository(db DBTX) repositories.MemberRepository {
+	return &MemberRepositoryImpl{db: db}
+}
+
+func (r *MemberRepositoryImpl) Save(householdID entities.HouseholdID, member *entities.Member) error {
+	query := `
+		INSERT INTO members (name, created_at, updated_at, household_id)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	var id int
+	err := r.db.QueryRow(
+		query,
+		member.Name(),
+		member.CreatedAt(),
+		member.UpdatedAt(),
+		int(householdID),
+	).Scan(&id)
+
+	if err != nil {
+		return fmt.Errorf("failed to save member: %w", err)
+	}
+
+	member.SetID(entities.MemberID(id))
+	return nil
+}
+
+func (r *MemberRepositoryImpl) FindByID(householdID entities.HouseholdID, id entities.MemberID) (*entities.Member, error) {
+	query := `SELECT id, name, created_at, updated_at FROM members WHERE id = $1 AND household_id = $2`
+
+	var dbo models.MemberDBO
+	row := r.db.QueryRow(query, int(id), int(householdID))
+	err := row.Scan(&dbo.ID, &dbo.Name, &dbo.CreatedAt, &dbo.UpdatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrMemberNotFound
+		}
+		return nil, fmt.Errorf("failed to find member: %w", err)
+	}
+
+	return dbo.ToDomainEntity(), nil
+}
+
+func (r *MemberRepositoryImpl) FindByName(householdID entities.HouseholdID, name string) (*entities.Member, error) {
+	query := `SELECT id, name, created_at, updated_at FROM members WHERE household_id = $1 AND LOWER(name) = LOWER($2)`
+
+	var dbo models.MemberDBO
+	row := r.db.QueryRow(query, int(householdID), name)
+	err := row.Scan(&dbo.ID, &dbo.Name, &dbo.CreatedAt, &dbo.UpdatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrMemberNotFound
+		}
+		return nil, fmt.Errorf("failed to find member by name: %w", err)
+	}
+
+	return dbo.ToDomainEntity(), nil
+}
+
+func (r *MemberRepositoryImpl) FindAll(householdID entities.HouseholdID) ([]*entities.Member, error) {
+	query := `SELECT id, name, created_at, updated_at FROM members WHERE household_id = $1 ORDER BY name ASC`
+
+	rows, err := r.db.Query(query, int(householdID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []*entities.Member
+	for rows.Next() {
+		var dbo models.MemberDBO
+		err := rows.Scan(&dbo.ID, &dbo.Name, &dbo.CreatedAt, &dbo.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan member: %w", err)
+		}
+
+		members = append(members, dbo.ToDomainEntity())
+	}
+
+	return members, nil
+}
+
+func (r *MemberRepositoryImpl) Update(householdID entities.HouseholdID, member *entities.Member) error {
+	query := `
+		UPDATE members
+		SET name = $2, updated_at = $3
+		WHERE id = $1 AND household_id = $4
+	`
+
+	result, err := r.db.Exec(
+		query,
+		int(member.ID()),
+		member.Name(),
+		member.UpdatedAt(),
+		int(householdID),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update member: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check update result: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrMemberNotFound
+	}
+
+	return nil
+}
+
+func (r *MemberRepositoryImpl) Delete(householdID entities.HouseholdID, id entities.MemberID) error {
+	query := `DELETE FROM members WHERE id = $1 AND household_id = $2`
+
+	result, err := r.db.Exec(query, int(id), int(householdID))
+	if err != nil {
+		return fmt.Errorf("failed to delete member: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check delete result: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrMemberNotFound
+	}
+
+	return nil
+}
+
+func (r *MemberRepositoryImpl) HasTransactions(householdID entities.HouseholdID, id entities.MemberID) (bool, error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM expenses WHERE household_id = $1 AND member_id = $2)
+		    OR EXISTS (SELECT 1 FROM incomes WHERE household_id = $1 AND member_id = $2)
+	`
+
+	var inUse bool
+	if err := r.db.QueryRow(query, int(householdID), int(id)).Scan(&inUse); err != nil {
+		return false, fmt.Errorf("failed to check member references: %w", err)
+	}
+
+	return inUse, nil
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"expenso-backend/usecases/interfaces/repositories"
+)
+
+// listQuery collects the conditions of a listing and their arguments
+type listQuery struct {
+	conditions []string
+	args       []interface{}
+}
+
+// where adds a condition, binding its ? placeholders to args in order
+func (q *listQuery) where(condition string, args ...interface{}) {
+	for _, arg := range args {
+		q.args = append(q.args, arg)
+		condition = strings.Replace(condition, "?", fmt.Sprintf("$%d", len(q.args)), 1)
+	}
+	q.conditions = append(q.conditions, condition)
+}
+
+func (q *listQuery) whereClause() string {
+	if len(q.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(q.conditions, " AND ")
+}
+
+// count returns how many rows of from match the conditions
+func (q *listQuery) count(db DBTX, from string) (int, error) {
+	var total int
+	if err := db.QueryRow("SELECT COUNT(*) FROM "+from+q.whereClause(), q.args...).Scan(&total); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// paginate adds the keyset condition for the rows after the cursor and returns the ORDER BY and LIMIT clauses.
+// One row more than the limit is fetched, so the caller can tell whether another page follows.
+func (q *listQuery) paginate(page repositories.PageRequest, sortExpr, idExpr string) string {
+	direction, comparison := "ASC", ">"
+	if page.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	if page.After != nil {
+		q.where(fmt.Sprintf("(%s, %s) %s (?, ?)", sortExpr, idExpr, comparison), page.After.Value, page.After.ID)
+	}
+
+	clause := fmt.Sprintf(" ORDER BY %s %s, %s %s", sortExpr, direction, idExpr, direction)
+	if page.Limit > 0 {
+		clause += fmt.Sprintf(" LIMIT %d", page.Limit+1)
+	}
+	return clause
+}
+
+// nextCursor returns the cursor of the page's last row if more rows were fetched than the limit, and how many rows to keep
+func nextCursor(page repositories.PageRequest, cursors []repositories.Cursor) (*repositories.Cursor, int) {
+	if page.Limit <= 0 || len(cursors) <= page.Limit {
+		return nil, len(cursors)
+	}
+	next := cursors[page.Limit-1]
+	return &next, page.Limit
+}
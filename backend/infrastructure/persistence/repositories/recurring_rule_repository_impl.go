@@ -0,0 +1,305 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/infrastructure/persistence/models"
+	"expenso-backend/usecases/interfaces/repositories"
+
+	"github.com/lib/pq"
+)
+
+type RecurringRuleRepositoryImpl struct {
+	db DBTX
+}
+
+func NewRecurringRuleRepository(db DBTX) repositories.RecurringRuleRepository {
+	return &RecurringRuleRepositoryImpl{db: db}
+}
+
+const recurringRuleColumns = `id, household_id, kind, name, amount, currency, category, source, comment, vendor_id, member_id, paid_by_card, tag_ids,
+	frequency, repeat_interval, day_of_month, start_date, end_date, active, created_at, updated_at`
+
+func (r *RecurringRuleRepositoryImpl) Save(householdID entities.HouseholdID, rule *entities.RecurringRule) error {
+	query := `
+		INSERT INTO recurring_rules (kind, name, amount, currency, category, source, comment, vendor_id, member_id, paid_by_card, tag_ids,
+			frequency, repeat_interval, day_of_month, start_date, end_date, active, created_at, updated_at, household_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+		RETURNING id
+	`
+
+	var dbo models.RecurringRuleDBO
+	dbo.FromDomainEntity(rule)
+
+	var id int
+	err := r.db.QueryRow(
+		query,
+		dbo.Kind,
+		dbo.Name,
+		dbo.Amount,
+		dbo.Currency,
+		dbo.Category,
+		dbo.Source,
+		dbo.Comment,
+		dbo.VendorID,
+		dbo.MemberID,
+		dbo.PaidByCard,
+		pq.Array(dbo.TagIDs),
+		dbo.Frequency,
+		dbo.Interval,
+		dbo.DayOfMonth,
+		dbo.StartDate,
+		dbo.EndDate,
+		dbo.Active,
+		dbo.CreatedAt,
+		dbo.UpdatedAt,
+		int(householdID),
+	).Scan(&id)
+
+	if err != nil {
+		return fmt.Errorf("failed to save recurring rule: %w", err)
+	}
+
+	rule.SetID(entities.RecurringRuleID(id))
+	return nil
+}
+
+func (r *RecurringRuleRepositoryImpl) FindByID(householdID entities.HouseholdID, id entities.RecurringRuleID) (*entities.RecurringRule, error) {
+	query := `SELECT ` + recurringRuleColumns + ` FROM recurring_rules WHERE id = $1 AND household_id = $2`
+
+	dbo, err := r.scan(r.db.QueryRow(query, int(id), int(householdID)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrRecurringRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to find recurring rule: %w", err)
+	}
+
+	return dbo.ToDomainEntity()
+}
+
+func (r *RecurringRuleRepositoryImpl) FindAll(householdID entities.HouseholdID) ([]*entities.RecurringRule, error) {
+	query := `SELECT ` + recurringRuleColumns + ` FROM recurring_rules WHERE household_id = $1 ORDER BY kind, name`
+
+	scheduled, err := r.findRules(query, int(householdID))
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]*entities.RecurringRule, 0, len(scheduled))
+	for _, s := range scheduled {
+		rules = append(rules, s.Rule)
+	}
+	return rules, nil
+}
+
+func (r *RecurringRuleRepositoryImpl) FindActive() ([]repositories.ScheduledRule, error) {
+	query := `SELECT ` + recurringRuleColumns + ` FROM recurring_rules WHERE active ORDER BY household_id, id`
+
+	return r.findRules(query)
+}
+
+func (r *RecurringRuleRepositoryImpl) Update(householdID entities.HouseholdID, rule *entities.RecurringRule) error {
+	query := `
+		UPDATE recurring_rules
+		SET name = $2, amount = $3, currency = $4, category = $5, source = $6, comment = $7, vendor_id = $8, member_id = $9,
+			paid_by_card = $10, tag_ids = $11, frequency = $12, repeat_interval = $13, day_of_month = $14, start_date = $15, end_date = $16,
+			active = $17, updated_at = $18
+		WHERE id = $1 AND household_id = $19
+	`
+
+	var dbo models.RecurringRuleDBO
+	dbo.FromDomainEntity(rule)
+
+	result, err := r.db.Exec(
+		query,
+		dbo.ID,
+		dbo.Name,
+		dbo.Amount,
+		dbo.Currency,
+		dbo.Category,
+		dbo.Source,
+		dbo.Comment,
+		dbo.VendorID,
+		dbo.MemberID,
+		dbo.PaidByCard,
+		pq.Array(dbo.TagIDs),
+		dbo.Frequency,
+		dbo.Interval,
+		dbo.DayOfMonth,
+		dbo.StartDate,
+		dbo.EndDate,
+		dbo.Active,
+		dbo.UpdatedAt,
+		int(householdID),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update recurring rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrRecurringRuleNotFound
+	}
+
+	return nil
+}
+
+func (r *RecurringRuleRepositoryImpl) Delete(householdID entities.HouseholdID, id entities.RecurringRuleID) error {
+	query := `DELETE FROM recurring_rules WHERE id = $1 AND household_id = $2`
+
+	result, err := r.db.Exec(query, int(id), int(householdID))
+	if err != nil {
+		return fmt.Errorf("failed to delete recurring rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrRecurringRuleNotFound
+	}
+
+	return nil
+}
+
+func (r *RecurringRuleRepositoryImpl) FindOccurrences(ruleID entities.RecurringRuleID, from, to time.Time) ([]*entities.RecurringOccurrence, error) {
+	query := `
+		SELECT rule_id, date, status, expense_id, income_id, created_at
+		FROM recurring_occurrences
+		WHERE rule_id = $1 AND date BETWEEN $2 AND $3
+		ORDER BY date
+	`
+
+	rows, err := r.db.Query(query, int(ruleID), from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find recurring occurrences: %w", err)
+	}
+	defer rows.Close()
+
+	var occurrences []*entities.RecurringOccurrence
+	for rows.Next() {
+		var dbo models.RecurringOccurrenceDBO
+		if err := rows.Scan(&dbo.RuleID, &dbo.Date, &dbo.Status, &dbo.ExpenseID, &dbo.IncomeID, &dbo.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan recurring occurrence: %w", err)
+		}
+		occurrences = append(occurrences, dbo.ToDomainEntity())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating recurring occurrences: %w", err)
+	}
+
+	return occurrences, nil
+}
+
+func (r *RecurringRuleRepositoryImpl) ClaimOccurrence(ruleID entities.RecurringRuleID, date time.Time, status entities.OccurrenceStatus) (bool, error) {
+	query := `
+		INSERT INTO recurring_occurrences (rule_id, date, status)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (rule_id, date) DO NOTHING
+	`
+
+	result, err := r.db.Exec(query, int(ruleID), date, string(status))
+	if err != nil {
+		return false, fmt.Errorf("failed to claim recurring occurrence: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (r *RecurringRuleRepositoryImpl) CompleteOccurrence(ruleID entities.RecurringRuleID, date time.Time, expenseID *entities.ExpenseID, incomeID *entities.IncomeID) error {
+	query := `
+		UPDATE recurring_occurrences
+		SET status = 'created', expense_id = $3, income_id = $4
+		WHERE rule_id = $1 AND date = $2 AND status = 'pending'
+	`
+
+	var expenseIDValue, incomeIDValue *int
+	if expenseID != nil {
+		id := int(*expenseID)
+		expenseIDValue = &id
+	}
+	if incomeID != nil {
+		id := int(*incomeID)
+		incomeIDValue = &id
+	}
+
+	if _, err := r.db.Exec(query, int(ruleID), date, expenseIDValue, incomeIDValue); err != nil {
+		return fmt.Errorf("failed to complete recurring occurrence: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RecurringRuleRepositoryImpl) findRules(query string, args ...interface{}) ([]repositories.ScheduledRule, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find recurring rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []repositories.ScheduledRule
+	for rows.Next() {
+		dbo, err := r.scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recurring rule: %w", err)
+		}
+		rule, err := dbo.ToDomainEntity()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, repositories.ScheduledRule{HouseholdID: entities.HouseholdID(dbo.HouseholdID), Rule: rule})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating recurring rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+func (r *RecurringRuleRepositoryImpl) scan(row interface{ Scan(...interface{}) error }) (*models.RecurringRuleDBO, error) {
+	var dbo models.RecurringRuleDBO
+	err := row.Scan(
+		&dbo.ID,
+		&dbo.HouseholdID,
+		&dbo.Kind,
+		&dbo.Name,
+		&dbo.Amount,
+		&dbo.Currency,
+		&dbo.Category,
+		&dbo.Source,
+		&dbo.Comment,
+		&dbo.VendorID,
+		&dbo.MemberID,
+		&dbo.PaidByCard,
+		pq.Array(&dbo.TagIDs),
+		&dbo.Frequency,
+		&dbo.Interval,
+		&dbo.DayOfMonth,
+		&dbo.StartDate,
+		&dbo.EndDate,
+		&dbo.Active,
+		&dbo.CreatedAt,
+		&dbo.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &dbo, nil
+}
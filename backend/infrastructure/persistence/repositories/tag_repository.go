@@ -2,25 +2,29 @@ package repositories
 
 import (
 	"database/sql"
+	"fmt"
 	"time"
 
 	"expenso-backend/domain/entities"
+	"expenso-backend/usecases/interfaces/repositories"
+
+	"github.com/lib/pq"
 )
 
 type TagRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewTagRepository(db *sql.DB) *TagRepository {
+func NewTagRepository(db DBTX) *TagRepository {
 	return &TagRepository{db: db}
 }
 
-func (r *TagRepository) Create(tag *entities.Tag) error {
-	query := `INSERT INTO tags (name, color, created_at, updated_at) 
-			  VALUES ($1, $2, $3, $4) RETURNING id`
+func (r *TagRepository) Create(householdID entities.HouseholdID, tag *entities.Tag) error {
+	query := `INSERT INTO tags (name, color, created_at, updated_at, household_id) 
+			  VALUES ($1, $2, $3, $4, $5) RETURNING id`
 
 	var id int
-	err := r.db.QueryRow(query, tag.Name(), tag.Color(), tag.CreatedAt(), tag.UpdatedAt()).Scan(&id)
+	err := r.db.QueryRow(query, tag.Name(), tag.Color(), tag.CreatedAt(), tag.UpdatedAt(), householdID).Scan(&id)
 	if err != nil {
 		return err
 	}
@@ -29,13 +33,13 @@ func (r *TagRepository) Create(tag *entities.Tag) error {
 	return nil
 }
 
-func (r *TagRepository) GetByID(id entities.TagID) (*entities.Tag, error) {
-	query := `SELECT id, name, color, created_at, updated_at FROM tags WHERE id = $1`
+func (r *TagRepository) GetByID(householdID entities.HouseholdID, id entities.TagID) (*entities.Tag, error) {
+	query := `SELECT id, name, color, created_at, updated_at FROM tags WHERE id = $1 AND household_id = $2`
 
 	var name, color string
 	var createdAt, updatedAt time.Time
 
-	err := r.db.QueryRow(query, id).Scan(&id, &name, &color, &createdAt, &updatedAt)
+	err := r.db.QueryRow(query, id, householdID).Scan(&id, &name, &color, &createdAt, &updatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -46,10 +50,10 @@ func (r *TagRepository) GetByID(id entities.TagID) (*entities.Tag, error) {
 	return entities.ReconstructTag(id, name, color, createdAt, updatedAt), nil
 }
 
-func (r *TagRepository) GetAll() ([]*entities.Tag, error) {
-	query := `SELECT id, name, color, created_at, updated_at FROM tags ORDER BY name`
+func (r *TagRepository) GetAll(householdID entities.HouseholdID) ([]*entities.Tag, error) {
+	query := `SELECT id, name, color, created_at, updated_at FROM tags WHERE household_id = $1 ORDER BY name`
 
-	rows, err := r.db.Query(query)
+	rows, err := r.db.Query(query, householdID)
 	if err != nil {
 		return nil, err
 	}
@@ -72,17 +76,72 @@ func (r *TagRepository) GetAll() ([]*entities.Tag, error) {
 	return tags, rows.Err()
 }
 
-func (r *TagRepository) Update(tag *entities.Tag) error {
-	query := `UPDATE tags SET name = $2, color = $3, updated_at = $4 WHERE id = $1`
+func (r *TagRepository) GetPage(householdID entities.HouseholdID, page repositories.PageRequest) (*repositories.TagPage, error) {
+	var sortExpr string
+	switch page.Sort {
+	case repositories.SortByName:
+		sortExpr = "name"
+	case repositories.SortByCreatedAt:
+		sortExpr = "created_at"
+	default:
+		return nil, fmt.Errorf("cannot sort tags by %q", page.Sort)
+	}
+
+	var q listQuery
+	q.where("household_id = ?", int(householdID))
+
+	total, err := q.count(r.db, "tags")
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tags: %w", err)
+	}
+
+	order := q.paginate(page, sortExpr, "id")
+	rows, err := r.db.Query(`SELECT id, name, color, created_at, updated_at FROM tags`+q.whereClause()+order, q.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []*entities.Tag
+	var cursors []repositories.Cursor
+	for rows.Next() {
+		var id entities.TagID
+		var name, color string
+		var createdAt, updatedAt time.Time
 
-	_, err := r.db.Exec(query, tag.ID(), tag.Name(), tag.Color(), tag.UpdatedAt())
+		if err := rows.Scan(&id, &name, &color, &createdAt, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+
+		tags = append(tags, entities.ReconstructTag(id, name, color, createdAt, updatedAt))
+		cursor := repositories.Cursor{Value: name, ID: int(id)}
+		if page.Sort == repositories.SortByCreatedAt {
+			cursor.Value = createdAt.Format(time.RFC3339Nano)
+		}
+		cursors = append(cursors, cursor)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find tags: %w", err)
+	}
+
+	next, keep := nextCursor(page, cursors)
+	return &repositories.TagPage{
+		Tags:     tags[:keep],
+		PageInfo: repositories.PageInfo{Total: total, NextCursor: next},
+	}, nil
+}
+
+func (r *TagRepository) Update(householdID entities.HouseholdID, tag *entities.Tag) error {
+	query := `UPDATE tags SET name = $2, color = $3, updated_at = $4 WHERE id = $1 AND household_id = $5`
+
+	_, err := r.db.Exec(query, tag.ID(), tag.Name(), tag.Color(), tag.UpdatedAt(), householdID)
 	return err
 }
 
-func (r *TagRepository) Delete(id entities.TagID) error {
-	query := `DELETE FROM tags WHERE id = $1`
+func (r *TagRepository) Delete(householdID entities.HouseholdID, id entities.TagID) error {
+	query := `DELETE FROM tags WHERE id = $1 AND household_id = $2`
 
-	_, err := r.db.Exec(query, id)
+	_, err := r.db.Exec(query, id, householdID)
 	return err
 }
 
@@ -187,3 +246,65 @@ func (r *TagRepository) ClearIncomeTags(incomeID entities.IncomeID) error {
 	_, err := r.db.Exec(query, incomeID)
 	return err
 }
+
+// tagsByExpenseIDs loads the tags of a page of expenses in one query
+func (r *TagRepository) tagsByExpenseIDs(expenseIDs []int) (map[entities.ExpenseID][]*entities.Tag, error) {
+	query := `SELECT et.expense_id, t.id, t.name, t.color, t.created_at, t.updated_at
+			  FROM tags t
+			  INNER JOIN expense_tags et ON t.id = et.tag_id
+			  WHERE et.expense_id = ANY($1)
+			  ORDER BY t.name`
+
+	rows, err := r.db.Query(query, pq.Array(expenseIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make(map[entities.ExpenseID][]*entities.Tag)
+	for rows.Next() {
+		var expenseID entities.ExpenseID
+		var id entities.TagID
+		var name, color string
+		var createdAt, updatedAt time.Time
+
+		if err := rows.Scan(&expenseID, &id, &name, &color, &createdAt, &updatedAt); err != nil {
+			return nil, err
+		}
+
+		tags[expenseID] = append(tags[expenseID], entities.ReconstructTag(id, name, color, createdAt, updatedAt))
+	}
+
+	return tags, rows.Err()
+}
+
+// tagsByIncomeIDs loads the tags of a page of incomes in one query
+func (r *TagRepository) tagsByIncomeIDs(incomeIDs []int) (map[entities.IncomeID][]*entities.Tag, error) {
+	query := `SELECT it.income_id, t.id, t.name, t.color, t.created_at, t.updated_at
+			  FROM tags t
+			  INNER JOIN income_tags it ON t.id = it.tag_id
+			  WHERE it.income_id = ANY($1)
+			  ORDER BY t.name`
+
+	rows, err := r.db.Query(query, pq.Array(incomeIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make(map[entities.IncomeID][]*entities.Tag)
+	for rows.Next() {
+		var incomeID entities.IncomeID
+		var id entities.TagID
+		var name, color string
+		var createdAt, updatedAt time.Time
+
+		if err := rows.Scan(&incomeID, &id, &name, &color, &createdAt, &updatedAt); err != nil {
+			return nil, err
+		}
+
+		tags[incomeID] = append(tags[incomeID], entities.ReconstructTag(id, name, color, createdAt, updatedAt))
+	}
+
+	return tags, rows.Err()
+}
@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"expenso-backend/usecases/interfaces/repositories"
+)
+
+type UnitOfWorkImpl struct {
+	db *sql.DB
+}
+
+func NewUnitOfWork(db *sql.DB) repositories.UnitOfWork {
+	return &UnitOfWorkImpl{db: db}
+}
+
+// Do hands fn repositories bound to a new transaction, committed if fn succeeds and rolled back otherwise.
+// A transaction is one connection: the expense and income listing methods, which load tags while reading rows, do not work on it.
+func (u *UnitOfWorkImpl) Do(fn func(repos repositories.Repositories) error) error {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	tagRepo := NewTagRepository(tx)
+	repos := repositories.Repositories{
+		Expenses:            NewExpenseRepository(tx, tagRepo),
+		Incomes:             NewIncomeRepository(tx, tagRepo),
+		Vendors:             NewVendorRepository(tx),
+		Tags:                tagRepo,
+		Members:             NewMemberRepository(tx),
+		ImportBatches:       NewImportBatchRepository(tx),
+		Categories:          NewCategoryRepository(tx),
+		Budgets:             NewBudgetRepository(tx),
+		RecurringRules:      NewRecurringRuleRepository(tx),
+		ImportProfiles:      NewImportProfileRepository(tx),
+		CategorizationRules: NewCategorizationRuleRepository(tx),
+	}
+
+	if err := fn(repos); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
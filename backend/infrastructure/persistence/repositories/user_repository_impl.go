@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/infrastructure/persistence/models"
+	"expenso-backend/usecases/interfaces/repositories"
+)
+
+type UserRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewUserRepository(db *sql.DB) repositories.UserRepository {
+	return &UserRepositoryImpl{db: db}
+}
+
+func (r *UserRepositoryImpl) Save(user *entities.User) error {
+	query := `
+		INSERT INTO users (email, name, password_hash, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	var id int
+	err := r.db.QueryRow(
+		query,
+		user.Email(),
+		user.Name(),
+		user.PasswordHash(),
+		user.CreatedAt(),
+		user.UpdatedAt(),
+	).Scan(&id)
+
+	if err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+
+	user.SetID(entities.UserID(id))
+	return nil
+}
+
+func (r *UserRepositoryImpl) FindByID(id entities.UserID) (*entities.User, error) {
+	query := `SELECT id, email, name, password_hash, created_at, updated_at FROM users WHERE id = $1`
+
+	var dbo models.UserDBO
+	row := r.db.QueryRow(query, int(id))
+	err := row.Scan(&dbo.ID, &dbo.Email, &dbo.Name, &dbo.PasswordHash, &dbo.CreatedAt, &dbo.UpdatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	return dbo.ToDomainEntity(), nil
+}
+
+func (r *UserRepositoryImpl) FindByEmail(email string) (*entities.User, error) {
+	query := `SELECT id, email, name, password_hash, created_at, updated_at FROM users WHERE email = $1`
+
+	var dbo models.UserDBO
+	row := r.db.QueryRow(query, email)
+	err := row.Scan(&dbo.ID, &dbo.Email, &dbo.Name, &dbo.PasswordHash, &dbo.CreatedAt, &dbo.UpdatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to find user by email: %w", err)
+	}
+
+	return dbo.ToDomainEntity(), nil
+}
+
+func (r *UserRepositoryImpl) Count() (int, error) {
+	var count int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM users`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+	return count, nil
+}
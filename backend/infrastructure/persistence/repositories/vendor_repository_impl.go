@@ -3,6 +3,7 @@ package repositories
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	"expenso-backend/domain/entities"
 	"expenso-backend/infrastructure/persistence/models"
@@ -10,17 +11,17 @@ import (
 )
 
 type VendorRepositoryImpl struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewVendorRepository(db *sql.DB) repositories.VendorRepository {
+func NewVendorRepository(db DBTX) repositories.VendorRepository {
 	return &VendorRepositoryImpl{db: db}
 }
 
-func (r *VendorRepositoryImpl) Save(vendor *entities.Vendor) error {
+func (r *VendorRepositoryImpl) Save(householdID entities.HouseholdID, vendor *entities.Vendor) error {
 	query := `
-		INSERT INTO vendors (name, type, created_at, updated_at)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO vendors (name, type, created_at, updated_at, household_id)
+		VALUES ($1, $2, $3, $4, $5)
 		RETURNING id
 	`
 
@@ -31,6 +32,7 @@ func (r *VendorRepositoryImpl) Save(vendor *entities.Vendor) error {
 		string(vendor.Type()),
 		vendor.CreatedAt(),
 		vendor.UpdatedAt(),
+		int(householdID),
 	).Scan(&id)
 
 	if err != nil {
@@ -41,11 +43,11 @@ func (r *VendorRepositoryImpl) Save(vendor *entities.Vendor) error {
 	return nil
 }
 
-func (r *VendorRepositoryImpl) FindByID(id entities.VendorID) (*entities.Vendor, error) {
-	query := `SELECT id, name, type, created_at, updated_at FROM vendors WHERE id = $1`
+func (r *VendorRepositoryImpl) FindByID(householdID entities.HouseholdID, id entities.VendorID) (*entities.Vendor, error) {
+	query := `SELECT id, name, type, created_at, updated_at FROM vendors WHERE id = $1 AND household_id = $2`
 
 	var dbo models.VendorDBO
-	row := r.db.QueryRow(query, int(id))
+	row := r.db.QueryRow(query, int(id), int(householdID))
 	err := row.Scan(&dbo.ID, &dbo.Name, &dbo.Type, &dbo.CreatedAt, &dbo.UpdatedAt)
 
 	if err != nil {
@@ -58,10 +60,10 @@ func (r *VendorRepositoryImpl) FindByID(id entities.VendorID) (*entities.Vendor,
 	return dbo.ToDomainEntity(), nil
 }
 
-func (r *VendorRepositoryImpl) FindAll() ([]*entities.Vendor, error) {
-	query := `SELECT id, name, type, created_at, updated_at FROM vendors ORDER BY name ASC`
+func (r *VendorRepositoryImpl) FindAll(householdID entities.HouseholdID) ([]*entities.Vendor, error) {
+	query := `SELECT id, name, type, created_at, updated_at FROM vendors WHERE household_id = $1 ORDER BY name ASC`
 
-	rows, err := r.db.Query(query)
+	rows, err := r.db.Query(query, int(householdID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to find vendors: %w", err)
 	}
@@ -81,10 +83,10 @@ func (r *VendorRepositoryImpl) FindAll() ([]*entities.Vendor, error) {
 	return vendors, nil
 }
 
-func (r *VendorRepositoryImpl) FindByType(vendorType entities.VendorType) ([]*entities.Vendor, error) {
-	query := `SELECT id, name, type, created_at, updated_at FROM vendors WHERE type = $1 ORDER BY name ASC`
+func (r *VendorRepositoryImpl) FindByType(householdID entities.HouseholdID, vendorType entities.VendorType) ([]*entities.Vendor, error) {
+	query := `SELECT id, name, type, created_at, updated_at FROM vendors WHERE household_id = $1 AND type = $2 ORDER BY name ASC`
 
-	rows, err := r.db.Query(query, string(vendorType))
+	rows, err := r.db.Query(query, int(householdID), string(vendorType))
 	if err != nil {
 		return nil, fmt.Errorf("failed to find vendors by type: %w", err)
 	}
@@ -104,11 +106,11 @@ func (r *VendorRepositoryImpl) FindByType(vendorType entities.VendorType) ([]*en
 	return vendors, nil
 }
 
-func (r *VendorRepositoryImpl) Update(vendor *entities.Vendor) error {
+func (r *VendorRepositoryImpl) Update(householdID entities.HouseholdID, vendor *entities.Vendor) error {
 	query := `
 		UPDATE vendors 
 		SET name = $2, type = $3, updated_at = $4
-		WHERE id = $1
+		WHERE id = $1 AND household_id = $5
 	`
 
 	result, err := r.db.Exec(
@@ -117,6 +119,7 @@ func (r *VendorRepositoryImpl) Update(vendor *entities.Vendor) error {
 		vendor.Name(),
 		string(vendor.Type()),
 		vendor.UpdatedAt(),
+		int(householdID),
 	)
 
 	if err != nil {
@@ -135,10 +138,10 @@ func (r *VendorRepositoryImpl) Update(vendor *entities.Vendor) error {
 	return nil
 }
 
-func (r *VendorRepositoryImpl) Delete(id entities.VendorID) error {
-	query := `DELETE FROM vendors WHERE id = $1`
+func (r *VendorRepositoryImpl) Delete(householdID entities.HouseholdID, id entities.VendorID) error {
+	query := `DELETE FROM vendors WHERE id = $1 AND household_id = $2`
 
-	result, err := r.db.Exec(query, int(id))
+	result, err := r.db.Exec(query, int(id), int(householdID))
 	if err != nil {
 		return fmt.Errorf("failed to delete vendor: %w", err)
 	}
@@ -155,11 +158,11 @@ func (r *VendorRepositoryImpl) Delete(id entities.VendorID) error {
 	return nil
 }
 
-func (r *VendorRepositoryImpl) FindByName(name string) (*entities.Vendor, error) {
-	query := `SELECT id, name, type, created_at, updated_at FROM vendors WHERE name = $1 LIMIT 1`
+func (r *VendorRepositoryImpl) FindByName(householdID entities.HouseholdID, name string) (*entities.Vendor, error) {
+	query := `SELECT id, name, type, created_at, updated_at FROM vendors WHERE household_id = $1 AND name = $2 LIMIT 1`
 
 	var dbo models.VendorDBO
-	row := r.db.QueryRow(query, name)
+	row := r.db.QueryRow(query, int(householdID), name)
 	err := row.Scan(&dbo.ID, &dbo.Name, &dbo.Type, &dbo.CreatedAt, &dbo.UpdatedAt)
 
 	if err != nil {
@@ -170,4 +173,59 @@ func (r *VendorRepositoryImpl) FindByName(name string) (*entities.Vendor, error)
 	}
 
 	return dbo.ToDomainEntity(), nil
-}
\ No newline at end of file
+}
+
+func (r *VendorRepositoryImpl) FindPage(householdID entities.HouseholdID, vendorType entities.VendorType, page repositories.PageRequest) (*repositories.VendorPage, error) {
+	var sortExpr string
+	switch page.Sort {
+	case repositories.SortByName:
+		sortExpr = "name"
+	case repositories.SortByCreatedAt:
+		sortExpr = "created_at"
+	default:
+		return nil, fmt.Errorf("cannot sort vendors by %q", page.Sort)
+	}
+
+	var q listQuery
+	q.where("household_id = ?", int(householdID))
+	if vendorType != "" {
+		q.where("type = ?", string(vendorType))
+	}
+
+	total, err := q.count(r.db, "vendors")
+	if err != nil {
+		return nil, fmt.Errorf("failed to count vendors: %w", err)
+	}
+
+	order := q.paginate(page, sortExpr, "id")
+	rows, err := r.db.Query(`SELECT id, name, type, created_at, updated_at FROM vendors`+q.whereClause()+order, q.args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find vendors: %w", err)
+	}
+	defer rows.Close()
+
+	var vendors []*entities.Vendor
+	var cursors []repositories.Cursor
+	for rows.Next() {
+		var dbo models.VendorDBO
+		if err := rows.Scan(&dbo.ID, &dbo.Name, &dbo.Type, &dbo.CreatedAt, &dbo.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan vendor: %w", err)
+		}
+
+		vendors = append(vendors, dbo.ToDomainEntity())
+		cursor := repositories.Cursor{Value: dbo.Name, ID: dbo.ID}
+		if page.Sort == repositories.SortByCreatedAt {
+			cursor.Value = dbo.CreatedAt.Format(time.RFC3339Nano)
+		}
+		cursors = append(cursors, cursor)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find vendors: %w", err)
+	}
+
+	next, keep := nextCursor(page, cursors)
+	return &repositories.VendorPage{
+		Vendors:  vendors[:keep],
+		PageInfo: repositories.PageInfo{Total: total, NextCursor: next},
+	}, nil
+}
@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"expenso-backend/usecases/interactors/recurring"
+)
+
+// RecurringScheduler periodically creates the transactions of recurring rules that fell due
+type RecurringScheduler struct {
+	interactor *recurring.RecurringInteractor
+	interval   time.Duration
+}
+
+func NewRecurringScheduler(interactor *recurring.RecurringInteractor, interval time.Duration) *RecurringScheduler {
+	return &RecurringScheduler{
+		interactor: interactor,
+		interval:   interval,
+	}
+}
+
+// Start runs the scheduler in a goroutine until ctx is cancelled.
+// The first run happens right away so occurrences missed while the server was down are caught up on startup.
+func (s *RecurringScheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			s.run()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *RecurringScheduler) run() {
+	created, err := s.interactor.MaterializeDue(time.Now())
+	if err != nil {
+		log.Printf("Recurring scheduler failed: %v", err)
+		return
+	}
+	if created > 0 {
+		log.Printf("Recurring scheduler created %d transactions", created)
+	}
+}
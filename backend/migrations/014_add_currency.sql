@@ -0,0 +1,8 @@
+-- Add ISO-4217 currency code to expenses and incomes
+-- Existing rows were all entered in euros
+
+ALTER TABLE expenses ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'EUR' CHECK (currency ~ '^[A-Z]{3}$');
+ALTER TABLE incomes ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'EUR' CHECK (currency ~ '^[A-Z]{3}$');
+
+CREATE INDEX idx_expenses_currency ON expenses(currency);
+CREATE INDEX idx_incomes_currency ON incomes(currency);
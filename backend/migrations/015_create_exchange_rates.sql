@@ -0,0 +1,15 @@
+-- Create exchange rates table
+-- Each row is the price of one unit of base_currency in quote_currency on a given day
+CREATE TABLE exchange_rates (
+    id SERIAL PRIMARY KEY,
+    base_currency CHAR(3) NOT NULL CHECK (base_currency ~ '^[A-Z]{3}$'),
+    quote_currency CHAR(3) NOT NULL CHECK (quote_currency ~ '^[A-Z]{3}$'),
+    date DATE NOT NULL,
+    rate NUMERIC(18,8) NOT NULL CHECK (rate > 0),
+    source VARCHAR(50) NOT NULL DEFAULT 'ecb',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(base_currency, quote_currency, date)
+);
+
+CREATE INDEX idx_exchange_rates_pair_date ON exchange_rates(base_currency, quote_currency, date DESC);
+CREATE INDEX idx_exchange_rates_date ON exchange_rates(date);
@@ -0,0 +1,5 @@
+-- Widen amount columns so three-decimal currencies (BHD, KWD, ...) and large
+-- zero-decimal amounts (JPY, KRW, ...) are stored exactly
+
+ALTER TABLE expenses ALTER COLUMN amount TYPE NUMERIC(15,3);
+ALTER TABLE incomes ALTER COLUMN amount TYPE NUMERIC(15,3);
@@ -0,0 +1,27 @@
+-- Replace the hard-coded 'he'/'she' added_by enum with household members
+
+CREATE TABLE members (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_members_name ON members(LOWER(name));
+
+-- Seed the two members the old enum knew about
+INSERT INTO members (name) VALUES ('he'), ('she');
+
+-- Expenses and incomes reference members by ID
+ALTER TABLE expenses ADD COLUMN member_id INTEGER REFERENCES members(id) ON DELETE RESTRICT;
+ALTER TABLE incomes ADD COLUMN member_id INTEGER REFERENCES members(id) ON DELETE RESTRICT;
+
+UPDATE expenses e SET member_id = m.id FROM members m WHERE m.name = e.added_by;
+UPDATE incomes i SET member_id = m.id FROM members m WHERE m.name = i.added_by;
+
+CREATE INDEX idx_expenses_member_id ON expenses(member_id);
+CREATE INDEX idx_incomes_member_id ON incomes(member_id);
+
+-- Dropping the columns also drops their CHECK constraints and indexes
+ALTER TABLE expenses DROP COLUMN added_by;
+ALTER TABLE incomes DROP COLUMN added_by;
@@ -0,0 +1,65 @@
+-- Multi-household tenancy: every expense, income, vendor, category, tag and member belongs to one household
+
+CREATE TABLE households (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE household_users (
+    household_id INTEGER NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'member')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (household_id, user_id)
+);
+
+CREATE INDEX idx_household_users_user_id ON household_users(user_id);
+
+-- Existing data moves into a single household owned by every existing user.
+-- If there are no users yet, the first account to register claims it.
+INSERT INTO households (name) VALUES ('Home');
+
+INSERT INTO household_users (household_id, user_id, role)
+SELECT (SELECT MIN(id) FROM households), id, 'owner' FROM users;
+
+ALTER TABLE categories ADD COLUMN household_id INTEGER REFERENCES households(id) ON DELETE CASCADE;
+ALTER TABLE vendors ADD COLUMN household_id INTEGER REFERENCES households(id) ON DELETE CASCADE;
+ALTER TABLE tags ADD COLUMN household_id INTEGER REFERENCES households(id) ON DELETE CASCADE;
+ALTER TABLE members ADD COLUMN household_id INTEGER REFERENCES households(id) ON DELETE CASCADE;
+ALTER TABLE expenses ADD COLUMN household_id INTEGER REFERENCES households(id) ON DELETE CASCADE;
+ALTER TABLE incomes ADD COLUMN household_id INTEGER REFERENCES households(id) ON DELETE CASCADE;
+
+UPDATE categories SET household_id = (SELECT MIN(id) FROM households);
+UPDATE vendors SET household_id = (SELECT MIN(id) FROM households);
+UPDATE tags SET household_id = (SELECT MIN(id) FROM households);
+UPDATE members SET household_id = (SELECT MIN(id) FROM households);
+UPDATE expenses SET household_id = (SELECT MIN(id) FROM households);
+UPDATE incomes SET household_id = (SELECT MIN(id) FROM households);
+
+ALTER TABLE categories ALTER COLUMN household_id SET NOT NULL;
+ALTER TABLE vendors ALTER COLUMN household_id SET NOT NULL;
+ALTER TABLE tags ALTER COLUMN household_id SET NOT NULL;
+ALTER TABLE members ALTER COLUMN household_id SET NOT NULL;
+ALTER TABLE expenses ALTER COLUMN household_id SET NOT NULL;
+ALTER TABLE incomes ALTER COLUMN household_id SET NOT NULL;
+
+CREATE INDEX idx_expenses_household_id ON expenses(household_id);
+CREATE INDEX idx_incomes_household_id ON incomes(household_id);
+
+-- Names are unique per household instead of globally
+ALTER TABLE expenses DROP CONSTRAINT IF EXISTS expenses_category_fkey;
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS categories_name_key;
+ALTER TABLE categories ADD CONSTRAINT categories_household_name_key UNIQUE (household_id, name);
+ALTER TABLE expenses ADD CONSTRAINT expenses_category_fkey
+    FOREIGN KEY (household_id, category) REFERENCES categories(household_id, name) ON UPDATE CASCADE;
+
+ALTER TABLE vendors DROP CONSTRAINT IF EXISTS vendors_name_type_key;
+ALTER TABLE vendors ADD CONSTRAINT vendors_household_name_type_key UNIQUE (household_id, name, type);
+
+ALTER TABLE tags DROP CONSTRAINT IF EXISTS tags_name_key;
+ALTER TABLE tags ADD CONSTRAINT tags_household_name_key UNIQUE (household_id, name);
+
+DROP INDEX IF EXISTS idx_members_name;
+CREATE UNIQUE INDEX idx_members_name ON members(household_id, LOWER(name));
@@ -0,0 +1,15 @@
+-- Spending budgets per category or vendor type
+
+CREATE TABLE budgets (
+    id SERIAL PRIMARY KEY,
+    household_id INTEGER NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    amount NUMERIC(15,3) NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL DEFAULT 'EUR' CHECK (currency ~ '^[A-Z]{3}$'),
+    period VARCHAR(20) NOT NULL CHECK (period IN ('monthly', 'quarterly', 'yearly')),
+    target_type VARCHAR(20) NOT NULL CHECK (target_type IN ('category', 'vendor_type')),
+    target VARCHAR(255) NOT NULL,
+    rollover BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (household_id, target_type, target, period)
+);
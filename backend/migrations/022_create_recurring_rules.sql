@@ -0,0 +1,40 @@
+-- Recurring expense and income templates, materialized by the scheduler
+
+CREATE TABLE recurring_rules (
+    id SERIAL PRIMARY KEY,
+    household_id INTEGER NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('expense', 'income')),
+    name VARCHAR(255) NOT NULL,
+    amount NUMERIC(15,3) NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL DEFAULT 'EUR' CHECK (currency ~ '^[A-Z]{3}$'),
+    category VARCHAR(255) NOT NULL DEFAULT '',
+    source VARCHAR(255) NOT NULL DEFAULT '',
+    comment TEXT NOT NULL DEFAULT '',
+    vendor_id INTEGER REFERENCES vendors(id) ON DELETE SET NULL,
+    member_id INTEGER REFERENCES members(id) ON DELETE SET NULL,
+    paid_by_card BOOLEAN NOT NULL DEFAULT TRUE,
+    tag_ids INTEGER[] NOT NULL DEFAULT '{}',
+    frequency VARCHAR(20) NOT NULL CHECK (frequency IN ('weekly', 'monthly', 'yearly')),
+    repeat_interval INTEGER NOT NULL DEFAULT 1 CHECK (repeat_interval > 0),
+    day_of_month INTEGER NOT NULL DEFAULT 0 CHECK (day_of_month BETWEEN 0 AND 31),
+    start_date DATE NOT NULL,
+    end_date DATE CHECK (end_date IS NULL OR end_date >= start_date),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_recurring_rules_household ON recurring_rules(household_id);
+
+-- One row per handled date. The unique key is what keeps the scheduler from creating a transaction twice,
+-- also when several instances run or the server restarts halfway through.
+CREATE TABLE recurring_occurrences (
+    id SERIAL PRIMARY KEY,
+    rule_id INTEGER NOT NULL REFERENCES recurring_rules(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'created', 'skipped')),
+    expense_id INTEGER REFERENCES expenses(id) ON DELETE SET NULL,
+    income_id INTEGER REFERENCES incomes(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (rule_id, date)
+);
@@ -0,0 +1,24 @@
+-- Saved CSV layouts for the expense import
+
+CREATE TABLE import_profiles (
+    id SERIAL PRIMARY KEY,
+    household_id INTEGER NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    delimiter VARCHAR(4) NOT NULL DEFAULT ',',
+    encoding VARCHAR(20) NOT NULL DEFAULT 'utf-8' CHECK (encoding IN ('utf-8', 'windows-1252', 'iso-8859-1')),
+    decimal_separator CHAR(1) NOT NULL DEFAULT '.' CHECK (decimal_separator IN ('.', ',')),
+    date_formats TEXT[] NOT NULL,
+    skip_rows INTEGER NOT NULL DEFAULT 0 CHECK (skip_rows >= 0),
+    layout VARCHAR(10) NOT NULL CHECK (layout IN ('wide', 'long')),
+    date_column VARCHAR(255) NOT NULL,
+    amount_column VARCHAR(255) NOT NULL DEFAULT '',
+    payee_column VARCHAR(255) NOT NULL DEFAULT '',
+    memo_column VARCHAR(255) NOT NULL DEFAULT '',
+    vendor_type_columns JSONB NOT NULL DEFAULT '{}',
+    currency CHAR(3) NOT NULL DEFAULT 'EUR' CHECK (currency ~ '^[A-Z]{3}$'),
+    default_category VARCHAR(255) NOT NULL DEFAULT '',
+    rules JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (household_id, name)
+);
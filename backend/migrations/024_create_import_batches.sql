@@ -0,0 +1,25 @@
+-- Confirmed imports and the transactions they created, for duplicate detection and rollback
+
+CREATE TABLE import_batches (
+    id SERIAL PRIMARY KEY,
+    household_id INTEGER NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    source VARCHAR(20) NOT NULL,
+    file_hash VARCHAR(64) NOT NULL DEFAULT '',
+    expense_count INTEGER NOT NULL DEFAULT 0,
+    income_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    rolled_back_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_import_batches_file_hash ON import_batches(household_id, file_hash) WHERE rolled_back_at IS NULL;
+
+CREATE TABLE import_batch_items (
+    id SERIAL PRIMARY KEY,
+    batch_id INTEGER NOT NULL REFERENCES import_batches(id) ON DELETE CASCADE,
+    fingerprint TEXT NOT NULL,
+    expense_id INTEGER REFERENCES expenses(id) ON DELETE SET NULL,
+    income_id INTEGER REFERENCES incomes(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_import_batch_items_batch ON import_batch_items(batch_id);
+CREATE INDEX idx_import_batch_items_fingerprint ON import_batch_items(fingerprint);
@@ -0,0 +1,23 @@
+-- User-defined rules that fill in vendor, category and tags of new and imported expenses
+
+CREATE TABLE categorization_rules (
+    id SERIAL PRIMARY KEY,
+    household_id INTEGER NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    priority INTEGER NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    pattern TEXT NOT NULL DEFAULT '',
+    vendor_type VARCHAR(50) NOT NULL DEFAULT '',
+    amount NUMERIC(15,3) CHECK (amount >= 0),
+    min_amount NUMERIC(15,3) CHECK (min_amount >= 0),
+    max_amount NUMERIC(15,3) CHECK (max_amount >= 0),
+    currency CHAR(3) NOT NULL DEFAULT 'EUR' CHECK (currency ~ '^[A-Z]{3}$'),
+    vendor_id INTEGER REFERENCES vendors(id) ON DELETE SET NULL,
+    category VARCHAR(255) NOT NULL DEFAULT '',
+    tag_ids INTEGER[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (household_id, name)
+);
+
+CREATE INDEX idx_categorization_rules_household ON categorization_rules(household_id, priority, id);
@@ -0,0 +1,207 @@
+package analytics
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+	"expenso-backend/usecases/interfaces/repositories"
+	"expenso-backend/usecases/interfaces/services"
+)
+
+var (
+	ErrInvalidPeriod    = errors.New("period must be one of day, week, month, quarter, year")
+	ErrInvalidDimension = errors.New("group_by must be one of category, vendor, vendor_type, tag, member, payment_method")
+)
+
+// Stats summarises the amounts of some transactions in the reporting currency
+type Stats struct {
+	Count   int
+	Sum     valueobjects.Money
+	Average valueobjects.Money
+	Min     valueobjects.Money
+	Max     valueobjects.Money
+}
+
+// Group holds the expenses and incomes of a bucket that share a dimension value.
+// Expenses or Incomes is nil if the group has none.
+type Group struct {
+	Key      string
+	Label    string
+	Expenses *Stats
+	Incomes  *Stats
+}
+
+// Bucket is one period, from its first to its last day
+type Bucket struct {
+	Start  time.Time
+	End    time.Time
+	Groups []Group
+}
+
+type Aggregation struct {
+	Period    repositories.Period
+	Dimension repositories.Dimension
+	Currency  string
+	Buckets   []Bucket
+}
+
+type AnalyticsInteractor struct {
+	expenseRepo repositories.ExpenseRepository
+	incomeRepo  repositories.IncomeRepository
+	converter   services.CurrencyConverter
+}
+
+func NewAnalyticsInteractor(expenseRepo repositories.ExpenseRepository, incomeRepo repositories.IncomeRepository, converter services.CurrencyConverter) *AnalyticsInteractor {
+	return &AnalyticsInteractor{
+		expenseRepo: expenseRepo,
+		incomeRepo:  incomeRepo,
+		converter:   converter,
+	}
+}
+
+// Aggregate totals expenses and incomes per period, split by the query's dimension.
+// The database does the grouping; amounts are then converted into the reporting currency at the rate of their day.
+// Buckets run without gaps from the first to the last one with transactions, or over the whole date range if one is given.
+func (i *AnalyticsInteractor) Aggregate(householdID entities.HouseholdID, query repositories.AggregateQuery) (*Aggregation, error) {
+	if !query.Period.IsValid() {
+		return nil, ErrInvalidPeriod
+	}
+	if !query.Dimension.IsValid() {
+		return nil, ErrInvalidDimension
+	}
+	currency, err := valueobjects.NormalizeCurrency(query.ReportingCurrency)
+	if err != nil {
+		return nil, err
+	}
+	query.ReportingCurrency = currency
+
+	expenseRows, err := i.expenseRepo.Aggregate(householdID, query)
+	if err != nil {
+		return nil, err
+	}
+	incomeRows, err := i.incomeRepo.Aggregate(householdID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[time.Time]map[string]*Group)
+	expenseStats := make(map[*Group]*accumulator)
+	incomeStats := make(map[*Group]*accumulator)
+	add := func(rows []repositories.AggregateRow, stats map[*Group]*accumulator) error {
+		for _, row := range rows {
+			// Bounds also normalises the location the driver returned the date in, so it can be a map key
+			bucket, _ := query.Period.Bounds(row.Bucket)
+			if groups[bucket] == nil {
+				groups[bucket] = make(map[string]*Group)
+			}
+			group := groups[bucket][row.Key]
+			if group == nil {
+				group = &Group{Key: row.Key, Label: row.Label}
+				groups[bucket][row.Key] = group
+			}
+			if stats[group] == nil {
+				stats[group] = &accumulator{}
+			}
+			if err := stats[group].add(i.converter, row, currency); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := add(expenseRows, expenseStats); err != nil {
+		return nil, err
+	}
+	if err := add(incomeRows, incomeStats); err != nil {
+		return nil, err
+	}
+
+	aggregation := &Aggregation{Period: query.Period, Dimension: query.Dimension, Currency: currency}
+
+	first, last := query.StartDate, query.EndDate
+	for bucket := range groups {
+		if first == nil || bucket.Before(*first) {
+			first = &bucket
+		}
+		if last == nil || bucket.After(*last) {
+			last = &bucket
+		}
+	}
+	if first == nil || last == nil {
+		return aggregation, nil
+	}
+
+	start, _ := query.Period.Bounds(*first)
+	for !start.After(*last) {
+		bucketStart, bucketEnd := query.Period.Bounds(start)
+		bucket := Bucket{Start: bucketStart, End: bucketEnd, Groups: []Group{}}
+		for _, group := range groups[bucketStart] {
+			if stats := expenseStats[group]; stats != nil {
+				group.Expenses = stats.result()
+			}
+			if stats := incomeStats[group]; stats != nil {
+				group.Incomes = stats.result()
+			}
+			bucket.Groups = append(bucket.Groups, *group)
+		}
+		sort.Slice(bucket.Groups, func(a, b int) bool {
+			if bucket.Groups[a].Label != bucket.Groups[b].Label {
+				return bucket.Groups[a].Label < bucket.Groups[b].Label
+			}
+			return bucket.Groups[a].Key < bucket.Groups[b].Key
+		})
+		aggregation.Buckets = append(aggregation.Buckets, bucket)
+		start = bucketEnd.AddDate(0, 0, 1)
+	}
+
+	return aggregation, nil
+}
+
+// accumulator merges the per-currency and per-day rows of a group
+type accumulator struct {
+	count         int
+	sum, min, max valueobjects.Money
+}
+
+func (a *accumulator) add(converter services.CurrencyConverter, row repositories.AggregateRow, currency string) error {
+	var amounts [3]valueobjects.Money
+	for n, decimal := range []string{row.Sum, row.Min, row.Max} {
+		amount, err := valueobjects.ParseMoney(decimal, row.Currency)
+		if err != nil {
+			return err
+		}
+		if row.Date != nil {
+			if amount, err = converter.Convert(amount, *row.Date, currency); err != nil {
+				return err
+			}
+		}
+		amounts[n] = amount
+	}
+	sum, smallest, largest := amounts[0], amounts[1], amounts[2]
+
+	if a.count == 0 {
+		a.count, a.sum, a.min, a.max = row.Count, sum, smallest, largest
+		return nil
+	}
+
+	var err error
+	if a.sum, err = a.sum.Add(sum); err != nil {
+		return err
+	}
+	a.count += row.Count
+	if smallest.MinorUnits() < a.min.MinorUnits() {
+		a.min = smallest
+	}
+	if largest.MinorUnits() > a.max.MinorUnits() {
+		a.max = largest
+	}
+	return nil
+}
+
+func (a *accumulator) result() *Stats {
+	// Rounded half up, amounts are never negative
+	average, _ := valueobjects.NewMoneyFromMinorUnits((a.sum.MinorUnits()+int64(a.count)/2)/int64(a.count), a.sum.Currency())
+	return &Stats{Count: a.count, Sum: a.sum, Average: average, Min: a.min, Max: a.max}
+}
@@ -0,0 +1,194 @@
+package analytics
+
+import (
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+	"expenso-backend/usecases/interfaces/repositories"
+	"expenso-backend/usecases/interfaces/services"
+)
+
+// CashFlowTotals are incomes against expenses over some stretch of time, in the reporting currency
+type CashFlowTotals struct {
+	Income       valueobjects.Money
+	Expenses     valueobjects.Money
+	Net          valueobjects.Money
+	IncomeCount  int
+	ExpenseCount int
+	SavingsRate  float64 // share of income not spent, 0 without income
+}
+
+// CashFlowPeriod is one period of a cash flow; Balance is the running balance at its end
+type CashFlowPeriod struct {
+	Start time.Time
+	End   time.Time
+	CashFlowTotals
+	Balance valueobjects.Money
+}
+
+// CashFlow lists the periods of a date range with their totals.
+// OpeningBalance is the net of everything before the range, so the running balance reflects all recorded history.
+type CashFlow struct {
+	Currency       string
+	OpeningBalance valueobjects.Money
+	Periods        []CashFlowPeriod
+	Totals         CashFlowTotals
+	ClosingBalance valueobjects.Money
+}
+
+type CashFlowInteractor struct {
+	expenseRepo repositories.ExpenseRepository
+	incomeRepo  repositories.IncomeRepository
+	converter   services.CurrencyConverter
+}
+
+func NewCashFlowInteractor(expenseRepo repositories.ExpenseRepository, incomeRepo repositories.IncomeRepository, converter services.CurrencyConverter) *CashFlowInteractor {
+	return &CashFlowInteractor{
+		expenseRepo: expenseRepo,
+		incomeRepo:  incomeRepo,
+		converter:   converter,
+	}
+}
+
+// GetCashFlow returns income, expenses, net, savings rate and running balance per period.
+// Periods run without gaps over the date range, or from the first to the last transaction without one.
+func (i *CashFlowInteractor) GetCashFlow(householdID entities.HouseholdID, period repositories.Period, startDate, endDate *time.Time, reportingCurrency string) (*CashFlow, error) {
+	if !period.IsValid() {
+		return nil, ErrInvalidPeriod
+	}
+	currency, err := valueobjects.NormalizeCurrency(reportingCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	query := repositories.AggregateQuery{Period: period, StartDate: startDate, EndDate: endDate, ReportingCurrency: currency}
+	expenses, err := i.totalsByBucket(i.expenseRepo.Aggregate, householdID, query)
+	if err != nil {
+		return nil, err
+	}
+	incomes, err := i.totalsByBucket(i.incomeRepo.Aggregate, householdID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	zero, err := valueobjects.ZeroMoney(currency)
+	if err != nil {
+		return nil, err
+	}
+	cashFlow := &CashFlow{Currency: currency, OpeningBalance: zero}
+
+	if startDate != nil {
+		before := startDate.AddDate(0, 0, -1)
+		history := repositories.AggregateQuery{Period: repositories.PeriodYear, EndDate: &before, ReportingCurrency: currency}
+		pastExpenses, err := i.totalsByBucket(i.expenseRepo.Aggregate, householdID, history)
+		if err != nil {
+			return nil, err
+		}
+		pastIncomes, err := i.totalsByBucket(i.incomeRepo.Aggregate, householdID, history)
+		if err != nil {
+			return nil, err
+		}
+		past, err := newCashFlowTotals(sumOf(pastIncomes), sumOf(pastExpenses), zero)
+		if err != nil {
+			return nil, err
+		}
+		cashFlow.OpeningBalance = past.Net
+	}
+
+	first, last := startDate, endDate
+	for _, buckets := range []map[time.Time]*accumulator{expenses, incomes} {
+		for bucket := range buckets {
+			if first == nil || bucket.Before(*first) {
+				first = &bucket
+			}
+			if last == nil || bucket.After(*last) {
+				last = &bucket
+			}
+		}
+	}
+
+	balance := cashFlow.OpeningBalance
+	if first != nil && last != nil {
+		start, _ := period.Bounds(*first)
+		for !start.After(*last) {
+			bucketStart, bucketEnd := period.Bounds(start)
+			totals, err := newCashFlowTotals(incomes[bucketStart], expenses[bucketStart], zero)
+			if err != nil {
+				return nil, err
+			}
+			if balance, err = balance.Add(totals.Net); err != nil {
+				return nil, err
+			}
+			cashFlow.Periods = append(cashFlow.Periods, CashFlowPeriod{Start: bucketStart, End: bucketEnd, CashFlowTotals: totals, Balance: balance})
+			start = bucketEnd.AddDate(0, 0, 1)
+		}
+	}
+
+	if cashFlow.Totals, err = newCashFlowTotals(sumOf(incomes), sumOf(expenses), zero); err != nil {
+		return nil, err
+	}
+	cashFlow.ClosingBalance = balance
+	return cashFlow, nil
+}
+
+// GetEarnings returns the incomes of the date range, for the routes that still call them earnings
+func (i *CashFlowInteractor) GetEarnings(householdID entities.HouseholdID, startDate, endDate *time.Time) ([]*entities.Income, error) {
+	return i.incomeRepo.FindByDateRange(householdID, startDate, endDate)
+}
+
+// totalsByBucket runs an aggregation without dimension and converts its rows into one total per bucket
+func (i *CashFlowInteractor) totalsByBucket(aggregate func(entities.HouseholdID, repositories.AggregateQuery) ([]repositories.AggregateRow, error), householdID entities.HouseholdID, query repositories.AggregateQuery) (map[time.Time]*accumulator, error) {
+	rows, err := aggregate(householdID, query)
+	if err != nil {
+		return nil, err
+	}
+	totals := make(map[time.Time]*accumulator)
+	for _, row := range rows {
+		bucket, _ := query.Period.Bounds(row.Bucket)
+		if totals[bucket] == nil {
+			totals[bucket] = &accumulator{}
+		}
+		if err := totals[bucket].add(i.converter, row, query.ReportingCurrency); err != nil {
+			return nil, err
+		}
+	}
+	return totals, nil
+}
+
+// sumOf merges the totals of several buckets
+func sumOf(buckets map[time.Time]*accumulator) *accumulator {
+	total := &accumulator{}
+	for _, bucket := range buckets {
+		if bucket.count == 0 {
+			continue
+		}
+		if total.count == 0 {
+			*total = *bucket
+			continue
+		}
+		total.count += bucket.count
+		total.sum, _ = total.sum.Add(bucket.sum) // same currency, cannot fail
+	}
+	return total
+}
+
+func newCashFlowTotals(incomes, expenses *accumulator, zero valueobjects.Money) (CashFlowTotals, error) {
+	totals := CashFlowTotals{Income: zero, Expenses: zero}
+	if incomes != nil && incomes.count > 0 {
+		totals.Income, totals.IncomeCount = incomes.sum, incomes.count
+	}
+	if expenses != nil && expenses.count > 0 {
+		totals.Expenses, totals.ExpenseCount = expenses.sum, expenses.count
+	}
+
+	net, err := totals.Income.Subtract(totals.Expenses)
+	if err != nil {
+		return totals, err
+	}
+	totals.Net = net
+	if !totals.Income.IsZero() {
+		totals.SavingsRate = net.Amount() / totals.Income.Amount()
+	}
+	return totals, nil
+}
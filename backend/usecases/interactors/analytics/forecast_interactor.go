@@ -0,0 +1,310 @@
+package analytics
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+	"expenso-backend/usecases/interfaces/repositories"
+	"expenso-backend/usecases/interfaces/services"
+)
+
+const (
+	// MaxForecastMonths limits how far ahead a forecast reaches
+	MaxForecastMonths = 24
+
+	// ForecastConfidence is the share of outcomes the bands are meant to cover
+	ForecastConfidence = 0.8
+
+	forecastHistoryYears   = 3      // how much history seasonality and spread are taken from
+	forecastBaselineMonths = 12     // variable spending is projected from the mean of this many months
+	forecastFixedMonths    = 3      // fixed costs are projected from the median of this many months
+	forecastBandZ          = 1.2816 // two-sided 80% quantile of the normal distribution
+)
+
+var ErrInvalidForecastMonths = errors.New("months must be between 0 and 24")
+
+// FixedVendorTypes are the vendor types whose expenses count as recurring, fixed costs
+var FixedVendorTypes = []entities.VendorType{entities.VendorTypeLiving, entities.VendorTypeSubscriptions}
+
+// ForecastMonth projects the spending of one month in the reporting currency.
+// Spent is what was recorded up to the forecast date, so it is zero for future months.
+// Low and High bound the projected total with ForecastConfidence.
+type ForecastMonth struct {
+	Start         time.Time
+	End           time.Time
+	SpentFixed    valueobjects.Money
+	SpentVariable valueobjects.Money
+	Fixed         valueobjects.Money
+	Variable      valueobjects.Money
+	Total         valueobjects.Money
+	Low           valueobjects.Money
+	High          valueobjects.Money
+}
+
+// Forecast projects the rest of the month containing AsOf and the months after it
+type Forecast struct {
+	Currency      string
+	AsOf          time.Time
+	HistoryMonths int // complete months of history the projection is based on
+	Seasonal      bool
+	CurrentMonth  ForecastMonth
+	Months        []ForecastMonth
+}
+
+type ForecastInteractor struct {
+	expenseRepo repositories.ExpenseRepository
+	converter   services.CurrencyConverter
+}
+
+func NewForecastInteractor(expenseRepo repositories.ExpenseRepository, converter services.CurrencyConverter) *ForecastInteractor {
+	return &ForecastInteractor{
+		expenseRepo: expenseRepo,
+		converter:   converter,
+	}
+}
+
+// GetForecast projects spending for the rest of the month containing asOf and the given number of months after it.
+// Only expenses up to asOf are considered, so the same history and date always give the same forecast.
+func (i *ForecastInteractor) GetForecast(householdID entities.HouseholdID, asOf time.Time, months int, reportingCurrency string) (*Forecast, error) {
+	if months < 0 || months > MaxForecastMonths {
+		return nil, ErrInvalidForecastMonths
+	}
+	currency, err := valueobjects.NormalizeCurrency(reportingCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	today := repositories.PeriodDay.Start(asOf)
+	monthStart := repositories.PeriodMonth.Start(today)
+	historyStart := monthStart.AddDate(-forecastHistoryYears, 0, 0)
+
+	rows, err := i.expenseRepo.Aggregate(householdID, repositories.AggregateQuery{
+		Period:            repositories.PeriodMonth,
+		Dimension:         repositories.DimensionVendorType,
+		StartDate:         &historyStart,
+		EndDate:           &today,
+		ReportingCurrency: currency,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fixed := make(map[time.Time]*accumulator)
+	variable := make(map[time.Time]*accumulator)
+	for _, row := range rows {
+		bucket := repositories.PeriodMonth.Start(row.Bucket)
+		totals := variable
+		if isFixedVendorType(entities.VendorType(row.Key)) {
+			totals = fixed
+		}
+		if totals[bucket] == nil {
+			totals[bucket] = &accumulator{}
+		}
+		if err := totals[bucket].add(i.converter, row, currency); err != nil {
+			return nil, err
+		}
+	}
+
+	// The history runs without gaps from the first month with expenses to the last complete month
+	var history []monthlySpending
+	var first *time.Time
+	for _, totals := range []map[time.Time]*accumulator{fixed, variable} {
+		for bucket := range totals {
+			if bucket.Before(monthStart) && (first == nil || bucket.Before(*first)) {
+				first = &bucket
+			}
+		}
+	}
+	if first != nil {
+		for month := *first; month.Before(monthStart); month = month.AddDate(0, 1, 0) {
+			history = append(history, monthlySpending{
+				month:    month,
+				fixed:    minorUnitsOf(fixed[month]),
+				variable: minorUnitsOf(variable[month]),
+			})
+		}
+	}
+
+	projection := projectSpending(history)
+	forecast := &Forecast{
+		Currency:      currency,
+		AsOf:          today,
+		HistoryMonths: len(history),
+		Seasonal:      projection.seasonal,
+	}
+
+	// The running month adds the expected spending of its remaining days to what was spent so far
+	_, monthEnd := repositories.PeriodMonth.Bounds(monthStart)
+	remaining := monthEnd.Sub(today).Hours() / 24 / float64(monthEnd.Day())
+	spentFixed, spentVariable := minorUnitsOf(fixed[monthStart]), minorUnitsOf(variable[monthStart])
+	current := forecastValues{
+		fixed:    math.Max(spentFixed, projection.fixed),
+		variable: spentVariable + projection.variable(monthStart.Month())*remaining,
+		spread:   projection.spread * math.Sqrt(remaining),
+	}
+	if forecast.CurrentMonth, err = current.month(monthStart, monthEnd, spentFixed, spentVariable, currency); err != nil {
+		return nil, err
+	}
+
+	forecast.Months = make([]ForecastMonth, 0, months)
+	for n := 1; n <= months; n++ {
+		start, end := repositories.PeriodMonth.Bounds(monthStart.AddDate(0, n, 0))
+		values := forecastValues{
+			fixed:    projection.fixed,
+			variable: projection.variable(start.Month()),
+			spread:   projection.spread,
+		}
+		month, err := values.month(start, end, 0, 0, currency)
+		if err != nil {
+			return nil, err
+		}
+		forecast.Months = append(forecast.Months, month)
+	}
+
+	return forecast, nil
+}
+
+// monthlySpending is one complete month of history, in minor units of the reporting currency
+type monthlySpending struct {
+	month    time.Time
+	fixed    float64
+	variable float64
+}
+
+// spendingProjection is what the history predicts for a month, in minor units of the reporting currency
+type spendingProjection struct {
+	fixed    float64
+	baseline float64
+	seasonal bool
+	season   map[time.Month]float64 // factor on the baseline, for calendar months seen in prior years
+	spread   float64                // standard deviation of a month's total around the projection
+}
+
+func (p spendingProjection) variable(month time.Month) float64 {
+	if factor, ok := p.season[month]; ok {
+		return p.baseline * factor
+	}
+	return p.baseline
+}
+
+// projectSpending derives the projection from a gap-free history, oldest month first.
+// Fixed costs are projected at the median of the last months, so a one-off does not move them;
+// variable spending at the mean of the last year, scaled by how each calendar month compared to the average before.
+// Seasonality needs at least a full year of history, otherwise every factor would compare against a partial year.
+func projectSpending(history []monthlySpending) spendingProjection {
+	projection := spendingProjection{season: make(map[time.Month]float64)}
+	if len(history) == 0 {
+		return projection
+	}
+
+	fixed := make([]float64, len(history))
+	variable := make([]float64, len(history))
+	for n, month := range history {
+		fixed[n], variable[n] = month.fixed, month.variable
+	}
+	projection.fixed = median(fixed[max(0, len(fixed)-forecastFixedMonths):])
+	projection.baseline = mean(variable[max(0, len(variable)-forecastBaselineMonths):])
+
+	overall := mean(variable)
+	if len(history) >= 12 && overall > 0 {
+		projection.seasonal = true
+		byMonth := make(map[time.Month][]float64)
+		for _, month := range history {
+			byMonth[month.month.Month()] = append(byMonth[month.month.Month()], month.variable)
+		}
+		for month, values := range byMonth {
+			projection.season[month] = mean(values) / overall
+		}
+	}
+
+	// The spread is how far the history strayed from what the projection would have said, fixed and variable together
+	var squares float64
+	recent := history[max(0, len(history)-forecastBaselineMonths):]
+	for _, month := range recent {
+		fixedDeviation := month.fixed - projection.fixed
+		variableDeviation := month.variable - projection.variable(month.month.Month())
+		squares += fixedDeviation*fixedDeviation + variableDeviation*variableDeviation
+	}
+	projection.spread = math.Sqrt(squares / float64(len(recent)))
+
+	return projection
+}
+
+// forecastValues are the projected amounts of a month in minor units, before rounding into money
+type forecastValues struct {
+	fixed    float64
+	variable float64
+	spread   float64
+}
+
+// month rounds the values into money; the band never reaches below what was already spent
+func (v forecastValues) month(start, end time.Time, spentFixed, spentVariable float64, currency string) (ForecastMonth, error) {
+	total := v.fixed + v.variable
+	band := forecastBandZ * v.spread
+	amounts := []float64{spentFixed, spentVariable, v.fixed, v.variable, total, math.Max(total-band, spentFixed+spentVariable), total + band}
+
+	money := make([]valueobjects.Money, len(amounts))
+	for n, amount := range amounts {
+		m, err := valueobjects.NewMoneyFromMinorUnits(int64(math.Round(amount)), currency)
+		if err != nil {
+			return ForecastMonth{}, err
+		}
+		money[n] = m
+	}
+
+	return ForecastMonth{
+		Start:         start,
+		End:           end,
+		SpentFixed:    money[0],
+		SpentVariable: money[1],
+		Fixed:         money[2],
+		Variable:      money[3],
+		Total:         money[4],
+		Low:           money[5],
+		High:          money[6],
+	}, nil
+}
+
+func isFixedVendorType(vendorType entities.VendorType) bool {
+	for _, fixed := range FixedVendorTypes {
+		if vendorType == fixed {
+			return true
+		}
+	}
+	return false
+}
+
+func minorUnitsOf(total *accumulator) float64 {
+	if total == nil || total.count == 0 {
+		return 0
+	}
+	return float64(total.sum.MinorUnits())
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
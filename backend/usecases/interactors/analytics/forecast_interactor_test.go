@@ -0,0 +1,145 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// fixtureHistory is two years of spending starting January 2023, in minor units.
+// Fixed costs are 1000 a month with a one-off of 5000 in November 2024,
+// variable spending is 100 a month and 300 every December.
+func fixtureHistory() []monthlySpending {
+	history := make([]monthlySpending, 24)
+	for n := range history {
+		month := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, n, 0)
+		history[n] = monthlySpending{month: month, fixed: 1000, variable: 100}
+		if month.Month() == time.December {
+			history[n].variable = 300
+		}
+	}
+	history[22].fixed = 5000
+	return history
+}
+
+func assertClose(t *testing.T, name string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+func TestProjectSpendingSplitsFixedAndVariable(t *testing.T) {
+	projection := projectSpending(fixtureHistory())
+
+	// The median of the last three months ignores the one-off
+	assertClose(t, "fixed", projection.fixed, 1000)
+	// The mean of the last twelve months, one December included
+	assertClose(t, "baseline", projection.baseline, (11*100+300)/12.0)
+}
+
+func TestProjectSpendingSeasonality(t *testing.T) {
+	projection := projectSpending(fixtureHistory())
+	if !projection.seasonal {
+		t.Fatal("two years of history are not seasonal")
+	}
+
+	overall := (22*100 + 2*300) / 24.0
+	assertClose(t, "December factor", projection.season[time.December], 300/overall)
+	assertClose(t, "June factor", projection.season[time.June], 100/overall)
+	if len(projection.season) != 12 {
+		t.Errorf("%d calendar months have a factor, want 12", len(projection.season))
+	}
+
+	assertClose(t, "December", projection.variable(time.December), 300)
+	assertClose(t, "June", projection.variable(time.June), 100)
+}
+
+func TestProjectSpendingSpread(t *testing.T) {
+	projection := projectSpending(fixtureHistory())
+
+	// Variable spending follows the seasons exactly, only the one-off strays from the projection
+	assertClose(t, "spread", projection.spread, 4000/math.Sqrt(12))
+}
+
+func TestProjectSpendingWithoutAFullYear(t *testing.T) {
+	history := fixtureHistory()[13:23] // February to November 2024, the one-off included
+	projection := projectSpending(history)
+
+	if projection.seasonal || len(projection.season) != 0 {
+		t.Error("less than a year of history is seasonal")
+	}
+	assertClose(t, "fixed", projection.fixed, 1000)
+	assertClose(t, "baseline", projection.baseline, 100)
+	assertClose(t, "December", projection.variable(time.December), 100)
+}
+
+func TestProjectSpendingWithoutHistory(t *testing.T) {
+	projection := projectSpending(nil)
+
+	if projection.fixed != 0 || projection.baseline != 0 || projection.spread != 0 || projection.seasonal {
+		t.Errorf("projection without history = %+v, want zero", projection)
+	}
+}
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		values []float64
+		want   float64
+	}{
+		{nil, 0},
+		{[]float64{7}, 7},
+		{[]float64{5000, 1000, 1000}, 1000},
+		{[]float64{4, 1, 3, 2}, 2.5},
+	}
+	for _, tt := range tests {
+		if got := median(tt.values); got != tt.want {
+			t.Errorf("median(%v) = %v, want %v", tt.values, got, tt.want)
+		}
+	}
+
+	values := []float64{3, 1, 2}
+	median(values)
+	if values[0] != 3 || values[1] != 1 || values[2] != 2 {
+		t.Errorf("median reordered its input to %v", values)
+	}
+}
+
+func TestForecastValuesMonthBands(t *testing.T) {
+	start := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		values        forecastValues
+		spentFixed    float64
+		spentVariable float64
+		wantTotal     int64
+		wantLow       int64
+		wantHigh      int64
+	}{
+		{"future month", forecastValues{fixed: 1000, variable: 500, spread: 100}, 0, 0, 1500, 1372, 1628},
+		{"without spread", forecastValues{fixed: 1000, variable: 500}, 0, 0, 1500, 1500, 1500},
+		{"low band above spending", forecastValues{fixed: 1000, variable: 500, spread: 100}, 1000, 200, 1500, 1372, 1628},
+		{"low band capped at spending", forecastValues{fixed: 1000, variable: 500, spread: 1000}, 1000, 450, 1500, 1450, 2782},
+	}
+	for _, tt := range tests {
+		month, err := tt.values.month(start, end, tt.spentFixed, tt.spentVariable, "EUR")
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if !month.Start.Equal(start) || !month.End.Equal(end) {
+			t.Errorf("%s: month runs from %v to %v, want %v to %v", tt.name, month.Start, month.End, start, end)
+		}
+		if month.Total.MinorUnits() != tt.wantTotal || month.Low.MinorUnits() != tt.wantLow || month.High.MinorUnits() != tt.wantHigh {
+			t.Errorf("%s: total %d, band %d to %d, want %d, band %d to %d", tt.name,
+				month.Total.MinorUnits(), month.Low.MinorUnits(), month.High.MinorUnits(), tt.wantTotal, tt.wantLow, tt.wantHigh)
+		}
+		if month.SpentFixed.MinorUnits() != int64(tt.spentFixed) || month.SpentVariable.MinorUnits() != int64(tt.spentVariable) {
+			t.Errorf("%s: spent %s and %s, want %v and %v", tt.name, month.SpentFixed, month.SpentVariable, tt.spentFixed, tt.spentVariable)
+		}
+		if month.Total.Currency() != "EUR" || month.Low.Currency() != "EUR" || month.High.Currency() != "EUR" {
+			t.Errorf("%s: amounts are not in EUR", tt.name)
+		}
+	}
+}
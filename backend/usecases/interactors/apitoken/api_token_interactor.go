@@ -0,0 +1,65 @@
+package apitoken
+
+import (
+	"expenso-backend/domain/entities"
+	"expenso-backend/usecases/interfaces/repositories"
+	"expenso-backend/usecases/interfaces/services"
+)
+
+type CreateAPITokenCommand struct {
+	Name   string
+	Scopes []string
+}
+
+// CreatedAPIToken carries the plain secret, which is only available right after creation
+type CreatedAPIToken struct {
+	Token  *entities.APIToken
+	Secret string
+}
+
+type APITokenInteractor struct {
+	tokenRepo repositories.APITokenRepository
+	generator services.APITokenGenerator
+}
+
+func NewAPITokenInteractor(tokenRepo repositories.APITokenRepository, generator services.APITokenGenerator) *APITokenInteractor {
+	return &APITokenInteractor{
+		tokenRepo: tokenRepo,
+		generator: generator,
+	}
+}
+
+// CreateToken issues a token acting on behalf of the user in the given household
+func (i *APITokenInteractor) CreateToken(userID entities.UserID, householdID entities.HouseholdID, cmd CreateAPITokenCommand) (*CreatedAPIToken, error) {
+	scopes := make([]entities.APITokenScope, len(cmd.Scopes))
+	for idx, scope := range cmd.Scopes {
+		scopes[idx] = entities.APITokenScope(scope)
+	}
+
+	secret, err := i.generator.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := entities.NewAPIToken(userID, householdID, cmd.Name, i.generator.DisplayPrefix(secret), i.generator.Hash(secret), scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.tokenRepo.Save(token); err != nil {
+		return nil, err
+	}
+
+	return &CreatedAPIToken{
+		Token:  token,
+		Secret: secret,
+	}, nil
+}
+
+func (i *APITokenInteractor) GetTokens(userID entities.UserID) ([]*entities.APIToken, error) {
+	return i.tokenRepo.FindByUser(userID)
+}
+
+func (i *APITokenInteractor) RevokeToken(userID entities.UserID, id entities.APITokenID) error {
+	return i.tokenRepo.Delete(userID, id)
+}
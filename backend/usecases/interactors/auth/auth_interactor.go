@@ -0,0 +1,316 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/usecases/interfaces/repositories"
+	"expenso-backend/usecases/interfaces/services"
+)
+
+const minPasswordLength = 8
+
+// lastUsedResolution limits how often an API token's last use is written back
+const lastUsedResolution = time.Minute
+
+type RegisterCommand struct {
+	Email    string
+	Name     string
+	Password string
+}
+
+type LoginCommand struct {
+	Email    string
+	Password string
+}
+
+// Session is the result of a successful registration, login or household switch
+type Session struct {
+	User        *entities.User
+	HouseholdID entities.HouseholdID
+	Token       string
+	ExpiresAt   time.Time
+}
+
+// Principal is the authenticated user together with the household the session acts on
+type Principal struct {
+	User        *entities.User
+	HouseholdID entities.HouseholdID
+	Role        entities.HouseholdRole
+	APIToken    *entities.APIToken // nil for session tokens
+}
+
+// HasScope reports whether the principal may use routes guarded by scope.
+// Session tokens carry every scope.
+func (p *Principal) HasScope(scope entities.APITokenScope) bool {
+	return p.APIToken == nil || p.APIToken.HasScope(scope)
+}
+
+type AuthInteractor struct {
+	userRepo            repositories.UserRepository
+	householdRepo       repositories.HouseholdRepository
+	apiTokenRepo        repositories.APITokenRepository
+	hasher              services.PasswordHasher
+	tokens              services.TokenIssuer
+	apiTokens           services.APITokenGenerator
+	sessionTTL          time.Duration
+	registrationEnabled bool
+}
+
+func NewAuthInteractor(userRepo repositories.UserRepository, householdRepo repositories.HouseholdRepository, apiTokenRepo repositories.APITokenRepository, hasher services.PasswordHasher, tokens services.TokenIssuer, apiTokens services.APITokenGenerator, sessionTTL time.Duration, registrationEnabled bool) *AuthInteractor {
+	return &AuthInteractor{
+		userRepo:            userRepo,
+		householdRepo:       householdRepo,
+		apiTokenRepo:        apiTokenRepo,
+		hasher:              hasher,
+		tokens:              tokens,
+		apiTokens:           apiTokens,
+		sessionTTL:          sessionTTL,
+		registrationEnabled: registrationEnabled,
+	}
+}
+
+// Register creates a user and signs them in. When registration is disabled
+// only the very first account can still be created, so a fresh server can be bootstrapped.
+// The first account claims the household holding pre-existing data; later accounts get a household of their own.
+func (i *AuthInteractor) Register(cmd RegisterCommand) (*Session, error) {
+	if !i.registrationEnabled {
+		count, err := i.userRepo.Count()
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, entities.ErrRegistrationDisabled
+		}
+	}
+
+	if len(cmd.Password) < minPasswordLength {
+		return nil, errors.New("password must be at least 8 characters")
+	}
+
+	email, err := entities.NormalizeEmail(cmd.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := i.userRepo.FindByEmail(email)
+	if err != nil && err != entities.ErrUserNotFound {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, entities.ErrUserAlreadyExists
+	}
+
+	hash, err := i.hasher.Hash(cmd.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := entities.NewUser(email, cmd.Name, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.userRepo.Save(user); err != nil {
+		return nil, err
+	}
+
+	householdID, err := i.claimOrCreateHousehold(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.newSession(user, householdID)
+}
+
+func (i *AuthInteractor) Login(cmd LoginCommand) (*Session, error) {
+	email, err := entities.NormalizeEmail(cmd.Email)
+	if err != nil {
+		return nil, entities.ErrInvalidCredentials
+	}
+
+	user, err := i.userRepo.FindByEmail(email)
+	if err != nil {
+		if err == entities.ErrUserNotFound {
+			return nil, entities.ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	if err := i.hasher.Compare(user.PasswordHash(), cmd.Password); err != nil {
+		return nil, entities.ErrInvalidCredentials
+	}
+
+	householdID, err := i.defaultHousehold(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.newSession(user, householdID)
+}
+
+// SwitchHousehold issues a new session token acting on another household the user belongs to
+func (i *AuthInteractor) SwitchHousehold(userID entities.UserID, householdID entities.HouseholdID) (*Session, error) {
+	user, err := i.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := i.householdRepo.FindRole(householdID, userID); err != nil {
+		return nil, err
+	}
+
+	return i.newSession(user, householdID)
+}
+
+// Authenticate resolves a session or API token to the user it was issued for and the household it acts on.
+// Access is re-checked on every request, so removing a user from a household takes effect immediately.
+func (i *AuthInteractor) Authenticate(token string) (*Principal, error) {
+	if strings.HasPrefix(token, entities.APITokenPrefix) {
+		return i.authenticateAPIToken(token)
+	}
+
+	claims, err := i.tokens.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := i.userRepo.FindByID(claims.UserID)
+	if err != nil {
+		if err == entities.ErrUserNotFound {
+			return nil, entities.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	householdID := claims.HouseholdID
+	if householdID == 0 {
+		memberships, err := i.householdRepo.FindByUser(user.ID())
+		if err != nil {
+			return nil, err
+		}
+		if len(memberships) == 0 {
+			return nil, entities.ErrNotHouseholdMember
+		}
+		householdID = memberships[0].Household().ID()
+	}
+
+	role, err := i.householdRepo.FindRole(householdID, user.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Principal{
+		User:        user,
+		HouseholdID: householdID,
+		Role:        role,
+	}, nil
+}
+
+func (i *AuthInteractor) GetUser(id entities.UserID) (*entities.User, error) {
+	return i.userRepo.FindByID(id)
+}
+
+func (i *AuthInteractor) authenticateAPIToken(secret string) (*Principal, error) {
+	token, err := i.apiTokenRepo.FindByHash(i.apiTokens.Hash(secret))
+	if err != nil {
+		if err == entities.ErrAPITokenNotFound {
+			return nil, entities.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	user, err := i.userRepo.FindByID(token.UserID())
+	if err != nil {
+		if err == entities.ErrUserNotFound {
+			return nil, entities.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	role, err := i.householdRepo.FindRole(token.HouseholdID(), user.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if token.LastUsedAt() == nil || now.Sub(*token.LastUsedAt()) >= lastUsedResolution {
+		if err := i.apiTokenRepo.UpdateLastUsed(token.ID(), now); err != nil {
+			return nil, err
+		}
+		token.MarkUsed(now)
+	}
+
+	return &Principal{
+		User:        user,
+		HouseholdID: token.HouseholdID(),
+		Role:        role,
+		APIToken:    token,
+	}, nil
+}
+
+// claimOrCreateHousehold makes the user owner of the household left without users by the
+// tenancy migration, or creates a fresh one for them
+func (i *AuthInteractor) claimOrCreateHousehold(user *entities.User) (entities.HouseholdID, error) {
+	unclaimed, err := i.householdRepo.FindUnclaimed()
+	if err != nil && err != entities.ErrHouseholdNotFound {
+		return 0, err
+	}
+	if unclaimed != nil {
+		if err := i.householdRepo.AddUser(unclaimed.ID(), user.ID(), entities.HouseholdRoleOwner); err != nil {
+			return 0, err
+		}
+		return unclaimed.ID(), nil
+	}
+
+	household, err := entities.NewHousehold(user.Name() + "'s household")
+	if err != nil {
+		return 0, err
+	}
+	if err := i.householdRepo.Create(household, user.ID()); err != nil {
+		return 0, err
+	}
+	return household.ID(), nil
+}
+
+// defaultHousehold picks the oldest household the user belongs to.
+// Users removed from every household get a fresh one so they can still sign in.
+func (i *AuthInteractor) defaultHousehold(user *entities.User) (entities.HouseholdID, error) {
+	memberships, err := i.householdRepo.FindByUser(user.ID())
+	if err != nil {
+		return 0, err
+	}
+	if len(memberships) > 0 {
+		return memberships[0].Household().ID(), nil
+	}
+
+	household, err := entities.NewHousehold(user.Name() + "'s household")
+	if err != nil {
+		return 0, err
+	}
+	if err := i.householdRepo.Create(household, user.ID()); err != nil {
+		return 0, err
+	}
+	return household.ID(), nil
+}
+
+func (i *AuthInteractor) newSession(user *entities.User, householdID entities.HouseholdID) (*Session, error) {
+	expiresAt := time.Now().Add(i.sessionTTL)
+	token, err := i.tokens.Issue(services.SessionClaims{
+		UserID:      user.ID(),
+		HouseholdID: householdID,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Session{
+		User:        user,
+		HouseholdID: householdID,
+		Token:       token,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
@@ -0,0 +1,305 @@
+package budget
+
+import (
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+	"expenso-backend/usecases/interfaces/repositories"
+	"expenso-backend/usecases/interfaces/services"
+)
+
+type CreateBudgetCommand struct {
+	Amount     string // Decimal amount, e.g. "400.00"
+	Currency   string // ISO-4217 code, defaults to EUR if empty
+	Period     string
+	TargetType string
+	Target     string // Category name or vendor type
+	Rollover   bool
+}
+
+type UpdateBudgetCommand struct {
+	ID         entities.BudgetID
+	Amount     *string
+	Currency   *string
+	Period     *string
+	TargetType *string
+	Target     *string
+	Rollover   *bool
+}
+
+// BudgetStatus compares a budget with the expenses of one period, all amounts in the budget currency
+type BudgetStatus struct {
+	Budget       *entities.Budget
+	PeriodStart  time.Time
+	PeriodEnd    time.Time
+	Carryover    valueobjects.Money // unspent amount of the previous period, only with rollover
+	Available    valueobjects.Money // budget amount plus carryover
+	Spent        valueobjects.Money
+	Remaining    valueobjects.Money // negative when overspent
+	Projected    valueobjects.Money // spend at the end of the period if the daily pace so far continues
+	ExpenseCount int
+}
+
+type BudgetInteractor struct {
+	budgetRepo   repositories.BudgetRepository
+	expenseRepo  repositories.ExpenseRepository
+	categoryRepo repositories.CategoryRepository
+	converter    services.CurrencyConverter
+}
+
+func NewBudgetInteractor(budgetRepo repositories.BudgetRepository, expenseRepo repositories.ExpenseRepository, categoryRepo repositories.CategoryRepository, converter services.CurrencyConverter) *BudgetInteractor {
+	return &BudgetInteractor{
+		budgetRepo:   budgetRepo,
+		expenseRepo:  expenseRepo,
+		categoryRepo: categoryRepo,
+		converter:    converter,
+	}
+}
+
+func (i *BudgetInteractor) CreateBudget(householdID entities.HouseholdID, cmd CreateBudgetCommand) (*entities.Budget, error) {
+	money, err := valueobjects.ParseMoney(cmd.Amount, cmd.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	budget, err := entities.NewBudget(money, entities.BudgetPeriod(cmd.Period), entities.BudgetTargetType(cmd.TargetType), cmd.Target, cmd.Rollover)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.ensureTargetAvailable(householdID, budget); err != nil {
+		return nil, err
+	}
+
+	if err := i.budgetRepo.Save(householdID, budget); err != nil {
+		return nil, err
+	}
+
+	return budget, nil
+}
+
+func (i *BudgetInteractor) GetBudgets(householdID entities.HouseholdID) ([]*entities.Budget, error) {
+	return i.budgetRepo.FindAll(householdID)
+}
+
+func (i *BudgetInteractor) GetBudget(householdID entities.HouseholdID, id entities.BudgetID) (*entities.Budget, error) {
+	return i.budgetRepo.FindByID(householdID, id)
+}
+
+func (i *BudgetInteractor) UpdateBudget(householdID entities.HouseholdID, cmd UpdateBudgetCommand) (*entities.Budget, error) {
+	budget, err := i.budgetRepo.FindByID(householdID, cmd.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update amount and/or currency if provided
+	if cmd.Amount != nil || cmd.Currency != nil {
+		currency := budget.Amount().Currency()
+		if cmd.Currency != nil {
+			currency = *cmd.Currency
+		}
+		// Without a new amount the old one is kept as it is, which fails rather than dropping digits the new currency lacks
+		var money valueobjects.Money
+		if cmd.Amount != nil {
+			money, err = valueobjects.ParseMoney(*cmd.Amount, currency)
+		} else {
+			money, err = budget.Amount().WithCurrency(currency)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := budget.UpdateAmount(money); err != nil {
+			return nil, err
+		}
+	}
+
+	if cmd.Period != nil {
+		if err := budget.UpdatePeriod(entities.BudgetPeriod(*cmd.Period)); err != nil {
+			return nil, err
+		}
+	}
+
+	if cmd.TargetType != nil || cmd.Target != nil {
+		targetType := budget.TargetType()
+		if cmd.TargetType != nil {
+			targetType = entities.BudgetTargetType(*cmd.TargetType)
+		}
+		target := budget.Target()
+		if cmd.Target != nil {
+			target = *cmd.Target
+		}
+		if err := budget.UpdateTarget(targetType, target); err != nil {
+			return nil, err
+		}
+	}
+
+	if cmd.Rollover != nil {
+		budget.UpdateRollover(*cmd.Rollover)
+	}
+
+	if cmd.Period != nil || cmd.TargetType != nil || cmd.Target != nil {
+		if err := i.ensureTargetAvailable(householdID, budget); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := i.budgetRepo.Update(householdID, budget); err != nil {
+		return nil, err
+	}
+
+	return budget, nil
+}
+
+func (i *BudgetInteractor) DeleteBudget(householdID entities.HouseholdID, id entities.BudgetID) error {
+	return i.budgetRepo.Delete(householdID, id)
+}
+
+// GetBudgetStatus computes the status of every budget for the period containing date.
+// Rollover only carries over the previous period's leftover, it does not accumulate across periods.
+func (i *BudgetInteractor) GetBudgetStatus(householdID entities.HouseholdID, date time.Time) ([]*BudgetStatus, error) {
+	budgets, err := i.budgetRepo.FindAll(householdID)
+	if err != nil {
+		return nil, err
+	}
+	if len(budgets) == 0 {
+		return []*BudgetStatus{}, nil
+	}
+
+	// Load the expenses of every period involved with a single query
+	var rangeStart, rangeEnd time.Time
+	for idx, budget := range budgets {
+		start, end := budget.Period().Bounds(date)
+		if budget.Rollover() {
+			start, _ = budget.Period().Bounds(start.AddDate(0, 0, -1))
+		}
+		if idx == 0 || start.Before(rangeStart) {
+			rangeStart = start
+		}
+		if idx == 0 || end.After(rangeEnd) {
+			rangeEnd = end
+		}
+	}
+
+	expenses, err := i.expenseRepo.FindByDateRange(householdID, &rangeStart, &rangeEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	today := repositories.PeriodDay.Start(time.Now())
+	statuses := make([]*BudgetStatus, 0, len(budgets))
+	for _, budget := range budgets {
+		status, err := i.budgetStatus(budget, expenses, date, today)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (i *BudgetInteractor) budgetStatus(budget *entities.Budget, expenses []*entities.Expense, date, today time.Time) (*BudgetStatus, error) {
+	start, end := budget.Period().Bounds(date)
+
+	spent, count, err := i.spentBetween(budget, expenses, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	carryover, err := valueobjects.ZeroMoney(budget.Amount().Currency())
+	if err != nil {
+		return nil, err
+	}
+	if budget.Rollover() && repositories.PeriodDay.Start(budget.CreatedAt()).Before(start) {
+		previousStart, previousEnd := budget.Period().Bounds(start.AddDate(0, 0, -1))
+		previousSpent, _, err := i.spentBetween(budget, expenses, previousStart, previousEnd)
+		if err != nil {
+			return nil, err
+		}
+		leftover, err := budget.Amount().Subtract(previousSpent)
+		if err != nil {
+			return nil, err
+		}
+		if !leftover.IsNegative() {
+			carryover = leftover
+		}
+	}
+
+	available, err := budget.Amount().Add(carryover)
+	if err != nil {
+		return nil, err
+	}
+	remaining, err := available.Subtract(spent)
+	if err != nil {
+		return nil, err
+	}
+
+	// Past and future periods are not projected, only the running one
+	projected := spent
+	if !today.Before(start) && !today.After(end) {
+		elapsedDays := today.Sub(start).Hours()/24 + 1
+		totalDays := end.Sub(start).Hours()/24 + 1
+		projected, err = spent.Multiply(totalDays / elapsedDays)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &BudgetStatus{
+		Budget:       budget,
+		PeriodStart:  start,
+		PeriodEnd:    end,
+		Carryover:    carryover,
+		Available:    available,
+		Spent:        spent,
+		Remaining:    remaining,
+		Projected:    projected,
+		ExpenseCount: count,
+	}, nil
+}
+
+// spentBetween sums the matching expenses between start and end inclusive, converted into the budget currency
+func (i *BudgetInteractor) spentBetween(budget *entities.Budget, expenses []*entities.Expense, start, end time.Time) (valueobjects.Money, int, error) {
+	total, err := valueobjects.ZeroMoney(budget.Amount().Currency())
+	if err != nil {
+		return valueobjects.Money{}, 0, err
+	}
+
+	count := 0
+	for _, expense := range expenses {
+		expenseDay := repositories.PeriodDay.Start(expense.Date())
+		if expenseDay.Before(start) || expenseDay.After(end) || !budget.Matches(expense) {
+			continue
+		}
+		converted, err := i.converter.Convert(expense.Amount(), expense.Date(), total.Currency())
+		if err != nil {
+			return valueobjects.Money{}, 0, err
+		}
+		total, err = total.Add(converted)
+		if err != nil {
+			return valueobjects.Money{}, 0, err
+		}
+		count++
+	}
+
+	return total, count, nil
+}
+
+// ensureTargetAvailable checks that a category target exists and that no other budget covers the same target and period
+func (i *BudgetInteractor) ensureTargetAvailable(householdID entities.HouseholdID, budget *entities.Budget) error {
+	if budget.TargetType() == entities.BudgetTargetCategory {
+		if _, err := i.categoryRepo.FindByName(householdID, budget.Target()); err != nil {
+			return err
+		}
+	}
+
+	existing, err := i.budgetRepo.FindByTarget(householdID, budget.TargetType(), budget.Target(), budget.Period())
+	if err != nil && err != entities.ErrBudgetNotFound {
+		return err
+	}
+	if existing != nil && existing.ID() != budget.ID() {
+		return entities.ErrBudgetAlreadyExists
+	}
+	return nil
+}
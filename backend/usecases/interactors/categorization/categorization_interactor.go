@@ -0,0 +1,289 @@
+package categorization
+
+import (
+	"errors"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+	"expenso-backend/usecases/interfaces/repositories"
+)
+
+// RuleConditionsCommand holds the conditions of a rule; empty fields are no condition
+type RuleConditionsCommand struct {
+	Pattern    string
+	VendorType string
+	Amount     string // Decimal amounts, e.g. "17.50"
+	MinAmount  string
+	MaxAmount  string
+	Currency   string // ISO-4217 code of the amounts, defaults to EUR if empty
+}
+
+type CreateCategorizationRuleCommand struct {
+	Name       string
+	Priority   *int  // Lower runs first, defaults to after the last rule
+	Active     *bool // Defaults to true if nil
+	Conditions RuleConditionsCommand
+	VendorID   *entities.VendorID
+	Category   string
+	TagIDs     []entities.TagID
+}
+
+type UpdateCategorizationRuleCommand struct {
+	ID         entities.CategorizationRuleID
+	Name       *string
+	Priority   *int
+	Active     *bool
+	Conditions *RuleConditionsCommand // Replaces all conditions, nil keeps them
+	VendorID   *entities.VendorID     // 0 removes the vendor
+	Category   *string                // Empty string removes the category
+	TagIDs     *[]entities.TagID      // nil means no change, empty slice means clear tags
+}
+
+// TestCategorizationRulesCommand describes an expense to run the rules against without saving anything.
+// A vendor supplies payee and vendor type where they are left empty.
+type TestCategorizationRulesCommand struct {
+	Payee      string
+	Comment    string
+	Amount     string
+	Currency   string
+	VendorID   *entities.VendorID
+	VendorType string
+}
+
+// RuleTestResult names the rule that would fire and every active rule that matches, in the order they are applied
+type RuleTestResult struct {
+	Rule    *entities.CategorizationRule // nil if no rule matches
+	Matches []*entities.CategorizationRule
+}
+
+type CategorizationInteractor struct {
+	ruleRepo     repositories.CategorizationRuleRepository
+	vendorRepo   repositories.VendorRepository
+	categoryRepo repositories.CategoryRepository
+	tagRepo      repositories.TagRepository
+}
+
+func NewCategorizationInteractor(ruleRepo repositories.CategorizationRuleRepository, vendorRepo repositories.VendorRepository,
+	categoryRepo repositories.CategoryRepository, tagRepo repositories.TagRepository) *CategorizationInteractor {
+	return &CategorizationInteractor{
+		ruleRepo:     ruleRepo,
+		vendorRepo:   vendorRepo,
+		categoryRepo: categoryRepo,
+		tagRepo:      tagRepo,
+	}
+}
+
+func (i *CategorizationInteractor) CreateRule(householdID entities.HouseholdID, cmd CreateCategorizationRuleCommand) (*entities.CategorizationRule, error) {
+	conditions, err := cmd.Conditions.parse()
+	if err != nil {
+		return nil, err
+	}
+	actions := entities.RuleActions{VendorID: cmd.VendorID, Category: cmd.Category, TagIDs: cmd.TagIDs}
+
+	priority := 0
+	if cmd.Priority != nil {
+		priority = *cmd.Priority
+	} else {
+		rules, err := i.ruleRepo.FindAll(householdID)
+		if err != nil {
+			return nil, err
+		}
+		if len(rules) > 0 {
+			priority = rules[len(rules)-1].Priority() + 1
+		}
+	}
+
+	rule, err := entities.NewCategorizationRule(cmd.Name, priority, conditions, actions)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.Active != nil {
+		rule.UpdateActive(*cmd.Active)
+	}
+
+	if err := i.validate(householdID, rule); err != nil {
+		return nil, err
+	}
+
+	if err := i.ruleRepo.Save(householdID, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// GetRules returns the rules in the order they are applied
+func (i *CategorizationInteractor) GetRules(householdID entities.HouseholdID) ([]*entities.CategorizationRule, error) {
+	return i.ruleRepo.FindAll(householdID)
+}
+
+func (i *CategorizationInteractor) GetRule(householdID entities.HouseholdID, id entities.CategorizationRuleID) (*entities.CategorizationRule, error) {
+	return i.ruleRepo.FindByID(householdID, id)
+}
+
+func (i *CategorizationInteractor) UpdateRule(householdID entities.HouseholdID, cmd UpdateCategorizationRuleCommand) (*entities.CategorizationRule, error) {
+	rule, err := i.ruleRepo.FindByID(householdID, cmd.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.Name != nil {
+		if err := rule.UpdateName(*cmd.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	if cmd.Priority != nil {
+		rule.UpdatePriority(*cmd.Priority)
+	}
+
+	if cmd.Active != nil {
+		rule.UpdateActive(*cmd.Active)
+	}
+
+	if cmd.Conditions != nil {
+		conditions, err := cmd.Conditions.parse()
+		if err != nil {
+			return nil, err
+		}
+		if err := rule.UpdateConditions(conditions); err != nil {
+			return nil, err
+		}
+	}
+
+	if cmd.VendorID != nil || cmd.Category != nil || cmd.TagIDs != nil {
+		actions := rule.Actions()
+		if cmd.VendorID != nil {
+			actions.VendorID = cmd.VendorID
+			if *cmd.VendorID == 0 {
+				actions.VendorID = nil
+			}
+		}
+		if cmd.Category != nil {
+			actions.Category = *cmd.Category
+		}
+		if cmd.TagIDs != nil {
+			actions.TagIDs = *cmd.TagIDs
+		}
+		if err := rule.UpdateActions(actions); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := i.validate(householdID, rule); err != nil {
+		return nil, err
+	}
+
+	if err := i.ruleRepo.Update(householdID, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// DeleteRule removes a rule; expenses it already categorized are kept as they are
+func (i *CategorizationInteractor) DeleteRule(householdID entities.HouseholdID, id entities.CategorizationRuleID) error {
+	return i.ruleRepo.Delete(householdID, id)
+}
+
+// TestRules runs the household's rules against an expense without creating it
+func (i *CategorizationInteractor) TestRules(householdID entities.HouseholdID, cmd TestCategorizationRulesCommand) (*RuleTestResult, error) {
+	amount, err := valueobjects.ParseMoney(cmd.Amount, cmd.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := entities.RuleSubject{
+		Payee:      cmd.Payee,
+		Comment:    cmd.Comment,
+		Amount:     amount,
+		VendorType: entities.VendorType(cmd.VendorType),
+	}
+	if subject.VendorType != "" && !subject.VendorType.IsValid() {
+		return nil, entities.ErrInvalidVendorType
+	}
+	if cmd.VendorID != nil {
+		vendor, err := i.vendorRepo.FindByID(householdID, *cmd.VendorID)
+		if err != nil {
+			return nil, err
+		}
+		if subject.Payee == "" {
+			subject.Payee = vendor.Name()
+		}
+		if subject.VendorType == "" {
+			subject.VendorType = vendor.Type()
+		}
+	}
+
+	rules, err := i.ruleRepo.FindAll(householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RuleTestResult{Rule: entities.FirstMatchingRule(rules, subject), Matches: []*entities.CategorizationRule{}}
+	for _, rule := range rules {
+		if rule.Active() && rule.Matches(subject) {
+			result.Matches = append(result.Matches, rule)
+		}
+	}
+	return result, nil
+}
+
+// validate checks the name is free and the vendor, category and tags the rule assigns belong to the household
+func (i *CategorizationInteractor) validate(householdID entities.HouseholdID, rule *entities.CategorizationRule) error {
+	existing, err := i.ruleRepo.FindByName(householdID, rule.Name())
+	if err != nil && err != entities.ErrCategorizationRuleNotFound {
+		return err
+	}
+	if existing != nil && existing.ID() != rule.ID() {
+		return entities.ErrCategorizationRuleExists
+	}
+
+	actions := rule.Actions()
+	if actions.VendorID != nil {
+		if _, err := i.vendorRepo.FindByID(householdID, *actions.VendorID); err != nil {
+			return err
+		}
+	}
+	if actions.Category != "" {
+		if _, err := i.categoryRepo.FindByName(householdID, actions.Category); err != nil {
+			return err
+		}
+	}
+	for _, tagID := range actions.TagIDs {
+		tag, err := i.tagRepo.GetByID(householdID, tagID)
+		if err != nil {
+			return err
+		}
+		if tag == nil {
+			return errors.New("tag not found")
+		}
+	}
+	return nil
+}
+
+// parse turns the decimal amounts into money
+func (c RuleConditionsCommand) parse() (entities.RuleConditions, error) {
+	conditions := entities.RuleConditions{
+		Pattern:    c.Pattern,
+		VendorType: entities.VendorType(c.VendorType),
+	}
+	for _, field := range []struct {
+		decimal string
+		target  **valueobjects.Money
+	}{
+		{c.Amount, &conditions.Amount},
+		{c.MinAmount, &conditions.MinAmount},
+		{c.MaxAmount, &conditions.MaxAmount},
+	} {
+		if field.decimal == "" {
+			continue
+		}
+		money, err := valueobjects.ParseMoney(field.decimal, c.Currency)
+		if err != nil {
+			return conditions, err
+		}
+		*field.target = &money
+	}
+	return conditions, nil
+}
@@ -30,9 +30,9 @@ func NewCategoryInteractor(categoryRepo repositories.CategoryRepository) *Catego
 	}
 }
 
-func (i *CategoryInteractor) CreateCategory(cmd CreateCategoryCommand) (*entities.CategoryEntity, error) {
+func (i *CategoryInteractor) CreateCategory(householdID entities.HouseholdID, cmd CreateCategoryCommand) (*entities.CategoryEntity, error) {
 	// Check if category with same name already exists
-	existingCategory, err := i.categoryRepo.FindByName(cmd.Name)
+	existingCategory, err := i.categoryRepo.FindByName(householdID, cmd.Name)
 	if err != nil && err != entities.ErrCategoryNotFound {
 		return nil, err
 	}
@@ -47,28 +47,28 @@ func (i *CategoryInteractor) CreateCategory(cmd CreateCategoryCommand) (*entitie
 	}
 
 	// Save category
-	if err := i.categoryRepo.Save(category); err != nil {
+	if err := i.categoryRepo.Save(householdID, category); err != nil {
 		return nil, err
 	}
 
 	return category, nil
 }
 
-func (i *CategoryInteractor) GetCategories() ([]*entities.CategoryEntity, error) {
-	return i.categoryRepo.FindAll()
+func (i *CategoryInteractor) GetCategories(householdID entities.HouseholdID) ([]*entities.CategoryEntity, error) {
+	return i.categoryRepo.FindAll(householdID)
 }
 
-func (i *CategoryInteractor) GetCategory(id entities.CategoryID) (*entities.CategoryEntity, error) {
-	return i.categoryRepo.FindByID(id)
+func (i *CategoryInteractor) GetCategory(householdID entities.HouseholdID, id entities.CategoryID) (*entities.CategoryEntity, error) {
+	return i.categoryRepo.FindByID(householdID, id)
 }
 
-func (i *CategoryInteractor) GetCategoryByName(name string) (*entities.CategoryEntity, error) {
-	return i.categoryRepo.FindByName(name)
+func (i *CategoryInteractor) GetCategoryByName(householdID entities.HouseholdID, name string) (*entities.CategoryEntity, error) {
+	return i.categoryRepo.FindByName(householdID, name)
 }
 
-func (i *CategoryInteractor) UpdateCategory(cmd UpdateCategoryCommand) (*entities.CategoryEntity, error) {
+func (i *CategoryInteractor) UpdateCategory(householdID entities.HouseholdID, cmd UpdateCategoryCommand) (*entities.CategoryEntity, error) {
 	// Find existing category
-	category, err := i.categoryRepo.FindByID(cmd.ID)
+	category, err := i.categoryRepo.FindByID(householdID, cmd.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -93,20 +93,20 @@ func (i *CategoryInteractor) UpdateCategory(cmd UpdateCategoryCommand) (*entitie
 	}
 
 	// Save updated category
-	if err := i.categoryRepo.Update(category); err != nil {
+	if err := i.categoryRepo.Update(householdID, category); err != nil {
 		return nil, err
 	}
 
 	return category, nil
 }
 
-func (i *CategoryInteractor) DeleteCategory(id entities.CategoryID) error {
+func (i *CategoryInteractor) DeleteCategory(householdID entities.HouseholdID, id entities.CategoryID) error {
 	// Check if category exists
-	_, err := i.categoryRepo.FindByID(id)
+	_, err := i.categoryRepo.FindByID(householdID, id)
 	if err != nil {
 		return err
 	}
 
 	// Delete category
-	return i.categoryRepo.Delete(id)
+	return i.categoryRepo.Delete(householdID, id)
 }
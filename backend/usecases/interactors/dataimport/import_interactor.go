@@ -0,0 +1,504 @@
+package dataimport
+
+import (
+	"fmt"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+	"expenso-backend/usecases/interactors/expense"
+	"expenso-backend/usecases/interactors/income"
+	"expenso-backend/usecases/interfaces/repositories"
+)
+
+// defaultImportCategory is suggested for debits until someone picks a better category
+const defaultImportCategory = "Other"
+
+// StatementPreviewRow is one statement entry with the expense or income it would become.
+// Debits become expenses, credits become incomes.
+type StatementPreviewRow struct {
+	RowNumber   int
+	Entry       *entities.StatementEntry
+	Fingerprint string                       // Identifies the transaction across imports, send it back on confirm
+	Vendor      *entities.Vendor             // Vendor named like the counterparty, if any
+	Category    string                       // Suggested expense category, empty for credits
+	TagIDs      []entities.TagID             // Tags of the matching categorization rule, debits only
+	Rule        *entities.CategorizationRule // Categorization rule the suggestion came from, if any
+	Source      string                       // Suggested income source, empty for debits
+	Comment     string
+	Duplicate   bool // Imported before, or an existing transaction has the same date and amount
+	Issues      []string
+}
+
+type StatementPreview struct {
+	Account    string
+	ImportedIn *entities.ImportBatch // Earlier batch that imported the same file, if any
+	Rows       []*StatementPreviewRow
+}
+
+// CSVPreviewRow is one CSV row with the expenses and incomes its amounts would become
+type CSVPreviewRow struct {
+	Row   *entities.CSVRow
+	Items []*StatementPreviewRow
+}
+
+type CSVPreview struct {
+	ImportedIn *entities.ImportBatch // Earlier batch that imported the same file, if any
+	Rows       []*CSVPreviewRow
+}
+
+// ImportedExpense is a reviewed expense of a preview.
+// Without a fingerprint from the preview, one is derived from date, amount and comment.
+type ImportedExpense struct {
+	Fingerprint string
+	Command     expense.CreateExpenseFromCSVCommand
+}
+
+type ImportedIncome struct {
+	Fingerprint string
+	Command     income.CreateIncomeFromCSVCommand
+}
+
+// ConfirmImportCommand holds the reviewed rows of a CSV or statement preview
+type ConfirmImportCommand struct {
+	Source     string // csv, camt053, mt940, ofx or qfx
+	FileHash   string // From the preview, optional
+	Force      bool   // Import even if the file or every one of its transactions was imported before
+	BestEffort bool   // Create every valid row and report the others, instead of creating nothing if one row fails
+	Expenses   []ImportedExpense
+	Incomes    []ImportedIncome
+}
+
+// ImportRowResult reports the outcome of one reviewed row in best-effort mode
+type ImportRowResult struct {
+	Kind        string // expense or income
+	Index       int    // Position in the command's expenses or incomes, starting at 1
+	Fingerprint string
+	Expense     *entities.Expense
+	Income      *entities.Income
+	Err         error
+}
+
+type ImportResult struct {
+	Batch    *entities.ImportBatch // Not saved if nothing was created
+	Expenses []*entities.Expense
+	Incomes  []*entities.Income
+	Rows     []ImportRowResult // Best-effort mode only
+}
+
+type ImportInteractor struct {
+	vendorRepo        repositories.VendorRepository
+	categoryRepo      repositories.CategoryRepository
+	expenseRepo       repositories.ExpenseRepository
+	incomeRepo        repositories.IncomeRepository
+	memberRepo        repositories.MemberRepository
+	tagRepo           repositories.TagRepository
+	batchRepo         repositories.ImportBatchRepository
+	ruleRepo          repositories.CategorizationRuleRepository
+	unitOfWork        repositories.UnitOfWork
+	expenseInteractor *expense.ExpenseInteractor
+	incomeInteractor  *income.IncomeInteractor
+}
+
+func NewImportInteractor(vendorRepo repositories.VendorRepository, categoryRepo repositories.CategoryRepository, expenseRepo repositories.ExpenseRepository, incomeRepo repositories.IncomeRepository,
+	memberRepo repositories.MemberRepository, tagRepo repositories.TagRepository, batchRepo repositories.ImportBatchRepository, ruleRepo repositories.CategorizationRuleRepository,
+	unitOfWork repositories.UnitOfWork, expenseInteractor *expense.ExpenseInteractor, incomeInteractor *income.IncomeInteractor) *ImportInteractor {
+	return &ImportInteractor{
+		vendorRepo:        vendorRepo,
+		categoryRepo:      categoryRepo,
+		expenseRepo:       expenseRepo,
+		incomeRepo:        incomeRepo,
+		memberRepo:        memberRepo,
+		tagRepo:           tagRepo,
+		batchRepo:         batchRepo,
+		ruleRepo:          ruleRepo,
+		unitOfWork:        unitOfWork,
+		expenseInteractor: expenseInteractor,
+		incomeInteractor:  incomeInteractor,
+	}
+}
+
+// PreviewStatement suggests an expense or income for every entry of a parsed bank statement without saving anything,
+// applying the household's categorization rules to debits
+func (i *ImportInteractor) PreviewStatement(householdID entities.HouseholdID, statement *entities.BankStatement, fileHash string) (*StatementPreview, error) {
+	suggester, err := i.newSuggester(householdID, nil)
+	if err != nil {
+		return nil, err
+	}
+	preview := &StatementPreview{
+		Account: statement.Account,
+		Rows:    make([]*StatementPreviewRow, 0, len(statement.Entries)),
+	}
+
+	for idx, entry := range statement.Entries {
+		row, err := suggester.suggest(idx+1, entry)
+		if err != nil {
+			return nil, err
+		}
+		preview.Rows = append(preview.Rows, row)
+	}
+
+	importedIn, err := i.findBatchByFileHash(householdID, fileHash)
+	if err != nil {
+		return nil, err
+	}
+	preview.ImportedIn = importedIn
+
+	if err := i.markDuplicates(householdID, statement.Account, preview.Rows); err != nil {
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+// PreviewCSV suggests an expense or income for every amount of a CSV file read with profile,
+// applying the household's categorization rules and then the profile's rules
+func (i *ImportInteractor) PreviewCSV(householdID entities.HouseholdID, profile *entities.ImportProfile, rows []*entities.CSVRow, fileHash string) (*CSVPreview, error) {
+	suggester, err := i.newSuggester(householdID, profile)
+	if err != nil {
+		return nil, err
+	}
+	preview := &CSVPreview{Rows: make([]*CSVPreviewRow, 0, len(rows))}
+	var items []*StatementPreviewRow
+
+	for _, row := range rows {
+		previewRow := &CSVPreviewRow{Row: row, Items: make([]*StatementPreviewRow, 0, len(row.Entries))}
+		for _, entry := range row.Entries {
+			item, err := suggester.suggest(row.RowNumber, entry)
+			if err != nil {
+				return nil, err
+			}
+			previewRow.Items = append(previewRow.Items, item)
+		}
+		preview.Rows = append(preview.Rows, previewRow)
+		items = append(items, previewRow.Items...)
+	}
+
+	importedIn, err := i.findBatchByFileHash(householdID, fileHash)
+	if err != nil {
+		return nil, err
+	}
+	preview.ImportedIn = importedIn
+
+	if err := i.markDuplicates(householdID, "", items); err != nil {
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+// ConfirmImport creates the reviewed expenses and incomes and records them as one import batch.
+// Unless forced, it refuses a file that was imported before, or rows that were all imported before.
+// Everything is created in one transaction, so if one row fails nothing is created; see BestEffort for the alternative.
+func (i *ImportInteractor) ConfirmImport(householdID entities.HouseholdID, cmd ConfirmImportCommand) (*ImportResult, error) {
+	batch, err := entities.NewImportBatch(cmd.Source, cmd.FileHash)
+	if err != nil {
+		return nil, err
+	}
+
+	expenseFingerprints := make([]string, len(cmd.Expenses))
+	incomeFingerprints := make([]string, len(cmd.Incomes))
+	for idx, imported := range cmd.Expenses {
+		expenseFingerprints[idx] = imported.Fingerprint
+		if expenseFingerprints[idx] == "" {
+			expenseFingerprints[idx] = commandFingerprint(imported.Command.Date, imported.Command.Amount, imported.Command.Currency, imported.Command.Comment)
+		}
+	}
+	for idx, imported := range cmd.Incomes {
+		incomeFingerprints[idx] = imported.Fingerprint
+		if incomeFingerprints[idx] == "" {
+			incomeFingerprints[idx] = commandFingerprint(imported.Command.Date, imported.Command.Amount, imported.Command.Currency, imported.Command.Comment)
+		}
+	}
+
+	if !cmd.Force {
+		if err := i.ensureNotImported(householdID, batch.FileHash(), append(append([]string{}, expenseFingerprints...), incomeFingerprints...)); err != nil {
+			return nil, err
+		}
+	}
+
+	if cmd.BestEffort {
+		return i.confirmBestEffort(householdID, cmd, batch, expenseFingerprints, incomeFingerprints)
+	}
+
+	result := &ImportResult{
+		Batch:    batch,
+		Expenses: []*entities.Expense{},
+		Incomes:  []*entities.Income{},
+	}
+
+	err = i.unitOfWork.Do(func(repos repositories.Repositories) error {
+		expenses := i.expenseInteractor.WithRepositories(repos)
+		incomes := i.incomeInteractor.WithRepositories(repos)
+
+		for idx, imported := range cmd.Expenses {
+			created, err := expenses.CreateExpenseFromCSV(householdID, imported.Command)
+			if err != nil {
+				return fmt.Errorf("expense %d: %w", idx+1, err)
+			}
+			batch.AddExpense(expenseFingerprints[idx], created.ID())
+			result.Expenses = append(result.Expenses, created)
+		}
+
+		for idx, imported := range cmd.Incomes {
+			created, err := incomes.CreateIncomeFromCSV(householdID, imported.Command)
+			if err != nil {
+				return fmt.Errorf("income %d: %w", idx+1, err)
+			}
+			batch.AddIncome(incomeFingerprints[idx], created.ID())
+			result.Incomes = append(result.Incomes, created)
+		}
+
+		if len(batch.Items()) == 0 {
+			return nil
+		}
+		return repos.ImportBatches.Save(householdID, batch)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// confirmBestEffort creates each row in a transaction of its own, so a failing row leaves nothing half-created
+// but does not stop the others, and reports the outcome of every row. Each row is recorded in the batch within its
+// transaction, so every created transaction can be rolled back and is recognized when the file is imported again.
+func (i *ImportInteractor) confirmBestEffort(householdID entities.HouseholdID, cmd ConfirmImportCommand, batch *entities.ImportBatch,
+	expenseFingerprints, incomeFingerprints []string) (*ImportResult, error) {
+	result := &ImportResult{
+		Batch:    batch,
+		Expenses: []*entities.Expense{},
+		Incomes:  []*entities.Income{},
+		Rows:     make([]ImportRowResult, 0, len(cmd.Expenses)+len(cmd.Incomes)),
+	}
+
+	for idx, imported := range cmd.Expenses {
+		row := ImportRowResult{Kind: "expense", Index: idx + 1, Fingerprint: expenseFingerprints[idx]}
+		row.Err = i.withBatchItem(householdID, batch, func(repos repositories.Repositories) (entities.ImportBatchItem, error) {
+			created, err := i.expenseInteractor.WithRepositories(repos).CreateExpenseFromCSV(householdID, imported.Command)
+			if err != nil {
+				return entities.ImportBatchItem{}, err
+			}
+			row.Expense = created
+			id := created.ID()
+			return entities.ImportBatchItem{Fingerprint: row.Fingerprint, ExpenseID: &id}, nil
+		})
+		if row.Err == nil {
+			batch.AddExpense(row.Fingerprint, row.Expense.ID())
+			result.Expenses = append(result.Expenses, row.Expense)
+		} else {
+			row.Expense = nil
+		}
+		result.Rows = append(result.Rows, row)
+	}
+
+	for idx, imported := range cmd.Incomes {
+		row := ImportRowResult{Kind: "income", Index: idx + 1, Fingerprint: incomeFingerprints[idx]}
+		row.Err = i.withBatchItem(householdID, batch, func(repos repositories.Repositories) (entities.ImportBatchItem, error) {
+			created, err := i.incomeInteractor.WithRepositories(repos).CreateIncomeFromCSV(householdID, imported.Command)
+			if err != nil {
+				return entities.ImportBatchItem{}, err
+			}
+			row.Income = created
+			id := created.ID()
+			return entities.ImportBatchItem{Fingerprint: row.Fingerprint, IncomeID: &id}, nil
+		})
+		if row.Err == nil {
+			batch.AddIncome(row.Fingerprint, row.Income.ID())
+			result.Incomes = append(result.Incomes, row.Income)
+		} else {
+			row.Income = nil
+		}
+		result.Rows = append(result.Rows, row)
+	}
+
+	return result, nil
+}
+
+// withBatchItem runs create in a transaction and records the item it returns in the batch within the same transaction.
+// The batch is saved with its first item, so a confirm that creates nothing leaves no batch behind.
+func (i *ImportInteractor) withBatchItem(householdID entities.HouseholdID, batch *entities.ImportBatch,
+	create func(repos repositories.Repositories) (entities.ImportBatchItem, error)) error {
+	saved := batch.ID() != 0
+	err := i.unitOfWork.Do(func(repos repositories.Repositories) error {
+		item, err := create(repos)
+		if err != nil {
+			return err
+		}
+		if !saved {
+			if err := repos.ImportBatches.Save(householdID, batch); err != nil {
+				return err
+			}
+		}
+		return repos.ImportBatches.AddItem(householdID, batch.ID(), item)
+	})
+	if err != nil && !saved {
+		batch.SetID(0) // the batch was rolled back with the row
+	}
+	return err
+}
+
+func (i *ImportInteractor) GetBatches(householdID entities.HouseholdID) ([]*entities.ImportBatch, error) {
+	return i.batchRepo.FindAll(householdID)
+}
+
+func (i *ImportInteractor) GetBatch(householdID entities.HouseholdID, id entities.ImportBatchID) (*entities.ImportBatch, error) {
+	return i.batchRepo.FindByID(householdID, id)
+}
+
+// RollBackBatch deletes every transaction a batch created that still exists, all or nothing.
+// The batch is kept, marked as rolled back, and no longer counts for duplicate detection.
+func (i *ImportInteractor) RollBackBatch(householdID entities.HouseholdID, id entities.ImportBatchID) (*entities.ImportBatch, error) {
+	batch, err := i.batchRepo.FindByID(householdID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := batch.RollBack(); err != nil {
+		return nil, err
+	}
+
+	err = i.unitOfWork.Do(func(repos repositories.Repositories) error {
+		expenses := i.expenseInteractor.WithRepositories(repos)
+		incomes := i.incomeInteractor.WithRepositories(repos)
+
+		for _, item := range batch.Items() {
+			if item.ExpenseID != nil {
+				if err := expenses.DeleteExpense(householdID, *item.ExpenseID); err != nil && err != entities.ErrExpenseNotFound {
+					return err
+				}
+			}
+			if item.IncomeID != nil {
+				if err := incomes.DeleteIncome(householdID, *item.IncomeID); err != nil && err != entities.ErrIncomeNotFound {
+					return err
+				}
+			}
+		}
+
+		return repos.ImportBatches.MarkRolledBack(householdID, id, *batch.RolledBackAt())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+func (i *ImportInteractor) findBatchByFileHash(householdID entities.HouseholdID, fileHash string) (*entities.ImportBatch, error) {
+	if fileHash == "" {
+		return nil, nil
+	}
+	batch, err := i.batchRepo.FindByFileHash(householdID, fileHash)
+	if err == entities.ErrImportBatchNotFound {
+		return nil, nil
+	}
+	return batch, err
+}
+
+func (i *ImportInteractor) ensureNotImported(householdID entities.HouseholdID, fileHash string, fingerprints []string) error {
+	previous, err := i.findBatchByFileHash(householdID, fileHash)
+	if err != nil {
+		return err
+	}
+	if previous != nil {
+		return fmt.Errorf("%w: the file is import batch %d", entities.ErrAlreadyImported, previous.ID())
+	}
+
+	if len(fingerprints) == 0 {
+		return nil
+	}
+	imported, err := i.batchRepo.FindImported(householdID, fingerprints)
+	if err != nil {
+		return err
+	}
+	for _, fingerprint := range fingerprints {
+		if _, ok := imported[fingerprint]; !ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: every row is in import batch %d", entities.ErrAlreadyImported, imported[fingerprints[0]])
+}
+
+// markDuplicates flags rows imported by an earlier batch, and rows matching an existing transaction by date and amount.
+// account is the bank account the rows were booked on, empty if the file does not name one.
+func (i *ImportInteractor) markDuplicates(householdID entities.HouseholdID, account string, rows []*StatementPreviewRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	fingerprints := make([]string, len(rows))
+	var from, to time.Time
+	for idx, row := range rows {
+		row.Fingerprint = row.Entry.Fingerprint(account)
+		fingerprints[idx] = row.Fingerprint
+
+		date := row.Entry.BookingDate
+		if date.IsZero() {
+			continue
+		}
+		if from.IsZero() || date.Before(from) {
+			from = date
+		}
+		if to.IsZero() || date.After(to) {
+			to = date
+		}
+	}
+
+	imported, err := i.batchRepo.FindImported(householdID, fingerprints)
+	if err != nil {
+		return err
+	}
+
+	existingExpenses := make(map[string]entities.ExpenseID)
+	existingIncomes := make(map[string]entities.IncomeID)
+	if !from.IsZero() {
+		expenses, err := i.expenseRepo.FindByDateRange(householdID, &from, &to)
+		if err != nil {
+			return err
+		}
+		for _, exp := range expenses {
+			existingExpenses[amountOnDate(exp.Date(), exp.Amount())] = exp.ID()
+		}
+		incomes, err := i.incomeRepo.FindByDateRange(householdID, &from, &to)
+		if err != nil {
+			return err
+		}
+		for _, inc := range incomes {
+			existingIncomes[amountOnDate(inc.Date(), inc.Amount())] = inc.ID()
+		}
+	}
+
+	for _, row := range rows {
+		if batchID, ok := imported[row.Fingerprint]; ok {
+			row.Duplicate = true
+			row.Issues = append(row.Issues, fmt.Sprintf("Already imported in batch %d", batchID))
+			continue
+		}
+
+		key := amountOnDate(row.Entry.BookingDate, row.Entry.Amount)
+		if row.Entry.Direction == entities.StatementDebit {
+			if id, ok := existingExpenses[key]; ok {
+				row.Duplicate = true
+				row.Issues = append(row.Issues, fmt.Sprintf("Possible duplicate of expense %d", id))
+			}
+		} else if id, ok := existingIncomes[key]; ok {
+			row.Duplicate = true
+			row.Issues = append(row.Issues, fmt.Sprintf("Possible duplicate of income %d", id))
+		}
+	}
+
+	return nil
+}
+
+func amountOnDate(date time.Time, amount valueobjects.Money) string {
+	return date.Format("2006-01-02") + "|" + amount.Decimal() + "|" + amount.Currency()
+}
+
+// commandFingerprint derives a fingerprint for a confirmed row that came without one; invalid amounts get none
+func commandFingerprint(date time.Time, amount, currency, comment string) string {
+	money, err := valueobjects.ParseMoney(amount, currency)
+	if err != nil {
+		return ""
+	}
+	return entities.TransactionFingerprint(date, money, comment)
+}
@@ -0,0 +1,103 @@
+package dataimport
+
+import (
+	"expenso-backend/domain/entities"
+	"expenso-backend/usecases/interfaces/repositories"
+)
+
+// SaveImportProfileCommand creates a profile or replaces all of its settings
+type SaveImportProfileCommand struct {
+	Name     string
+	Settings entities.ImportProfileSettings
+}
+
+type ImportProfileInteractor struct {
+	profileRepo repositories.ImportProfileRepository
+	vendorRepo  repositories.VendorRepository
+}
+
+func NewImportProfileInteractor(profileRepo repositories.ImportProfileRepository, vendorRepo repositories.VendorRepository) *ImportProfileInteractor {
+	return &ImportProfileInteractor{
+		profileRepo: profileRepo,
+		vendorRepo:  vendorRepo,
+	}
+}
+
+func (i *ImportProfileInteractor) CreateProfile(householdID entities.HouseholdID, cmd SaveImportProfileCommand) (*entities.ImportProfile, error) {
+	profile, err := entities.NewImportProfile(cmd.Name, cmd.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.validate(householdID, profile); err != nil {
+		return nil, err
+	}
+
+	if err := i.profileRepo.Save(householdID, profile); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+func (i *ImportProfileInteractor) GetProfiles(householdID entities.HouseholdID) ([]*entities.ImportProfile, error) {
+	return i.profileRepo.FindAll(householdID)
+}
+
+// GetProfile returns a saved profile, or the built-in default profile for ID 0
+func (i *ImportProfileInteractor) GetProfile(householdID entities.HouseholdID, id entities.ImportProfileID) (*entities.ImportProfile, error) {
+	if id == 0 {
+		return entities.DefaultImportProfile(), nil
+	}
+	return i.profileRepo.FindByID(householdID, id)
+}
+
+func (i *ImportProfileInteractor) UpdateProfile(householdID entities.HouseholdID, id entities.ImportProfileID, cmd SaveImportProfileCommand) (*entities.ImportProfile, error) {
+	profile, err := i.profileRepo.FindByID(householdID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := profile.UpdateName(cmd.Name); err != nil {
+		return nil, err
+	}
+	if err := profile.UpdateSettings(cmd.Settings); err != nil {
+		return nil, err
+	}
+
+	if err := i.validate(householdID, profile); err != nil {
+		return nil, err
+	}
+
+	if err := i.profileRepo.Update(householdID, profile); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+func (i *ImportProfileInteractor) DeleteProfile(householdID entities.HouseholdID, id entities.ImportProfileID) error {
+	return i.profileRepo.Delete(householdID, id)
+}
+
+// validate checks the name is free and the vendors named by rules belong to the household.
+// Categories are only checked at preview time, so a profile can be set up before its categories.
+func (i *ImportProfileInteractor) validate(householdID entities.HouseholdID, profile *entities.ImportProfile) error {
+	existing, err := i.profileRepo.FindByName(householdID, profile.Name())
+	if err != nil && err != entities.ErrImportProfileNotFound {
+		return err
+	}
+	if existing != nil && existing.ID() != profile.ID() {
+		return entities.ErrImportProfileExists
+	}
+
+	for _, rule := range profile.Settings().Rules {
+		if rule.VendorID == nil {
+			continue
+		}
+		if _, err := i.vendorRepo.FindByID(householdID, *rule.VendorID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package dataimport
+
+import (
+	"fmt"
+	"strings"
+
+	"expenso-backend/domain/entities"
+)
+
+// IncomeCSVPreviewRow is one income CSV row with the income it would become
+type IncomeCSVPreviewRow struct {
+	Row    *entities.IncomeCSVRow
+	Item   *StatementPreviewRow // Nil if the date or amount could not be read
+	Member *entities.Member     // Member named in the added_by column, if any
+	Tags   []*entities.Tag
+}
+
+type IncomeCSVPreview struct {
+	ImportedIn *entities.ImportBatch // Earlier batch that imported the same file, if any
+	Rows       []*IncomeCSVPreviewRow
+}
+
+// PreviewIncomeCSV resolves the vendor, member and tag names of every income CSV row and flags rows imported before
+func (i *ImportInteractor) PreviewIncomeCSV(householdID entities.HouseholdID, rows []*entities.IncomeCSVRow, fileHash string) (*IncomeCSVPreview, error) {
+	tags, err := i.tagRepo.GetAll(householdID)
+	if err != nil {
+		return nil, err
+	}
+	tagsByName := make(map[string]*entities.Tag, len(tags))
+	for _, tag := range tags {
+		tagsByName[strings.ToLower(tag.Name())] = tag
+	}
+	vendors := make(map[string]*entities.Vendor)
+	members := make(map[string]*entities.Member)
+
+	preview := &IncomeCSVPreview{Rows: make([]*IncomeCSVPreviewRow, 0, len(rows))}
+	var items []*StatementPreviewRow
+
+	for _, row := range rows {
+		previewRow := &IncomeCSVPreviewRow{Row: row}
+		preview.Rows = append(preview.Rows, previewRow)
+		if row.Entry == nil {
+			continue
+		}
+
+		item := &StatementPreviewRow{
+			RowNumber: row.RowNumber,
+			Entry:     row.Entry,
+			Source:    row.Entry.Counterparty,
+			Comment:   row.Entry.Reference,
+		}
+
+		if row.Vendor != "" {
+			vendor, cached := vendors[row.Vendor]
+			if !cached {
+				vendor, err = i.vendorRepo.FindByName(householdID, row.Vendor)
+				if err != nil && err != entities.ErrVendorNotFound {
+					return nil, err
+				}
+				vendors[row.Vendor] = vendor
+			}
+			if vendor == nil {
+				item.Issues = append(item.Issues, fmt.Sprintf("Vendor %q does not exist", row.Vendor))
+			}
+			item.Vendor = vendor
+		}
+
+		if row.AddedBy != "" {
+			member, cached := members[row.AddedBy]
+			if !cached {
+				member, err = i.memberRepo.FindByName(householdID, row.AddedBy)
+				if err != nil && err != entities.ErrMemberNotFound {
+					return nil, err
+				}
+				members[row.AddedBy] = member
+			}
+			if member == nil {
+				item.Issues = append(item.Issues, fmt.Sprintf("Member %q does not exist", row.AddedBy))
+			}
+			previewRow.Member = member
+		}
+
+		for _, name := range row.Tags {
+			tag, ok := tagsByName[strings.ToLower(name)]
+			if !ok {
+				item.Issues = append(item.Issues, fmt.Sprintf("Tag %q does not exist", name))
+				continue
+			}
+			previewRow.Tags = append(previewRow.Tags, tag)
+		}
+
+		previewRow.Item = item
+		items = append(items, item)
+	}
+
+	importedIn, err := i.findBatchByFileHash(householdID, fileHash)
+	if err != nil {
+		return nil, err
+	}
+	preview.ImportedIn = importedIn
+
+	if err := i.markDuplicates(householdID, "", items); err != nil {
+		return nil, err
+	}
+
+	return preview, nil
+}
@@ -0,0 +1,191 @@
+package dataimport
+
+import (
+	"fmt"
+
+	"expenso-backend/domain/entities"
+)
+
+// suggester turns parsed entries into preview rows, caching the lookups one preview repeats
+type suggester struct {
+	interactor  *ImportInteractor
+	householdID entities.HouseholdID
+	profile     *entities.ImportProfile // nil for bank statements, which have no rules
+	rules       []*entities.CategorizationRule
+	categories  map[string]bool
+	vendors     map[entities.VendorID]*entities.Vendor
+	tags        map[entities.TagID]bool
+}
+
+func (i *ImportInteractor) newSuggester(householdID entities.HouseholdID, profile *entities.ImportProfile) (*suggester, error) {
+	rules, err := i.ruleRepo.FindAll(householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &suggester{
+		interactor:  i,
+		householdID: householdID,
+		profile:     profile,
+		rules:       rules,
+		categories:  make(map[string]bool),
+		vendors:     make(map[entities.VendorID]*entities.Vendor),
+	}, nil
+}
+
+// suggest picks vendor, category or source for one entry.
+// For debits a matching categorization rule of the household wins, then a matching profile rule,
+// then the profile's default category, then the Other category. Credits only use profile rules.
+func (s *suggester) suggest(rowNumber int, entry *entities.StatementEntry) (*StatementPreviewRow, error) {
+	row := &StatementPreviewRow{
+		RowNumber: rowNumber,
+		Entry:     entry,
+		Comment:   entry.Reference,
+		Issues:    append([]string{}, entry.Issues...),
+	}
+	if row.Comment == "" {
+		row.Comment = entry.Counterparty
+	}
+
+	var named *entities.Vendor
+	if entry.Counterparty != "" {
+		vendor, err := s.interactor.vendorRepo.FindByName(s.householdID, entry.Counterparty)
+		if err != nil && err != entities.ErrVendorNotFound {
+			return nil, err
+		}
+		named = vendor
+	}
+
+	if entry.Direction == entities.StatementDebit {
+		subject := entities.RuleSubject{
+			Payee:      entry.Counterparty,
+			Comment:    entry.Reference,
+			Amount:     entry.Amount,
+			VendorType: entry.VendorType,
+		}
+		if subject.VendorType == "" && named != nil {
+			subject.VendorType = named.Type()
+		}
+		row.Rule = entities.FirstMatchingRule(s.rules, subject)
+	}
+
+	var rule *entities.ImportRule
+	if s.profile != nil {
+		rule = s.profile.SuggestRule(entry.Counterparty, entry.Reference, entry.VendorType)
+	}
+
+	var ruleVendorID *entities.VendorID
+	switch {
+	case row.Rule != nil && row.Rule.Actions().VendorID != nil:
+		ruleVendorID = row.Rule.Actions().VendorID
+	case rule != nil && rule.VendorID != nil:
+		ruleVendorID = rule.VendorID
+	}
+
+	if ruleVendorID != nil {
+		vendor, err := s.vendor(*ruleVendorID)
+		if err != nil {
+			return nil, err
+		}
+		if vendor == nil {
+			row.Issues = append(row.Issues, fmt.Sprintf("Vendor %d of the matching rule no longer exists", *ruleVendorID))
+		}
+		row.Vendor = vendor
+	} else {
+		row.Vendor = named
+	}
+
+	if entry.Direction == entities.StatementCredit {
+		row.Source = entry.Counterparty
+		if row.Source == "" {
+			row.Issues = append(row.Issues, "No payer name, set a source")
+		}
+		return row, nil
+	}
+
+	category := ""
+	if row.Rule != nil {
+		category = row.Rule.Actions().Category
+		if err := s.addRuleTags(row); err != nil {
+			return nil, err
+		}
+	}
+	if category == "" && rule != nil {
+		category = rule.Category
+	}
+	if category == "" && s.profile != nil {
+		category = s.profile.Settings().DefaultCategory
+	}
+	if category == "" {
+		category = defaultImportCategory
+	}
+
+	exists, err := s.categoryExists(category)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case exists:
+		row.Category = category
+	case category == defaultImportCategory:
+		row.Issues = append(row.Issues, "No category suggested")
+	default:
+		row.Category = category
+		row.Issues = append(row.Issues, fmt.Sprintf("Category %q does not exist", category))
+	}
+
+	if row.Vendor == nil && entry.Counterparty != "" {
+		row.Issues = append(row.Issues, fmt.Sprintf("No vendor named %q", entry.Counterparty))
+	}
+
+	return row, nil
+}
+
+// addRuleTags suggests the tags of the row's categorization rule that still exist
+func (s *suggester) addRuleTags(row *StatementPreviewRow) error {
+	tagIDs := row.Rule.Actions().TagIDs
+	if len(tagIDs) == 0 {
+		return nil
+	}
+	if s.tags == nil {
+		tags, err := s.interactor.tagRepo.GetAll(s.householdID)
+		if err != nil {
+			return err
+		}
+		s.tags = make(map[entities.TagID]bool, len(tags))
+		for _, tag := range tags {
+			s.tags[tag.ID()] = true
+		}
+	}
+	for _, tagID := range tagIDs {
+		if s.tags[tagID] {
+			row.TagIDs = append(row.TagIDs, tagID)
+		}
+	}
+	return nil
+}
+
+func (s *suggester) categoryExists(name string) (bool, error) {
+	if exists, ok := s.categories[name]; ok {
+		return exists, nil
+	}
+	_, err := s.interactor.categoryRepo.FindByName(s.householdID, name)
+	if err != nil && err != entities.ErrCategoryNotFound {
+		return false, err
+	}
+	s.categories[name] = err == nil
+	return err == nil, nil
+}
+
+// vendor returns nil if the vendor was deleted
+func (s *suggester) vendor(id entities.VendorID) (*entities.Vendor, error) {
+	if vendor, ok := s.vendors[id]; ok {
+		return vendor, nil
+	}
+	vendor, err := s.interactor.vendorRepo.FindByID(s.householdID, id)
+	if err != nil && err != entities.ErrVendorNotFound {
+		return nil, err
+	}
+	s.vendors[id] = vendor
+	return vendor, nil
+}
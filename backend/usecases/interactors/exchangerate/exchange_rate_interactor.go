@@ -0,0 +1,88 @@
+package exchangerate
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+	"expenso-backend/usecases/interfaces/repositories"
+)
+
+// pivotCurrency is the base of the imported reference rates; other pairs are crossed through it
+const pivotCurrency = "EUR"
+
+type ExchangeRateInteractor struct {
+	rateRepo repositories.ExchangeRateRepository
+}
+
+func NewExchangeRateInteractor(rateRepo repositories.ExchangeRateRepository) *ExchangeRateInteractor {
+	return &ExchangeRateInteractor{
+		rateRepo: rateRepo,
+	}
+}
+
+func (i *ExchangeRateInteractor) ImportRates(rates []*entities.ExchangeRate) error {
+	if len(rates) == 0 {
+		return errors.New("no exchange rates to import")
+	}
+	return i.rateRepo.SaveAll(rates)
+}
+
+func (i *ExchangeRateInteractor) GetRatesByDate(date time.Time) ([]*entities.ExchangeRate, error) {
+	return i.rateRepo.FindByDate(date)
+}
+
+// Convert expresses amount in targetCurrency using the most recent rate published on or before date
+func (i *ExchangeRateInteractor) Convert(amount valueobjects.Money, date time.Time, targetCurrency string) (valueobjects.Money, error) {
+	target, err := valueobjects.NormalizeCurrency(targetCurrency)
+	if err != nil {
+		return valueobjects.Money{}, err
+	}
+
+	if amount.Currency() == target {
+		return amount, nil
+	}
+
+	rate, err := i.GetRate(amount.Currency(), target, date)
+	if err != nil {
+		return valueobjects.Money{}, err
+	}
+
+	return amount.Convert(rate, target)
+}
+
+// GetRate returns how many units of quote one unit of base buys on the given date.
+// Direct, inverse and pivot-crossed rates are tried in that order.
+func (i *ExchangeRateInteractor) GetRate(base, quote string, date time.Time) (float64, error) {
+	if base == quote {
+		return 1, nil
+	}
+
+	if rate, err := i.rateRepo.FindLatest(base, quote, date); err == nil {
+		return rate.Rate(), nil
+	} else if err != entities.ErrExchangeRateNotFound {
+		return 0, err
+	}
+
+	if rate, err := i.rateRepo.FindLatest(quote, base, date); err == nil {
+		return 1 / rate.Rate(), nil
+	} else if err != entities.ErrExchangeRateNotFound {
+		return 0, err
+	}
+
+	if base != pivotCurrency && quote != pivotCurrency {
+		baseRate, err := i.GetRate(pivotCurrency, base, date)
+		if err != nil {
+			return 0, err
+		}
+		quoteRate, err := i.GetRate(pivotCurrency, quote, date)
+		if err != nil {
+			return 0, err
+		}
+		return quoteRate / baseRate, nil
+	}
+
+	return 0, fmt.Errorf("%w: %s to %s on %s", entities.ErrExchangeRateNotFound, base, quote, date.Format("2006-01-02"))
+}
@@ -2,73 +2,139 @@ package expense
 
 import (
 	"errors"
+	"sort"
+	"strings"
 	"time"
 
 	"expenso-backend/domain/entities"
 	"expenso-backend/domain/valueobjects"
 	"expenso-backend/usecases/interfaces/repositories"
+	"expenso-backend/usecases/interfaces/services"
 )
 
 type CreateExpenseCommand struct {
-	Amount     float64
+	Amount     string // Decimal amount, e.g. "12.30"
+	Currency   string // ISO-4217 code, defaults to EUR if empty
 	Date       time.Time
 	Type       string
 	Category   string
 	Comment    string
 	VendorID   *entities.VendorID
-	PaidByCard *bool            // Optional, defaults to true if nil
-	AddedBy    *string          // Optional, defaults to "he" if nil
-	TagIDs     []entities.TagID // Optional list of tag IDs to assign
+	PaidByCard *bool              // Optional, defaults to true if nil
+	MemberID   *entities.MemberID // Optional household member who recorded the expense
+	TagIDs     []entities.TagID   // Optional list of tag IDs to assign
 }
 
 // CreateExpenseFromCSVCommand allows setting custom created/updated dates for CSV imports
 type CreateExpenseFromCSVCommand struct {
-	Amount     float64
+	Amount     string // Decimal amount, e.g. "12.30"
+	Currency   string // ISO-4217 code, defaults to EUR if empty
 	Date       time.Time
 	Type       string
 	Category   string
 	Comment    string
 	VendorID   *entities.VendorID
-	PaidByCard *bool            // Optional, defaults to true if nil
-	AddedBy    *string          // Optional, defaults to "he" if nil
-	TagIDs     []entities.TagID // Optional list of tag IDs to assign
-	CreatedAt  time.Time        // Custom created date
-	UpdatedAt  time.Time        // Custom updated date
+	PaidByCard *bool              // Optional, defaults to true if nil
+	MemberID   *entities.MemberID // Optional household member who recorded the expense
+	TagIDs     []entities.TagID   // Optional list of tag IDs to assign
+	CreatedAt  time.Time          // Custom created date
+	UpdatedAt  time.Time          // Custom updated date
 }
 
 type UpdateExpenseCommand struct {
 	ID         entities.ExpenseID
-	Amount     *float64
+	Amount     *string
+	Currency   *string
 	Date       *time.Time
 	Category   *string
 	Comment    *string
 	VendorID   *entities.VendorID
 	PaidByCard *bool
-	AddedBy    *string
-	TagIDs     *[]entities.TagID // Optional list of tag IDs to assign (nil means no change, empty slice means clear tags)
+	MemberID   *entities.MemberID // 0 removes the member
+	TagIDs     *[]entities.TagID  // Optional list of tag IDs to assign (nil means no change, empty slice means clear tags)
+}
+
+// ExportFilter narrows an export; nil and empty fields match every expense
+type ExportFilter struct {
+	StartDate  *time.Time
+	EndDate    *time.Time
+	MemberID   *entities.MemberID
+	PaidByCard *bool
+	Category   string // Compared ignoring case
+	TagID      *entities.TagID
+}
+
+func (f ExportFilter) matches(expense *entities.Expense) bool {
+	if f.MemberID != nil && (expense.Member() == nil || expense.Member().ID() != *f.MemberID) {
+		return false
+	}
+	if f.PaidByCard != nil && expense.PaidByCard() != *f.PaidByCard {
+		return false
+	}
+	if f.Category != "" && !strings.EqualFold(expense.Category().String(), f.Category) {
+		return false
+	}
+	if f.TagID != nil && !expense.HasTag(*f.TagID) {
+		return false
+	}
+	return true
 }
 
 type ExpenseInteractor struct {
 	expenseRepo repositories.ExpenseRepository
 	vendorRepo  repositories.VendorRepository
 	tagRepo     repositories.TagRepository
+	memberRepo  repositories.MemberRepository
+	ruleRepo    repositories.CategorizationRuleRepository
+	converter   services.CurrencyConverter
 }
 
-func NewExpenseInteractor(expenseRepo repositories.ExpenseRepository, vendorRepo repositories.VendorRepository, tagRepo repositories.TagRepository) *ExpenseInteractor {
+func NewExpenseInteractor(expenseRepo repositories.ExpenseRepository, vendorRepo repositories.VendorRepository, tagRepo repositories.TagRepository, memberRepo repositories.MemberRepository,
+	ruleRepo repositories.CategorizationRuleRepository, converter services.CurrencyConverter) *ExpenseInteractor {
 	return &ExpenseInteractor{
 		expenseRepo: expenseRepo,
 		vendorRepo:  vendorRepo,
 		tagRepo:     tagRepo,
+		memberRepo:  memberRepo,
+		ruleRepo:    ruleRepo,
+		converter:   converter,
 	}
 }
 
-func (i *ExpenseInteractor) CreateExpense(cmd CreateExpenseCommand) (*entities.Expense, error) {
+// WithRepositories returns a copy of the interactor working on repos, e.g. those of a unit of work
+func (i *ExpenseInteractor) WithRepositories(repos repositories.Repositories) *ExpenseInteractor {
+	return &ExpenseInteractor{
+		expenseRepo: repos.Expenses,
+		vendorRepo:  repos.Vendors,
+		tagRepo:     repos.Tags,
+		memberRepo:  repos.Members,
+		ruleRepo:    repos.CategorizationRules,
+		converter:   i.converter,
+	}
+}
+
+// CreateExpense records an expense. The first matching categorization rule fills in category and vendor
+// if the command leaves them empty, and adds its tags.
+func (i *ExpenseInteractor) CreateExpense(householdID entities.HouseholdID, cmd CreateExpenseCommand) (*entities.Expense, error) {
 	// Create money value object
-	money, err := valueobjects.NewMoney(cmd.Amount, "USD")
+	money, err := valueobjects.ParseMoney(cmd.Amount, cmd.Currency)
 	if err != nil {
 		return nil, err
 	}
 
+	// Load the vendor first, rules match on its name and type
+	var vendor *entities.Vendor
+	if cmd.VendorID != nil {
+		vendor, err = i.vendorRepo.FindByID(householdID, *cmd.VendorID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := i.applyRules(householdID, &cmd, &vendor, money); err != nil {
+		return nil, err
+	}
+
 	// Create category
 	category, err := entities.NewCategory(cmd.Category)
 	if err != nil {
@@ -93,36 +159,31 @@ func (i *ExpenseInteractor) CreateExpense(cmd CreateExpenseCommand) (*entities.E
 	}
 	// If cmd.PaidByCard is nil, the default value (true) from NewExpense is used
 
-	// Handle AddedBy field - if not provided, defaults to "he"
-	if cmd.AddedBy != nil {
-		addedBy := entities.AddedBy(*cmd.AddedBy)
-		if err := expense.UpdateAddedBy(addedBy); err != nil {
+	// Handle member assignment if provided
+	if cmd.MemberID != nil {
+		member, err := i.memberRepo.FindByID(householdID, *cmd.MemberID)
+		if err != nil {
 			return nil, err
 		}
+		expense.AssignMember(member)
 	}
-	// If cmd.AddedBy is nil, the default value ("he") from NewExpense is used
 
-	// Handle vendor assignment if provided
-	if cmd.VendorID != nil {
-		vendor, err := i.vendorRepo.FindByID(*cmd.VendorID)
-		if err != nil {
-			return nil, err
-		}
+	if vendor != nil {
 		expense.AssignVendor(vendor)
 	}
 
 	// Save expense first to get the ID
-	if err := i.expenseRepo.Save(expense); err != nil {
+	if err := i.expenseRepo.Save(householdID, expense); err != nil {
 		return nil, err
 	}
 
 	// Handle tag assignment if provided
 	if len(cmd.TagIDs) > 0 {
-		if err := i.assignTagsToExpense(expense.ID(), cmd.TagIDs); err != nil {
+		if err := i.assignTagsToExpense(householdID, expense.ID(), cmd.TagIDs); err != nil {
 			return nil, err
 		}
 		// Reload expense with tags
-		expense, err = i.expenseRepo.FindByID(expense.ID())
+		expense, err = i.expenseRepo.FindByID(householdID, expense.ID())
 		if err != nil {
 			return nil, err
 		}
@@ -131,9 +192,9 @@ func (i *ExpenseInteractor) CreateExpense(cmd CreateExpenseCommand) (*entities.E
 	return expense, nil
 }
 
-func (i *ExpenseInteractor) CreateExpenseFromCSV(cmd CreateExpenseFromCSVCommand) (*entities.Expense, error) {
+func (i *ExpenseInteractor) CreateExpenseFromCSV(householdID entities.HouseholdID, cmd CreateExpenseFromCSVCommand) (*entities.Expense, error) {
 	// Create money value object
-	money, err := valueobjects.NewMoney(cmd.Amount, "USD")
+	money, err := valueobjects.ParseMoney(cmd.Amount, cmd.Currency)
 	if err != nil {
 		return nil, err
 	}
@@ -161,17 +222,18 @@ func (i *ExpenseInteractor) CreateExpenseFromCSV(cmd CreateExpenseFromCSVCommand
 		expense.UpdatePaidByCard(*cmd.PaidByCard)
 	}
 
-	// Handle AddedBy field - if not provided, defaults to "he"
-	if cmd.AddedBy != nil {
-		addedBy := entities.AddedBy(*cmd.AddedBy)
-		if err := expense.UpdateAddedBy(addedBy); err != nil {
+	// Handle member assignment if provided
+	if cmd.MemberID != nil {
+		member, err := i.memberRepo.FindByID(householdID, *cmd.MemberID)
+		if err != nil {
 			return nil, err
 		}
+		expense.AssignMember(member)
 	}
 
 	// Handle vendor assignment if provided
 	if cmd.VendorID != nil {
-		vendor, err := i.vendorRepo.FindByID(*cmd.VendorID)
+		vendor, err := i.vendorRepo.FindByID(householdID, *cmd.VendorID)
 		if err != nil {
 			return nil, err
 		}
@@ -182,17 +244,17 @@ func (i *ExpenseInteractor) CreateExpenseFromCSV(cmd CreateExpenseFromCSVCommand
 	expense.SetTimestamps(cmd.CreatedAt, cmd.UpdatedAt)
 
 	// Save expense first to get the ID
-	if err := i.expenseRepo.Save(expense); err != nil {
+	if err := i.expenseRepo.Save(householdID, expense); err != nil {
 		return nil, err
 	}
 
 	// Handle tag assignment if provided
 	if len(cmd.TagIDs) > 0 {
-		if err := i.assignTagsToExpense(expense.ID(), cmd.TagIDs); err != nil {
+		if err := i.assignTagsToExpense(householdID, expense.ID(), cmd.TagIDs); err != nil {
 			return nil, err
 		}
 		// Reload expense with tags
-		expense, err = i.expenseRepo.FindByID(expense.ID())
+		expense, err = i.expenseRepo.FindByID(householdID, expense.ID())
 		if err != nil {
 			return nil, err
 		}
@@ -201,77 +263,97 @@ func (i *ExpenseInteractor) CreateExpenseFromCSV(cmd CreateExpenseFromCSVCommand
 	return expense, nil
 }
 
-func (i *ExpenseInteractor) GetExpenses() ([]*entities.Expense, error) {
-	return i.expenseRepo.FindAll()
+func (i *ExpenseInteractor) GetExpenses(householdID entities.HouseholdID) ([]*entities.Expense, error) {
+	return i.expenseRepo.FindAll(householdID)
 }
 
-func (i *ExpenseInteractor) GetExpensesByDateRange(startDate, endDate *time.Time) ([]*entities.Expense, error) {
-	return i.expenseRepo.FindByDateRange(startDate, endDate)
+// ListExpenses returns one page of the expenses matching the filter, filtered and ordered by the database
+func (i *ExpenseInteractor) ListExpenses(householdID entities.HouseholdID, filter repositories.ExpenseFilter, page repositories.PageRequest) (*repositories.ExpensePage, error) {
+	if filter.VendorType != "" && !filter.VendorType.IsValid() {
+		return nil, entities.ErrInvalidVendorType
+	}
+	return i.expenseRepo.FindPage(householdID, filter, page)
 }
 
-func (i *ExpenseInteractor) GetExpensesByCategoryAndDateRange(category string, startDate, endDate *time.Time) ([]*entities.Expense, error) {
-	// Create category entity
-	categoryEntity, err := entities.NewCategory(category)
+// GetExpensesForExport returns the expenses matching filter, oldest first
+func (i *ExpenseInteractor) GetExpensesForExport(householdID entities.HouseholdID, filter ExportFilter) ([]*entities.Expense, error) {
+	var expenses []*entities.Expense
+	var err error
+	if filter.StartDate != nil || filter.EndDate != nil {
+		expenses, err = i.expenseRepo.FindByDateRange(householdID, filter.StartDate, filter.EndDate)
+	} else {
+		expenses, err = i.expenseRepo.FindAll(householdID)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	return i.expenseRepo.FindByCategoryAndDateRange(categoryEntity, startDate, endDate)
-}
+	var filtered []*entities.Expense
+	for _, expense := range expenses {
+		if filter.matches(expense) {
+			filtered = append(filtered, expense)
+		}
+	}
 
-// GetActualExpensesByDateRange returns all expenses (salary entries have been moved to income table)
-func (i *ExpenseInteractor) GetActualExpensesByDateRange(startDate, endDate *time.Time) ([]*entities.Expense, error) {
-	// Since salary entries have been migrated to the income table, all remaining expenses are actual expenses
-	return i.expenseRepo.FindByDateRange(startDate, endDate)
+	sort.SliceStable(filtered, func(a, b int) bool {
+		if !filtered[a].Date().Equal(filtered[b].Date()) {
+			return filtered[a].Date().Before(filtered[b].Date())
+		}
+		return filtered[a].ID() < filtered[b].ID()
+	})
+
+	return filtered, nil
 }
 
-// GetEarningsByDateRange returns empty array (earnings have been moved to income table)
-// This method is kept for backward compatibility but should use the income API instead
-func (i *ExpenseInteractor) GetEarningsByDateRange(startDate, endDate *time.Time) ([]*entities.Expense, error) {
-	// Return empty array since all salary entries have been migrated to the income table
-	return []*entities.Expense{}, nil
+func (i *ExpenseInteractor) GetExpensesByDateRange(householdID entities.HouseholdID, startDate, endDate *time.Time) ([]*entities.Expense, error) {
+	return i.expenseRepo.FindByDateRange(householdID, startDate, endDate)
 }
 
-// GetBalanceSummaryByDateRange calculates expenses only (earnings have been moved to income table)
-// This method is kept for backward compatibility but earnings will be 0
-func (i *ExpenseInteractor) GetBalanceSummaryByDateRange(startDate, endDate *time.Time) (map[string]interface{}, error) {
-	expenses, err := i.GetActualExpensesByDateRange(startDate, endDate)
+func (i *ExpenseInteractor) GetExpensesByCategoryAndDateRange(householdID entities.HouseholdID, category string, startDate, endDate *time.Time) ([]*entities.Expense, error) {
+	// Create category entity
+	categoryEntity, err := entities.NewCategory(category)
 	if err != nil {
 		return nil, err
 	}
 
-	var totalExpenses float64
-	for _, expense := range expenses {
-		totalExpenses += expense.Amount().Amount()
-	}
+	return i.expenseRepo.FindByCategoryAndDateRange(householdID, categoryEntity, startDate, endDate)
+}
 
-	// Earnings are now 0 since they've been moved to the income table
-	totalEarnings := 0.0
-	balance := totalEarnings - totalExpenses
+// GetActualExpensesByDateRange returns all expenses (salary entries have been moved to income table)
+func (i *ExpenseInteractor) GetActualExpensesByDateRange(householdID entities.HouseholdID, startDate, endDate *time.Time) ([]*entities.Expense, error) {
+	// Since salary entries have been migrated to the income table, all remaining expenses are actual expenses
+	return i.expenseRepo.FindByDateRange(householdID, startDate, endDate)
+}
 
-	return map[string]interface{}{
-		"total_earnings": totalEarnings,
-		"total_expenses": totalExpenses,
-		"balance":        balance,
-		"earnings_count": 0, // Earnings are now in the income table
-		"expenses_count": len(expenses),
-	}, nil
+// ConvertAmount expresses the expense amount in currency at the rate valid on the expense date
+func (i *ExpenseInteractor) ConvertAmount(expense *entities.Expense, currency string) (valueobjects.Money, error) {
+	return i.converter.Convert(expense.Amount(), expense.Date(), currency)
 }
 
-func (i *ExpenseInteractor) GetExpense(id entities.ExpenseID) (*entities.Expense, error) {
-	return i.expenseRepo.FindByID(id)
+func (i *ExpenseInteractor) GetExpense(householdID entities.HouseholdID, id entities.ExpenseID) (*entities.Expense, error) {
+	return i.expenseRepo.FindByID(householdID, id)
 }
 
-func (i *ExpenseInteractor) UpdateExpense(cmd UpdateExpenseCommand) (*entities.Expense, error) {
+func (i *ExpenseInteractor) UpdateExpense(householdID entities.HouseholdID, cmd UpdateExpenseCommand) (*entities.Expense, error) {
 	// Find existing expense
-	expense, err := i.expenseRepo.FindByID(cmd.ID)
+	expense, err := i.expenseRepo.FindByID(householdID, cmd.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Update amount if provided
-	if cmd.Amount != nil {
-		money, err := valueobjects.NewMoney(*cmd.Amount, "USD")
+	// Update amount and/or currency if provided
+	if cmd.Amount != nil || cmd.Currency != nil {
+		currency := expense.Amount().Currency()
+		if cmd.Currency != nil {
+			currency = *cmd.Currency
+		}
+		// Without a new amount the old one is kept as it is, which fails rather than dropping digits the new currency lacks
+		var money valueobjects.Money
+		if cmd.Amount != nil {
+			money, err = valueobjects.ParseMoney(*cmd.Amount, currency)
+		} else {
+			money, err = expense.Amount().WithCurrency(currency)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -303,11 +385,16 @@ func (i *ExpenseInteractor) UpdateExpense(cmd UpdateExpenseCommand) (*entities.E
 		expense.UpdateComment(*cmd.Comment)
 	}
 
-	// Update addedBy if provided
-	if cmd.AddedBy != nil {
-		addedBy := entities.AddedBy(*cmd.AddedBy)
-		if err := expense.UpdateAddedBy(addedBy); err != nil {
-			return nil, err
+	// Update member if provided
+	if cmd.MemberID != nil {
+		if *cmd.MemberID == 0 {
+			expense.RemoveMember()
+		} else {
+			member, err := i.memberRepo.FindByID(householdID, *cmd.MemberID)
+			if err != nil {
+				return nil, err
+			}
+			expense.AssignMember(member)
 		}
 	}
 
@@ -317,7 +404,7 @@ func (i *ExpenseInteractor) UpdateExpense(cmd UpdateExpenseCommand) (*entities.E
 			// Remove vendor
 			expense.RemoveVendor()
 		} else {
-			vendor, err := i.vendorRepo.FindByID(*cmd.VendorID)
+			vendor, err := i.vendorRepo.FindByID(householdID, *cmd.VendorID)
 			if err != nil {
 				return nil, err
 			}
@@ -334,7 +421,7 @@ func (i *ExpenseInteractor) UpdateExpense(cmd UpdateExpenseCommand) (*entities.E
 
 		// Assign new tags if any
 		if len(*cmd.TagIDs) > 0 {
-			if err := i.assignTagsToExpense(expense.ID(), *cmd.TagIDs); err != nil {
+			if err := i.assignTagsToExpense(householdID, expense.ID(), *cmd.TagIDs); err != nil {
 				return nil, err
 			}
 		}
@@ -344,13 +431,13 @@ func (i *ExpenseInteractor) UpdateExpense(cmd UpdateExpenseCommand) (*entities.E
 	}
 
 	// Save updated expense
-	if err := i.expenseRepo.Update(expense); err != nil {
+	if err := i.expenseRepo.Update(householdID, expense); err != nil {
 		return nil, err
 	}
 
 	// If tags were updated, reload the expense to get the updated tags
 	if cmd.TagIDs != nil {
-		expense, err := i.expenseRepo.FindByID(expense.ID())
+		expense, err := i.expenseRepo.FindByID(householdID, expense.ID())
 		if err != nil {
 			return nil, err
 		}
@@ -360,22 +447,77 @@ func (i *ExpenseInteractor) UpdateExpense(cmd UpdateExpenseCommand) (*entities.E
 	return expense, nil
 }
 
-func (i *ExpenseInteractor) DeleteExpense(id entities.ExpenseID) error {
+func (i *ExpenseInteractor) DeleteExpense(householdID entities.HouseholdID, id entities.ExpenseID) error {
 	// Check if expense exists
-	_, err := i.expenseRepo.FindByID(id)
+	_, err := i.expenseRepo.FindByID(householdID, id)
 	if err != nil {
 		return err
 	}
 
 	// Delete expense (tags will be deleted via cascade)
-	return i.expenseRepo.Delete(id)
+	return i.expenseRepo.Delete(householdID, id)
+}
+
+// applyRules fills in category and vendor of the command from the first matching categorization rule and adds its tags.
+// Vendors and tags deleted since the rule was written are left out.
+func (i *ExpenseInteractor) applyRules(householdID entities.HouseholdID, cmd *CreateExpenseCommand, vendor **entities.Vendor, amount valueobjects.Money) error {
+	rules, err := i.ruleRepo.FindAll(householdID)
+	if err != nil || len(rules) == 0 {
+		return err
+	}
+
+	subject := entities.RuleSubject{Comment: cmd.Comment, Amount: amount}
+	if *vendor != nil {
+		subject.Payee = (*vendor).Name()
+		subject.VendorType = (*vendor).Type()
+	}
+	rule := entities.FirstMatchingRule(rules, subject)
+	if rule == nil {
+		return nil
+	}
+	actions := rule.Actions()
+
+	if strings.TrimSpace(cmd.Category) == "" {
+		cmd.Category = actions.Category
+	}
+
+	if *vendor == nil && actions.VendorID != nil {
+		ruleVendor, err := i.vendorRepo.FindByID(householdID, *actions.VendorID)
+		if err != nil && err != entities.ErrVendorNotFound {
+			return err
+		}
+		*vendor = ruleVendor
+	}
+
+	if len(actions.TagIDs) > 0 {
+		tags, err := i.tagRepo.GetAll(householdID)
+		if err != nil {
+			return err
+		}
+		existing := make(map[entities.TagID]bool, len(tags))
+		for _, tag := range tags {
+			existing[tag.ID()] = true
+		}
+		assigned := make(map[entities.TagID]bool, len(cmd.TagIDs))
+		for _, tagID := range cmd.TagIDs {
+			assigned[tagID] = true
+		}
+		for _, tagID := range actions.TagIDs {
+			if existing[tagID] && !assigned[tagID] {
+				cmd.TagIDs = append(cmd.TagIDs, tagID)
+				assigned[tagID] = true
+			}
+		}
+	}
+
+	return nil
 }
 
 // assignTagsToExpense is a helper method to assign multiple tags to an expense
-func (i *ExpenseInteractor) assignTagsToExpense(expenseID entities.ExpenseID, tagIDs []entities.TagID) error {
+func (i *ExpenseInteractor) assignTagsToExpense(householdID entities.HouseholdID, expenseID entities.ExpenseID, tagIDs []entities.TagID) error {
 	for _, tagID := range tagIDs {
 		// Verify tag exists
-		tag, err := i.tagRepo.GetByID(tagID)
+		tag, err := i.tagRepo.GetByID(householdID, tagID)
 		if err != nil {
 			return err
 		}
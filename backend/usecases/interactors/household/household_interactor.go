@@ -0,0 +1,153 @@
+package household
+
+import (
+	"errors"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/usecases/interfaces/repositories"
+)
+
+type CreateHouseholdCommand struct {
+	Name string
+}
+
+type AddUserCommand struct {
+	Email string
+	Role  string // defaults to member if empty
+}
+
+type HouseholdInteractor struct {
+	householdRepo repositories.HouseholdRepository
+	userRepo      repositories.UserRepository
+}
+
+func NewHouseholdInteractor(householdRepo repositories.HouseholdRepository, userRepo repositories.UserRepository) *HouseholdInteractor {
+	return &HouseholdInteractor{
+		householdRepo: householdRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// CreateHousehold creates a household owned by the user, seeded with the default categories and tags
+func (i *HouseholdInteractor) CreateHousehold(userID entities.UserID, cmd CreateHouseholdCommand) (*entities.Household, error) {
+	household, err := entities.NewHousehold(cmd.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.householdRepo.Create(household, userID); err != nil {
+		return nil, err
+	}
+
+	return household, nil
+}
+
+func (i *HouseholdInteractor) GetHouseholds(userID entities.UserID) ([]*entities.HouseholdMembership, error) {
+	return i.householdRepo.FindByUser(userID)
+}
+
+// GetUsers lists who has access to the household; any of its users may ask
+func (i *HouseholdInteractor) GetUsers(householdID entities.HouseholdID, requester entities.UserID) ([]*entities.HouseholdMembership, error) {
+	if _, err := i.householdRepo.FindRole(householdID, requester); err != nil {
+		return nil, err
+	}
+	return i.householdRepo.FindUsers(householdID)
+}
+
+// AddUser grants an existing account access to the household, or changes its role if it already has access
+func (i *HouseholdInteractor) AddUser(householdID entities.HouseholdID, requester entities.UserID, cmd AddUserCommand) (*entities.HouseholdMembership, error) {
+	if err := i.ensureOwner(householdID, requester); err != nil {
+		return nil, err
+	}
+
+	role := entities.HouseholdRoleMember
+	if cmd.Role != "" {
+		role = entities.HouseholdRole(cmd.Role)
+	}
+	if !role.IsValid() {
+		return nil, errors.New("role must be owner or member")
+	}
+
+	email, err := entities.NormalizeEmail(cmd.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := i.userRepo.FindByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	// Demoting an owner must not leave the household without one
+	if role != entities.HouseholdRoleOwner {
+		if err := i.ensureOtherOwner(householdID, user.ID()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := i.householdRepo.AddUser(householdID, user.ID(), role); err != nil {
+		return nil, err
+	}
+
+	memberships, err := i.householdRepo.FindUsers(householdID)
+	if err != nil {
+		return nil, err
+	}
+	for _, membership := range memberships {
+		if membership.User().ID() == user.ID() {
+			return membership, nil
+		}
+	}
+	return nil, entities.ErrNotHouseholdMember
+}
+
+// RemoveUser revokes access to the household. Owners can remove anyone; everyone can remove themselves.
+func (i *HouseholdInteractor) RemoveUser(householdID entities.HouseholdID, requester, userID entities.UserID) error {
+	if requester != userID {
+		if err := i.ensureOwner(householdID, requester); err != nil {
+			return err
+		}
+	}
+
+	if err := i.ensureOtherOwner(householdID, userID); err != nil {
+		return err
+	}
+
+	return i.householdRepo.RemoveUser(householdID, userID)
+}
+
+func (i *HouseholdInteractor) ensureOwner(householdID entities.HouseholdID, userID entities.UserID) error {
+	role, err := i.householdRepo.FindRole(householdID, userID)
+	if err != nil {
+		return err
+	}
+	if role != entities.HouseholdRoleOwner {
+		return entities.ErrNotHouseholdOwner
+	}
+	return nil
+}
+
+// ensureOtherOwner fails if userID is the only owner of the household
+func (i *HouseholdInteractor) ensureOtherOwner(householdID entities.HouseholdID, userID entities.UserID) error {
+	memberships, err := i.householdRepo.FindUsers(householdID)
+	if err != nil {
+		return err
+	}
+
+	isOwner := false
+	owners := 0
+	for _, membership := range memberships {
+		if membership.Role() != entities.HouseholdRoleOwner {
+			continue
+		}
+		owners++
+		if membership.User().ID() == userID {
+			isOwner = true
+		}
+	}
+
+	if isOwner && owners == 1 {
+		return entities.ErrLastHouseholdOwner
+	}
+	return nil
+}
@@ -6,59 +6,78 @@ import (
 	"expenso-backend/domain/entities"
 	"expenso-backend/domain/valueobjects"
 	"expenso-backend/usecases/interfaces/repositories"
+	"expenso-backend/usecases/interfaces/services"
 )
 
 type CreateIncomeCommand struct {
-	Amount   float64
+	Amount   string // Decimal amount, e.g. "12.30"
+	Currency string // ISO-4217 code, defaults to EUR if empty
 	Date     time.Time
 	Source   string
 	Comment  string
 	VendorID *entities.VendorID
-	AddedBy  *string          // Optional, defaults to "he" if nil
-	TagIDs   []entities.TagID // Optional list of tag IDs to assign
+	MemberID *entities.MemberID // Optional household member who recorded the income
+	TagIDs   []entities.TagID   // Optional list of tag IDs to assign
 }
 
 // CreateIncomeFromCSVCommand allows setting custom created/updated dates for CSV imports
 type CreateIncomeFromCSVCommand struct {
-	Amount    float64
+	Amount    string // Decimal amount, e.g. "12.30"
+	Currency  string // ISO-4217 code, defaults to EUR if empty
 	Date      time.Time
 	Source    string
 	Comment   string
 	VendorID  *entities.VendorID
-	AddedBy   *string          // Optional, defaults to "he" if nil
-	TagIDs    []entities.TagID // Optional list of tag IDs to assign
-	CreatedAt time.Time        // Custom created date
-	UpdatedAt time.Time        // Custom updated date
+	MemberID  *entities.MemberID // Optional household member who recorded the income
+	TagIDs    []entities.TagID   // Optional list of tag IDs to assign
+	CreatedAt time.Time          // Custom created date
+	UpdatedAt time.Time          // Custom updated date
 }
 
 type UpdateIncomeCommand struct {
 	ID       entities.IncomeID
-	Amount   *float64
+	Amount   *string
+	Currency *string
 	Date     *time.Time
 	Source   *string
 	Comment  *string
 	VendorID *entities.VendorID
-	AddedBy  *string
-	TagIDs   *[]entities.TagID // Optional list of tag IDs to assign (nil means no change, empty slice means clear tags)
+	MemberID *entities.MemberID // 0 removes the member
+	TagIDs   *[]entities.TagID  // Optional list of tag IDs to assign (nil means no change, empty slice means clear tags)
 }
 
 type IncomeInteractor struct {
 	incomeRepo repositories.IncomeRepository
 	vendorRepo repositories.VendorRepository
 	tagRepo    repositories.TagRepository
+	memberRepo repositories.MemberRepository
+	converter  services.CurrencyConverter
 }
 
-func NewIncomeInteractor(incomeRepo repositories.IncomeRepository, vendorRepo repositories.VendorRepository, tagRepo repositories.TagRepository) *IncomeInteractor {
+func NewIncomeInteractor(incomeRepo repositories.IncomeRepository, vendorRepo repositories.VendorRepository, tagRepo repositories.TagRepository, memberRepo repositories.MemberRepository, converter services.CurrencyConverter) *IncomeInteractor {
 	return &IncomeInteractor{
 		incomeRepo: incomeRepo,
 		vendorRepo: vendorRepo,
 		tagRepo:    tagRepo,
+		memberRepo: memberRepo,
+		converter:  converter,
 	}
 }
 
-func (i *IncomeInteractor) CreateIncome(cmd CreateIncomeCommand) (*entities.Income, error) {
+// WithRepositories returns a copy of the interactor working on repos, e.g. those of a unit of work
+func (i *IncomeInteractor) WithRepositories(repos repositories.Repositories) *IncomeInteractor {
+	return &IncomeInteractor{
+		incomeRepo: repos.Incomes,
+		vendorRepo: repos.Vendors,
+		tagRepo:    repos.Tags,
+		memberRepo: repos.Members,
+		converter:  i.converter,
+	}
+}
+
+func (i *IncomeInteractor) CreateIncome(householdID entities.HouseholdID, cmd CreateIncomeCommand) (*entities.Income, error) {
 	// Create money value object
-	money, err := valueobjects.NewMoney(cmd.Amount, "USD")
+	money, err := valueobjects.ParseMoney(cmd.Amount, cmd.Currency)
 	if err != nil {
 		return nil, err
 	}
@@ -69,17 +88,18 @@ func (i *IncomeInteractor) CreateIncome(cmd CreateIncomeCommand) (*entities.Inco
 		return nil, err
 	}
 
-	// Set addedBy if provided, otherwise use default
-	if cmd.AddedBy != nil {
-		addedBy := entities.AddedBy(*cmd.AddedBy)
-		if err := income.UpdateAddedBy(addedBy); err != nil {
+	// Assign member if provided
+	if cmd.MemberID != nil {
+		member, err := i.memberRepo.FindByID(householdID, *cmd.MemberID)
+		if err != nil {
 			return nil, err
 		}
+		income.AssignMember(member)
 	}
 
 	// Assign vendor if provided
 	if cmd.VendorID != nil {
-		vendor, err := i.vendorRepo.FindByID(*cmd.VendorID)
+		vendor, err := i.vendorRepo.FindByID(householdID, *cmd.VendorID)
 		if err != nil {
 			return nil, err
 		}
@@ -90,14 +110,14 @@ func (i *IncomeInteractor) CreateIncome(cmd CreateIncomeCommand) (*entities.Inco
 	}
 
 	// Save the income first to get an ID
-	if err := i.incomeRepo.Save(income); err != nil {
+	if err := i.incomeRepo.Save(householdID, income); err != nil {
 		return nil, err
 	}
 
 	// Assign tags if provided
 	if len(cmd.TagIDs) > 0 {
 		for _, tagID := range cmd.TagIDs {
-			tag, err := i.tagRepo.GetByID(tagID)
+			tag, err := i.tagRepo.GetByID(householdID, tagID)
 			if err != nil || tag == nil {
 				// Skip invalid tags but don't fail the entire operation
 				continue
@@ -117,9 +137,9 @@ func (i *IncomeInteractor) CreateIncome(cmd CreateIncomeCommand) (*entities.Inco
 	return income, nil
 }
 
-func (i *IncomeInteractor) CreateIncomeFromCSV(cmd CreateIncomeFromCSVCommand) (*entities.Income, error) {
+func (i *IncomeInteractor) CreateIncomeFromCSV(householdID entities.HouseholdID, cmd CreateIncomeFromCSVCommand) (*entities.Income, error) {
 	// Create money value object
-	money, err := valueobjects.NewMoney(cmd.Amount, "USD")
+	money, err := valueobjects.ParseMoney(cmd.Amount, cmd.Currency)
 	if err != nil {
 		return nil, err
 	}
@@ -133,17 +153,18 @@ func (i *IncomeInteractor) CreateIncomeFromCSV(cmd CreateIncomeFromCSVCommand) (
 	// Set custom timestamps for CSV imports
 	income.SetTimestamps(cmd.CreatedAt, cmd.UpdatedAt)
 
-	// Set addedBy if provided, otherwise use default
-	if cmd.AddedBy != nil {
-		addedBy := entities.AddedBy(*cmd.AddedBy)
-		if err := income.UpdateAddedBy(addedBy); err != nil {
+	// Assign member if provided
+	if cmd.MemberID != nil {
+		member, err := i.memberRepo.FindByID(householdID, *cmd.MemberID)
+		if err != nil {
 			return nil, err
 		}
+		income.AssignMember(member)
 	}
 
 	// Assign vendor if provided
 	if cmd.VendorID != nil {
-		vendor, err := i.vendorRepo.FindByID(*cmd.VendorID)
+		vendor, err := i.vendorRepo.FindByID(householdID, *cmd.VendorID)
 		if err != nil {
 			return nil, err
 		}
@@ -154,14 +175,14 @@ func (i *IncomeInteractor) CreateIncomeFromCSV(cmd CreateIncomeFromCSVCommand) (
 	}
 
 	// Save the income first to get an ID
-	if err := i.incomeRepo.Save(income); err != nil {
+	if err := i.incomeRepo.Save(householdID, income); err != nil {
 		return nil, err
 	}
 
 	// Assign tags if provided
 	if len(cmd.TagIDs) > 0 {
 		for _, tagID := range cmd.TagIDs {
-			tag, err := i.tagRepo.GetByID(tagID)
+			tag, err := i.tagRepo.GetByID(householdID, tagID)
 			if err != nil || tag == nil {
 				// Skip invalid tags but don't fail the entire operation
 				continue
@@ -181,8 +202,8 @@ func (i *IncomeInteractor) CreateIncomeFromCSV(cmd CreateIncomeFromCSVCommand) (
 	return income, nil
 }
 
-func (i *IncomeInteractor) GetIncomeByID(id entities.IncomeID) (*entities.Income, error) {
-	income, err := i.incomeRepo.FindByID(id)
+func (i *IncomeInteractor) GetIncomeByID(householdID entities.HouseholdID, id entities.IncomeID) (*entities.Income, error) {
+	income, err := i.incomeRepo.FindByID(householdID, id)
 	if err != nil {
 		return nil, err
 	}
@@ -192,17 +213,30 @@ func (i *IncomeInteractor) GetIncomeByID(id entities.IncomeID) (*entities.Income
 	return income, nil
 }
 
-func (i *IncomeInteractor) GetAllIncomes() ([]*entities.Income, error) {
-	return i.incomeRepo.FindAll()
+// ConvertAmount expresses the income amount in currency at the rate valid on the income date
+func (i *IncomeInteractor) ConvertAmount(income *entities.Income, currency string) (valueobjects.Money, error) {
+	return i.converter.Convert(income.Amount(), income.Date(), currency)
+}
+
+func (i *IncomeInteractor) GetAllIncomes(householdID entities.HouseholdID) ([]*entities.Income, error) {
+	return i.incomeRepo.FindAll(householdID)
+}
+
+// ListIncomes returns one page of the incomes matching the filter, filtered and ordered by the database
+func (i *IncomeInteractor) ListIncomes(householdID entities.HouseholdID, filter repositories.IncomeFilter, page repositories.PageRequest) (*repositories.IncomePage, error) {
+	if filter.VendorType != "" && !filter.VendorType.IsValid() {
+		return nil, entities.ErrInvalidVendorType
+	}
+	return i.incomeRepo.FindPage(householdID, filter, page)
 }
 
-func (i *IncomeInteractor) GetIncomesByDateRange(startDate, endDate *time.Time) ([]*entities.Income, error) {
-	return i.incomeRepo.FindByDateRange(startDate, endDate)
+func (i *IncomeInteractor) GetIncomesByDateRange(householdID entities.HouseholdID, startDate, endDate *time.Time) ([]*entities.Income, error) {
+	return i.incomeRepo.FindByDateRange(householdID, startDate, endDate)
 }
 
-func (i *IncomeInteractor) UpdateIncome(cmd UpdateIncomeCommand) (*entities.Income, error) {
+func (i *IncomeInteractor) UpdateIncome(householdID entities.HouseholdID, cmd UpdateIncomeCommand) (*entities.Income, error) {
 	// Get existing income
-	income, err := i.incomeRepo.FindByID(cmd.ID)
+	income, err := i.incomeRepo.FindByID(householdID, cmd.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -211,8 +245,18 @@ func (i *IncomeInteractor) UpdateIncome(cmd UpdateIncomeCommand) (*entities.Inco
 	}
 
 	// Update fields if provided
-	if cmd.Amount != nil {
-		money, err := valueobjects.NewMoney(*cmd.Amount, "USD")
+	if cmd.Amount != nil || cmd.Currency != nil {
+		currency := income.Amount().Currency()
+		if cmd.Currency != nil {
+			currency = *cmd.Currency
+		}
+		// Without a new amount the old one is kept as it is, which fails rather than dropping digits the new currency lacks
+		var money valueobjects.Money
+		if cmd.Amount != nil {
+			money, err = valueobjects.ParseMoney(*cmd.Amount, currency)
+		} else {
+			money, err = income.Amount().WithCurrency(currency)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -237,16 +281,22 @@ func (i *IncomeInteractor) UpdateIncome(cmd UpdateIncomeCommand) (*entities.Inco
 		income.UpdateComment(*cmd.Comment)
 	}
 
-	if cmd.AddedBy != nil {
-		addedBy := entities.AddedBy(*cmd.AddedBy)
-		if err := income.UpdateAddedBy(addedBy); err != nil {
-			return nil, err
+	// Update member if provided
+	if cmd.MemberID != nil {
+		if *cmd.MemberID == 0 {
+			income.RemoveMember()
+		} else {
+			member, err := i.memberRepo.FindByID(householdID, *cmd.MemberID)
+			if err != nil {
+				return nil, err
+			}
+			income.AssignMember(member)
 		}
 	}
 
 	// Update vendor if provided
 	if cmd.VendorID != nil {
-		vendor, err := i.vendorRepo.FindByID(*cmd.VendorID)
+		vendor, err := i.vendorRepo.FindByID(householdID, *cmd.VendorID)
 		if err != nil {
 			return nil, err
 		}
@@ -266,7 +316,7 @@ func (i *IncomeInteractor) UpdateIncome(cmd UpdateIncomeCommand) (*entities.Inco
 
 		// Add new tags
 		for _, tagID := range *cmd.TagIDs {
-			tag, err := i.tagRepo.GetByID(tagID)
+			tag, err := i.tagRepo.GetByID(householdID, tagID)
 			if err != nil || tag == nil {
 				continue // Skip invalid tags
 			}
@@ -281,16 +331,16 @@ func (i *IncomeInteractor) UpdateIncome(cmd UpdateIncomeCommand) (*entities.Inco
 	}
 
 	// Save the updated income
-	if err := i.incomeRepo.Update(income); err != nil {
+	if err := i.incomeRepo.Update(householdID, income); err != nil {
 		return nil, err
 	}
 
 	return income, nil
 }
 
-func (i *IncomeInteractor) DeleteIncome(id entities.IncomeID) error {
+func (i *IncomeInteractor) DeleteIncome(householdID entities.HouseholdID, id entities.IncomeID) error {
 	// Check if income exists
-	income, err := i.incomeRepo.FindByID(id)
+	income, err := i.incomeRepo.FindByID(householdID, id)
 	if err != nil {
 		return err
 	}
@@ -304,37 +354,55 @@ func (i *IncomeInteractor) DeleteIncome(id entities.IncomeID) error {
 	}
 
 	// Delete the income
-	return i.incomeRepo.Delete(id)
+	return i.incomeRepo.Delete(householdID, id)
 }
 
-func (i *IncomeInteractor) GetIncomesBySource(source string) ([]*entities.Income, error) {
-	return i.incomeRepo.FindBySource(source)
+func (i *IncomeInteractor) GetIncomesBySource(householdID entities.HouseholdID, source string) ([]*entities.Income, error) {
+	return i.incomeRepo.FindBySource(householdID, source)
 }
 
-func (i *IncomeInteractor) GetIncomesByVendor(vendorID entities.VendorID) ([]*entities.Income, error) {
-	return i.incomeRepo.FindByVendor(vendorID)
+func (i *IncomeInteractor) GetIncomesByVendor(householdID entities.HouseholdID, vendorID entities.VendorID) ([]*entities.Income, error) {
+	return i.incomeRepo.FindByVendor(householdID, vendorID)
 }
 
 // Business logic methods
-func (i *IncomeInteractor) GetTotalIncomeByDateRange(startDate, endDate *time.Time) (float64, error) {
-	incomes, err := i.incomeRepo.FindByDateRange(startDate, endDate)
+
+// GetTotalIncomeByDateRange sums incomes converted into reportingCurrency at the rate valid on each income date
+func (i *IncomeInteractor) GetTotalIncomeByDateRange(householdID entities.HouseholdID, startDate, endDate *time.Time, reportingCurrency string) (valueobjects.Money, error) {
+	currency, err := valueobjects.NormalizeCurrency(reportingCurrency)
 	if err != nil {
-		return 0, err
+		return valueobjects.Money{}, err
+	}
+
+	incomes, err := i.incomeRepo.FindByDateRange(householdID, startDate, endDate)
+	if err != nil {
+		return valueobjects.Money{}, err
+	}
+
+	total, err := valueobjects.ZeroMoney(currency)
+	if err != nil {
+		return valueobjects.Money{}, err
 	}
 
-	var total float64
 	for _, income := range incomes {
-		total += income.Amount().Amount()
+		converted, err := i.converter.Convert(income.Amount(), income.Date(), currency)
+		if err != nil {
+			return valueobjects.Money{}, err
+		}
+		total, err = total.Add(converted)
+		if err != nil {
+			return valueobjects.Money{}, err
+		}
 	}
 
 	return total, nil
 }
 
-func (i *IncomeInteractor) GetIncomeCountByDateRange(startDate, endDate *time.Time) (int, error) {
-	incomes, err := i.incomeRepo.FindByDateRange(startDate, endDate)
+func (i *IncomeInteractor) GetIncomeCountByDateRange(householdID entities.HouseholdID, startDate, endDate *time.Time) (int, error) {
+	incomes, err := i.incomeRepo.FindByDateRange(householdID, startDate, endDate)
 	if err != nil {
 		return 0, err
 	}
 
 	return len(incomes), nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,257 @@
+package insights
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+	"expenso-backend/usecases/interfaces/repositories"
+	"expenso-backend/usecases/interfaces/services"
+)
+
+const (
+	// DefaultNewVendorThreshold is the decimal amount in the reporting currency above which a first expense at a vendor is flagged
+	DefaultNewVendorThreshold = "100"
+
+	anomalyWindowDays   = 365 // expenses are compared with the ones of the year before them
+	anomalyMinSamples   = 5   // fewer earlier expenses at a vendor say too little about its usual amounts
+	anomalyFenceIQRs    = 3.0 // an amount is unusual beyond the third quartile plus this many interquartile ranges
+	anomalyMinSpread    = 0.1 // share of the median the interquartile range is taken as at least, so fixed prices allow small changes
+	anomalyBurstDays    = 7
+	anomalyMinBurst     = 3   // expenses at one vendor within anomalyBurstDays before a burst is flagged at all
+	anomalyBurstFactor  = 3.0 // how many times the usual rate a burst has to reach
+	anomalyMaxRangeDays = 366
+)
+
+var ErrInvalidAnomalyRange = errors.New("the date range must run forward and span at most a year")
+
+// AnomalyKind says why an expense was flagged
+type AnomalyKind string
+
+const (
+	AnomalyUnusualAmount    AnomalyKind = "unusual_amount"
+	AnomalyUnusualFrequency AnomalyKind = "unusual_frequency"
+	AnomalyNewVendor        AnomalyKind = "new_vendor"
+)
+
+// AnomalyQuery selects the expenses to check; amounts are compared in ReportingCurrency
+type AnomalyQuery struct {
+	StartDate          time.Time
+	EndDate            time.Time
+	ReportingCurrency  string
+	NewVendorThreshold valueobjects.Money // in ReportingCurrency
+}
+
+// Anomaly is a flagged expense with one reason per check it failed
+type Anomaly struct {
+	Expense *entities.Expense
+	Amount  valueobjects.Money // the expense amount in the reporting currency
+	Kinds   []AnomalyKind
+	Reasons []string
+}
+
+type AnomalyInteractor struct {
+	expenseRepo repositories.ExpenseRepository
+	converter   services.CurrencyConverter
+}
+
+func NewAnomalyInteractor(expenseRepo repositories.ExpenseRepository, converter services.CurrencyConverter) *AnomalyInteractor {
+	return &AnomalyInteractor{
+		expenseRepo: expenseRepo,
+		converter:   converter,
+	}
+}
+
+// DetectAnomalies flags the expenses of the date range that stand out against the year before each of them:
+// amounts far above the usual ones at their vendor (or vendor type, while the vendor has too little history),
+// unusually many expenses at one vendor within a week, and first expenses at a vendor above a threshold.
+// Newest expenses come first.
+func (i *AnomalyInteractor) DetectAnomalies(householdID entities.HouseholdID, query AnomalyQuery) ([]*Anomaly, error) {
+	start, end := repositories.PeriodDay.Start(query.StartDate), repositories.PeriodDay.Start(query.EndDate)
+	if end.Before(start) || end.Sub(start).Hours()/24 >= anomalyMaxRangeDays {
+		return nil, ErrInvalidAnomalyRange
+	}
+	currency, err := valueobjects.NormalizeCurrency(query.ReportingCurrency)
+	if err != nil {
+		return nil, err
+	}
+	threshold := query.NewVendorThreshold
+	if threshold.Currency() != currency {
+		return nil, fmt.Errorf("%w: the threshold is in %s, not in %s", valueobjects.ErrCurrencyMismatch, threshold.Currency(), currency)
+	}
+
+	windowStart := start.AddDate(0, 0, -anomalyWindowDays)
+	expenses, err := i.expenseRepo.FindByDateRange(householdID, &windowStart, &end)
+	if err != nil {
+		return nil, err
+	}
+
+	// Vendors with expenses before the window are not new, however long ago they were used
+	beforeWindow := windowStart.AddDate(0, 0, -1)
+	rows, err := i.expenseRepo.Aggregate(householdID, repositories.AggregateQuery{
+		Period:            repositories.PeriodYear,
+		Dimension:         repositories.DimensionVendor,
+		EndDate:           &beforeWindow,
+		ReportingCurrency: currency,
+	})
+	if err != nil {
+		return nil, err
+	}
+	knownVendors := make(map[string]bool)
+	for _, row := range rows {
+		knownVendors[row.Key] = true
+	}
+
+	history := make([]*observation, 0, len(expenses))
+	for _, expense := range expenses {
+		if expense.Vendor() == nil {
+			continue
+		}
+		amount, err := i.converter.Convert(expense.Amount(), expense.Date(), currency)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, &observation{expense: expense, date: repositories.PeriodDay.Start(expense.Date()), amount: amount})
+	}
+	sort.Slice(history, func(a, b int) bool { return history[a].before(history[b]) })
+
+	byVendor := make(map[entities.VendorID][]*observation)
+	byVendorType := make(map[entities.VendorType][]*observation)
+	for _, o := range history {
+		byVendor[o.expense.Vendor().ID()] = append(byVendor[o.expense.Vendor().ID()], o)
+		byVendorType[o.expense.Vendor().Type()] = append(byVendorType[o.expense.Vendor().Type()], o)
+	}
+
+	var anomalies []*Anomaly
+	for _, o := range history {
+		if o.date.Before(start) {
+			continue
+		}
+		vendor := o.expense.Vendor()
+		anomaly := &Anomaly{Expense: o.expense, Amount: o.amount}
+
+		earlier := earlierInWindow(byVendor[vendor.ID()], o)
+		reference, scope := earlier, vendor.Name()
+		if len(reference) < anomalyMinSamples {
+			reference, scope = earlierInWindow(byVendorType[vendor.Type()], o), string(vendor.Type())+" vendors"
+		}
+		if reason, ok := unusualAmount(o, reference, scope); ok {
+			anomaly.Kinds = append(anomaly.Kinds, AnomalyUnusualAmount)
+			anomaly.Reasons = append(anomaly.Reasons, reason)
+		}
+
+		if reason, ok := unusualFrequency(o, earlier); ok {
+			anomaly.Kinds = append(anomaly.Kinds, AnomalyUnusualFrequency)
+			anomaly.Reasons = append(anomaly.Reasons, reason)
+		}
+
+		firstAtVendor := byVendor[vendor.ID()][0] == o
+		if firstAtVendor && !knownVendors[strconv.Itoa(int(vendor.ID()))] && o.amount.MinorUnits() > threshold.MinorUnits() {
+			anomaly.Kinds = append(anomaly.Kinds, AnomalyNewVendor)
+			anomaly.Reasons = append(anomaly.Reasons, fmt.Sprintf("first expense at %s and above %s", vendor.Name(), threshold))
+		}
+
+		if len(anomaly.Kinds) > 0 {
+			anomalies = append(anomalies, anomaly)
+		}
+	}
+
+	// history is sorted oldest first, so reversing puts the newest first
+	for a, b := 0, len(anomalies)-1; a < b; a, b = a+1, b-1 {
+		anomalies[a], anomalies[b] = anomalies[b], anomalies[a]
+	}
+	return anomalies, nil
+}
+
+// observation is an expense with its amount in the reporting currency
+type observation struct {
+	expense *entities.Expense
+	date    time.Time
+	amount  valueobjects.Money
+}
+
+// before orders by date, then by ID so expenses of the same day keep the order they were recorded in
+func (o *observation) before(other *observation) bool {
+	if !o.date.Equal(other.date) {
+		return o.date.Before(other.date)
+	}
+	return o.expense.ID() < other.expense.ID()
+}
+
+// earlierInWindow returns the observations of the sorted list that precede o within the window
+func earlierInWindow(sorted []*observation, o *observation) []*observation {
+	windowStart := o.date.AddDate(0, 0, -anomalyWindowDays)
+	from := sort.Search(len(sorted), func(n int) bool { return !sorted[n].date.Before(windowStart) })
+	to := sort.Search(len(sorted), func(n int) bool { return !sorted[n].before(o) })
+	if from > to {
+		return nil
+	}
+	return sorted[from:to]
+}
+
+// unusualAmount compares the amount with the interquartile range of the reference expenses.
+// Quartiles rather than mean and standard deviation keep a single earlier outlier from hiding the next one.
+func unusualAmount(o *observation, reference []*observation, scope string) (string, bool) {
+	if len(reference) < anomalyMinSamples {
+		return "", false
+	}
+	amounts := make([]float64, len(reference))
+	for n, r := range reference {
+		amounts[n] = float64(r.amount.MinorUnits())
+	}
+	sort.Float64s(amounts)
+
+	median := quantile(amounts, 0.5)
+	q1, q3 := quantile(amounts, 0.25), quantile(amounts, 0.75)
+	fence := q3 + anomalyFenceIQRs*math.Max(q3-q1, anomalyMinSpread*median)
+	if float64(o.amount.MinorUnits()) <= fence {
+		return "", false
+	}
+
+	currency := o.amount.Currency()
+	return fmt.Sprintf("%s is far above the usual amount at %s (median %s, up to %s expected, from %d expenses)",
+		o.amount, scope, minorUnitsToMoney(median, currency), minorUnitsToMoney(fence, currency), len(reference)), true
+}
+
+// unusualFrequency compares the expenses at the vendor in the days up to o with the vendor's usual weekly rate.
+// The rate counts the days since the vendor's first expense in the window, so a new vendor has none.
+func unusualFrequency(o *observation, earlier []*observation) (string, bool) {
+	burstStart := o.date.AddDate(0, 0, 1-anomalyBurstDays)
+	burst := 1
+	for n := len(earlier) - 1; n >= 0 && !earlier[n].date.Before(burstStart); n-- {
+		burst++
+	}
+	if burst < anomalyMinBurst {
+		return "", false
+	}
+
+	before := earlier[:len(earlier)-(burst-1)]
+	rate := 0.0
+	if len(before) > 0 {
+		days := burstStart.Sub(before[0].date).Hours() / 24
+		rate = float64(len(before)) / math.Max(days, anomalyBurstDays) * anomalyBurstDays
+	}
+	if float64(burst) < anomalyBurstFactor*rate {
+		return "", false
+	}
+
+	return fmt.Sprintf("%d expenses at %s within %d days, usually %.1f", burst, o.expense.Vendor().Name(), anomalyBurstDays, rate), true
+}
+
+// quantile interpolates linearly between the closest ranks of the sorted values
+func quantile(sorted []float64, q float64) float64 {
+	position := q * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(position-float64(lower))
+}
+
+func minorUnitsToMoney(minorUnits float64, currency string) valueobjects.Money {
+	money, _ := valueobjects.NewMoneyFromMinorUnits(int64(math.Round(minorUnits)), currency) // currency is already normalised
+	return money
+}
@@ -0,0 +1,101 @@
+package member
+
+import (
+	"expenso-backend/domain/entities"
+	"expenso-backend/usecases/interfaces/repositories"
+)
+
+type CreateMemberCommand struct {
+	Name string
+}
+
+type UpdateMemberCommand struct {
+	ID   entities.MemberID
+	Name *string
+}
+
+type MemberInteractor struct {
+	memberRepo repositories.MemberRepository
+}
+
+func NewMemberInteractor(memberRepo repositories.MemberRepository) *MemberInteractor {
+	return &MemberInteractor{
+		memberRepo: memberRepo,
+	}
+}
+
+func (i *MemberInteractor) CreateMember(householdID entities.HouseholdID, cmd CreateMemberCommand) (*entities.Member, error) {
+	member, err := entities.NewMember(cmd.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Member names must be unique so they can be used in imports and exports
+	if err := i.ensureNameAvailable(householdID, member.Name(), 0); err != nil {
+		return nil, err
+	}
+
+	if err := i.memberRepo.Save(householdID, member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (i *MemberInteractor) GetMembers(householdID entities.HouseholdID) ([]*entities.Member, error) {
+	return i.memberRepo.FindAll(householdID)
+}
+
+func (i *MemberInteractor) GetMember(householdID entities.HouseholdID, id entities.MemberID) (*entities.Member, error) {
+	return i.memberRepo.FindByID(householdID, id)
+}
+
+func (i *MemberInteractor) UpdateMember(householdID entities.HouseholdID, cmd UpdateMemberCommand) (*entities.Member, error) {
+	member, err := i.memberRepo.FindByID(householdID, cmd.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.Name != nil {
+		if err := member.UpdateName(*cmd.Name); err != nil {
+			return nil, err
+		}
+		if err := i.ensureNameAvailable(householdID, member.Name(), member.ID()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := i.memberRepo.Update(householdID, member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// DeleteMember removes a member who has not recorded any expenses or incomes
+func (i *MemberInteractor) DeleteMember(householdID entities.HouseholdID, id entities.MemberID) error {
+	if _, err := i.memberRepo.FindByID(householdID, id); err != nil {
+		return err
+	}
+
+	inUse, err := i.memberRepo.HasTransactions(householdID, id)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return entities.ErrMemberInUse
+	}
+
+	return i.memberRepo.Delete(householdID, id)
+}
+
+func (i *MemberInteractor) ensureNameAvailable(householdID entities.HouseholdID, name string, self entities.MemberID) error {
+	existing, err := i.memberRepo.FindByName(householdID, name)
+	if err != nil && err != entities.ErrMemberNotFound {
+		return err
+	}
+	if existing != nil && existing.ID() != self {
+		return entities.ErrMemberAlreadyExists
+	}
+	return nil
+}
@@ -0,0 +1,519 @@
+package recurring
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
+	"expenso-backend/usecases/interactors/expense"
+	"expenso-backend/usecases/interactors/income"
+	"expenso-backend/usecases/interfaces/repositories"
+)
+
+type CreateRecurringRuleCommand struct {
+	Kind       string
+	Name       string
+	Amount     string // Decimal amount, e.g. "950.00"
+	Currency   string // ISO-4217 code, defaults to EUR if empty
+	Category   string // Expense rules only
+	Source     string // Income rules only
+	Comment    string // Comment of the created transactions, defaults to the rule name
+	VendorID   *entities.VendorID
+	MemberID   *entities.MemberID
+	PaidByCard *bool // Expense rules only, defaults to true if nil
+	TagIDs     []entities.TagID
+	Frequency  string
+	Interval   int // Defaults to 1
+	DayOfMonth int // Monthly rules only, defaults to the day of StartDate
+	StartDate  time.Time
+	EndDate    *time.Time
+}
+
+type UpdateRecurringRuleCommand struct {
+	ID         entities.RecurringRuleID
+	Name       *string
+	Amount     *string
+	Currency   *string
+	Category   *string
+	Source     *string
+	Comment    *string
+	VendorID   *entities.VendorID // 0 removes the vendor
+	MemberID   *entities.MemberID // 0 removes the member
+	PaidByCard *bool
+	TagIDs     *[]entities.TagID // nil means no change, empty slice means clear tags
+	Frequency  *string
+	Interval   *int
+	DayOfMonth *int
+	StartDate  *time.Time
+	EndDate    *time.Time // zero time removes the end date
+	Active     *bool
+}
+
+// UpcomingOccurrence is one scheduled date of a rule
+type UpcomingOccurrence struct {
+	Rule       *entities.RecurringRule
+	Date       time.Time
+	Occurrence *entities.RecurringOccurrence // nil while the date has not been created or skipped yet
+}
+
+type RecurringInteractor struct {
+	ruleRepo          repositories.RecurringRuleRepository
+	vendorRepo        repositories.VendorRepository
+	memberRepo        repositories.MemberRepository
+	categoryRepo      repositories.CategoryRepository
+	tagRepo           repositories.TagRepository
+	unitOfWork        repositories.UnitOfWork
+	expenseInteractor *expense.ExpenseInteractor
+	incomeInteractor  *income.IncomeInteractor
+}
+
+func NewRecurringInteractor(ruleRepo repositories.RecurringRuleRepository, vendorRepo repositories.VendorRepository, memberRepo repositories.MemberRepository,
+	categoryRepo repositories.CategoryRepository, tagRepo repositories.TagRepository, unitOfWork repositories.UnitOfWork,
+	expenseInteractor *expense.ExpenseInteractor, incomeInteractor *income.IncomeInteractor) *RecurringInteractor {
+	return &RecurringInteractor{
+		ruleRepo:          ruleRepo,
+		vendorRepo:        vendorRepo,
+		memberRepo:        memberRepo,
+		categoryRepo:      categoryRepo,
+		tagRepo:           tagRepo,
+		unitOfWork:        unitOfWork,
+		expenseInteractor: expenseInteractor,
+		incomeInteractor:  incomeInteractor,
+	}
+}
+
+func (i *RecurringInteractor) CreateRule(householdID entities.HouseholdID, cmd CreateRecurringRuleCommand) (*entities.RecurringRule, error) {
+	money, err := valueobjects.ParseMoney(cmd.Amount, cmd.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := valueobjects.NewRecurrence(valueobjects.RecurrenceFrequency(cmd.Frequency), cmd.Interval, cmd.DayOfMonth, cmd.StartDate, cmd.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := entities.NewRecurringRule(entities.RecurringKind(cmd.Kind), cmd.Name, money, schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule.Kind() == entities.RecurringKindExpense {
+		if err := i.updateCategory(householdID, rule, cmd.Category); err != nil {
+			return nil, err
+		}
+		if cmd.PaidByCard != nil {
+			rule.UpdatePaidByCard(*cmd.PaidByCard)
+		}
+	} else {
+		if err := rule.UpdateSource(cmd.Source); err != nil {
+			return nil, err
+		}
+	}
+
+	rule.UpdateComment(cmd.Comment)
+
+	if err := i.updateReferences(householdID, rule, cmd.VendorID, cmd.MemberID, &cmd.TagIDs); err != nil {
+		return nil, err
+	}
+
+	if err := i.ruleRepo.Save(householdID, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (i *RecurringInteractor) GetRules(householdID entities.HouseholdID) ([]*entities.RecurringRule, error) {
+	return i.ruleRepo.FindAll(householdID)
+}
+
+func (i *RecurringInteractor) GetRule(householdID entities.HouseholdID, id entities.RecurringRuleID) (*entities.RecurringRule, error) {
+	return i.ruleRepo.FindByID(householdID, id)
+}
+
+func (i *RecurringInteractor) UpdateRule(householdID entities.HouseholdID, cmd UpdateRecurringRuleCommand) (*entities.RecurringRule, error) {
+	rule, err := i.ruleRepo.FindByID(householdID, cmd.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.Name != nil {
+		if err := rule.UpdateName(*cmd.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	// Update amount and/or currency if provided
+	if cmd.Amount != nil || cmd.Currency != nil {
+		currency := rule.Amount().Currency()
+		if cmd.Currency != nil {
+			currency = *cmd.Currency
+		}
+		// Without a new amount the old one is kept as it is, which fails rather than dropping digits the new currency lacks
+		var money valueobjects.Money
+		if cmd.Amount != nil {
+			money, err = valueobjects.ParseMoney(*cmd.Amount, currency)
+		} else {
+			money, err = rule.Amount().WithCurrency(currency)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := rule.UpdateAmount(money); err != nil {
+			return nil, err
+		}
+	}
+
+	if cmd.Category != nil {
+		if err := i.updateCategory(householdID, rule, *cmd.Category); err != nil {
+			return nil, err
+		}
+	}
+
+	if cmd.Source != nil {
+		if err := rule.UpdateSource(*cmd.Source); err != nil {
+			return nil, err
+		}
+	}
+
+	if cmd.Comment != nil {
+		rule.UpdateComment(*cmd.Comment)
+	}
+
+	if cmd.PaidByCard != nil {
+		rule.UpdatePaidByCard(*cmd.PaidByCard)
+	}
+
+	if err := i.updateReferences(householdID, rule, cmd.VendorID, cmd.MemberID, cmd.TagIDs); err != nil {
+		return nil, err
+	}
+
+	// Rebuild the schedule if any part of it changed
+	if cmd.Frequency != nil || cmd.Interval != nil || cmd.DayOfMonth != nil || cmd.StartDate != nil || cmd.EndDate != nil {
+		current := rule.Schedule()
+		frequency := current.Frequency()
+		if cmd.Frequency != nil {
+			frequency = valueobjects.RecurrenceFrequency(*cmd.Frequency)
+		}
+		interval := current.Interval()
+		if cmd.Interval != nil {
+			interval = *cmd.Interval
+		}
+		dayOfMonth := current.DayOfMonth()
+		if cmd.DayOfMonth != nil {
+			dayOfMonth = *cmd.DayOfMonth
+		} else if frequency != valueobjects.RecurrenceMonthly {
+			dayOfMonth = 0
+		}
+		startDate := current.StartDate()
+		if cmd.StartDate != nil {
+			startDate = *cmd.StartDate
+		}
+		endDate := current.EndDate()
+		if cmd.EndDate != nil {
+			endDate = cmd.EndDate
+			if cmd.EndDate.IsZero() {
+				endDate = nil
+			}
+		}
+
+		schedule, err := valueobjects.NewRecurrence(frequency, interval, dayOfMonth, startDate, endDate)
+		if err != nil {
+			return nil, err
+		}
+		rule.UpdateSchedule(schedule)
+	}
+
+	if cmd.Active != nil {
+		rule.UpdateActive(*cmd.Active)
+	}
+
+	if err := i.ruleRepo.Update(householdID, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// DeleteRule removes a rule; transactions it already created are kept
+func (i *RecurringInteractor) DeleteRule(householdID entities.HouseholdID, id entities.RecurringRuleID) error {
+	return i.ruleRepo.Delete(householdID, id)
+}
+
+// GetUpcoming lists the scheduled dates of every active rule between from and to inclusive, ordered by date
+func (i *RecurringInteractor) GetUpcoming(householdID entities.HouseholdID, from, to time.Time) ([]*UpcomingOccurrence, error) {
+	rules, err := i.ruleRepo.FindAll(householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	upcoming := []*UpcomingOccurrence{}
+	for _, rule := range rules {
+		if !rule.Active() {
+			continue
+		}
+		dates := rule.Schedule().Occurrences(from, to)
+		if len(dates) == 0 {
+			continue
+		}
+
+		handled, err := i.handledOccurrences(rule, dates[0], dates[len(dates)-1])
+		if err != nil {
+			return nil, err
+		}
+		for _, date := range dates {
+			upcoming = append(upcoming, &UpcomingOccurrence{
+				Rule:       rule,
+				Date:       date,
+				Occurrence: handled[dateKey(date)],
+			})
+		}
+	}
+
+	sort.SliceStable(upcoming, func(a, b int) bool {
+		return upcoming[a].Date.Before(upcoming[b].Date)
+	})
+	return upcoming, nil
+}
+
+// SkipOccurrence marks a scheduled date so the scheduler does not create a transaction for it
+func (i *RecurringInteractor) SkipOccurrence(householdID entities.HouseholdID, id entities.RecurringRuleID, date time.Time) error {
+	rule, err := i.ruleRepo.FindByID(householdID, id)
+	if err != nil {
+		return err
+	}
+
+	if !rule.Schedule().IsOccurrence(date) {
+		return entities.ErrNotAnOccurrence
+	}
+
+	claimed, err := i.ruleRepo.ClaimOccurrence(rule.ID(), repositories.PeriodDay.Start(date), entities.OccurrenceSkipped)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return entities.ErrOccurrenceHandled
+	}
+	return nil
+}
+
+// MaterializeDue creates the transactions of every active rule that fell due up to now and returns how many were created.
+// Dates before a rule was created are not back-filled. Each date is claimed, created and completed in one database
+// transaction, so it is created exactly once, even with several instances: a concurrent claim waits for the first one
+// to commit or roll back. If the process dies midway, nothing is committed and the next run tries the date again.
+// Failing rules are logged and do not stop the others.
+func (i *RecurringInteractor) MaterializeDue(now time.Time) (int, error) {
+	scheduled, err := i.ruleRepo.FindActive()
+	if err != nil {
+		return 0, err
+	}
+
+	today := repositories.PeriodDay.Start(now)
+	created := 0
+	for _, s := range scheduled {
+		count, err := i.materializeRule(s.HouseholdID, s.Rule, today)
+		created += count
+		if err != nil {
+			log.Printf("Recurring rule %d (%s): %v", s.Rule.ID(), s.Rule.Name(), err)
+		}
+	}
+
+	return created, nil
+}
+
+func (i *RecurringInteractor) materializeRule(householdID entities.HouseholdID, rule *entities.RecurringRule, today time.Time) (int, error) {
+	from := repositories.PeriodDay.Start(rule.CreatedAt())
+	if start := rule.Schedule().StartDate(); start.After(from) {
+		from = start
+	}
+	dates := rule.Schedule().Occurrences(from, today)
+	if len(dates) == 0 {
+		return 0, nil
+	}
+
+	handled, err := i.handledOccurrences(rule, dates[0], dates[len(dates)-1])
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for _, date := range dates {
+		if handled[dateKey(date)] != nil {
+			continue
+		}
+
+		claimed := false
+		err := i.unitOfWork.Do(func(repos repositories.Repositories) error {
+			var err error
+			claimed, err = repos.RecurringRules.ClaimOccurrence(rule.ID(), date, entities.OccurrencePending)
+			if err != nil || !claimed {
+				return err // not claimed: another instance got there first
+			}
+
+			expenseID, incomeID, err := i.createTransaction(repos, householdID, rule, date)
+			if err != nil {
+				return fmt.Errorf("failed to create occurrence %s: %w", dateKey(date), err)
+			}
+
+			return repos.RecurringRules.CompleteOccurrence(rule.ID(), date, expenseID, incomeID)
+		})
+		if err != nil {
+			return created, err
+		}
+		if claimed {
+			created++
+		}
+	}
+
+	return created, nil
+}
+
+// createTransaction creates the expense or income of one date with repos, those of the transaction claiming it
+func (i *RecurringInteractor) createTransaction(repos repositories.Repositories, householdID entities.HouseholdID, rule *entities.RecurringRule,
+	date time.Time) (*entities.ExpenseID, *entities.IncomeID, error) {
+	comment := rule.Comment()
+	if comment == "" {
+		comment = rule.Name()
+	}
+
+	// Tags deleted since the rule was set up are dropped rather than failing every run
+	tagIDs, err := existingTagIDs(repos.Tags, householdID, rule.TagIDs())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if rule.Kind() == entities.RecurringKindIncome {
+		created, err := i.incomeInteractor.WithRepositories(repos).CreateIncome(householdID, income.CreateIncomeCommand{
+			Amount:   rule.Amount().Decimal(),
+			Currency: rule.Amount().Currency(),
+			Date:     date,
+			Source:   rule.Source(),
+			Comment:  comment,
+			VendorID: rule.VendorID(),
+			MemberID: rule.MemberID(),
+			TagIDs:   tagIDs,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		incomeID := created.ID()
+		return nil, &incomeID, nil
+	}
+
+	paidByCard := rule.PaidByCard()
+	created, err := i.expenseInteractor.WithRepositories(repos).CreateExpense(householdID, expense.CreateExpenseCommand{
+		Amount:     rule.Amount().Decimal(),
+		Currency:   rule.Amount().Currency(),
+		Date:       date,
+		Type:       string(entities.ExpenseTypeExpense),
+		Category:   rule.Category(),
+		Comment:    comment,
+		VendorID:   rule.VendorID(),
+		PaidByCard: &paidByCard,
+		MemberID:   rule.MemberID(),
+		TagIDs:     tagIDs,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	expenseID := created.ID()
+	return &expenseID, nil, nil
+}
+
+// updateCategory sets the category of an expense rule after checking that it exists in the household
+func (i *RecurringInteractor) updateCategory(householdID entities.HouseholdID, rule *entities.RecurringRule, name string) error {
+	category, err := entities.NewCategory(name)
+	if err != nil {
+		return err
+	}
+	if _, err := i.categoryRepo.FindByName(householdID, category.String()); err != nil {
+		return err
+	}
+	return rule.UpdateCategory(category)
+}
+
+// updateReferences checks and sets vendor, member and tags; nil leaves a field unchanged and ID 0 removes it
+func (i *RecurringInteractor) updateReferences(householdID entities.HouseholdID, rule *entities.RecurringRule, vendorID *entities.VendorID, memberID *entities.MemberID, tagIDs *[]entities.TagID) error {
+	if vendorID != nil {
+		if *vendorID == 0 {
+			rule.UpdateVendor(nil)
+		} else {
+			if _, err := i.vendorRepo.FindByID(householdID, *vendorID); err != nil {
+				return err
+			}
+			rule.UpdateVendor(vendorID)
+		}
+	}
+
+	if memberID != nil {
+		if *memberID == 0 {
+			rule.UpdateMember(nil)
+		} else {
+			if _, err := i.memberRepo.FindByID(householdID, *memberID); err != nil {
+				return err
+			}
+			rule.UpdateMember(memberID)
+		}
+	}
+
+	if tagIDs != nil {
+		for _, tagID := range *tagIDs {
+			tag, err := i.tagRepo.GetByID(householdID, tagID)
+			if err != nil {
+				return err
+			}
+			if tag == nil {
+				return errors.New("tag not found")
+			}
+		}
+		rule.UpdateTags(append([]entities.TagID{}, *tagIDs...))
+	}
+
+	return nil
+}
+
+func existingTagIDs(tagRepo repositories.TagRepository, householdID entities.HouseholdID, tagIDs []entities.TagID) ([]entities.TagID, error) {
+	if len(tagIDs) == 0 {
+		return nil, nil
+	}
+
+	tags, err := tagRepo.GetAll(householdID)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[entities.TagID]bool, len(tags))
+	for _, tag := range tags {
+		existing[tag.ID()] = true
+	}
+
+	var kept []entities.TagID
+	for _, tagID := range tagIDs {
+		if existing[tagID] {
+			kept = append(kept, tagID)
+		}
+	}
+	return kept, nil
+}
+
+// handledOccurrences loads the created, skipped and pending dates of a rule keyed by dateKey
+func (i *RecurringInteractor) handledOccurrences(rule *entities.RecurringRule, from, to time.Time) (map[string]*entities.RecurringOccurrence, error) {
+	occurrences, err := i.ruleRepo.FindOccurrences(rule.ID(), from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	handled := make(map[string]*entities.RecurringOccurrence, len(occurrences))
+	for _, occurrence := range occurrences {
+		handled[dateKey(occurrence.Date)] = occurrence
+	}
+	return handled, nil
+}
+
+func dateKey(date time.Time) string {
+	return date.Format("2006-01-02")
+}
@@ -1,43 +1,52 @@
 package tag
 
 import (
+	"errors"
+
 	"expenso-backend/domain/entities"
 	"expenso-backend/infrastructure/persistence/repositories"
+	repositoryinterfaces "expenso-backend/usecases/interfaces/repositories"
 )
 
 type TagInteractor struct {
-	tagRepo *repositories.TagRepository
+	tagRepo     *repositories.TagRepository
+	expenseRepo repositoryinterfaces.ExpenseRepository
 }
 
-func NewTagInteractor(tagRepo *repositories.TagRepository) *TagInteractor {
+func NewTagInteractor(tagRepo *repositories.TagRepository, expenseRepo repositoryinterfaces.ExpenseRepository) *TagInteractor {
 	return &TagInteractor{
-		tagRepo: tagRepo,
+		tagRepo:     tagRepo,
+		expenseRepo: expenseRepo,
 	}
 }
 
-func (i *TagInteractor) CreateTag(name, color string) (*entities.Tag, error) {
+func (i *TagInteractor) CreateTag(householdID entities.HouseholdID, name, color string) (*entities.Tag, error) {
 	tag, err := entities.NewTag(name, color)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := i.tagRepo.Create(tag); err != nil {
+	if err := i.tagRepo.Create(householdID, tag); err != nil {
 		return nil, err
 	}
 
 	return tag, nil
 }
 
-func (i *TagInteractor) GetTag(id entities.TagID) (*entities.Tag, error) {
-	return i.tagRepo.GetByID(id)
+func (i *TagInteractor) GetTag(householdID entities.HouseholdID, id entities.TagID) (*entities.Tag, error) {
+	return i.tagRepo.GetByID(householdID, id)
+}
+
+func (i *TagInteractor) GetAllTags(householdID entities.HouseholdID) ([]*entities.Tag, error) {
+	return i.tagRepo.GetAll(householdID)
 }
 
-func (i *TagInteractor) GetAllTags() ([]*entities.Tag, error) {
-	return i.tagRepo.GetAll()
+func (i *TagInteractor) ListTags(householdID entities.HouseholdID, page repositoryinterfaces.PageRequest) (*repositoryinterfaces.TagPage, error) {
+	return i.tagRepo.GetPage(householdID, page)
 }
 
-func (i *TagInteractor) UpdateTag(id entities.TagID, name, color string) (*entities.Tag, error) {
-	tag, err := i.tagRepo.GetByID(id)
+func (i *TagInteractor) UpdateTag(householdID entities.HouseholdID, id entities.TagID, name, color string) (*entities.Tag, error) {
+	tag, err := i.tagRepo.GetByID(householdID, id)
 	if err != nil {
 		return nil, err
 	}
@@ -57,25 +66,49 @@ func (i *TagInteractor) UpdateTag(id entities.TagID, name, color string) (*entit
 		}
 	}
 
-	if err := i.tagRepo.Update(tag); err != nil {
+	if err := i.tagRepo.Update(householdID, tag); err != nil {
 		return nil, err
 	}
 
 	return tag, nil
 }
 
-func (i *TagInteractor) DeleteTag(id entities.TagID) error {
-	return i.tagRepo.Delete(id)
+func (i *TagInteractor) DeleteTag(householdID entities.HouseholdID, id entities.TagID) error {
+	return i.tagRepo.Delete(householdID, id)
 }
 
-func (i *TagInteractor) GetTagsByExpense(expenseID entities.ExpenseID) ([]*entities.Tag, error) {
+func (i *TagInteractor) GetTagsByExpense(householdID entities.HouseholdID, expenseID entities.ExpenseID) ([]*entities.Tag, error) {
+	if _, err := i.expenseRepo.FindByID(householdID, expenseID); err != nil {
+		return nil, err
+	}
 	return i.tagRepo.GetTagsByExpenseID(expenseID)
 }
 
-func (i *TagInteractor) AddTagToExpense(expenseID entities.ExpenseID, tagID entities.TagID) error {
+func (i *TagInteractor) AddTagToExpense(householdID entities.HouseholdID, expenseID entities.ExpenseID, tagID entities.TagID) error {
+	if err := i.ensureSameHousehold(householdID, expenseID, tagID); err != nil {
+		return err
+	}
 	return i.tagRepo.AddTagToExpense(expenseID, tagID)
 }
 
-func (i *TagInteractor) RemoveTagFromExpense(expenseID entities.ExpenseID, tagID entities.TagID) error {
+func (i *TagInteractor) RemoveTagFromExpense(householdID entities.HouseholdID, expenseID entities.ExpenseID, tagID entities.TagID) error {
+	if err := i.ensureSameHousehold(householdID, expenseID, tagID); err != nil {
+		return err
+	}
 	return i.tagRepo.RemoveTagFromExpense(expenseID, tagID)
 }
+
+// ensureSameHousehold checks that both the expense and the tag belong to the household
+func (i *TagInteractor) ensureSameHousehold(householdID entities.HouseholdID, expenseID entities.ExpenseID, tagID entities.TagID) error {
+	if _, err := i.expenseRepo.FindByID(householdID, expenseID); err != nil {
+		return err
+	}
+	tag, err := i.tagRepo.GetByID(householdID, tagID)
+	if err != nil {
+		return err
+	}
+	if tag == nil {
+		return errors.New("tag not found")
+	}
+	return nil
+}
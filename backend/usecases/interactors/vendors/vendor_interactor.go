@@ -26,7 +26,7 @@ func NewVendorInteractor(vendorRepo repositories.VendorRepository) *VendorIntera
 	}
 }
 
-func (i *VendorInteractor) CreateVendor(cmd CreateVendorCommand) (*entities.Vendor, error) {
+func (i *VendorInteractor) CreateVendor(householdID entities.HouseholdID, cmd CreateVendorCommand) (*entities.Vendor, error) {
 	// Validate vendor type
 	vendorType := entities.VendorType(cmd.Type)
 	if !vendorType.IsValid() {
@@ -40,37 +40,45 @@ func (i *VendorInteractor) CreateVendor(cmd CreateVendorCommand) (*entities.Vend
 	}
 
 	// Check if vendor with same name and type already exists
-	existingVendor, err := i.vendorRepo.FindByName(vendor.Name())
+	existingVendor, err := i.vendorRepo.FindByName(householdID, vendor.Name())
 	if err == nil && existingVendor != nil && existingVendor.Type() == vendor.Type() {
 		return nil, entities.ErrVendorAlreadyExists
 	}
 
 	// Save vendor
-	if err := i.vendorRepo.Save(vendor); err != nil {
+	if err := i.vendorRepo.Save(householdID, vendor); err != nil {
 		return nil, err
 	}
 
 	return vendor, nil
 }
 
-func (i *VendorInteractor) GetVendors() ([]*entities.Vendor, error) {
-	return i.vendorRepo.FindAll()
+func (i *VendorInteractor) GetVendors(householdID entities.HouseholdID) ([]*entities.Vendor, error) {
+	return i.vendorRepo.FindAll(householdID)
 }
 
-func (i *VendorInteractor) GetVendor(id entities.VendorID) (*entities.Vendor, error) {
-	return i.vendorRepo.FindByID(id)
+// ListVendors returns one page of the vendors of the given type, or of every type if it is empty
+func (i *VendorInteractor) ListVendors(householdID entities.HouseholdID, vendorType entities.VendorType, page repositories.PageRequest) (*repositories.VendorPage, error) {
+	if vendorType != "" && !vendorType.IsValid() {
+		return nil, entities.ErrInvalidVendorType
+	}
+	return i.vendorRepo.FindPage(householdID, vendorType, page)
+}
+
+func (i *VendorInteractor) GetVendor(householdID entities.HouseholdID, id entities.VendorID) (*entities.Vendor, error) {
+	return i.vendorRepo.FindByID(householdID, id)
 }
 
-func (i *VendorInteractor) GetVendorsByType(vendorType entities.VendorType) ([]*entities.Vendor, error) {
+func (i *VendorInteractor) GetVendorsByType(householdID entities.HouseholdID, vendorType entities.VendorType) ([]*entities.Vendor, error) {
 	if !vendorType.IsValid() {
 		return nil, entities.ErrInvalidVendorType
 	}
-	return i.vendorRepo.FindByType(vendorType)
+	return i.vendorRepo.FindByType(householdID, vendorType)
 }
 
-func (i *VendorInteractor) UpdateVendor(cmd UpdateVendorCommand) (*entities.Vendor, error) {
+func (i *VendorInteractor) UpdateVendor(householdID entities.HouseholdID, cmd UpdateVendorCommand) (*entities.Vendor, error) {
 	// Find existing vendor
-	vendor, err := i.vendorRepo.FindByID(cmd.ID)
+	vendor, err := i.vendorRepo.FindByID(householdID, cmd.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -91,13 +99,13 @@ func (i *VendorInteractor) UpdateVendor(cmd UpdateVendorCommand) (*entities.Vend
 	}
 
 	// Save updated vendor
-	if err := i.vendorRepo.Update(vendor); err != nil {
+	if err := i.vendorRepo.Update(householdID, vendor); err != nil {
 		return nil, err
 	}
 
 	return vendor, nil
 }
 
-func (i *VendorInteractor) DeleteVendor(id entities.VendorID) error {
-	return i.vendorRepo.Delete(id)
+func (i *VendorInteractor) DeleteVendor(householdID entities.HouseholdID, id entities.VendorID) error {
+	return i.vendorRepo.Delete(householdID, id)
 }
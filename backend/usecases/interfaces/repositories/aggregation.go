@@ -0,0 +1,95 @@
+package repositories
+
+import "time"
+
+// Period is the length of the buckets an aggregation groups transactions into
+type Period string
+
+const (
+	PeriodDay     Period = "day"
+	PeriodWeek    Period = "week" // starting on Monday
+	PeriodMonth   Period = "month"
+	PeriodQuarter Period = "quarter"
+	PeriodYear    Period = "year"
+)
+
+func (p Period) IsValid() bool {
+	switch p {
+	case PeriodDay, PeriodWeek, PeriodMonth, PeriodQuarter, PeriodYear:
+		return true
+	}
+	return false
+}
+
+// Bounds returns the first and last day of the period containing date, as the database's date_trunc does
+func (p Period) Bounds(date time.Time) (time.Time, time.Time) {
+	year, month, day := date.Date()
+	switch p {
+	case PeriodWeek:
+		start := time.Date(year, month, day-(int(date.Weekday())+6)%7, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 0, 6)
+	case PeriodMonth:
+		start := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, -1)
+	case PeriodQuarter:
+		start := time.Date(year, month-(month-1)%3, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 3, -1)
+	case PeriodYear:
+		start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(1, 0, -1)
+	default:
+		start := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		return start, start
+	}
+}
+
+// Start returns the first day of the period containing date, so PeriodDay.Start drops the time of day
+func (p Period) Start(date time.Time) time.Time {
+	start, _ := p.Bounds(date)
+	return start
+}
+
+// Dimension splits each bucket of an aggregation into groups
+type Dimension string
+
+const (
+	DimensionNone          Dimension = ""
+	DimensionCategory      Dimension = "category" // the source for incomes
+	DimensionVendor        Dimension = "vendor"
+	DimensionVendorType    Dimension = "vendor_type"
+	DimensionTag           Dimension = "tag" // a transaction counts once for each of its tags
+	DimensionMember        Dimension = "member"
+	DimensionPaymentMethod Dimension = "payment_method" // card or cash; incomes have none
+)
+
+func (d Dimension) IsValid() bool {
+	switch d {
+	case DimensionNone, DimensionCategory, DimensionVendor, DimensionVendorType, DimensionTag, DimensionMember, DimensionPaymentMethod:
+		return true
+	}
+	return false
+}
+
+// AggregateQuery asks for totals per bucket and group.
+// Amounts in ReportingCurrency are summed across days, the others per day so each can be converted at its own rate.
+type AggregateQuery struct {
+	Period            Period
+	Dimension         Dimension
+	StartDate         *time.Time
+	EndDate           *time.Time
+	ReportingCurrency string
+}
+
+// AggregateRow totals the transactions of a bucket that share a group and a currency.
+// Amounts are decimals in Currency.
+type AggregateRow struct {
+	Bucket   time.Time // first day of the bucket
+	Key      string    // ID for vendors, tags and members, the name otherwise; empty for transactions without one
+	Label    string
+	Currency string
+	Date     *time.Time // the day of the transactions, nil when Currency is the reporting currency
+	Count    int
+	Sum      string
+	Min      string
+	Max      string
+}
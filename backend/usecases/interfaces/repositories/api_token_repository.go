@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"time"
+
+	"expenso-backend/domain/entities"
+)
+
+type APITokenRepository interface {
+	Save(token *entities.APIToken) error
+	FindByHash(tokenHash string) (*entities.APIToken, error)
+	FindByUser(userID entities.UserID) ([]*entities.APIToken, error)
+	// Delete only removes the token if it belongs to userID
+	Delete(userID entities.UserID, id entities.APITokenID) error
+	UpdateLastUsed(id entities.APITokenID, lastUsedAt time.Time) error
+}
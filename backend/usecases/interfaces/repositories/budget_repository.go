@@ -0,0 +1,13 @@
+package repositories
+
+import "expenso-backend/domain/entities"
+
+// BudgetRepository methods are scoped to a single household
+type BudgetRepository interface {
+	Save(householdID entities.HouseholdID, budget *entities.Budget) error
+	FindByID(householdID entities.HouseholdID, id entities.BudgetID) (*entities.Budget, error)
+	FindAll(householdID entities.HouseholdID) ([]*entities.Budget, error)
+	FindByTarget(householdID entities.HouseholdID, targetType entities.BudgetTargetType, target string, period entities.BudgetPeriod) (*entities.Budget, error)
+	Update(householdID entities.HouseholdID, budget *entities.Budget) error
+	Delete(householdID entities.HouseholdID, id entities.BudgetID) error
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import "expenso-backend/domain/entities"
+
+// CategorizationRuleRepository methods are scoped to a single household.
+// FindAll returns the rules in the order they are applied: by priority, then by ID.
+type CategorizationRuleRepository interface {
+	Save(householdID entities.HouseholdID, rule *entities.CategorizationRule) error
+	FindByID(householdID entities.HouseholdID, id entities.CategorizationRuleID) (*entities.CategorizationRule, error)
+	FindAll(householdID entities.HouseholdID) ([]*entities.CategorizationRule, error)
+	FindByName(householdID entities.HouseholdID, name string) (*entities.CategorizationRule, error)
+	Update(householdID entities.HouseholdID, rule *entities.CategorizationRule) error
+	Delete(householdID entities.HouseholdID, id entities.CategorizationRuleID) error
+}
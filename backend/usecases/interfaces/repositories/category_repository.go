@@ -2,11 +2,12 @@ package repositories
 
 import "expenso-backend/domain/entities"
 
+// CategoryRepository methods are scoped to a single household
 type CategoryRepository interface {
-	Save(category *entities.CategoryEntity) error
-	FindByID(id entities.CategoryID) (*entities.CategoryEntity, error)
-	FindByName(name string) (*entities.CategoryEntity, error)
-	FindAll() ([]*entities.CategoryEntity, error)
-	Update(category *entities.CategoryEntity) error
-	Delete(id entities.CategoryID) error
+	Save(householdID entities.HouseholdID, category *entities.CategoryEntity) error
+	FindByID(householdID entities.HouseholdID, id entities.CategoryID) (*entities.CategoryEntity, error)
+	FindByName(householdID entities.HouseholdID, name string) (*entities.CategoryEntity, error)
+	FindAll(householdID entities.HouseholdID) ([]*entities.CategoryEntity, error)
+	Update(householdID entities.HouseholdID, category *entities.CategoryEntity) error
+	Delete(householdID entities.HouseholdID, id entities.CategoryID) error
 }
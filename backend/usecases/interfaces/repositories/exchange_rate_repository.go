@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"time"
+
+	"expenso-backend/domain/entities"
+)
+
+type ExchangeRateRepository interface {
+	// SaveAll inserts the rates, replacing any existing rate for the same currency pair and date
+	SaveAll(rates []*entities.ExchangeRate) error
+	// FindLatest returns the most recent rate published on or before the given date
+	FindLatest(baseCurrency, quoteCurrency string, date time.Time) (*entities.ExchangeRate, error)
+	// FindByDate returns all rates of the most recent publication day on or before the given date
+	FindByDate(date time.Time) ([]*entities.ExchangeRate, error)
+}
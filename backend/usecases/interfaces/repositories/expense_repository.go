@@ -2,17 +2,42 @@ package repositories
 
 import (
 	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
 	"time"
 )
 
+// ExpenseFilter narrows a listing; nil and empty fields match every expense
+type ExpenseFilter struct {
+	StartDate  *time.Time
+	EndDate    *time.Time
+	Category   string // Compared ignoring case
+	VendorID   *entities.VendorID
+	VendorType entities.VendorType
+	TagID      *entities.TagID
+	PaidByCard *bool
+	MemberID   *entities.MemberID
+	Currency   string              // Only amounts recorded in this currency
+	MinAmount  *valueobjects.Money // In Currency, amounts are never compared across currencies
+	MaxAmount  *valueobjects.Money
+}
+
+// ExpensePage is one page of a filtered listing
+type ExpensePage struct {
+	Expenses []*entities.Expense
+	PageInfo
+}
+
+// ExpenseRepository methods are scoped to a single household
 type ExpenseRepository interface {
-	Save(expense *entities.Expense) error
-	FindByID(id entities.ExpenseID) (*entities.Expense, error)
-	FindAll() ([]*entities.Expense, error)
-	FindByDateRange(startDate, endDate *time.Time) ([]*entities.Expense, error)
-	Update(expense *entities.Expense) error
-	Delete(id entities.ExpenseID) error
-	FindByCategory(category entities.Category) ([]*entities.Expense, error)
-	FindByCategoryAndDateRange(category entities.Category, startDate, endDate *time.Time) ([]*entities.Expense, error)
-	FindByVendor(vendorID entities.VendorID) ([]*entities.Expense, error)
+	Save(householdID entities.HouseholdID, expense *entities.Expense) error
+	FindByID(householdID entities.HouseholdID, id entities.ExpenseID) (*entities.Expense, error)
+	FindAll(householdID entities.HouseholdID) ([]*entities.Expense, error)
+	FindByDateRange(householdID entities.HouseholdID, startDate, endDate *time.Time) ([]*entities.Expense, error)
+	Update(householdID entities.HouseholdID, expense *entities.Expense) error
+	Delete(householdID entities.HouseholdID, id entities.ExpenseID) error
+	FindByCategory(householdID entities.HouseholdID, category entities.Category) ([]*entities.Expense, error)
+	FindByCategoryAndDateRange(householdID entities.HouseholdID, category entities.Category, startDate, endDate *time.Time) ([]*entities.Expense, error)
+	FindByVendor(householdID entities.HouseholdID, vendorID entities.VendorID) ([]*entities.Expense, error)
+	FindPage(householdID entities.HouseholdID, filter ExpenseFilter, page PageRequest) (*ExpensePage, error)
+	Aggregate(householdID entities.HouseholdID, query AggregateQuery) ([]AggregateRow, error)
 }
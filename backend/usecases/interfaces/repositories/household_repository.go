@@ -0,0 +1,17 @@
+package repositories
+
+import "expenso-backend/domain/entities"
+
+type HouseholdRepository interface {
+	// Create saves the household with owner as its first owner and seeds the default categories and tags
+	Create(household *entities.Household, owner entities.UserID) error
+	FindByID(id entities.HouseholdID) (*entities.Household, error)
+	// FindUnclaimed returns the oldest household without any users, left behind by the tenancy migration
+	FindUnclaimed() (*entities.Household, error)
+	FindByUser(userID entities.UserID) ([]*entities.HouseholdMembership, error)
+	FindUsers(id entities.HouseholdID) ([]*entities.HouseholdMembership, error)
+	// FindRole returns entities.ErrNotHouseholdMember if the user has no access to the household
+	FindRole(id entities.HouseholdID, userID entities.UserID) (entities.HouseholdRole, error)
+	AddUser(id entities.HouseholdID, userID entities.UserID, role entities.HouseholdRole) error
+	RemoveUser(id entities.HouseholdID, userID entities.UserID) error
+}
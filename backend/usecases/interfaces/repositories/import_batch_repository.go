@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"time"
+
+	"expenso-backend/domain/entities"
+)
+
+// ImportBatchRepository methods are scoped to a single household.
+// Rolled back batches are kept for the record but ignored by FindByFileHash and FindImported.
+type ImportBatchRepository interface {
+	// Save stores the batch together with its items
+	Save(householdID entities.HouseholdID, batch *entities.ImportBatch) error
+	// AddItem adds an item to a saved batch and counts it
+	AddItem(householdID entities.HouseholdID, id entities.ImportBatchID, item entities.ImportBatchItem) error
+	// FindByID loads the batch with its items
+	FindByID(householdID entities.HouseholdID, id entities.ImportBatchID) (*entities.ImportBatch, error)
+	// FindAll loads the batches without their items, newest first
+	FindAll(householdID entities.HouseholdID) ([]*entities.ImportBatch, error)
+	FindByFileHash(householdID entities.HouseholdID, fileHash string) (*entities.ImportBatch, error)
+	// FindImported maps each fingerprint that was already imported to the batch that imported it
+	FindImported(householdID entities.HouseholdID, fingerprints []string) (map[string]entities.ImportBatchID, error)
+	MarkRolledBack(householdID entities.HouseholdID, id entities.ImportBatchID, rolledBackAt time.Time) error
+}
@@ -0,0 +1,13 @@
+package repositories
+
+import "expenso-backend/domain/entities"
+
+// ImportProfileRepository methods are scoped to a single household
+type ImportProfileRepository interface {
+	Save(householdID entities.HouseholdID, profile *entities.ImportProfile) error
+	FindByID(householdID entities.HouseholdID, id entities.ImportProfileID) (*entities.ImportProfile, error)
+	FindAll(householdID entities.HouseholdID) ([]*entities.ImportProfile, error)
+	FindByName(householdID entities.HouseholdID, name string) (*entities.ImportProfile, error)
+	Update(householdID entities.HouseholdID, profile *entities.ImportProfile) error
+	Delete(householdID entities.HouseholdID, id entities.ImportProfileID) error
+}
@@ -2,16 +2,40 @@ package repositories
 
 import (
 	"expenso-backend/domain/entities"
+	"expenso-backend/domain/valueobjects"
 	"time"
 )
 
+// IncomeFilter narrows a listing; nil and empty fields match every income
+type IncomeFilter struct {
+	StartDate  *time.Time
+	EndDate    *time.Time
+	Source     string // Compared ignoring case
+	VendorID   *entities.VendorID
+	VendorType entities.VendorType
+	TagID      *entities.TagID
+	MemberID   *entities.MemberID
+	Currency   string              // Only amounts recorded in this currency
+	MinAmount  *valueobjects.Money // In Currency, amounts are never compared across currencies
+	MaxAmount  *valueobjects.Money
+}
+
+// IncomePage is one page of a filtered listing
+type IncomePage struct {
+	Incomes []*entities.Income
+	PageInfo
+}
+
+// IncomeRepository methods are scoped to a single household
 type IncomeRepository interface {
-	Save(income *entities.Income) error
-	FindByID(id entities.IncomeID) (*entities.Income, error)
-	FindAll() ([]*entities.Income, error)
-	FindByDateRange(startDate, endDate *time.Time) ([]*entities.Income, error)
-	Update(income *entities.Income) error
-	Delete(id entities.IncomeID) error
-	FindBySource(source string) ([]*entities.Income, error)
-	FindByVendor(vendorID entities.VendorID) ([]*entities.Income, error)
+	Save(householdID entities.HouseholdID, income *entities.Income) error
+	FindByID(householdID entities.HouseholdID, id entities.IncomeID) (*entities.Income, error)
+	FindAll(householdID entities.HouseholdID) ([]*entities.Income, error)
+	FindByDateRange(householdID entities.HouseholdID, startDate, endDate *time.Time) ([]*entities.Income, error)
+	Update(householdID entities.HouseholdID, income *entities.Income) error
+	Delete(householdID entities.HouseholdID, id entities.IncomeID) error
+	FindBySource(householdID entities.HouseholdID, source string) ([]*entities.Income, error)
+	FindByVendor(householdID entities.HouseholdID, vendorID entities.VendorID) ([]*entities.Income, error)
+	FindPage(householdID entities.HouseholdID, filter IncomeFilter, page PageRequest) (*IncomePage, error)
+	Aggregate(householdID entities.HouseholdID, query AggregateQuery) ([]AggregateRow, error)
 }
\ No newline at end of file
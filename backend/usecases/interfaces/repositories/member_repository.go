@@ -0,0 +1,15 @@
+package repositories
+
+import "expenso-backend/domain/entities"
+
+// MemberRepository methods are scoped to a single household
+type MemberRepository interface {
+	Save(householdID entities.HouseholdID, member *entities.Member) error
+	FindByID(householdID entities.HouseholdID, id entities.MemberID) (*entities.Member, error)
+	FindByName(householdID entities.HouseholdID, name string) (*entities.Member, error)
+	FindAll(householdID entities.HouseholdID) ([]*entities.Member, error)
+	Update(householdID entities.HouseholdID, member *entities.Member) error
+	Delete(householdID entities.HouseholdID, id entities.MemberID) error
+	// HasTransactions reports whether any expense or income references the member
+	HasTransactions(householdID entities.HouseholdID, id entities.MemberID) (bool, error)
+}
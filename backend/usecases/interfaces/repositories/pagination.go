@@ -0,0 +1,34 @@
+package repositories
+
+// SortField names what a listing is ordered by
+type SortField string
+
+const (
+	SortByDate      SortField = "date"
+	SortByAmount    SortField = "amount"
+	SortByCreatedAt SortField = "created_at"
+	SortByVendor    SortField = "vendor" // the vendor's name, rows without a vendor first
+	SortByName      SortField = "name"
+)
+
+// Cursor is the position of the last row of a page: its value of the sort field and its ID
+type Cursor struct {
+	Value string
+	ID    int
+}
+
+// PageRequest asks for the rows after the cursor, ordered by Sort with the ID breaking ties.
+// A zero Limit returns every remaining row.
+type PageRequest struct {
+	Sort       SortField
+	Descending bool
+	Limit      int
+	After      *Cursor
+}
+
+// PageInfo reports how many rows match the filters regardless of the page, and where the next page starts.
+// NextCursor is nil on the last page.
+type PageInfo struct {
+	Total      int
+	NextCursor *Cursor
+}
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"time"
+
+	"expenso-backend/domain/entities"
+)
+
+// ScheduledRule is an active rule together with the household it belongs to
+type ScheduledRule struct {
+	HouseholdID entities.HouseholdID
+	Rule        *entities.RecurringRule
+}
+
+// RecurringRuleRepository rule methods are scoped to a single household.
+// FindActive and the occurrence methods work across households for the scheduler;
+// callers acting for a household check the rule with FindByID first.
+type RecurringRuleRepository interface {
+	Save(householdID entities.HouseholdID, rule *entities.RecurringRule) error
+	FindByID(householdID entities.HouseholdID, id entities.RecurringRuleID) (*entities.RecurringRule, error)
+	FindAll(householdID entities.HouseholdID) ([]*entities.RecurringRule, error)
+	Update(householdID entities.HouseholdID, rule *entities.RecurringRule) error
+	Delete(householdID entities.HouseholdID, id entities.RecurringRuleID) error
+	FindActive() ([]ScheduledRule, error)
+
+	// FindOccurrences returns the handled dates of a rule between from and to inclusive
+	FindOccurrences(ruleID entities.RecurringRuleID, from, to time.Time) ([]*entities.RecurringOccurrence, error)
+	// ClaimOccurrence records a date with the given status; it returns false if the date was already recorded
+	ClaimOccurrence(ruleID entities.RecurringRuleID, date time.Time, status entities.OccurrenceStatus) (bool, error)
+	// CompleteOccurrence marks a pending date as created by the given expense or income
+	CompleteOccurrence(ruleID entities.RecurringRuleID, date time.Time, expenseID *entities.ExpenseID, incomeID *entities.IncomeID) error
+}
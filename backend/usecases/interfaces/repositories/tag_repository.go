@@ -2,12 +2,21 @@ package repositories
 
 import "expenso-backend/domain/entities"
 
+// TagPage is one page of a listing
+type TagPage struct {
+	Tags []*entities.Tag
+	PageInfo
+}
+
+// TagRepository methods taking a householdID are scoped to that household.
+// The expense/income link methods assume both sides were already loaded from the same household.
 type TagRepository interface {
-	Create(tag *entities.Tag) error
-	GetByID(id entities.TagID) (*entities.Tag, error)
-	GetAll() ([]*entities.Tag, error)
-	Update(tag *entities.Tag) error
-	Delete(id entities.TagID) error
+	Create(householdID entities.HouseholdID, tag *entities.Tag) error
+	GetByID(householdID entities.HouseholdID, id entities.TagID) (*entities.Tag, error)
+	GetAll(householdID entities.HouseholdID) ([]*entities.Tag, error)
+	GetPage(householdID entities.HouseholdID, page PageRequest) (*TagPage, error)
+	Update(householdID entities.HouseholdID, tag *entities.Tag) error
+	Delete(householdID entities.HouseholdID, id entities.TagID) error
 	GetTagsByExpenseID(expenseID entities.ExpenseID) ([]*entities.Tag, error)
 	AddTagToExpense(expenseID entities.ExpenseID, tagID entities.TagID) error
 	RemoveTagFromExpense(expenseID entities.ExpenseID, tagID entities.TagID) error
@@ -0,0 +1,22 @@
+package repositories
+
+// Repositories are the repositories a unit of work hands out, all sharing its transaction
+type Repositories struct {
+	Expenses            ExpenseRepository
+	Incomes             IncomeRepository
+	Vendors             VendorRepository
+	Tags                TagRepository
+	Members             MemberRepository
+	ImportBatches       ImportBatchRepository
+	Categories          CategoryRepository
+	Budgets             BudgetRepository
+	RecurringRules      RecurringRuleRepository
+	ImportProfiles      ImportProfileRepository
+	CategorizationRules CategorizationRuleRepository
+}
+
+// UnitOfWork runs fn in one database transaction.
+// The transaction is committed if fn returns nil and rolled back if it returns an error.
+type UnitOfWork interface {
+	Do(fn func(repos Repositories) error) error
+}
@@ -0,0 +1,10 @@
+package repositories
+
+import "expenso-backend/domain/entities"
+
+type UserRepository interface {
+	Save(user *entities.User) error
+	FindByID(id entities.UserID) (*entities.User, error)
+	FindByEmail(email string) (*entities.User, error)
+	Count() (int, error)
+}
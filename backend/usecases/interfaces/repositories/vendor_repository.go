@@ -4,12 +4,21 @@ import (
 	"expenso-backend/domain/entities"
 )
 
+// VendorPage is one page of a listing
+type VendorPage struct {
+	Vendors []*entities.Vendor
+	PageInfo
+}
+
+// VendorRepository methods are scoped to a single household
 type VendorRepository interface {
-	Save(vendor *entities.Vendor) error
-	FindByID(id entities.VendorID) (*entities.Vendor, error)
-	FindAll() ([]*entities.Vendor, error)
-	FindByType(vendorType entities.VendorType) ([]*entities.Vendor, error)
-	Update(vendor *entities.Vendor) error
-	Delete(id entities.VendorID) error
-	FindByName(name string) (*entities.Vendor, error)
+	Save(householdID entities.HouseholdID, vendor *entities.Vendor) error
+	FindByID(householdID entities.HouseholdID, id entities.VendorID) (*entities.Vendor, error)
+	FindAll(householdID entities.HouseholdID) ([]*entities.Vendor, error)
+	FindByType(householdID entities.HouseholdID, vendorType entities.VendorType) ([]*entities.Vendor, error)
+	Update(householdID entities.HouseholdID, vendor *entities.Vendor) error
+	Delete(householdID entities.HouseholdID, id entities.VendorID) error
+	FindByName(householdID entities.HouseholdID, name string) (*entities.Vendor, error)
+	// FindPage lists vendors of the given type, or of every type if it is empty
+	FindPage(householdID entities.HouseholdID, vendorType entities.VendorType, page PageRequest) (*VendorPage, error)
 }
\ No newline at end of file
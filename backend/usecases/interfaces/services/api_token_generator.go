@@ -0,0 +1,11 @@
+package services
+
+// APITokenGenerator creates API token secrets and derives the values stored for them
+type APITokenGenerator interface {
+	// Generate returns a new secret starting with entities.APITokenPrefix
+	Generate() (string, error)
+	// Hash is deterministic so tokens can be looked up by their hash
+	Hash(token string) string
+	// DisplayPrefix is the part of the secret that is safe to show in token listings
+	DisplayPrefix(token string) string
+}
@@ -0,0 +1,12 @@
+package services
+
+import (
+	"time"
+
+	"expenso-backend/domain/valueobjects"
+)
+
+// CurrencyConverter converts money into another currency using the rate valid on a given date
+type CurrencyConverter interface {
+	Convert(amount valueobjects.Money, date time.Time, targetCurrency string) (valueobjects.Money, error)
+}
@@ -0,0 +1,21 @@
+package services
+
+import (
+	"time"
+
+	"expenso-backend/domain/entities"
+)
+
+// SessionClaims is what a verified session token asserts about its bearer
+type SessionClaims struct {
+	UserID      entities.UserID
+	HouseholdID entities.HouseholdID // zero for tokens issued before households existed
+	ExpiresAt   time.Time
+}
+
+// TokenIssuer signs and verifies session tokens
+type TokenIssuer interface {
+	Issue(claims SessionClaims) (string, error)
+	// Verify returns entities.ErrInvalidToken for malformed, tampered or expired tokens
+	Verify(token string) (*SessionClaims, error)
+}